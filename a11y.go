@@ -0,0 +1,172 @@
+package clix
+
+import (
+	"cmp"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/voltycodes/clix/compose"
+)
+
+const (
+	// defaultMaxEmojiRun is the most emoji in a row before the check warns;
+	// a screen reader reads out the name of every one
+	defaultMaxEmojiRun = 3
+	// minCamelHashtag is the length from which an all lowercase or all
+	// uppercase hashtag is likely several words run together
+	minCamelHashtag = 8
+)
+
+// A11ySettings are the accessibility checks run before posting. They are
+// on unless turned off.
+type A11ySettings struct {
+	// AltText false stops the warning about media without alt text
+	AltText *bool `json:"alt_text,omitempty"`
+	// HashtagCase false stops the warning about hashtags that are not
+	// CamelCase, which screen readers read as one word
+	HashtagCase *bool `json:"hashtag_case,omitempty"`
+	// MaxEmojiRun is the most emoji in a row before it warns (default 3);
+	// -1 turns the check off
+	MaxEmojiRun int `json:"max_emoji_run,omitempty"`
+	// Strict refuses to post with a warning, as --strict-a11y does
+	Strict *bool `json:"strict,omitempty"`
+}
+
+// A11yConfig is the "a11y" section of the config, with, by account name,
+// the settings that differ for an account
+type A11yConfig struct {
+	A11ySettings
+	Accounts map[string]A11ySettings `json:"accounts,omitempty"`
+}
+
+// over returns s with the settings it leaves unset taken from defaults
+func (s A11ySettings) over(defaults A11ySettings) A11ySettings {
+	s.AltText = cmp.Or(s.AltText, defaults.AltText)
+	s.HashtagCase = cmp.Or(s.HashtagCase, defaults.HashtagCase)
+	s.MaxEmojiRun = cmp.Or(s.MaxEmojiRun, defaults.MaxEmojiRun)
+	s.Strict = cmp.Or(s.Strict, defaults.Strict)
+	return s
+}
+
+// a11ySettings returns the active account's accessibility settings, then
+// those for every account
+func (c *Config) a11ySettings() A11ySettings {
+	var s A11ySettings
+	if c.A11y != nil {
+		s = c.A11y.Accounts[c.Active].over(c.A11y.A11ySettings)
+	}
+	return s
+}
+
+// strictA11yFlag adds --strict-a11y to fs
+func strictA11yFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("strict-a11y", false, "refuse to post when the accessibility checks warn (default from config)")
+}
+
+// checkA11y prints the accessibility warnings for the parts about to be
+// posted, media holding each part's attachments, and refuses to go on
+// with any when strict or the config says so. offset numbers the parts as
+// checkLint does.
+func checkA11y(config *Config, parts []string, media [][]*mediaFile, offset int, strict bool) error {
+	s := config.a11ySettings()
+	var warnings []lintWarning
+	for i, part := range parts {
+		var files []*mediaFile
+		if i < len(media) {
+			files = media[i]
+		}
+		for _, message := range s.check(part, files) {
+			warnings = append(warnings, lintWarning{i, message})
+		}
+	}
+	if len(warnings) == 0 {
+		return nil
+	}
+	for _, w := range warnings {
+		if len(parts) > 1 || offset > 0 {
+			fmt.Fprintf(os.Stderr, tr("Accessibility: part %d: %s\n"), offset+w.part+1, w.message)
+		} else {
+			fmt.Fprintf(os.Stderr, tr("Accessibility: %s\n"), w.message)
+		}
+	}
+	switch {
+	case strict:
+		return withExitCode(exitRefused, errors.New(tr("refusing to post with accessibility warnings; fix them or leave out --strict-a11y")))
+	case s.Strict != nil && *s.Strict:
+		return withExitCode(exitRefused, errors.New(tr("refusing to post with accessibility warnings; fix them or set strict to false in a11y in the config")))
+	}
+	return nil
+}
+
+// check returns the warnings for a tweet's text and media
+func (s A11ySettings) check(text string, media []*mediaFile) []string {
+	var warnings []string
+	if s.AltText == nil || *s.AltText {
+		for _, file := range media {
+			if file.alt == "" {
+				warnings = append(warnings, trf("%s has no alt text; describe it with --alt", filepath.Base(cmp.Or(file.source, file.path))))
+			}
+		}
+	}
+	if s.HashtagCase == nil || *s.HashtagCase {
+		for _, tag := range hashtagPattern.FindAllString(text, -1) {
+			if strings.HasPrefix(tag, "#") && !camelCased(tag[1:]) {
+				warnings = append(warnings, trf("%s is read as one word by screen readers; capitalize each word, like #ReadMeAloud", tag))
+			}
+		}
+	}
+	if limit := cmp.Or(s.MaxEmojiRun, defaultMaxEmojiRun); limit > 0 {
+		if n := longestEmojiRun(text); n > limit {
+			warnings = append(warnings, trf("%d emoji in a row; screen readers read out the name of each, more than %d gets tiring", n, limit))
+		}
+	}
+	return warnings
+}
+
+// camelCased reports whether a hashtag can be read word by word: it is
+// short, mixes capitals with small letters, or its script has no case
+func camelCased(tag string) bool {
+	letters, lower, upper := 0, 0, 0
+	for _, r := range tag {
+		switch {
+		case unicode.IsLower(r):
+			lower++
+		case unicode.IsUpper(r):
+			upper++
+		case !unicode.IsLetter(r):
+			continue
+		}
+		letters++
+	}
+	if letters < minCamelHashtag || lower+upper < letters {
+		return true
+	}
+	return lower > 0 && upper > 0
+}
+
+// longestEmojiRun counts the emoji of the longest run of them in text,
+// with only spaces between them, each sequence compose.EmojiLength finds,
+// such as a flag or a ZWJ sequence, counting once
+func longestEmojiRun(text string) int {
+	longest, run := 0, 0
+	for len(text) > 0 {
+		if n := compose.EmojiLength(text); n > 0 {
+			run++
+			longest = max(longest, run)
+			text = text[n:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(text)
+		if !unicode.IsSpace(r) {
+			run = 0
+		}
+		text = text[size:]
+	}
+	return longest
+}
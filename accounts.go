@@ -0,0 +1,115 @@
+package clix
+
+import (
+	"fmt"
+	"os"
+
+	clixconfig "github.com/voltycodes/clix/config"
+)
+
+// accountInfo is one entry of `clix accounts list`
+type accountInfo struct {
+	Name     string `json:"name"`
+	Active   bool   `json:"active"`
+	Default  bool   `json:"default"`
+	Complete bool   `json:"complete"`
+}
+
+func runAccounts(args []string) error {
+	fs := newFlagSet("accounts", "accounts [list|add <name>|remove <name>|default <name>]")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	action := "list"
+	if len(args) > 0 {
+		action = args[0]
+	}
+	if action != "list" && len(args) != 2 {
+		fs.Usage()
+		return errUsage
+	}
+
+	configFilePath := getConfigFilePath()
+	config, err := readConfig(configFilePath)
+	if err != nil {
+		return fmt.Errorf(tr("failed to load configuration: %w"), err)
+	}
+
+	switch action {
+	case "list":
+		def := config.DefaultAccount
+		if def == "" {
+			def = defaultAccountName
+		}
+		list := []accountInfo{}
+		for _, name := range config.AccountNames() {
+			creds, _ := config.account(name, false)
+			list = append(list, accountInfo{name, name == config.Active, name == def, creds.Complete()})
+		}
+		if machineReadable() {
+			return printResult(list)
+		}
+		for _, info := range list {
+			marker := " "
+			if info.Active {
+				marker = "*"
+			}
+			note := ""
+			if info.Default {
+				note = " (default)"
+			}
+			if !info.Complete {
+				note += " (incomplete)"
+			}
+			fmt.Printf("%s %s%s\n", marker, info.Name, note)
+		}
+		return nil
+	case "add":
+		name := args[1]
+		if creds, err := config.account(name, false); err == nil && (name != defaultAccountName || creds.Complete()) {
+			return fmt.Errorf(tr("account %q already exists"), name)
+		}
+		if stdinIsTerminal() {
+			return initWizard(config, configFilePath, initOptions{profile: name, verify: true})
+		}
+		creds, _ := config.account(name, true)
+		*creds = Credentials{}
+		fmt.Printf(tr("Enter credentials for account %q\n"), name)
+		if err := promptForConfigValues(creds); err != nil {
+			return err
+		}
+		return saveConfig(config, configFilePath)
+	case "remove":
+		name := args[1]
+		if name == defaultAccountName {
+			return fmt.Errorf(tr("the %q account cannot be removed; use 'clix config reset' to replace it"), name)
+		}
+		if _, ok := config.Accounts[name]; !ok {
+			return fmt.Errorf(tr("unknown account %q"), name)
+		}
+		if config.Accounts[name].Keychain {
+			if err := clixconfig.DeleteFromKeychain(name); err != nil {
+				fmt.Fprintln(os.Stderr, tr("Warning:"), err)
+			}
+		}
+		delete(config.Accounts, name)
+		if config.DefaultAccount == name {
+			config.DefaultAccount = ""
+		}
+		return saveConfig(config, configFilePath)
+	case "default":
+		name := args[1]
+		if _, err := config.account(name, false); err != nil {
+			return err
+		}
+		config.DefaultAccount = name
+		if name == defaultAccountName {
+			config.DefaultAccount = ""
+		}
+		return saveConfig(config, configFilePath)
+	default:
+		return withExitCode(exitUsage, fmt.Errorf(tr("unknown accounts action %q"), action))
+	}
+}
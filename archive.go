@@ -0,0 +1,381 @@
+package clix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/tweet/timeline"
+	"github.com/michimani/gotwi/tweet/timeline/types"
+	"github.com/michimani/gotwi/user/userlookup"
+	userlookuptypes "github.com/michimani/gotwi/user/userlookup/types"
+)
+
+// The files of an archive directory
+const (
+	archiveProgressFile = "archive.json"
+	archiveProfileFile  = "profile.json"
+	archiveTweetsFile   = "tweets.json"
+	archiveMediaDir     = "media"
+)
+
+// archiveMediaTimeout bounds the download of one media file, videos
+// included
+const archiveMediaTimeout = 5 * time.Minute
+
+// archiveProgress is where an archive run got to, saved after every page
+// so the next run picks up from there. Once the whole timeline is in, a
+// run fetches only the tweets posted since the newest one.
+type archiveProgress struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	// SinceID is the newest tweet before the current pass, empty for the
+	// first pass which goes back as far as the API allows
+	SinceID   string    `json:"since_id,omitempty"`
+	NextToken string    `json:"next_token,omitempty"`
+	Complete  bool      `json:"complete"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// archivedTweet is a tweet in tweets.json. Files are the downloaded media,
+// relative to the archive directory and in the order of Media; a file that
+// failed to download is empty and tried again on the next run.
+type archivedTweet struct {
+	exportedTweet
+	Files []string `json:"media_files,omitempty"`
+}
+
+// archive is an archive directory being filled
+type archive struct {
+	dir      string
+	progress archiveProgress
+	tweets   []archivedTweet
+}
+
+// openArchive reads what an earlier run left in dir, if anything
+func openArchive(dir string) (*archive, error) {
+	ar := &archive{dir: dir, tweets: []archivedTweet{}}
+	if err := readArchiveFile(filepath.Join(dir, archiveProgressFile), &ar.progress); err != nil {
+		return nil, err
+	}
+	if err := readArchiveFile(filepath.Join(dir, archiveTweetsFile), &ar.tweets); err != nil {
+		return nil, err
+	}
+	return ar, nil
+}
+
+func readArchiveFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf(tr("failed to parse %s: %w"), path, err)
+	}
+	return nil
+}
+
+// writeArchiveFile replaces a file of the archive in one go, so stopping
+// a run at any point leaves the files as they were after the last page
+func writeArchiveFile(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".clix-archive-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// save writes the tweets before the progress, so a progress file never
+// points past tweets that were not written
+func (ar *archive) save() error {
+	if err := writeArchiveFile(filepath.Join(ar.dir, archiveTweetsFile), ar.tweets); err != nil {
+		return err
+	}
+	ar.progress.UpdatedAt = time.Now().UTC()
+	return writeArchiveFile(filepath.Join(ar.dir, archiveProgressFile), ar.progress)
+}
+
+// merge adds a page of tweets, replacing ones already archived so their
+// metrics are current, and keeps the newest first
+func (ar *archive) merge(page []archivedTweet) {
+	byID := make(map[string]int, len(ar.tweets))
+	for i, t := range ar.tweets {
+		byID[t.ID] = i
+	}
+	for _, t := range page {
+		i, ok := byID[t.ID]
+		if !ok {
+			ar.tweets = append(ar.tweets, t)
+			continue
+		}
+		if len(t.Files) == 0 {
+			t.Files = ar.tweets[i].Files
+		}
+		ar.tweets[i] = t
+	}
+	slices.SortStableFunc(ar.tweets, func(x, y archivedTweet) int {
+		return compareTweetIDs(y.ID, x.ID)
+	})
+}
+
+// compareTweetIDs orders snowflake IDs, which grow with time
+func compareTweetIDs(x, y string) int {
+	if len(x) != len(y) {
+		return len(x) - len(y)
+	}
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// archiveTimeline walks the user's timeline from where the last run
+// stopped, saving after every page
+func (a *app) archiveTimeline(ctx context.Context, ar *archive, media bool) (int, error) {
+	p := &ar.progress
+	if p.Complete {
+		// Everything up to the newest archived tweet is in; fetch what came since
+		p.Complete, p.NextToken, p.SinceID = false, "", ""
+		if len(ar.tweets) > 0 {
+			p.SinceID = ar.tweets[0].ID
+		}
+	}
+	input := &types.ListTweetsInput{
+		ID:              p.UserID,
+		MaxResults:      100,
+		SinceID:         p.SinceID,
+		PaginationToken: p.NextToken,
+		TweetFields:     exportTweetFields,
+		Expansions:      showExpand,
+		MediaFields:     showMediaFields,
+		UserFields:      tweetViewUserFld,
+	}
+
+	fetched := 0
+	for {
+		if err := a.ensureToken(ctx); err != nil {
+			return fetched, err
+		}
+		res, err := timeline.ListTweets(ctx, a.client, input)
+		if err != nil {
+			if input.PaginationToken != "" {
+				return fetched, fmt.Errorf(tr("failed to fetch your tweets; if the saved page token expired, run again with --restart: %w"), err)
+			}
+			return fetched, fmt.Errorf(tr("failed to fetch your tweets: %w"), err)
+		}
+		views := withMedia(newTweetViews(res.Data, res.Includes.Users), res.Data, res.Includes.Media)
+		page := make([]archivedTweet, len(views))
+		for i, view := range views {
+			page[i] = archivedTweet{exportedTweet: exportedTweet{tweetView: view, Links: tweetLinks(res.Data[i])}}
+			if media {
+				page[i].Files = downloadTweetMedia(ctx, ar.dir, page[i])
+			}
+		}
+		ar.merge(page)
+		fetched += len(page)
+
+		next := gotwi.StringValue(res.Meta.NextToken)
+		p.NextToken = next
+		p.Complete = next == ""
+		if err := ar.save(); err != nil {
+			return fetched, err
+		}
+		if p.Complete {
+			return fetched, nil
+		}
+		input.PaginationToken = next
+		fmt.Fprintf(os.Stderr, tr("Archived %d tweets...\n"), fetched)
+	}
+}
+
+// retryMedia downloads the media files earlier runs could not get
+func (ar *archive) retryMedia(ctx context.Context) error {
+	retried := false
+	for i, t := range ar.tweets {
+		if len(t.Media) == 0 || len(t.Files) == len(t.Media) && !slices.Contains(t.Files, "") {
+			continue
+		}
+		ar.tweets[i].Files = downloadTweetMedia(ctx, ar.dir, t)
+		retried = true
+	}
+	if !retried {
+		return nil
+	}
+	return ar.save()
+}
+
+// downloadTweetMedia fetches the media of a tweet into the media
+// directory, skipping files already there. A failure leaves an empty
+// entry and a warning rather than stopping the archive.
+func downloadTweetMedia(ctx context.Context, dir string, t archivedTweet) []string {
+	if len(t.Media) == 0 {
+		return nil
+	}
+	files := make([]string, len(t.Media))
+	for i, m := range t.Media {
+		if m.URL == "" {
+			continue
+		}
+		name := filepath.Join(archiveMediaDir, fmt.Sprintf("%s-%d%s", t.ID, i+1, mediaExt(m.URL)))
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			files[i] = name
+			continue
+		}
+		if err := downloadFile(ctx, m.URL, filepath.Join(dir, name)); err != nil {
+			fmt.Fprintf(os.Stderr, tr("Warning: media of tweet %s: %v\n"), t.ID, err)
+			continue
+		}
+		files[i] = name
+	}
+	return files
+}
+
+// mediaExt returns the extension of a media URL, dropping the query
+func mediaExt(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return ""
+	}
+	return path.Ext(parsed.Path)
+}
+
+// downloadFile saves the body of u to dest, through a temporary file so
+// an interrupted download is not taken for a finished one
+func downloadFile(ctx context.Context, u, dest string) error {
+	ctx, cancel := context.WithTimeout(ctx, archiveMediaTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	res, err := newDirectHTTPClient().Do(req)
+	if err != nil {
+		return fmt.Errorf(tr("failed to download: %w"), err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf(tr("failed to download %s: %s"), u, res.Status)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dest), ".clix-download-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, res.Body); err != nil {
+		tmp.Close()
+		return fmt.Errorf(tr("failed to download %s: %w"), u, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dest)
+}
+
+func runArchive(args []string) error {
+	fs := newFlagSet("archive", "archive [--dir path] [--no-media] [--restart]  (your profile, tweets and media; running again resumes, then adds new tweets)")
+	dir := fs.String("dir", "", "directory to archive into (default clix-archive-<username>)")
+	noMedia := fs.Bool("no-media", false, "do not download photos and videos")
+	restart := fs.Bool("restart", false, "walk the whole timeline again instead of resuming, keeping the tweets archived so far")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+	ctx := rootCtx
+
+	if err := a.ensureToken(ctx); err != nil {
+		return err
+	}
+	me, err := userlookup.GetMe(ctx, a.client, &userlookuptypes.GetMeInput{})
+	if err != nil {
+		return fmt.Errorf(tr("failed to look up the authenticated user: %w"), err)
+	}
+	username := gotwi.StringValue(me.Data.Username)
+	if *dir == "" {
+		*dir = "clix-archive-" + username
+	}
+	if err := os.MkdirAll(filepath.Join(*dir, archiveMediaDir), 0755); err != nil {
+		return err
+	}
+
+	ar, err := openArchive(*dir)
+	if err != nil {
+		return err
+	}
+	userID := gotwi.StringValue(me.Data.ID)
+	if ar.progress.UserID != "" && ar.progress.UserID != userID {
+		return withExitCode(exitRefused, fmt.Errorf(tr("%s is the archive of @%s, not @%s; use another --dir"), *dir, ar.progress.Username, username))
+	}
+	ar.progress.UserID, ar.progress.Username = userID, username
+	if *restart {
+		ar.progress.SinceID, ar.progress.NextToken, ar.progress.Complete = "", "", false
+	}
+
+	profile, err := a.profile(ctx, username, 0)
+	if err != nil {
+		return err
+	}
+	if err := writeArchiveFile(filepath.Join(*dir, archiveProfileFile), profile); err != nil {
+		return err
+	}
+
+	if !*noMedia {
+		if err := ar.retryMedia(ctx); err != nil {
+			return err
+		}
+	}
+	fetched, err := a.archiveTimeline(ctx, ar, !*noMedia)
+	if err != nil {
+		if fetched > 0 {
+			err = withExitCode(exitPartial, fmt.Errorf(tr("%w; %d tweets were archived, and running again resumes"), err, fetched))
+		}
+		return err
+	}
+
+	if machineReadable() {
+		return printResult(map[string]any{"dir": *dir, "fetched": fetched, "tweets": len(ar.tweets)})
+	}
+	fmt.Printf(tr("Archived %d tweets of @%s in %s (%d fetched this run).\n"), len(ar.tweets), username, *dir, fetched)
+	return nil
+}
@@ -0,0 +1,185 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rivo/uniseg"
+	clixclient "github.com/voltycodes/clix/client"
+	"github.com/voltycodes/clix/compose"
+)
+
+// BlueskyConfig is the "bluesky" section of the config. The password
+// should be an app password from the Bluesky settings, not the account's.
+type BlueskyConfig struct {
+	Handle      string `json:"handle"`
+	AppPassword string `json:"app_password"`
+	// Service is the PDS to log in to, https://bsky.social by default
+	Service string `json:"service,omitempty"`
+}
+
+const (
+	blueskyDefaultService = "https://bsky.social"
+	blueskyMaxLength      = 300 // graphemes
+	blueskyMaxImages      = 4
+	blueskyMaxImageBytes  = 1000000
+)
+
+type bluesky struct {
+	service, handle, password string
+	client                    *http.Client
+}
+
+func newBluesky(config *BlueskyConfig) (*bluesky, error) {
+	if config == nil || config.Handle == "" || config.AppPassword == "" {
+		return nil, fmt.Errorf(tr(`bluesky is not configured; add "bluesky": {"handle": "you.bsky.social", "app_password": "..."} to %s`), getConfigFilePath())
+	}
+	service := strings.TrimSuffix(config.Service, "/")
+	if service == "" {
+		service = blueskyDefaultService
+	}
+	return &bluesky{service: service, handle: strings.TrimPrefix(config.Handle, "@"), password: config.AppPassword, client: newHTTPClient()}, nil
+}
+
+func (b *bluesky) name() string {
+	return "bsky"
+}
+
+func (b *bluesky) check(text string, media []*mediaFile) error {
+	if n := uniseg.GraphemeClusterCount(text); n > blueskyMaxLength {
+		return fmt.Errorf(tr("post is %d characters, the limit is %d"), n, blueskyMaxLength)
+	}
+	if len(media) > blueskyMaxImages {
+		return fmt.Errorf(tr("at most %d images can be attached"), blueskyMaxImages)
+	}
+	for _, file := range media {
+		if file.category != "tweet_image" {
+			return fmt.Errorf(tr("%s: only images can be cross-posted"), file.path)
+		}
+		if file.size > blueskyMaxImageBytes {
+			return fmt.Errorf(tr("%s is over the 1 MB image limit"), file.path)
+		}
+	}
+	return nil
+}
+
+// blobRef is an uploaded blob as it is referenced from a record
+type blobRef = json.RawMessage
+
+type blueskySession struct {
+	AccessJwt string `json:"accessJwt"`
+	DID       string `json:"did"`
+	Handle    string `json:"handle"`
+}
+
+func (b *bluesky) post(ctx context.Context, text string, media []*mediaFile) (crossPost, error) {
+	var session blueskySession
+	err := b.call(ctx, "", "com.atproto.server.createSession", map[string]string{
+		"identifier": b.handle, "password": b.password,
+	}, &session)
+	if err != nil {
+		return crossPost{}, fmt.Errorf(tr("failed to log in: %w"), err)
+	}
+
+	record := map[string]any{
+		"$type":     "app.bsky.feed.post",
+		"text":      text,
+		"createdAt": time.Now().UTC().Format(time.RFC3339),
+	}
+	if facets := blueskyLinkFacets(text); len(facets) > 0 {
+		record["facets"] = facets
+	}
+	if len(media) > 0 {
+		images := make([]map[string]any, 0, len(media))
+		for _, file := range media {
+			blob, err := b.uploadBlob(ctx, session.AccessJwt, file)
+			if err != nil {
+				return crossPost{}, err
+			}
+			images = append(images, map[string]any{"alt": file.alt, "image": blob})
+		}
+		record["embed"] = map[string]any{"$type": "app.bsky.embed.images", "images": images}
+	}
+
+	var created struct {
+		URI string `json:"uri"`
+	}
+	err = b.call(ctx, session.AccessJwt, "com.atproto.repo.createRecord", map[string]any{
+		"repo": session.DID, "collection": "app.bsky.feed.post", "record": record,
+	}, &created)
+	if err != nil {
+		return crossPost{}, fmt.Errorf(tr("failed to post: %w"), err)
+	}
+
+	// at://did/app.bsky.feed.post/<rkey>
+	rkey := created.URI[strings.LastIndex(created.URI, "/")+1:]
+	return crossPost{
+		Destination: b.name(),
+		ID:          created.URI,
+		URL:         "https://bsky.app/profile/" + session.Handle + "/post/" + rkey,
+	}, nil
+}
+
+// blueskyLinkFacets marks the links in text so Bluesky shows them as links;
+// facets are located by byte offsets
+func blueskyLinkFacets(text string) []map[string]any {
+	var facets []map[string]any
+	offset := 0
+	for _, span := range compose.SplitURLs(text) {
+		if span.URL {
+			uri := span.Text
+			if !strings.Contains(uri, "://") {
+				uri = "https://" + uri
+			}
+			facets = append(facets, map[string]any{
+				"index":    map[string]int{"byteStart": offset, "byteEnd": offset + len(span.Text)},
+				"features": []map[string]string{{"$type": "app.bsky.richtext.facet#link", "uri": uri}},
+			})
+		}
+		offset += len(span.Text)
+	}
+	return facets
+}
+
+func (b *bluesky) uploadBlob(ctx context.Context, token string, file *mediaFile) (blobRef, error) {
+	data, err := os.ReadFile(file.path)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.service+"/xrpc/com.atproto.repo.uploadBlob", bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", file.mediaType)
+	req.Header.Set("Authorization", "Bearer "+token)
+	var res struct {
+		Blob blobRef `json:"blob"`
+	}
+	if err := clixclient.DoJSON(b.client, req, &res); err != nil {
+		return nil, fmt.Errorf(tr("failed to upload %s: %w"), file.path, err)
+	}
+	return res.Blob, nil
+}
+
+// call invokes an XRPC procedure with a JSON body
+func (b *bluesky) call(ctx context.Context, token, method string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.service+"/xrpc/"+method, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return clixclient.DoJSON(b.client, req, out)
+}
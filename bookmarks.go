@@ -0,0 +1,165 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/tweet/bookmark"
+	"github.com/michimani/gotwi/tweet/bookmark/types"
+)
+
+const bookmarksEndpoint = "https://api.twitter.com/2/users/:id/bookmarks"
+
+// bookmarksOutput is the bookmarks list response. gotwi's own output type
+// drops the included users, which are needed to show authors.
+type bookmarksOutput struct {
+	Data     []resources.Tweet        `json:"data"`
+	Meta     resources.PaginationMeta `json:"meta"`
+	Includes struct {
+		Users []resources.User `json:"users,omitempty"`
+	} `json:"includes,omitempty"`
+}
+
+func (r *bookmarksOutput) HasPartialError() bool {
+	return false
+}
+
+// bookmarks returns up to count of the authenticated user's most recent
+// bookmarks, following pagination
+func (a *app) bookmarks(ctx context.Context, count int) ([]tweetView, error) {
+	userID, err := a.me(ctx)
+	if err != nil {
+		return nil, err
+	}
+	input := &types.ListInput{
+		ID:          userID,
+		MaxResults:  types.ListMaxResults(min(max(count, 10), 100)),
+		TweetFields: tweetViewFields,
+		Expansions:  tweetViewExpand,
+		UserFields:  tweetViewUserFld,
+	}
+
+	views := []tweetView{}
+	for len(views) < count {
+		if err := a.ensureToken(ctx); err != nil {
+			return nil, err
+		}
+		res := &bookmarksOutput{}
+		if err := a.client.CallAPI(ctx, bookmarksEndpoint, "GET", input, res); err != nil {
+			return nil, fmt.Errorf(tr("failed to fetch bookmarks: %w"), err)
+		}
+		views = append(views, newTweetViews(res.Data, res.Includes.Users)...)
+		if res.Meta.NextToken == nil || *res.Meta.NextToken == "" {
+			break
+		}
+		input.PaginationToken = *res.Meta.NextToken
+	}
+	if len(views) > count {
+		views = views[:count]
+	}
+	return views, nil
+}
+
+func (a *app) addBookmark(ctx context.Context, tweetID string) error {
+	userID, err := a.me(ctx)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	_, err = bookmark.Create(ctx, a.client, &types.CreateInput{ID: userID, TweetID: tweetID})
+	a.stats.observe("bookmarks", "bookmark", start, err)
+	if err != nil {
+		return fmt.Errorf(tr("failed to bookmark tweet: %w"), err)
+	}
+	return nil
+}
+
+func (a *app) removeBookmark(ctx context.Context, tweetID string) error {
+	userID, err := a.me(ctx)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	_, err = bookmark.Delete(ctx, a.client, &types.DeleteInput{ID: userID, TweetID: tweetID})
+	a.stats.observe("unbookmarks", "unbookmark", start, err)
+	if err != nil {
+		return fmt.Errorf(tr("failed to remove bookmark: %w"), err)
+	}
+	return nil
+}
+
+func runBookmark(args []string) error {
+	if len(args) > 0 && isHelpArg(args[0]) {
+		fmt.Fprintln(os.Stderr, tr("Usage: clix bookmark [list|add <id|url>...|remove <id|url>...]"))
+		return nil
+	}
+	action := "list"
+	if len(args) > 0 && !isFlagArg(args[0]) {
+		action, args = args[0], args[1:]
+	}
+
+	switch action {
+	case "list":
+		return runBookmarkList(args)
+	case "add":
+		return runEngage("bookmark add", "Bookmarked %s.\n", (*app).addBookmark, args)
+	case "remove":
+		return runEngage("bookmark remove", "Removed bookmark of %s.\n", (*app).removeBookmark, args)
+	default:
+		return withExitCode(exitUsage, fmt.Errorf(tr("unknown bookmark action %q"), action))
+	}
+}
+
+func runBookmarkList(args []string) error {
+	fs := newFlagSet("bookmark list", "bookmark list [--count n] [--open n]")
+	count := fs.Int("count", 20, "number of bookmarks to show, up to 800")
+	open := fs.Int("open", 0, "open the nth bookmark of the list in the browser")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *count < 1 {
+		return errors.New(tr("--count must be at least 1"))
+	}
+	if *open < 0 {
+		return errors.New(tr("--open must be a position in the list, starting at 1"))
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	views, err := a.bookmarks(rootCtx, max(*count, *open))
+	if err != nil {
+		return err
+	}
+
+	if *open > 0 {
+		if *open > len(views) {
+			return fmt.Errorf(tr("there are only %d bookmarks"), len(views))
+		}
+		url := views[*open-1].URL
+		if err := openBrowser(url); err != nil {
+			fmt.Fprintln(os.Stderr, tr("Could not open a browser:"), url)
+		}
+		return nil
+	}
+
+	if machineReadable() {
+		return printResult(views)
+	}
+	if len(views) == 0 {
+		fmt.Println(tr("No bookmarks."))
+		return nil
+	}
+	for i, view := range views {
+		fmt.Printf("%d. ", i+1)
+		printTweet(os.Stdout, view)
+	}
+	return nil
+}
@@ -0,0 +1,492 @@
+package clix
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
+)
+
+// calendarCollision is how close two scheduled posts of an account may be
+// before the calendar flags them; followers see them as one burst
+const calendarCollision = 30 * time.Minute
+
+// Kinds of calendar items
+const (
+	calendarScheduled = "scheduled"
+	calendarDraft     = "draft"
+)
+
+var (
+	calendarTodayStyle     = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	calendarCollisionStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	calendarCursorStyle    = lipgloss.NewStyle().Reverse(true)
+)
+
+const calendarHelp = "←→↑↓ day · tab item · H/L/K/J move item a day or week · w week/month · t today · q quit"
+
+// calendarItem is a scheduled post or a draft planned for a day
+type calendarItem struct {
+	Kind    string    `json:"kind"`
+	ID      string    `json:"id"` // the schedule ID, or the draft's name
+	Account string    `json:"account,omitempty"`
+	At      time.Time `json:"at"`
+	Text    string    `json:"text"`
+	// Collides is set for scheduled posts within calendarCollision of
+	// another of the same account
+	Collides bool `json:"collides,omitempty"`
+}
+
+// calendarView is what `clix calendar --json` prints
+type calendarView struct {
+	Start time.Time      `json:"start"`
+	End   time.Time      `json:"end"`
+	Items []calendarItem `json:"items"`
+	// Gaps are the days from today on with nothing on them
+	Gaps []string `json:"gaps"`
+	// Unplanned are the drafts not planned for a day
+	Unplanned []string `json:"unplanned"`
+}
+
+// calendarZone is the time zone days are cut in, the one times are shown in
+func calendarZone() *time.Location {
+	if globalOptions.utc {
+		return time.UTC
+	}
+	return time.Local
+}
+
+func calendarDay(t time.Time) time.Time {
+	t = t.In(calendarZone())
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// loadCalendarItems returns the pending scheduled posts and the planned
+// drafts in time order, and the names of the drafts with no day
+func loadCalendarItems() ([]calendarItem, []string, error) {
+	posts, err := loadSchedule()
+	if err != nil {
+		return nil, nil, err
+	}
+	drafts, err := loadDrafts()
+	if err != nil {
+		return nil, nil, err
+	}
+	var items []calendarItem
+	for _, post := range posts {
+		if post.Status == schedulePending {
+			items = append(items, calendarItem{Kind: calendarScheduled, ID: post.ID, Account: post.Account, At: post.At, Text: post.Text})
+		}
+	}
+	unplanned := []string{}
+	for _, name := range sortedKeys(drafts) {
+		draft := drafts[name]
+		if draft.Planned.IsZero() {
+			unplanned = append(unplanned, name)
+			continue
+		}
+		items = append(items, calendarItem{Kind: calendarDraft, ID: name, At: calendarDay(draft.Planned), Text: draft.Text})
+	}
+	slices.SortStableFunc(items, func(x, y calendarItem) int {
+		return cmp.Or(x.At.Compare(y.At), cmp.Compare(x.Kind, y.Kind), cmp.Compare(x.ID, y.ID))
+	})
+
+	last := map[string]int{}
+	for i := range items {
+		if items[i].Kind != calendarScheduled {
+			continue
+		}
+		if j, ok := last[items[i].Account]; ok && items[i].At.Sub(items[j].At) < calendarCollision {
+			items[i].Collides, items[j].Collides = true, true
+		}
+		last[items[i].Account] = i
+	}
+	return items, unplanned, nil
+}
+
+// calendarRange returns the first day shown and how many: the week from
+// Monday around day, or the whole weeks covering its month
+func calendarRange(month bool, day time.Time) (time.Time, int) {
+	day = calendarDay(day)
+	monday := func(t time.Time) time.Time {
+		return t.AddDate(0, 0, -(int(t.Weekday())+6)%7)
+	}
+	if !month {
+		return monday(day), 7
+	}
+	first := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+	start := monday(first)
+	days := 0
+	for d := start; d.Before(first.AddDate(0, 1, 0)); d = d.AddDate(0, 0, 1) {
+		days++
+	}
+	return start, (days + 6) / 7 * 7
+}
+
+// moveCalendarItem moves item by days, keeping a scheduled post's time of
+// day. Scheduled posts cannot be moved into the past.
+func moveCalendarItem(item calendarItem, days int) error {
+	if item.Kind == calendarDraft {
+		drafts, err := loadDrafts()
+		if err != nil {
+			return err
+		}
+		draft, ok := drafts[item.ID]
+		if !ok {
+			return fmt.Errorf(tr("no draft named %q"), item.ID)
+		}
+		draft.Planned = calendarDay(draft.Planned).AddDate(0, 0, days)
+		return saveDrafts(drafts)
+	}
+	return updateSchedule(func(posts []*scheduledPost) ([]*scheduledPost, error) {
+		for _, post := range posts {
+			if post.ID != item.ID || post.Status != schedulePending {
+				continue
+			}
+			at := post.At.In(calendarZone()).AddDate(0, 0, days)
+			if at.Before(time.Now()) {
+				return nil, errors.New(tr("that would be in the past"))
+			}
+			post.At = at
+			return posts, nil
+		}
+		return nil, fmt.Errorf(tr("%s is no longer scheduled"), item.ID)
+	})
+}
+
+// calendarCursor is the day and item the TUI has selected
+type calendarCursor struct {
+	day  time.Time
+	item int
+}
+
+// renderCalendar draws days from start as a grid of weeks. Month view
+// fades the days of other months. cellLines is how many item lines each
+// day has, or 0 for as many as the busiest day of the week needs.
+func renderCalendar(items []calendarItem, start time.Time, days int, month time.Month, width, cellLines int, cursor *calendarCursor) string {
+	byDay := map[time.Time][]calendarItem{}
+	for _, item := range items {
+		day := calendarDay(item.At)
+		byDay[day] = append(byDay[day], item)
+	}
+	today := calendarDay(time.Now())
+	colWidth := max((width-6)/7, 8)
+	pad := func(s string) string {
+		s = truncate(s, colWidth)
+		return s + strings.Repeat(" ", max(colWidth-lipgloss.Width(s), 0))
+	}
+	rule := strings.Repeat("─", colWidth)
+	separator := strings.Repeat(rule+"┼", 6) + rule
+
+	var rows []string
+	for week := 0; week < days/7; week++ {
+		var cells [7][]string
+		lines := cellLines
+		if lines == 0 {
+			lines = 1
+			for d := range 7 {
+				lines = max(lines, len(byDay[start.AddDate(0, 0, week*7+d)]))
+			}
+		}
+		for d := range 7 {
+			day := start.AddDate(0, 0, week*7+d)
+			header := pad(day.Format("Mon 2"))
+			switch {
+			case cursor != nil && day.Equal(cursor.day):
+				header = calendarCursorStyle.Render(header)
+			case day.Equal(today):
+				header = calendarTodayStyle.Render(header)
+			case month != 0 && day.Month() != month:
+				header = tuiMutedStyle.Render(header)
+			}
+			cell := []string{header}
+			dayItems := byDay[day]
+			for i, item := range dayItems {
+				if len(cell) == lines && i < len(dayItems)-1 {
+					cell = append(cell, tuiMutedStyle.Render(pad(trf("+%d more", len(dayItems)-i))))
+					break
+				}
+				text := strings.Join(strings.Fields(item.Text), " ")
+				var line string
+				if item.Kind == calendarDraft {
+					line = tuiMutedStyle.Render(pad("draft " + text))
+				} else {
+					line = pad(item.At.In(calendarZone()).Format("15:04") + " " + text)
+					if item.Collides {
+						line = calendarCollisionStyle.Render(line)
+					}
+				}
+				if cursor != nil && day.Equal(cursor.day) && i == cursor.item {
+					line = calendarCursorStyle.Render(line)
+				}
+				cell = append(cell, line)
+			}
+			if len(dayItems) == 0 && !day.Before(today) {
+				cell = append(cell, tuiMutedStyle.Render(pad("—")))
+			}
+			for len(cell) < lines+1 {
+				cell = append(cell, pad(""))
+			}
+			cells[d] = cell
+		}
+		if week > 0 {
+			rows = append(rows, separator)
+		}
+		for line := 0; line <= lines; line++ {
+			parts := make([]string, 7)
+			for d := range 7 {
+				parts[d] = cells[d][line]
+			}
+			rows = append(rows, strings.Join(parts, "│"))
+		}
+	}
+	return strings.Join(rows, "\n")
+}
+
+// calendarGaps lists the days from today on in the range with nothing
+func calendarGaps(items []calendarItem, start time.Time, days int) []string {
+	busy := map[time.Time]bool{}
+	for _, item := range items {
+		busy[calendarDay(item.At)] = true
+	}
+	today := calendarDay(time.Now())
+	gaps := []string{}
+	for d := range days {
+		day := start.AddDate(0, 0, d)
+		if !day.Before(today) && !busy[day] {
+			gaps = append(gaps, day.Format("2006-01-02"))
+		}
+	}
+	return gaps
+}
+
+func runCalendar(args []string) error {
+	fs := newFlagSet("calendar", "calendar [--month] [--from YYYY-MM-DD] [--tui]  (scheduled posts and planned drafts by day)")
+	month := fs.Bool("month", false, "show the month instead of the week")
+	from := fs.String("from", "", "show the week or month of this day (default today)")
+	interactive := fs.Bool("tui", false, "browse the calendar full-screen and move posts between days")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	day := time.Now()
+	if *from != "" {
+		t, err := parseDate(*from)
+		if err != nil {
+			return err
+		}
+		day = t
+	}
+
+	if *interactive {
+		if !stdinIsTerminal() {
+			return errors.New(tr("clix calendar --tui needs a terminal"))
+		}
+		m := calendarModel{month: *month, cursor: calendarCursor{day: calendarDay(day)}}
+		m.reload()
+		_, err := tea.NewProgram(m, tea.WithAltScreen(), tea.WithContext(rootCtx)).Run()
+		if errors.Is(err, tea.ErrProgramKilled) && interrupted() {
+			return nil
+		}
+		return err
+	}
+
+	items, unplanned, err := loadCalendarItems()
+	if err != nil {
+		return err
+	}
+	start, days := calendarRange(*month, day)
+	end := start.AddDate(0, 0, days)
+	var shown []calendarItem
+	for _, item := range items {
+		if !item.At.Before(start) && item.At.Before(end) {
+			shown = append(shown, item)
+		}
+	}
+	gaps := calendarGaps(shown, start, days)
+
+	if machineReadable() {
+		return printResult(calendarView{Start: start, End: end, Items: append([]calendarItem{}, shown...), Gaps: gaps, Unplanned: unplanned})
+	}
+	shownMonth := time.Month(0)
+	if *month {
+		shownMonth = calendarDay(day).Month()
+	}
+	width := 120
+	if cols, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		width = cols
+	}
+	fmt.Println(renderCalendar(shown, start, days, shownMonth, width, 0, nil))
+	fmt.Println()
+	collisions := 0
+	for _, item := range shown {
+		if item.Collides {
+			collisions++
+		}
+	}
+	if collisions > 0 {
+		fmt.Printf(tr("%s %d scheduled posts are within %d minutes of another on the same account.\n"),
+			calendarCollisionStyle.Render("!"), collisions, int(calendarCollision.Minutes()))
+	}
+	switch len(gaps) {
+	case 0:
+	case 1:
+		fmt.Println(tr("1 day with nothing planned."))
+	default:
+		fmt.Printf(tr("%d days with nothing planned.\n"), len(gaps))
+	}
+	switch len(unplanned) {
+	case 0:
+	case 1:
+		fmt.Printf(tr("Draft %s has no day; plan it with 'clix draft edit %s --plan YYYY-MM-DD'.\n"), unplanned[0], unplanned[0])
+	default:
+		fmt.Printf(tr("%d drafts have no day; plan them with 'clix draft edit <name> --plan YYYY-MM-DD'.\n"), len(unplanned))
+	}
+	return nil
+}
+
+// calendarModel is the calendar of `clix calendar --tui`
+type calendarModel struct {
+	month         bool
+	cursor        calendarCursor
+	items         []calendarItem
+	status        string
+	statusIsError bool
+	width, height int
+}
+
+func (m *calendarModel) reload() {
+	items, _, err := loadCalendarItems()
+	if err != nil {
+		m.status, m.statusIsError = err.Error(), true
+		return
+	}
+	m.items = items
+	m.cursor.item = min(m.cursor.item, max(len(m.dayItems())-1, 0))
+}
+
+func (m calendarModel) dayItems() []calendarItem {
+	var items []calendarItem
+	for _, item := range m.items {
+		if calendarDay(item.At).Equal(m.cursor.day) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (m calendarModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m calendarModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		m.status, m.statusIsError = "", false
+		step := func(days int) {
+			m.cursor.day, m.cursor.item = m.cursor.day.AddDate(0, 0, days), 0
+		}
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "left", "h":
+			step(-1)
+		case "right", "l":
+			step(1)
+		case "up", "k":
+			step(-7)
+		case "down", "j":
+			step(7)
+		case "tab":
+			if n := len(m.dayItems()); n > 0 {
+				m.cursor.item = (m.cursor.item + 1) % n
+			}
+		case "shift+tab":
+			if n := len(m.dayItems()); n > 0 {
+				m.cursor.item = (m.cursor.item + n - 1) % n
+			}
+		case "H", "shift+left":
+			m.move(-1)
+		case "L", "shift+right":
+			m.move(1)
+		case "K", "shift+up":
+			m.move(-7)
+		case "J", "shift+down":
+			m.move(7)
+		case "w":
+			m.month = !m.month
+		case "t":
+			step(0)
+			m.cursor.day = calendarDay(time.Now())
+		case "r":
+			m.reload()
+		}
+	}
+	return m, nil
+}
+
+// move moves the selected item by days, taking the cursor along
+func (m *calendarModel) move(days int) {
+	items := m.dayItems()
+	if len(items) == 0 {
+		return
+	}
+	item := items[m.cursor.item]
+	if err := moveCalendarItem(item, days); err != nil {
+		m.status, m.statusIsError = err.Error(), true
+		return
+	}
+	m.cursor.day = m.cursor.day.AddDate(0, 0, days)
+	m.reload()
+	for i, moved := range m.dayItems() {
+		if moved.Kind == item.Kind && moved.ID == item.ID {
+			m.cursor.item = i
+		}
+	}
+	m.status = trf("Moved %s %s to %s", item.Kind, item.ID, m.cursor.day.Format("Mon 2 Jan"))
+}
+
+func (m calendarModel) View() string {
+	if m.width == 0 {
+		return tr("Loading...")
+	}
+	start, days := calendarRange(m.month, m.cursor.day)
+	month := time.Month(0)
+	title := trf("Week of %s", start.Format("2 January 2006"))
+	if m.month {
+		month = m.cursor.day.Month()
+		title = m.cursor.day.Format("January 2006")
+	}
+	header := tuiActiveStyle.Render(title)
+
+	status := m.status
+	if m.statusIsError {
+		status = tuiErrorStyle.Render(status)
+	} else if items := m.dayItems(); status == "" && len(items) > 0 {
+		item := items[m.cursor.item]
+		status = fmt.Sprintf("%s %s", item.Kind, item.ID)
+		if item.Kind == calendarScheduled {
+			status += " · " + item.At.In(calendarZone()).Format("Mon 2 Jan 15:04")
+			if item.Account != "" {
+				status += " · " + item.Account
+			}
+		}
+		status += " · " + strings.Join(strings.Fields(item.Text), " ")
+	}
+	footer := truncate(status, m.width) + "\n" + tuiMutedStyle.Render(truncate(tr(calendarHelp), m.width))
+
+	// Each week takes a header line and a separator besides its items
+	weeks := days / 7
+	available := m.height - lipgloss.Height(header) - lipgloss.Height(footer) - 1
+	lines := max((available-(weeks-1))/weeks-1, 1)
+	grid := renderCalendar(m.items, start, days, month, m.width, lines, &m.cursor)
+	return lipgloss.JoinVertical(lipgloss.Left, header, grid, "", footer)
+}
@@ -0,0 +1,219 @@
+package clix
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+const followerSnapshotsFile = "followers.json"
+
+// maxFollowerSnapshots is how many snapshots are kept per account; a
+// snapshot of a large account is most of a megabyte
+const maxFollowerSnapshots = 30
+
+// followerSnapshot is who followed an account at one time
+type followerSnapshot struct {
+	At        time.Time      `json:"at"`
+	Followers []followerInfo `json:"followers"`
+	// Truncated is set when the account had more followers than were
+	// fetched, so some that seem gone may only have been left out
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+type followerInfo struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name,omitempty"`
+}
+
+// followerDiff is who followed and unfollowed between two snapshots
+type followerDiff struct {
+	Account    string         `json:"account"`
+	From       time.Time      `json:"from"`
+	To         time.Time      `json:"to"`
+	Before     int            `json:"before"`
+	After      int            `json:"after"`
+	Followed   []followerInfo `json:"followed"`
+	Unfollowed []followerInfo `json:"unfollowed"`
+	Truncated  bool           `json:"truncated,omitempty"`
+}
+
+func diffFollowers(account string, from, to followerSnapshot) followerDiff {
+	d := followerDiff{
+		Account: account, From: from.At, To: to.At,
+		Before: len(from.Followers), After: len(to.Followers),
+		Followed: []followerInfo{}, Unfollowed: []followerInfo{},
+		Truncated: from.Truncated || to.Truncated,
+	}
+	had := map[string]bool{}
+	for _, f := range from.Followers {
+		had[f.ID] = true
+	}
+	has := map[string]bool{}
+	for _, f := range to.Followers {
+		has[f.ID] = true
+		if !had[f.ID] {
+			d.Followed = append(d.Followed, f)
+		}
+	}
+	for _, f := range from.Followers {
+		if !has[f.ID] {
+			d.Unfollowed = append(d.Unfollowed, f)
+		}
+	}
+	return d
+}
+
+func loadFollowerSnapshots() (map[string][]followerSnapshot, error) {
+	snapshots := map[string][]followerSnapshot{}
+	if err := loadState(followerSnapshotsFile, &snapshots); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+func runFollowersSnapshot(args []string) error {
+	fs := newFlagSet("followers snapshot", "followers snapshot [--count 10000] [--webhook url]  (records your followers and shows who came and went since the last snapshot)")
+	count := fs.Int("count", 10000, "the most followers to fetch")
+	var webhooks stringList
+	fs.Var(&webhooks, "webhook", "also POST each follow and unfollow as a hook event to this URL (repeatable)")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *count < 1 {
+		return errors.New(tr("--count must be at least 1"))
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+	ctx := rootCtx
+	userID, err := a.me(ctx)
+	if err != nil {
+		return err
+	}
+	views, err := a.followList(ctx, userID, true, *count)
+	if err != nil {
+		return err
+	}
+	snapshot := followerSnapshot{At: time.Now().UTC(), Followers: []followerInfo{}, Truncated: len(views) == *count}
+	for _, view := range views {
+		snapshot.Followers = append(snapshot.Followers, followerInfo{view.ID, view.Username, view.Name})
+	}
+
+	unlock, err := lockState(followerSnapshotsFile)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	all, err := loadFollowerSnapshots()
+	if err != nil {
+		return err
+	}
+	account := a.config.Active
+	previous := all[account]
+	all[account] = append(previous, snapshot)
+	if n := len(all[account]); n > maxFollowerSnapshots {
+		all[account] = all[account][n-maxFollowerSnapshots:]
+	}
+	if err := saveState(followerSnapshotsFile, all); err != nil {
+		return err
+	}
+
+	if len(previous) == 0 {
+		if machineReadable() {
+			return printResult(snapshot)
+		}
+		fmt.Printf(tr("Recorded %d followers; the next snapshot shows who came and went.\n"), len(snapshot.Followers))
+		return nil
+	}
+	diff := diffFollowers(account, previous[len(previous)-1], snapshot)
+	var hooks []HookConfig
+	for _, u := range webhooks {
+		hooks = append(hooks, HookConfig{URL: u})
+	}
+	announce := func(event, verb string, users []followerInfo) {
+		for _, f := range users {
+			a.fireHooks(hookEvent{
+				Event:   event,
+				Account: account,
+				User:    &hookUser{ID: f.ID, Username: f.Username, Name: f.Name},
+				Time:    snapshot.At,
+				Text:    fmt.Sprintf("@%s %s you", f.Username, verb),
+			}, hooks)
+		}
+	}
+	announce(hookFollowed, "followed", diff.Followed)
+	announce(hookUnfollowed, "unfollowed", diff.Unfollowed)
+
+	if machineReadable() {
+		return printResult(diff)
+	}
+	printFollowerDiff(diff)
+	return nil
+}
+
+func runFollowersDiff(args []string) error {
+	fs := newFlagSet("followers diff", "followers diff [--since 7d]  (who followed and unfollowed between the last two snapshots, or since the snapshot before --since)")
+	since := fs.String("since", "", "compare with the last snapshot taken before this date, time or duration ago")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	all, err := loadFollowerSnapshots()
+	if err != nil {
+		return err
+	}
+	config, err := readConfig(getConfigFilePath())
+	if err != nil {
+		return fmt.Errorf(tr("failed to load configuration: %w"), err)
+	}
+	account := config.Active
+	snapshots := all[account]
+	if len(snapshots) < 2 {
+		return withExitCode(exitNotFound, fmt.Errorf(tr("a diff needs two snapshots of %q's followers, and there are %d; take them with 'clix followers snapshot'"), account, len(snapshots)))
+	}
+	from := snapshots[len(snapshots)-2]
+	if *since != "" {
+		start, err := parseHistoryTime(*since, time.Now())
+		if err != nil {
+			return err
+		}
+		// The oldest snapshot there is when none is old enough
+		from = snapshots[0]
+		for _, s := range snapshots[:len(snapshots)-1] {
+			if !s.At.After(start) {
+				from = s
+			}
+		}
+	}
+	diff := diffFollowers(account, from, snapshots[len(snapshots)-1])
+	if machineReadable() {
+		return printResult(diff)
+	}
+	printFollowerDiff(diff)
+	return nil
+}
+
+func printFollowerDiff(d followerDiff) {
+	fmt.Printf(tr("From %s to %s: %d to %d followers, %d new and %d gone.\n"),
+		formatTime(d.From), formatTime(d.To), d.Before, d.After, len(d.Followed), len(d.Unfollowed))
+	byName := func(x, y followerInfo) int { return cmp.Compare(x.Username, y.Username) }
+	followed, unfollowed := slices.Clone(d.Followed), slices.Clone(d.Unfollowed)
+	slices.SortFunc(followed, byName)
+	slices.SortFunc(unfollowed, byName)
+	for _, f := range followed {
+		fmt.Printf("+ @%-16s %s\n", f.Username, f.Name)
+	}
+	for _, f := range unfollowed {
+		fmt.Printf("- @%-16s %s\n", f.Username, f.Name)
+	}
+	if d.Truncated {
+		fmt.Fprintln(os.Stderr, tr("Warning: a snapshot stopped at --count, so some of those gone may only have been left out"))
+	}
+}
@@ -0,0 +1,172 @@
+package clix
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/user/userlookup"
+	userlookuptypes "github.com/michimani/gotwi/user/userlookup/types"
+	clixclient "github.com/voltycodes/clix/client"
+)
+
+// app holds what a command needs to talk to the API
+type app struct {
+	config *Config
+	creds  *Credentials
+	client *gotwi.Client
+	stats  *metrics
+
+	userID string // authenticated user, see me
+}
+
+// setup loads the configuration and creates an authenticated client. When
+// interactive is false missing credentials are an error instead of a prompt.
+func setup(interactive bool) (*app, error) {
+	load := loadConfig
+	// Mock mode needs no credentials to ask for
+	if interactive && !mocking() {
+		load = loadOrCreateConfig
+	}
+	config, err := load()
+	if err != nil {
+		return nil, fmt.Errorf(tr("failed to load configuration: %w"), err)
+	}
+	return newApp(config)
+}
+
+// newApp creates a client for the config's active account
+func newApp(config *Config) (*app, error) {
+	creds, err := config.activeCredentials()
+	if err != nil {
+		return nil, err
+	}
+	if mocking() {
+		creds = mockCredentials()
+	}
+
+	if creds.HasOAuth2() {
+		if err := refreshOAuth2Token(rootCtx, config, creds); err != nil {
+			return nil, err
+		}
+	}
+	client, err := clixclient.New(creds, newHTTPClient())
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := client.Client.Transport.(*retryTransport); ok {
+		t.budget = loadLimitBudget(config.Active)
+	}
+
+	return &app{
+		config: config,
+		creds:  creds,
+		client: client,
+		stats:  newMetrics(config.Metrics, config.Active),
+	}, nil
+}
+
+// ensureToken refreshes an OAuth 2.0 token that expired during a long
+// session, such as the REPL. OAuth 1.0a credentials never expire.
+func (a *app) ensureToken(ctx context.Context) error {
+	if !a.creds.HasOAuth2() || !a.creds.OAuth2.Expired() {
+		return nil
+	}
+	if err := refreshOAuth2Token(ctx, a.config, a.creds); err != nil {
+		return err
+	}
+	a.client.SetAccessToken(a.creds.OAuth2.AccessToken)
+	return nil
+}
+
+func (a *app) close() {
+	// After an interrupt nothing new is started
+	if t, ok := a.client.Client.Transport.(*retryTransport); ok && t.responded.Load() && !globalOptions.dryRun && !interrupted() {
+		// The API is reachable again, so post anything queued while offline
+		if _, err := flushQueue(context.Background(), a); err != nil {
+			fmt.Fprintln(os.Stderr, tr("Warning: could not post queued tweets:"), err)
+		}
+	}
+	if t, ok := a.client.Client.Transport.(*retryTransport); ok {
+		if err := t.budget.save(); err != nil {
+			fmt.Fprintln(os.Stderr, tr("Warning: could not save the rate limits:"), err)
+		}
+	}
+	a.stats.close()
+	notifyUpdate(rootCtx, a.config)
+}
+
+// appPool creates one app per account on demand, for posting items that
+// were queued by different accounts
+type appPool struct {
+	config *Config
+	apps   map[string]*app
+}
+
+func newAppPool() (*appPool, error) {
+	if envCredentialsSet() {
+		config, err := envConfig()
+		if err != nil {
+			return nil, err
+		}
+		return &appPool{config: config, apps: map[string]*app{}}, nil
+	}
+	config, err := readConfig(getConfigFilePath())
+	if err != nil {
+		return nil, fmt.Errorf(tr("failed to load configuration: %w"), err)
+	}
+	return &appPool{config: config, apps: map[string]*app{}}, nil
+}
+
+func (p *appPool) get(account string) (*app, error) {
+	if a, ok := p.apps[account]; ok {
+		return a, nil
+	}
+	p.config.Active = account
+	a, err := newApp(p.config)
+	if err != nil {
+		return nil, err
+	}
+	p.apps[account] = a
+	return a, nil
+}
+
+func (p *appPool) close() {
+	for _, a := range p.apps {
+		a.close()
+	}
+}
+
+// newSignedRequest builds a request for an endpoint gotwi does not wrap,
+// signed for the app's account, see clixclient.NewRequest
+func (a *app) newSignedRequest(ctx context.Context, method, endpoint string, query url.Values, body io.Reader) (*http.Request, error) {
+	if err := a.ensureToken(ctx); err != nil {
+		return nil, err
+	}
+	return clixclient.NewRequest(ctx, a.client, method, endpoint, query, body)
+}
+
+// doJSON sends req and decodes a JSON response into out, which may be nil
+func (a *app) doJSON(req *http.Request, out any) error {
+	return clixclient.DoJSON(a.client.Client, req, out)
+}
+
+// me returns the authenticated user's ID, looking it up once per run
+func (a *app) me(ctx context.Context) (string, error) {
+	if a.userID != "" {
+		return a.userID, nil
+	}
+	if err := a.ensureToken(ctx); err != nil {
+		return "", err
+	}
+	res, err := userlookup.GetMe(ctx, a.client, &userlookuptypes.GetMeInput{})
+	if err != nil {
+		return "", fmt.Errorf(tr("failed to look up the authenticated user: %w"), err)
+	}
+	a.userID = gotwi.StringValue(res.Data.ID)
+	return a.userID, nil
+}
@@ -0,0 +1,138 @@
+// Package client talks to the X API for clix: gotwi clients for an
+// account's credentials, signed requests for the endpoints gotwi does not
+// wrap, and the HTTP transport the network settings describe.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/tweet/managetweet"
+	"github.com/michimani/gotwi/tweet/managetweet/types"
+	"github.com/voltycodes/clix/config"
+)
+
+// New creates a client for creds sending its requests with httpClient:
+// with the OAuth 2.0 token of `clix login` when there is one, otherwise
+// with the OAuth 1.0a keys
+func New(creds *config.Credentials, httpClient *http.Client) (*gotwi.Client, error) {
+	var client *gotwi.Client
+	var err error
+	if creds.HasOAuth2() {
+		client, err = gotwi.NewClientWithAccessToken(&gotwi.NewClientWithAccessTokenInput{
+			HTTPClient:  httpClient,
+			AccessToken: creds.OAuth2.AccessToken,
+		})
+	} else {
+		client, err = gotwi.NewClient(&gotwi.NewClientInput{
+			HTTPClient:           httpClient,
+			AuthenticationMethod: gotwi.AuthenMethodOAuth1UserContext,
+			OAuthToken:           creds.AccessToken,
+			OAuthTokenSecret:     creds.AccessSecret,
+			APIKey:               creds.ConsumerKey,
+			APIKeySecret:         creds.ConsumerSecret,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+	return client, nil
+}
+
+// Post creates a tweet and returns its ID
+func Post(ctx context.Context, client *gotwi.Client, input *types.CreateInput) (string, error) {
+	res, err := managetweet.Create(ctx, client, input)
+	if err != nil {
+		return "", err
+	}
+	return gotwi.StringValue(res.Data.ID), nil
+}
+
+// NewRequest builds a request for an endpoint gotwi does not wrap, signed
+// with the client's OAuth 1.0a credentials or carrying its OAuth 2.0
+// token. Query parameters are part of the signature; a multipart body is
+// not.
+func NewRequest(ctx context.Context, client *gotwi.Client, method, endpoint string, query url.Values, body io.Reader) (*http.Request, error) {
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	if client.AuthenticationMethod() == gotwi.AuthenMethodOAuth2BearerToken {
+		req.Header.Set("Authorization", "Bearer "+client.AccessToken())
+		return req, nil
+	}
+
+	params := map[string]string{}
+	for key := range query {
+		params[key] = query.Get(key)
+	}
+	sig, err := gotwi.CreateOAuthSignature(&gotwi.CreateOAuthSignatureInput{
+		HTTPMethod:       method,
+		RawEndpoint:      endpoint,
+		OAuthConsumerKey: client.OAuthConsumerKey(),
+		OAuthToken:       client.OAuthToken(),
+		SigningKey:       client.SigningKey(),
+		ParameterMap:     params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		`OAuth oauth_consumer_key="%s",oauth_nonce="%s",oauth_signature="%s",oauth_signature_method="%s",oauth_timestamp="%s",oauth_token="%s",oauth_version="%s"`,
+		url.QueryEscape(client.OAuthConsumerKey()),
+		url.QueryEscape(sig.OAuthNonce),
+		url.QueryEscape(sig.OAuthSignature),
+		url.QueryEscape(sig.OAuthSignatureMethod),
+		url.QueryEscape(sig.OAuthTimestamp),
+		url.QueryEscape(client.OAuthToken()),
+		url.QueryEscape(sig.OAuthVersion),
+	))
+	return req, nil
+}
+
+// StatusError is a response outside 2xx from DoJSON
+type StatusError struct {
+	Method, Path string
+	Status       string
+	StatusCode   int
+	Body         string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s %s returned %s: %s", e.Method, e.Path, e.Status, e.Body)
+}
+
+// DoJSON sends req with httpClient and decodes a JSON response into out,
+// which may be nil
+func DoJSON(httpClient *http.Client, req *http.Request, out any) error {
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return &StatusError{req.Method, req.URL.Path, res.Status, res.StatusCode, strings.TrimSpace(string(data))}
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
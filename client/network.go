@@ -0,0 +1,82 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// ResponseTimeout bounds how long a single attempt waits for the API,
+// unless the network settings say otherwise. It is set on the transport
+// rather than the http.Client so that waiting out a rate limit between
+// attempts does not count against it.
+const ResponseTimeout = 30 * time.Second
+
+// Network is the "network" section of the config, for proxies and
+// restricted networks. The --proxy, --ca-bundle, --request-timeout and
+// --timeout flags override it.
+type Network struct {
+	// Proxy is an http://, https:// or socks5:// URL. Without it the
+	// $HTTPS_PROXY, $HTTP_PROXY and $NO_PROXY variables apply.
+	Proxy string `json:"proxy,omitempty"`
+	// CABundle is a PEM file of certificates to trust in addition to the
+	// system ones, e.g. for a proxy that intercepts TLS
+	CABundle string `json:"ca_bundle,omitempty"`
+	// RequestTimeout bounds each attempt of a request, e.g. "10s"
+	RequestTimeout string `json:"request_timeout,omitempty"`
+	// Timeout bounds each API call as a whole, retries and rate limit
+	// waits included, e.g. "1m". There is none by default.
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// NewTransport returns a transport set up with the proxy, CA bundle and
+// request timeout of settings
+func NewTransport(settings Network) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	timeout := ResponseTimeout
+	if settings.RequestTimeout != "" {
+		d, err := time.ParseDuration(settings.RequestTimeout)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid request timeout %q, expected a duration like 30s", settings.RequestTimeout)
+		}
+		timeout = d
+	}
+	transport.ResponseHeaderTimeout = timeout
+	transport.TLSHandshakeTimeout = min(transport.TLSHandshakeTimeout, timeout)
+	transport.DialContext = (&net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}).DialContext
+
+	if settings.Proxy != "" {
+		proxy, err := url.Parse(settings.Proxy)
+		if err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("invalid proxy %q, expected a URL like http://host:port or socks5://host:port", settings.Proxy)
+		}
+		switch proxy.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return nil, fmt.Errorf("unsupported proxy scheme %q, expected http, https or socks5", proxy.Scheme)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if settings.CABundle != "" {
+		pem, err := os.ReadFile(settings.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", settings.CABundle)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return transport, nil
+}
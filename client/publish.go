@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/tweet/managetweet/types"
+)
+
+// Tweet is one tweet of a Thread
+type Tweet struct {
+	Text     string
+	MediaIDs []string
+	// Upload, when set, uploads the tweet's media just before it is posted
+	// and returns their IDs, which are added to MediaIDs
+	Upload func(ctx context.Context) ([]string, error)
+}
+
+// Thread is a tweet, or a thread of them, to publish. ReplyTo, Quote and
+// Poll apply to the first tweet; each later one replies to the one before.
+type Thread struct {
+	Tweets  []Tweet
+	ReplyTo string
+	Quote   string
+	Poll    *types.CreateInputPoll
+	// Settings, when set, fills in every tweet's reply settings, place
+	// and the like
+	Settings func(*types.CreateInput)
+	// Delay is waited between the tweets of a thread
+	Delay time.Duration
+}
+
+// ThreadError reports a thread that stopped part way through
+type ThreadError struct {
+	Posted []string // IDs of the tweets that were posted, in order
+	Failed int      // zero-based index of the tweet that failed
+	Err    error
+}
+
+func (e *ThreadError) Error() string {
+	return fmt.Sprintf("posted %d tweets, tweet %d failed: %v", len(e.Posted), e.Failed+1, e.Err)
+}
+
+func (e *ThreadError) Unwrap() error {
+	return e.Err
+}
+
+// Sender creates one tweet and returns its ID
+type Sender func(ctx context.Context, input *types.CreateInput) (string, error)
+
+// Sending returns the Sender that posts with client
+func Sending(client *gotwi.Client) Sender {
+	return func(ctx context.Context, input *types.CreateInput) (string, error) {
+		return Post(ctx, client, input)
+	}
+}
+
+// Publish posts t with send, returning the IDs of the tweets posted.
+// onPosted, when not nil, is called after each one. When a tweet fails,
+// or ctx is done between two, Publish returns the IDs posted so far and a
+// *ThreadError.
+func Publish(ctx context.Context, t *Thread, send Sender, onPosted func(index int, id string)) ([]string, error) {
+	ids := make([]string, 0, len(t.Tweets))
+	replyTo := t.ReplyTo
+	for i, tweet := range t.Tweets {
+		if i > 0 && t.Delay > 0 {
+			select {
+			case <-ctx.Done():
+				return ids, &ThreadError{Posted: ids, Failed: i, Err: ctx.Err()}
+			case <-time.After(t.Delay):
+			}
+		}
+		input := &types.CreateInput{}
+		if t.Settings != nil {
+			t.Settings(input)
+		}
+		if tweet.Text != "" {
+			input.Text = gotwi.String(tweet.Text)
+		}
+		if replyTo != "" {
+			input.Reply = &types.CreateInputReply{InReplyToTweetID: replyTo}
+		}
+		if i == 0 {
+			if t.Quote != "" {
+				input.QuoteTweetID = gotwi.String(t.Quote)
+			}
+			input.Poll = t.Poll
+		}
+		mediaIDs := tweet.MediaIDs
+		if tweet.Upload != nil {
+			uploaded, err := tweet.Upload(ctx)
+			if err != nil {
+				return ids, &ThreadError{Posted: ids, Failed: i, Err: err}
+			}
+			mediaIDs = append(mediaIDs, uploaded...)
+		}
+		if len(mediaIDs) > 0 {
+			input.Media = &types.CreateInputMedia{MediaIDs: mediaIDs}
+		}
+
+		id, err := send(ctx, input)
+		if err != nil {
+			return ids, &ThreadError{Posted: ids, Failed: i, Err: err}
+		}
+		ids = append(ids, id)
+		replyTo = id
+		if onPosted != nil {
+			onPosted(i, id)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,96 @@
+package clix
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// clipboardTool is a pair of commands that print and set the clipboard
+type clipboardTool struct {
+	read, write []string
+}
+
+// clipboardTools are tried in order on Linux and the BSDs: Wayland first,
+// then X11, then Termux on Android
+var clipboardTools = []clipboardTool{
+	{[]string{"wl-paste", "--no-newline"}, []string{"wl-copy"}},
+	{[]string{"xclip", "-selection", "clipboard", "-out"}, []string{"xclip", "-selection", "clipboard", "-in"}},
+	{[]string{"xsel", "--clipboard", "--output"}, []string{"xsel", "--clipboard", "--input"}},
+	{[]string{"termux-clipboard-get"}, []string{"termux-clipboard-set"}},
+}
+
+// clipboardCommand returns the command that reads the clipboard, or sets
+// it from stdin when write is true
+func clipboardCommand(write bool) (*exec.Cmd, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		if write {
+			return exec.Command("pbcopy"), nil
+		}
+		return exec.Command("pbpaste"), nil
+	case "windows":
+		if write {
+			return exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", "$input | Set-Clipboard"), nil
+		}
+		return exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", "Get-Clipboard -Raw"), nil
+	}
+	for _, tool := range clipboardTools {
+		args := tool.read
+		if write {
+			args = tool.write
+		}
+		if args[0] == "wl-paste" || args[0] == "wl-copy" {
+			if os.Getenv("WAYLAND_DISPLAY") == "" {
+				continue
+			}
+		}
+		if _, err := exec.LookPath(args[0]); err == nil {
+			return exec.Command(args[0], args[1:]...), nil
+		}
+	}
+	return nil, errors.New(tr("no clipboard tool found; install wl-clipboard, xclip or xsel"))
+}
+
+// readClipboard returns the text on the system clipboard, trimmed
+func readClipboard() (string, error) {
+	cmd, err := clipboardCommand(false)
+	if err != nil {
+		return "", err
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf(tr("failed to read the clipboard with %s: %w"), cmd.Args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// writeClipboard puts text on the system clipboard
+func writeClipboard(text string) error {
+	cmd, err := clipboardCommand(true)
+	if err != nil {
+		return err
+	}
+	// No output is captured: xclip and xsel stay in the background to serve
+	// the selection, and would hold a pipe open with them
+	cmd.Stdin = strings.NewReader(text)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf(tr("failed to set the clipboard with %s: %w"), cmd.Args[0], err)
+	}
+	return nil
+}
+
+// copyLink puts the URL of a posted tweet on the clipboard. The tweet is
+// out by then, so a failure is only a warning.
+func copyLink(url string) {
+	if err := writeClipboard(url); err != nil {
+		fmt.Fprintln(os.Stderr, tr("Warning: could not copy the link:"), err)
+		return
+	}
+	if !machineReadable() {
+		fmt.Fprintln(os.Stderr, tr("Copied"), url)
+	}
+}
@@ -0,0 +1,9 @@
+// Command clix posts to X and reads from it on the command line. The
+// commands live in package github.com/voltycodes/clix.
+package main
+
+import "github.com/voltycodes/clix"
+
+func main() {
+	clix.Main()
+}
@@ -0,0 +1,312 @@
+package clix
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	clixconfig "github.com/voltycodes/clix/config"
+)
+
+// completeCommand is the hidden command the completion scripts call with
+// the words typed so far, the last one being the word under the cursor
+const completeCommand = "__complete"
+
+// completionActions lists the actions of commands that take one as their
+// first argument
+var completionActions = map[string][]string{
+	"accounts":   {"list", "add", "remove", "default"},
+	"blocks":     {"list"},
+	"bookmark":   {"list", "add", "remove"},
+	"completion": {"bash", "zsh", "fish", "powershell"},
+	"config":     {"show", "path", "set", "reset", "encrypt", "decrypt"},
+	"dm":         {"list", "send"},
+	"draft":      {"save", "list", "edit", "post", "delete"},
+	"export":     {"bookmarks", "likes"},
+	"handles":    {"list", "refresh"},
+	"history":    {"list", "undo"},
+	"list":       {"list", "create", "add", "remove", "show", "timeline"},
+	"mutes":      {"list"},
+	"watch":      {"mentions"},
+	"queue":      {"list", "flush", "drop"},
+	"schedule":   {"list", "cancel"},
+	"scheduler":  {"run"},
+	"stream":     {"rules"},
+	"template":   {"list", "save", "show", "delete"},
+}
+
+// capturingFlags is set while completing: newFlagSet records the flag set
+// of the command being completed and parseFlags stops it from running
+var (
+	capturingFlags bool
+	capturedFlags  *flag.FlagSet
+)
+
+var errCapturedFlags = errors.New("flags captured for completion")
+
+func runCompletion(args []string) error {
+	fs := newFlagSet("completion", "completion bash|zsh|fish|powershell")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	script, ok := completionScripts[args[0]]
+	if !ok {
+		return fmt.Errorf(tr("unsupported shell %q, expected bash, zsh, fish or powershell"), args[0])
+	}
+	fmt.Print(script)
+	return nil
+}
+
+// runComplete prints the candidates for the last of words, one per line.
+// Printing nothing lets the shell fall back to completing file names.
+func runComplete(words []string) error {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	current, before := words[len(words)-1], words[:len(words)-1]
+	for _, candidate := range completionCandidates(before, current) {
+		if strings.HasPrefix(candidate, current) {
+			fmt.Println(candidate)
+		}
+	}
+	return nil
+}
+
+func completionCandidates(before []string, current string) []string {
+	global := flag.NewFlagSet("clix", flag.ContinueOnError)
+	addGlobalFlags(global)
+
+	// Find the command, skipping global flags and their values
+	i := 0
+	for ; i < len(before) && isFlagArg(before[i]); i++ {
+		if takesValue(global, before[i]) {
+			i++
+		}
+	}
+	if i >= len(before) {
+		if len(before) > 0 && takesValue(global, before[len(before)-1]) {
+			return flagValues(before[len(before)-1])
+		}
+		if isFlagArg(current) {
+			return flagNames(global)
+		}
+		names := make([]string, 0, len(commands))
+		for _, cmd := range commands {
+			names = append(names, cmd.name)
+		}
+		return names
+	}
+
+	name, rest := before[i], before[i+1:]
+	cmd := findCommand(name)
+	if cmd == nil {
+		return nil
+	}
+	if name == "help" {
+		if len(rest) > 0 {
+			return nil
+		}
+		return completionCandidates(nil, current)
+	}
+
+	fs := commandFlags(cmd, rest)
+	if fs == nil {
+		fs = global
+	}
+	if len(rest) > 0 && takesValue(fs, rest[len(rest)-1]) {
+		return flagValues(rest[len(rest)-1])
+	}
+	if isFlagArg(current) {
+		return flagNames(fs)
+	}
+
+	var positional []string
+	for j := 0; j < len(rest); j++ {
+		if isFlagArg(rest[j]) {
+			if takesValue(fs, rest[j]) {
+				j++
+			}
+			continue
+		}
+		positional = append(positional, rest[j])
+	}
+	return positionalValues(name, positional)
+}
+
+// commandFlags returns the flag set of cmd, as chosen by its arguments so
+// far, by running it in capture mode. Every command defines its flags and
+// parses them before doing anything else.
+func commandFlags(cmd *command, args []string) *flag.FlagSet {
+	var first []string
+	for _, arg := range args {
+		if !isFlagArg(arg) {
+			first = []string{arg}
+			break
+		}
+	}
+
+	stderr := os.Stderr
+	if devNull, err := os.Open(os.DevNull); err == nil {
+		os.Stderr = devNull
+		defer devNull.Close()
+	}
+	capturingFlags, capturedFlags = true, nil
+	cmd.run(first)
+	capturingFlags, os.Stderr = false, stderr
+	return capturedFlags
+}
+
+// takesValue reports whether arg is a flag of fs that is followed by a
+// separate value
+func takesValue(fs *flag.FlagSet, arg string) bool {
+	name := strings.TrimLeft(arg, "-")
+	if !isFlagArg(arg) || strings.Contains(name, "=") {
+		return false
+	}
+	f := fs.Lookup(name)
+	if f == nil {
+		return false
+	}
+	if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+		return false
+	}
+	return true
+}
+
+func flagNames(fs *flag.FlagSet) []string {
+	var names []string
+	fs.VisitAll(func(f *flag.Flag) {
+		names = append(names, "--"+f.Name)
+	})
+	return names
+}
+
+// flagValues returns the known values of a flag, such as account names
+func flagValues(flagArg string) []string {
+	switch strings.TrimLeft(flagArg, "-") {
+	case "account":
+		return completionAccounts()
+	case "template":
+		return completionTemplates()
+	case "to":
+		return []string{xDestination, "mastodon", "bsky"}
+	}
+	return nil
+}
+
+func positionalValues(command string, positional []string) []string {
+	if len(positional) == 0 {
+		return completionActions[command]
+	}
+	action := positional[0]
+	switch {
+	case command == "draft" && slices.Contains([]string{"edit", "post", "delete"}, action):
+		return completionDrafts()
+	case command == "stream" && action == "rules" && len(positional) == 1:
+		return []string{"list", "add", "delete"}
+	case command == "template" && (action == "show" || action == "delete"):
+		return completionTemplates()
+	case command == "accounts" && (action == "remove" || action == "default") && len(positional) == 1:
+		return completionAccounts()
+	case command == "config" && action == "set" && len(positional) == 1:
+		var keys []string
+		for _, field := range clixconfig.CredentialFields(&Credentials{}) {
+			keys = append(keys, field.Key)
+		}
+		return keys
+	}
+	return nil
+}
+
+// completionAccounts lists the configured accounts, without prompting for
+// the passphrase of an encrypted config
+func completionAccounts() []string {
+	path := getConfigFilePath()
+	data, err := os.ReadFile(path)
+	if err != nil || clixconfig.IsEncrypted(data) && os.Getenv(passphraseEnvVar) == "" {
+		return nil
+	}
+	config, err := readConfig(path)
+	if err != nil {
+		return nil
+	}
+	return config.AccountNames()
+}
+
+func completionDrafts() []string {
+	drafts, err := loadDrafts()
+	if err != nil {
+		return nil
+	}
+	return sortedKeys(drafts)
+}
+
+func completionTemplates() []string {
+	templates, err := loadTemplates()
+	if err != nil {
+		return nil
+	}
+	return sortedKeys(templates)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var completionScripts = map[string]string{
+	"bash": `# clix completion for bash; add to ~/.bashrc:
+#   source <(clix completion bash)
+_clix() {
+    local IFS=$'\n'
+    COMPREPLY=($(clix __complete "${COMP_WORDS[@]:1:$COMP_CWORD}" 2>/dev/null))
+}
+complete -o default -F _clix clix
+`,
+	"zsh": `#compdef clix
+# clix completion for zsh; add to ~/.zshrc:
+#   source <(clix completion zsh)
+_clix() {
+    local -a candidates
+    candidates=(${(f)"$(clix __complete "${(@)words[2,CURRENT]}" 2>/dev/null)"})
+    if (( ${#candidates} )); then
+        compadd -a candidates
+    else
+        _files
+    fi
+}
+compdef _clix clix
+`,
+	"fish": `# clix completion for fish; save as ~/.config/fish/completions/clix.fish
+function __clix_complete
+    clix __complete (commandline -opc)[2..-1] (commandline -ct) 2>/dev/null
+end
+complete -c clix -f -n 'test -n "$(__clix_complete)"' -a '(__clix_complete)'
+complete -c clix -F -n 'test -z "$(__clix_complete)"'
+`,
+	"powershell": `# clix completion for PowerShell; add to $PROFILE:
+#   clix completion powershell | Out-String | Invoke-Expression
+Register-ArgumentCompleter -Native -CommandName clix -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+    $words = @($commandAst.CommandElements | Select-Object -Skip 1 | ForEach-Object { $_.ToString() })
+    if ($wordToComplete -eq '') { $words += '' }
+    & clix __complete @words 2>$null | ForEach-Object {
+        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+    }
+}
+`,
+}
@@ -0,0 +1,267 @@
+// Package compose measures and splits tweet text the way X does: the
+// weighted length with URLs and emoji counted as X counts them, and
+// splitting long text or thread files into tweets.
+package compose
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Counting follows the twitter-text v3 configuration: code points in the
+// ranges below weigh 1, everything else 2, every URL counts as 23 and an
+// emoji sequence counts as 2 however many code points it contains.
+const (
+	// MaxLength is the weighted length a tweet may have
+	MaxLength = 280
+	// URLLength is what every link counts as, being replaced with a t.co
+	// link
+	URLLength         = 23
+	defaultCharWeight = 2
+)
+
+var lightRanges = []struct{ lo, hi rune }{
+	{0, 4351},    // Latin through Hangul Jamo
+	{8192, 8205}, // spaces
+	{8208, 8223}, // punctuation
+	{8242, 8247}, // primes
+}
+
+// urlPattern matches links X shortens with t.co: explicit http(s) URLs,
+// www. hosts and bare domains on common TLDs
+var urlPattern = regexp.MustCompile(`(?i)\b(?:https?://[^\s<>"]+|www\.[^\s<>"]+|[a-z0-9][a-z0-9-]*(?:\.[a-z0-9-]+)*\.(?:com|org|net|io|dev|co|app|me|xyz|ai|gg|tv|info|edu|gov|uk|de|jp|fr)(?:/[^\s<>"]*)?)`)
+
+// trailingURLPunctuation is not part of a URL when it ends one
+const trailingURLPunctuation = ".,:;!?'\")]}"
+
+func charWeight(r rune) int {
+	for _, rg := range lightRanges {
+		if r >= rg.lo && r <= rg.hi {
+			return 1
+		}
+	}
+	return defaultCharWeight
+}
+
+func isEmojiBase(r rune) bool {
+	return r >= 0x1F000 && r <= 0x1FAFF || // pictographs, emoticons, transport, flags
+		r >= 0x2600 && r <= 0x27BF || // misc symbols and dingbats
+		r >= 0x2B00 && r <= 0x2BFF || // arrows and stars such as ⭐
+		r == 0x00A9 || r == 0x00AE || r == 0x203C || r == 0x2049 || r == 0x2122 ||
+		r >= 0x2190 && r <= 0x21FF || r >= 0x2300 && r <= 0x23FF
+}
+
+func isEmojiModifier(r rune) bool {
+	return r == 0xFE0F || r == 0xFE0E || // variation selectors
+		r >= 0x1F3FB && r <= 0x1F3FF || // skin tones
+		r == 0x20E3 || // combining keycap
+		r >= 0xE0020 && r <= 0xE007F // tag sequences (subdivision flags)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// EmojiLength returns the byte length of the emoji sequence at the start
+// of s, or 0 if s does not start with one. A sequence is what X counts as
+// one emoji: a flag, a keycap, or an emoji with its skin tone, variation
+// selector and any joined on with zero width joiners.
+func EmojiLength(s string) int {
+	r, size := utf8.DecodeRuneInString(s)
+	keycap := r == '#' || r == '*' || r >= '0' && r <= '9'
+	if !isEmojiBase(r) && !keycap {
+		return 0
+	}
+	if keycap {
+		// Only "1️⃣" style keycaps are emoji; a bare digit is not
+		rest := s[size:]
+		next, n := utf8.DecodeRuneInString(rest)
+		if next == 0xFE0F {
+			next, _ = utf8.DecodeRuneInString(rest[n:])
+		}
+		if next != 0x20E3 {
+			return 0
+		}
+	}
+	if isRegionalIndicator(r) {
+		if next, n := utf8.DecodeRuneInString(s[size:]); isRegionalIndicator(next) {
+			return size + n
+		}
+		return size
+	}
+
+	end := size
+	for end < len(s) {
+		next, n := utf8.DecodeRuneInString(s[end:])
+		switch {
+		case isEmojiModifier(next):
+			end += n
+		case next == 0x200D: // zero width joiner glues the next emoji on
+			joined, m := utf8.DecodeRuneInString(s[end+n:])
+			if !isEmojiBase(joined) {
+				return end
+			}
+			end += n + m
+		default:
+			return end
+		}
+	}
+	return end
+}
+
+// Length counts text the way X does when enforcing the length limit
+func Length(text string) int {
+	weight := 0
+	for _, span := range SplitURLs(text) {
+		if span.URL {
+			weight += URLLength
+			continue
+		}
+		s := span.Text
+		for len(s) > 0 {
+			if n := EmojiLength(s); n > 0 {
+				weight += defaultCharWeight
+				s = s[n:]
+				continue
+			}
+			r, size := utf8.DecodeRuneInString(s)
+			weight += charWeight(r)
+			s = s[size:]
+		}
+	}
+	return weight
+}
+
+// Span is a piece of text, either plain or a link
+type Span struct {
+	Text string
+	URL  bool
+}
+
+// SplitURLs cuts text into alternating plain and URL spans
+func SplitURLs(text string) []Span {
+	var spans []Span
+	last := 0
+	for _, loc := range urlPattern.FindAllStringIndex(text, -1) {
+		start, end := loc[0], loc[1]
+		for end > start && strings.ContainsRune(trailingURLPunctuation, rune(text[end-1])) {
+			end--
+		}
+		if start < last || end == start {
+			continue
+		}
+		if start > last {
+			spans = append(spans, Span{Text: text[last:start]})
+		}
+		spans = append(spans, Span{Text: text[start:end], URL: true})
+		last = end
+	}
+	if last < len(text) {
+		spans = append(spans, Span{Text: text[last:]})
+	}
+	return spans
+}
+
+var wordPattern = regexp.MustCompile(`\S+`)
+
+// Split breaks text into parts that each fit in a tweet, at word
+// boundaries where possible, keeping the original spacing and line breaks
+// within a part. URLs are never broken.
+func Split(text string) []string {
+	return splitWithin(text, MaxLength)
+}
+
+// SplitNumbered is Split for a thread whose parts get added(n, total) once
+// split, such as a " 1/n" number or a signature: each part leaves room for
+// what it gets, which grows with the number of parts, so " 10/12" fits as
+// well as " 1/9" did
+func SplitNumbered(text string, added func(n, total int) string) []string {
+	reserve := 0
+	for {
+		parts := splitWithin(text, MaxLength-reserve)
+		need := 0
+		for i := range parts {
+			need = max(need, Length(added(i+1, len(parts))))
+		}
+		// More room only makes more parts, so this settles, unless what is
+		// added leaves no room for a character
+		if need <= reserve || need > MaxLength-defaultCharWeight {
+			return parts
+		}
+		reserve = need
+	}
+}
+
+// splitWithin is Split with parts of at most limit
+func splitWithin(text string, limit int) []string {
+	var parts []string
+	current, last := "", 0
+	for _, loc := range wordPattern.FindAllStringIndex(text, -1) {
+		space, word := text[last:loc[0]], text[loc[0]:loc[1]]
+		last = loc[1]
+
+		candidate := word
+		if current != "" {
+			candidate = current + space + word
+		}
+		if Length(candidate) <= limit {
+			current = candidate
+			continue
+		}
+		if current != "" {
+			parts = append(parts, current)
+		}
+		// A single word longer than a tweet has to be cut mid-word
+		for Length(word) > limit {
+			cut := fitPrefix(word, limit)
+			parts = append(parts, word[:cut])
+			word = word[cut:]
+		}
+		current = word
+	}
+	if current != "" {
+		parts = append(parts, current)
+	}
+	return parts
+}
+
+// fitPrefix returns the byte length of the longest prefix of word that
+// fits in limit
+func fitPrefix(word string, limit int) int {
+	weight, end := 0, 0
+	for i, r := range word {
+		w := charWeight(r)
+		if weight+w > limit {
+			break
+		}
+		weight += w
+		end = i + utf8.RuneLen(r)
+	}
+	return end
+}
+
+// Separator is a line on its own that separates parts in a thread file
+const Separator = "---"
+
+// SplitThread splits a thread file into its parts at Separator lines,
+// dropping empty parts
+func SplitThread(text string) []string {
+	var parts []string
+	var current []string
+	flush := func() {
+		if part := strings.TrimSpace(strings.Join(current, "\n")); part != "" {
+			parts = append(parts, part)
+		}
+		current = nil
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == Separator {
+			flush()
+			continue
+		}
+		current = append(current, line)
+	}
+	flush()
+	return parts
+}
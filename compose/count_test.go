@@ -0,0 +1,177 @@
+package compose
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLength(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{"empty", "", 0},
+		{"ascii", "hello world", 11},
+		{"url", "https://example.com/a/very/long/path/that/goes/on/and/on", URLLength},
+		{"url in text", "see https://example.com/x now", 4 + URLLength + 4},
+		{"bare domain", "go.dev", URLLength},
+		{"trailing punctuation", "read example.com.", 5 + URLLength + 1},
+		{"two urls", "https://a.com https://b.com", 2*URLLength + 1},
+		{"cjk", "日本語", 6},
+		{"hangul syllables", "한국", 4},
+		{"mixed cjk and latin", "東京 tokyo", 2*2 + 6},
+		{"general punctuation", "a—b", 3},
+		{"emoji", "👍", 2},
+		{"skin tone", "👍🏽", 2},
+		{"zwj family", "👨‍👩‍👧‍👦", 2},
+		{"zwj with variation selector", "🏳️‍🌈", 2},
+		{"flag", "🇯🇵", 2},
+		{"keycap", "1️⃣", 2},
+		{"bare digit", "1", 1},
+		{"emoji and text", "gm ☀️", 3 + 2},
+		{"limit", strings.Repeat("a", MaxLength), MaxLength},
+		{"over the limit", strings.Repeat("a", MaxLength+1), MaxLength + 1},
+		{"cjk at the limit", strings.Repeat("日", MaxLength/2), MaxLength},
+		{"emoji at the limit", strings.Repeat("👨‍👩‍👧‍👦", MaxLength/2), MaxLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Length(tt.text); got != tt.want {
+				t.Errorf("Length(%q) = %d, want %d", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	url := "https://example.com/" + strings.Repeat("x", 300)
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"short", "hello world", []string{"hello world"}},
+		{"at the limit", strings.Repeat("a", MaxLength), []string{strings.Repeat("a", MaxLength)}},
+		{"one over", strings.Repeat("a", MaxLength+1), []string{strings.Repeat("a", MaxLength), "a"}},
+		{"at a word", strings.Repeat("a", 276) + " bbbb", []string{strings.Repeat("a", 276), "bbbb"}},
+		{"words at the limit", strings.Repeat("a", 275) + " bbbb", []string{strings.Repeat("a", 275) + " bbbb"}},
+		{"keeps line breaks", "one\ntwo\n\nthree", []string{"one\ntwo\n\nthree"}},
+		{"cjk", strings.Repeat("日", MaxLength/2+1), []string{strings.Repeat("日", MaxLength/2), "日"}},
+		// 93 emoji weigh 2 each, with 92 spaces: 278, so " end" does not fit
+		{"emoji", strings.Repeat("👍 ", 93) + "end", []string{strings.TrimSpace(strings.Repeat("👍 ", 93)), "end"}},
+		{"url never broken", strings.Repeat("a", 270) + " " + url, []string{strings.Repeat("a", 270), url}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Split(tt.text)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Split(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitFits(t *testing.T) {
+	texts := map[string]string{
+		"words":         strings.Repeat("lorem ipsum dolor sit amet ", 60),
+		"cjk":           strings.Repeat("吾輩は猫である。名前はまだ無い。", 40),
+		"urls":          strings.Repeat("read https://example.com/posts/1 and ", 30),
+		"emoji":         strings.Repeat("👨‍👩‍👧‍👦 🇯🇵 ok ", 80),
+		"one long word": strings.Repeat("z", 1000),
+	}
+	for name, text := range texts {
+		t.Run(name, func(t *testing.T) {
+			parts := Split(text)
+			if len(parts) < 2 {
+				t.Fatalf("Split gave %d parts, want several", len(parts))
+			}
+			for i, part := range parts {
+				if n := Length(part); n > MaxLength || n == 0 {
+					t.Errorf("part %d is %d long", i+1, n)
+				}
+			}
+			// Words may be cut mid-word, so compare without the spacing
+			if got, want := strings.Join(strings.Fields(strings.Join(parts, "")), ""), strings.Join(strings.Fields(text), ""); got != want {
+				t.Errorf("the parts do not add up to the text")
+			}
+		})
+	}
+}
+
+func TestSplitThread(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"one part", "hello\nworld\n", []string{"hello\nworld"}},
+		{"parts", "one\n---\ntwo\n---\nthree", []string{"one", "two", "three"}},
+		{"separator with spaces", "one\n  ---  \ntwo", []string{"one", "two"}},
+		{"empty parts dropped", "---\none\n---\n\n---\ntwo\n---\n", []string{"one", "two"}},
+		{"dashes in text", "a --- b\n----\nc", []string{"a --- b\n----\nc"}},
+		{"crlf", "one\r\n---\r\ntwo", []string{"one", "two"}},
+		{"long part left whole", strings.Repeat("a", 300), []string{strings.Repeat("a", 300)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitThread(tt.text); !slices.Equal(got, tt.want) {
+				t.Errorf("SplitThread(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitNumbered(t *testing.T) {
+	suffix := func(n, total int) string { return fmt.Sprintf(" %d/%d", n, total) }
+	signed := func(n, total int) string { return fmt.Sprintf(" (%d/%d)\n\n— the clix team", n, total) }
+	tests := []struct {
+		name  string
+		text  string
+		added func(n, total int) string
+		// atLeast is the fewest parts the text makes
+		atLeast int
+	}{
+		{"nine parts", strings.Repeat("word ", 9*55), suffix, 9},
+		{"ten parts", strings.Repeat("word ", 10*55), suffix, 10},
+		// Split alone makes nine parts here, which " 1/9" leaves room for,
+		// but the room makes ten, which " 10/10" needs more of
+		{"nine becoming ten", strings.Repeat("word ", 9*56-1), suffix, 10},
+		{"ninety-nine becoming a hundred", strings.Repeat("word ", 99*56-1), suffix, 100},
+		{"cjk", strings.Repeat("日本語の文章。", 200), suffix, 10},
+		{"urls", strings.Repeat("see https://example.com/x ", 120), suffix, 10},
+		{"signature", strings.Repeat("word ", 12*55), signed, 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts := SplitNumbered(tt.text, tt.added)
+			if len(parts) < tt.atLeast {
+				t.Fatalf("SplitNumbered made %d parts, want at least %d", len(parts), tt.atLeast)
+			}
+			for i, part := range parts {
+				numbered := part + tt.added(i+1, len(parts))
+				if n := Length(numbered); n > MaxLength {
+					t.Errorf("part %d is %d long with %q added", i+1, n, tt.added(i+1, len(parts)))
+				}
+			}
+		})
+	}
+}
+
+func TestSplitNumberedShort(t *testing.T) {
+	// A text that fits is one part, however long its number would be
+	text := strings.Repeat("a", MaxLength)
+	parts := SplitNumbered(text, func(n, total int) string {
+		if total == 1 {
+			return ""
+		}
+		return fmt.Sprintf(" %d/%d", n, total)
+	})
+	if !slices.Equal(parts, []string{text}) {
+		t.Errorf("SplitNumbered split a text that fits into %d parts", len(parts))
+	}
+}
@@ -0,0 +1,491 @@
+package clix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	clixconfig "github.com/voltycodes/clix/config"
+)
+
+// Credentials are the keys for one account
+type Credentials = clixconfig.Credentials
+
+// OAuth2Token is an OAuth 2.0 user-context token obtained by `clix login`
+type OAuth2Token = clixconfig.OAuth2Token
+
+// Config represents the structure of the configuration file: the accounts,
+// as the config package reads them, and clix's settings beside them
+type Config struct {
+	clixconfig.Config
+
+	PostingWindow *PostingWindow `json:"posting_window,omitempty"`
+	Metrics       *MetricsConfig `json:"metrics,omitempty"`
+	// UndoDelay holds tweets this long before posting them, e.g. "10s",
+	// so they can be taken back
+	UndoDelay string `json:"undo_delay,omitempty"`
+	// ConfirmBeforePost previews every post and asks before sending it, as
+	// --confirm does; false also stops the repl from asking
+	ConfirmBeforePost *bool             `json:"confirm_before_post,omitempty"`
+	SafeMode          *SafeModeConfig   `json:"safe_mode,omitempty"`
+	Lint              *LintConfig       `json:"lint,omitempty"`
+	Style             *StyleConfig      `json:"style,omitempty"`
+	A11y              *A11yConfig       `json:"a11y,omitempty"`
+	Transforms        []TransformConfig `json:"transforms,omitempty"`
+	// UpdateCheck false turns off the notice of new releases
+	UpdateCheck *bool `json:"update_check,omitempty"`
+	// Language is the one clix's messages are shown in, en, es or ja,
+	// unless $CLIX_LANG says otherwise
+	Language string `json:"language,omitempty"`
+
+	// Mastodon and Bluesky are the destinations for post --to
+	Mastodon *MastodonConfig `json:"mastodon,omitempty"`
+	Bluesky  *BlueskyConfig  `json:"bluesky,omitempty"`
+
+	Shortener *ShortenerConfig `json:"shortener,omitempty"`
+	GIF       *GIFConfig       `json:"gif,omitempty"`
+	Thread    *ThreadConfig    `json:"thread,omitempty"`
+	Tweet     *TweetConfig     `json:"tweet,omitempty"`
+	// Duplicates catches a post sent twice by mistake
+	Duplicates *DuplicatesConfig `json:"duplicates,omitempty"`
+	// Review is where proposals are shared and whose are trusted
+	Review *ReviewConfig `json:"review,omitempty"`
+
+	Hooks   []HookConfig   `json:"hooks,omitempty"`
+	Network *NetworkConfig `json:"network,omitempty"`
+	Log     *LogConfig     `json:"log,omitempty"`
+	// Theme colors the output of read commands on a terminal
+	Theme *ThemeConfig `json:"theme,omitempty"`
+}
+
+// projectConfigFileName is a config in the current directory, which takes
+// precedence over the user's config. Credentials stay in the user's, and
+// its hooks only run once it is trusted, see withUserSecrets.
+const projectConfigFileName = clixconfig.ProjectFileName
+
+// defaultAccountName names the account stored at the top level of the config
+const defaultAccountName = clixconfig.DefaultAccountName
+
+// accountEnvVar selects an account when --account is not given
+const accountEnvVar = clixconfig.AccountEnvVar
+
+// stateDirEnvVar overrides where history, drafts and other state are kept
+const stateDirEnvVar = "CLIX_STATE_DIR"
+
+// envCredentialsSet reports whether any credential is set in the
+// environment, which makes clix ignore the config file
+func envCredentialsSet() bool {
+	return clixconfig.EnvSet()
+}
+
+// envConfig builds a config from credentials in the environment for CI
+// and containers. The config file is not read or written in this mode.
+func envConfig() (*Config, error) {
+	accounts, err := clixconfig.FromEnv()
+	var missing *clixconfig.MissingEnvError
+	if errors.As(err, &missing) {
+		return nil, fmt.Errorf(tr("$%s is not set; credentials from the environment need all of $CLIX_CONSUMER_KEY, $CLIX_CONSUMER_SECRET, $CLIX_ACCESS_TOKEN and $CLIX_ACCESS_SECRET"), missing.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Config{Config: *accounts}, nil
+}
+
+// getConfigFilePath returns the config file in use: .clix.json in the
+// current directory if there is one, otherwise the user's config
+func getConfigFilePath() string {
+	path, _ := configFileSource()
+	return path
+}
+
+// configFileSource returns the config file in use and where it was found:
+// "project", "XDG_CONFIG_HOME" or "home"
+func configFileSource() (string, string) {
+	path, source, err := clixconfig.File()
+	if err != nil {
+		fmt.Println(tr("Error getting home directory:"), err)
+		os.Exit(1)
+	}
+	return path, source
+}
+
+// userConfigFileSource returns the user's config file, under
+// $XDG_CONFIG_HOME or ~/.config
+func userConfigFileSource() (string, string) {
+	path, source, err := clixconfig.UserFile()
+	if err != nil {
+		fmt.Println(tr("Error getting home directory:"), err)
+		os.Exit(1)
+	}
+	return path, source
+}
+
+// selectAccount decides which account this invocation uses: --account,
+// then $CLIX_ACCOUNT, then the configured default
+func (c *Config) selectAccount() {
+	c.Select(globalOptions.account)
+}
+
+// account returns the credentials stored under name. With create set, a
+// missing account is added to the config.
+func (c *Config) account(name string, create bool) (*Credentials, error) {
+	creds, err := c.Account(name, create)
+	if errors.Is(err, clixconfig.ErrUnknownAccount) {
+		return nil, fmt.Errorf(tr("unknown account %q (see 'clix accounts list')"), name)
+	}
+	return creds, keychainError(err, name)
+}
+
+// confirmBeforePost decides whether to preview a post and ask first:
+// --confirm or --no-confirm when given, then confirm_before_post, then def
+func (c *Config) confirmBeforePost(flag *bool, def bool) bool {
+	switch {
+	case flag != nil:
+		return *flag
+	case c.ConfirmBeforePost != nil:
+		return *c.ConfirmBeforePost
+	}
+	return def
+}
+
+// confirmFlag reads --confirm and --no-confirm, returning nil when neither
+// was given
+func confirmFlag(yes, no bool) (*bool, error) {
+	switch {
+	case yes && no:
+		return nil, errors.New(tr("--confirm and --no-confirm cannot be combined"))
+	case yes || no:
+		return &yes, nil
+	}
+	return nil, nil
+}
+
+// activeCredentials returns the credentials of the selected account
+func (c *Config) activeCredentials() (*Credentials, error) {
+	return c.account(c.Active, false)
+}
+
+// readConfig decodes the config file, returning an empty config if it does
+// not exist yet. An encrypted config is decrypted, asking for the
+// passphrase if needed.
+func readConfig(configFilePath string) (*Config, error) {
+	config, data, err := decodeConfig(configFilePath)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		config.selectAccount()
+		return config, nil
+	}
+	if isProjectConfig(configFilePath) {
+		if err := config.withUserSecrets(configFilePath, data); err != nil {
+			return nil, err
+		}
+	}
+	config.selectAccount()
+	setLanguage(config.Language)
+	networkConfig = config.Network
+	themeConfig = config.Theme
+	openLogFile(config.Log)
+	return config, nil
+}
+
+// loadConfig reads the configuration without ever prompting, for
+// non-interactive commands
+func loadConfig() (*Config, error) {
+	config, err := readActiveConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := config.requireCredentials(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// readActiveConfig is loadConfig for commands that need credentials for
+// only some of what they do, which call requireCredentials for those
+func readActiveConfig() (*Config, error) {
+	if envCredentialsSet() {
+		return envConfig()
+	}
+	return readConfig(getConfigFilePath())
+}
+
+// requireCredentials fails unless the active account has all it needs to
+// sign in
+func (c *Config) requireCredentials() error {
+	creds, err := c.activeCredentials()
+	if err != nil {
+		return err
+	}
+	if !creds.Complete() && !mocking() {
+		return withExitCode(exitAuth, fmt.Errorf(tr("configuration for account %q is incomplete; run 'clix login' or 'clix config reset'"), c.Active))
+	}
+	return nil
+}
+
+func loadOrCreateConfig() (*Config, error) {
+	if envCredentialsSet() {
+		return envConfig()
+	}
+	configFilePath := getConfigFilePath()
+	configDir := filepath.Dir(configFilePath)
+	if _, err := os.Stat(configDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(configDir, 0755); err != nil {
+			return nil, fmt.Errorf(tr("failed to create config directory: %w"), err)
+		}
+	}
+
+	_, statErr := os.Stat(configFilePath)
+	config, err := readConfig(configFilePath)
+	if err != nil {
+		return nil, err
+	}
+	creds, err := config.activeCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	if !creds.Complete() {
+		if os.IsNotExist(statErr) {
+			fmt.Println(tr("Configuration file not found. Creating a new one..."))
+		} else {
+			fmt.Printf(tr("Configuration for account %q is incomplete. Prompting for missing values...\n"), config.Active)
+		}
+		if stdinIsTerminal() {
+			if err := initWizard(config, configFilePath, initOptions{profile: config.Active, verify: true}); err != nil {
+				return nil, err
+			}
+			return config, nil
+		}
+		if err := promptForConfigValues(creds); err != nil {
+			return nil, err
+		}
+		if err := saveConfig(config, configFilePath); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
+func promptForConfigValues(creds *Credentials) error {
+	for _, field := range []struct {
+		label  string
+		value  *string
+		secret bool
+	}{
+		{"Enter Consumer Key: ", &creds.ConsumerKey, false},
+		{"Enter Consumer Secret: ", &creds.ConsumerSecret, true},
+		{"Enter Access Token: ", &creds.AccessToken, false},
+		{"Enter Access Secret: ", &creds.AccessSecret, true},
+	} {
+		if *field.value != "" {
+			continue
+		}
+		var answer string
+		var err error
+		if field.secret {
+			answer, err = promptSecret(tr(field.label))
+		} else {
+			answer, err = promptLine(tr(field.label))
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		*field.value = credentialValue(answer)
+	}
+	return nil
+}
+
+// credentialValue cleans up a pasted key or token. None has spaces, so
+// any are line breaks and indentation picked up copying it, wrapped, out
+// of a page or document.
+func credentialValue(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
+
+// saveConfig writes config to configFilePath, or, for a project config,
+// its credentials to the user's config and the rest to the project's
+func saveConfig(config *Config, configFilePath string) error {
+	if isProjectConfig(configFilePath) {
+		return saveProjectConfig(config, configFilePath)
+	}
+	return writeConfigFile(config, configFilePath, configPassphrase)
+}
+
+// writeConfigFile writes config to path, encrypted with passphrase unless
+// it is empty
+func writeConfigFile(config *Config, path, passphrase string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf(tr("failed to create config directory: %w"), err)
+	}
+	file, err := config.forFile()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf(tr("failed to write config file: %w"), err)
+	}
+	if data, err = clixconfig.Encrypt(data, passphrase); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf(tr("failed to write config file: %w"), err)
+	}
+	return nil
+}
+
+// forFile returns the config as it is written, with the credentials of
+// accounts kept in the keychain stored there instead
+func (c *Config) forFile() (*Config, error) {
+	file := *c
+	creds, err := c.Credentials.ForFile(defaultAccountName)
+	if err != nil {
+		return nil, err
+	}
+	file.Credentials = *creds
+	if c.Accounts != nil {
+		file.Accounts = make(map[string]*Credentials, len(c.Accounts))
+		for name, account := range c.Accounts {
+			if file.Accounts[name], err = account.ForFile(name); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &file, nil
+}
+
+// maskSecret hides all but the last four characters of a credential
+func maskSecret(s string) string {
+	if len(s) <= 4 {
+		return strings.Repeat("*", len(s))
+	}
+	return strings.Repeat("*", len(s)-4) + s[len(s)-4:]
+}
+
+func runConfig(args []string) error {
+	fs := newFlagSet("config", "config [show|path|trust|untrust|set <key> <value>|reset|encrypt|decrypt]  (applies to the selected account)")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	action := "show"
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	if action == "trust" || action == "untrust" {
+		return runConfigTrust(action == "trust")
+	}
+	configFilePath, source := configFileSource()
+	if action == "path" {
+		_, statErr := os.Stat(configFilePath)
+		if machineReadable() {
+			return printResult(struct {
+				Path   string `json:"path"`
+				Source string `json:"source"`
+				Exists bool   `json:"exists"`
+			}{configFilePath, source, statErr == nil})
+		}
+		fmt.Println(configFilePath)
+		return nil
+	}
+	config, err := readConfig(configFilePath)
+	if err != nil {
+		return fmt.Errorf(tr("failed to load configuration: %w"), err)
+	}
+	switch action {
+	case "encrypt":
+		if source == "project" {
+			return fmt.Errorf(tr("%s keeps no credentials; encrypt the user config from another directory"), projectConfigFileName)
+		}
+		if configPassphrase != "" {
+			return errors.New(tr("the config file is already encrypted"))
+		}
+		if configPassphrase, err = readPassphrase(tr("New passphrase: "), true); err != nil {
+			return err
+		}
+		if err := saveConfig(config, configFilePath); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, tr("Config encrypted. clix will ask for the passphrase, or read it from $%s.\n"), passphraseEnvVar)
+		return nil
+	case "decrypt":
+		if configPassphrase == "" {
+			return errors.New(tr("the config file is not encrypted"))
+		}
+		configPassphrase = ""
+		if err := saveConfig(config, configFilePath); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, tr("Config decrypted."))
+		return nil
+	}
+	creds, err := config.account(config.Active, action != "show")
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "show":
+		if machineReadable() {
+			masked := map[string]string{}
+			for _, field := range clixconfig.CredentialFields(creds) {
+				masked[field.Key] = maskSecret(*field.Value)
+			}
+			return printResult(struct {
+				File            string            `json:"file"`
+				CredentialsFile string            `json:"credentials_file"`
+				Encrypted       bool              `json:"encrypted"`
+				Account         string            `json:"account"`
+				Keychain        bool              `json:"keychain"`
+				Credentials     map[string]string `json:"credentials"`
+			}{configFilePath, secretsFile(configFilePath), configPassphrase != "", config.Active, creds.Keychain, masked})
+		}
+		fmt.Println(tr("Config file:"), configFilePath)
+		if secrets := secretsFile(configFilePath); secrets != configFilePath {
+			fmt.Println(tr("Credentials file:"), secrets)
+		}
+		if configPassphrase != "" {
+			fmt.Println(tr("Encrypted: yes"))
+		}
+		fmt.Println(tr("Account:"), config.Active)
+		if creds.Keychain {
+			fmt.Println(tr("Credentials: in the keychain"))
+		}
+		for _, field := range clixconfig.CredentialFields(creds) {
+			fmt.Printf("  %-16s %s\n", field.Key, maskSecret(*field.Value))
+		}
+		return nil
+	case "set":
+		if len(args) != 3 {
+			return errors.New(tr("usage: clix config set <key> <value>"))
+		}
+		for _, field := range clixconfig.CredentialFields(creds) {
+			if field.Key == args[1] {
+				*field.Value = args[2]
+				return saveConfig(config, configFilePath)
+			}
+		}
+		return fmt.Errorf(tr("unknown config key %q"), args[1])
+	case "reset":
+		if stdinIsTerminal() {
+			return initWizard(config, configFilePath, initOptions{profile: config.Active, verify: true})
+		}
+		*creds = Credentials{}
+		if err := promptForConfigValues(creds); err != nil {
+			return err
+		}
+		return saveConfig(config, configFilePath)
+	default:
+		return withExitCode(exitUsage, fmt.Errorf(tr("unknown config action %q"), action))
+	}
+}
@@ -0,0 +1,219 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// DefaultAccountName names the account stored at the top level of a
+// config
+const DefaultAccountName = "default"
+
+// AccountEnvVar selects an account when none is given
+const AccountEnvVar = "CLIX_ACCOUNT"
+
+// PassphraseEnvVar holds the passphrase of an encrypted config for
+// non-interactive use
+const PassphraseEnvVar = "CLIX_PASSPHRASE"
+
+// Config is the part of a config file that says who posts: the
+// credentials of the default account at the top level and of the others
+// under "accounts". clix keeps its own settings beside them in a struct
+// that embeds Config; other programs can decode the file into Config
+// alone.
+type Config struct {
+	Credentials
+	DefaultAccount string                  `json:"default_account,omitempty"`
+	Accounts       map[string]*Credentials `json:"accounts,omitempty"`
+
+	// Active is the account in use, as Select picked it
+	Active string `json:"-"`
+}
+
+// ErrUnknownAccount is returned by Account for an account the config does
+// not have
+var ErrUnknownAccount = errors.New("unknown account")
+
+// Select picks the account in use: name when it is not empty, then
+// $CLIX_ACCOUNT, then the configured default
+func (c *Config) Select(name string) {
+	switch {
+	case name != "":
+		c.Active = name
+	case os.Getenv(AccountEnvVar) != "":
+		c.Active = os.Getenv(AccountEnvVar)
+	case c.DefaultAccount != "":
+		c.Active = c.DefaultAccount
+	default:
+		c.Active = DefaultAccountName
+	}
+}
+
+// Account returns the credentials stored under name, reading them from the
+// keychain when they are kept there. With create set, a missing account is
+// added to the config.
+func (c *Config) Account(name string, create bool) (*Credentials, error) {
+	if name == DefaultAccountName {
+		return &c.Credentials, c.Credentials.LoadFromKeychain(name)
+	}
+	if creds, ok := c.Accounts[name]; ok {
+		return creds, creds.LoadFromKeychain(name)
+	}
+	if !create {
+		return nil, fmt.Errorf("%w %q", ErrUnknownAccount, name)
+	}
+	if c.Accounts == nil {
+		c.Accounts = make(map[string]*Credentials)
+	}
+	creds := &Credentials{}
+	c.Accounts[name] = creds
+	return creds, nil
+}
+
+// ActiveCredentials returns the credentials of the account in use
+func (c *Config) ActiveCredentials() (*Credentials, error) {
+	return c.Account(c.Active, false)
+}
+
+// AccountNames lists every configured account, the default first
+func (c *Config) AccountNames() []string {
+	names := make([]string, 0, len(c.Accounts)+1)
+	for name := range c.Accounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{DefaultAccountName}, names...)
+}
+
+// CredentialField is a credential as the config file and the environment
+// name it
+type CredentialField struct {
+	Key   string
+	Value *string
+}
+
+// CredentialFields maps config keys to the OAuth 1.0a credentials of creds,
+// in the order they are asked for
+func CredentialFields(creds *Credentials) []CredentialField {
+	return []CredentialField{
+		{"consumer_key", &creds.ConsumerKey},
+		{"consumer_secret", &creds.ConsumerSecret},
+		{"access_token", &creds.AccessToken},
+		{"access_secret", &creds.AccessSecret},
+	}
+}
+
+// CredentialEnvVar returns the environment variable for a credential key,
+// e.g. CLIX_CONSUMER_KEY for consumer_key
+func CredentialEnvVar(key string) string {
+	return "CLIX_" + strings.ToUpper(key)
+}
+
+// EnvSet reports whether any credential is set in the environment, which
+// takes the place of the config file
+func EnvSet() bool {
+	for _, field := range CredentialFields(&Credentials{}) {
+		if os.Getenv(CredentialEnvVar(field.Key)) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingEnvError is returned by FromEnv when some of the credentials are
+// set in the environment but not all of them
+type MissingEnvError struct {
+	Name string
+}
+
+func (e *MissingEnvError) Error() string {
+	return fmt.Sprintf("$%s is not set; credentials from the environment need all of $CLIX_CONSUMER_KEY, $CLIX_CONSUMER_SECRET, $CLIX_ACCESS_TOKEN and $CLIX_ACCESS_SECRET", e.Name)
+}
+
+// FromEnv builds a config of the default account from the credentials in
+// the environment, for CI and containers
+func FromEnv() (*Config, error) {
+	c := &Config{Active: DefaultAccountName}
+	for _, field := range CredentialFields(&c.Credentials) {
+		name := CredentialEnvVar(field.Key)
+		if *field.Value = os.Getenv(name); *field.Value == "" {
+			return nil, &MissingEnvError{name}
+		}
+	}
+	return c, nil
+}
+
+// FileError is a config file that could not be read: Op is "open",
+// "decrypt" or "parse"
+type FileError struct {
+	Op   string
+	Path string
+	Err  error
+}
+
+func (e *FileError) Error() string {
+	return fmt.Sprintf("failed to %s config file %s: %v", e.Op, e.Path, e.Err)
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// Read decodes the config file at path into into, a *Config or a struct
+// embedding one. An encrypted file is decrypted with what passphrase
+// returns, which is only called then. Read returns the file as it is
+// stored, or nil when there is none, leaving into as it was.
+func Read(path string, into any, passphrase func() (string, error)) ([]byte, error) {
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &FileError{"open", path, err}
+	}
+	data := raw
+	if IsEncrypted(raw) {
+		secret, err := passphrase()
+		if err != nil {
+			return nil, &FileError{"decrypt", path, err}
+		}
+		if data, err = Decrypt(raw, secret); err != nil {
+			return nil, &FileError{"decrypt", path, err}
+		}
+	}
+	if err := json.Unmarshal(data, into); err != nil {
+		return nil, &FileError{"parse", path, err}
+	}
+	return raw, nil
+}
+
+// Load reads the accounts clix posts with and selects account, "" for the
+// one clix would pick. Credentials in the environment take the place of
+// the user's config file, which is decrypted with $CLIX_PASSPHRASE when it
+// is encrypted.
+func Load(account string) (*Config, error) {
+	if EnvSet() {
+		return FromEnv()
+	}
+	path, _, err := UserFile()
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{}
+	if _, err := Read(path, c, envPassphrase); err != nil {
+		return nil, err
+	}
+	c.Select(account)
+	return c, nil
+}
+
+func envPassphrase() (string, error) {
+	if passphrase := os.Getenv(PassphraseEnvVar); passphrase != "" {
+		return passphrase, nil
+	}
+	return "", fmt.Errorf("the config is encrypted; set $%s", PassphraseEnvVar)
+}
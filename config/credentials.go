@@ -0,0 +1,53 @@
+// Package config holds what clix keeps of an account and where: the
+// credentials of each account, the config files they are read from, the
+// system keychain and the encryption of a config under a passphrase.
+package config
+
+import "time"
+
+// Credentials are the keys for one account: either OAuth 1.0a keys copied
+// from the developer portal or an OAuth 2.0 token from `clix login`
+type Credentials struct {
+	ConsumerKey    string `json:"consumer_key"`
+	ConsumerSecret string `json:"consumer_secret"`
+	AccessToken    string `json:"access_token"`
+	AccessSecret   string `json:"access_secret"`
+
+	ClientID     string       `json:"client_id,omitempty"`
+	ClientSecret string       `json:"client_secret,omitempty"`
+	OAuth2       *OAuth2Token `json:"oauth2,omitempty"`
+
+	// Keychain keeps the account's credentials in the system keychain,
+	// leaving only this flag in the file
+	Keychain bool `json:"keychain,omitempty"`
+	// keychainCopy is what the keychain held when it was read, empty
+	// until then
+	keychainCopy string
+}
+
+// Complete reports whether the credentials are enough to post with
+func (c *Credentials) Complete() bool {
+	// Credentials in the keychain are read only when used
+	if c.Keychain && c.keychainCopy == "" {
+		return true
+	}
+	return c.HasOAuth2() || c.ConsumerKey != "" && c.ConsumerSecret != "" && c.AccessToken != "" && c.AccessSecret != ""
+}
+
+// HasOAuth2 reports whether the account logged in with `clix login`
+func (c *Credentials) HasOAuth2() bool {
+	return c.OAuth2 != nil && c.OAuth2.AccessToken != ""
+}
+
+// OAuth2Token is an OAuth 2.0 user-context token obtained by `clix login`
+type OAuth2Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	Scope        string    `json:"scope,omitempty"`
+}
+
+// Expired reports whether the token is expired or about to expire
+func (t *OAuth2Token) Expired() bool {
+	return !t.ExpiresAt.IsZero() && time.Until(t.ExpiresAt) < time.Minute
+}
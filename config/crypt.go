@@ -0,0 +1,117 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// scrypt parameters for deriving the config key from the passphrase
+const (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+	keyLen  = 32
+)
+
+// encryptedConfig is the on-disk form of an encrypted clix.json: the
+// plain config JSON sealed with AES-256-GCM under a key derived from the
+// passphrase with scrypt
+type encryptedConfig struct {
+	Encrypted *sealedConfig `json:"encrypted"`
+}
+
+type sealedConfig struct {
+	KDF        string `json:"kdf"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// ErrWrongPassphrase is returned by Decrypt when the passphrase does not
+// open the config
+var ErrWrongPassphrase = errors.New("wrong passphrase or damaged config file")
+
+// IsEncrypted reports whether data is an encrypted config
+func IsEncrypted(data []byte) bool {
+	var envelope encryptedConfig
+	return json.Unmarshal(data, &envelope) == nil && envelope.Encrypted != nil
+}
+
+// Encrypt seals the config JSON with passphrase, leaving it plain when
+// passphrase is empty
+func Encrypt(plain []byte, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return plain, nil
+	}
+	sealed, err := seal(plain, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt config: %w", err)
+	}
+	return json.MarshalIndent(encryptedConfig{sealed}, "", "  ")
+}
+
+// Decrypt returns the plain config JSON of an encrypted config. Plain
+// configs are returned as they are.
+func Decrypt(data []byte, passphrase string) ([]byte, error) {
+	var envelope encryptedConfig
+	if json.Unmarshal(data, &envelope) != nil || envelope.Encrypted == nil {
+		return data, nil
+	}
+	return envelope.Encrypted.open(passphrase)
+}
+
+func seal(plain []byte, passphrase string) (*sealedConfig, error) {
+	sealed := &sealedConfig{KDF: "scrypt", N: scryptN, R: scryptR, P: scryptP, Salt: make([]byte, 16)}
+	if _, err := rand.Read(sealed.Salt); err != nil {
+		return nil, err
+	}
+	aead, err := sealed.cipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	sealed.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(sealed.Nonce); err != nil {
+		return nil, err
+	}
+	sealed.Ciphertext = aead.Seal(nil, sealed.Nonce, plain, nil)
+	return sealed, nil
+}
+
+func (s *sealedConfig) open(passphrase string) ([]byte, error) {
+	if s.KDF != "scrypt" {
+		return nil, fmt.Errorf("unsupported key derivation %q", s.KDF)
+	}
+	aead, err := s.cipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.Nonce) != aead.NonceSize() {
+		return nil, ErrWrongPassphrase
+	}
+	plain, err := aead.Open(nil, s.Nonce, s.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plain, nil
+}
+
+func (s *sealedConfig) cipher(passphrase string) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), s.Salt, s.N, s.R, s.P, keyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testConfig = `{"consumer_key": "ck", "accounts": {"work": {"consumer_key": "wk"}}}`
+
+func TestEncryptDecrypt(t *testing.T) {
+	sealed, err := Encrypt([]byte(testConfig), "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(sealed) || bytes.Contains(sealed, []byte("ck")) {
+		t.Fatalf("Encrypt() = %s, want the config sealed", sealed)
+	}
+	tests := []struct {
+		name       string
+		data       []byte
+		passphrase string
+		want       string
+		wantErr    error
+	}{
+		{"right passphrase", sealed, "correct horse", testConfig, nil},
+		{"wrong passphrase", sealed, "battery staple", "", ErrWrongPassphrase},
+		{"no passphrase", sealed, "", "", ErrWrongPassphrase},
+		{"damaged", tamper(t, sealed, func(s *sealedConfig) { s.Ciphertext[0] ^= 1 }), "correct horse", "", ErrWrongPassphrase},
+		{"other salt", tamper(t, sealed, func(s *sealedConfig) { s.Salt[0] ^= 1 }), "correct horse", "", ErrWrongPassphrase},
+		{"short nonce", tamper(t, sealed, func(s *sealedConfig) { s.Nonce = s.Nonce[1:] }), "correct horse", "", ErrWrongPassphrase},
+		{"plain", []byte(testConfig), "anything", testConfig, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decrypt(tt.data, tt.passphrase)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Decrypt() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Decrypt() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncryptSaltsEachTime(t *testing.T) {
+	first, err := Encrypt([]byte(testConfig), "pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := Encrypt([]byte(testConfig), "pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(first, second) {
+		t.Error("two encryptions of the same config are the same")
+	}
+}
+
+func TestEncryptWithoutPassphrase(t *testing.T) {
+	got, err := Encrypt([]byte(testConfig), "")
+	if err != nil || string(got) != testConfig || IsEncrypted(got) {
+		t.Errorf("Encrypt() with no passphrase = %s, %v, want the config as it was", got, err)
+	}
+}
+
+func TestUnsupportedKDF(t *testing.T) {
+	sealed, err := Encrypt([]byte(testConfig), "pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Decrypt(tamper(t, sealed, func(s *sealedConfig) { s.KDF = "argon2" }), "pw"); err == nil {
+		t.Error("Decrypt() with an unknown key derivation = nil error, want one")
+	}
+}
+
+func TestReadEncrypted(t *testing.T) {
+	sealed, err := Encrypt([]byte(testConfig), "pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "clix.json")
+	if err := os.WriteFile(path, sealed, 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		passphrase string
+		wantOp     string
+	}{
+		{"right passphrase", "pw", ""},
+		{"wrong passphrase", "nope", "decrypt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c Config
+			raw, err := Read(path, &c, func() (string, error) { return tt.passphrase, nil })
+			var fileErr *FileError
+			if tt.wantOp != "" {
+				if !errors.As(err, &fileErr) || fileErr.Op != tt.wantOp {
+					t.Fatalf("Read() error = %v, want a %s error", err, tt.wantOp)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(raw, sealed) {
+				t.Error("Read() did not return the file as it is stored")
+			}
+			if c.ConsumerKey != "ck" || c.Accounts["work"].ConsumerKey != "wk" {
+				t.Errorf("Read() decoded %+v", c)
+			}
+		})
+	}
+}
+
+// tamper returns sealed with change made to its envelope
+func tamper(t *testing.T, sealed []byte, change func(*sealedConfig)) []byte {
+	t.Helper()
+	var envelope encryptedConfig
+	if err := json.Unmarshal(sealed, &envelope); err != nil {
+		t.Fatal(err)
+	}
+	copied := *envelope.Encrypted
+	copied.Salt = bytes.Clone(copied.Salt)
+	copied.Nonce = bytes.Clone(copied.Nonce)
+	copied.Ciphertext = bytes.Clone(copied.Ciphertext)
+	change(&copied)
+	data, err := json.Marshal(encryptedConfig{&copied})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
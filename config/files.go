@@ -0,0 +1,48 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// FileName is the user's config under the config directory
+const FileName = "clix.json"
+
+// ProjectFileName is a config in the current directory, which takes
+// precedence over the user's config
+const ProjectFileName = ".clix.json"
+
+// File returns the config file in use and where it was found: "project"
+// for .clix.json in the current directory, otherwise as UserFile
+func File() (path, source string, err error) {
+	if _, err := os.Stat(ProjectFileName); err == nil {
+		if path, err := filepath.Abs(ProjectFileName); err == nil {
+			return path, "project", nil
+		}
+	}
+	return UserFile()
+}
+
+// UserFile returns the user's config file: clix.json under
+// $XDG_CONFIG_HOME, or under ~/.config when that is unset, with source
+// "XDG_CONFIG_HOME" or "home". A config that already exists under
+// ~/.config keeps being used when $XDG_CONFIG_HOME has none.
+func UserFile() (path, source string, err error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", "", err
+	}
+	home := filepath.Join(homeDir, ".config", FileName)
+
+	xdg := os.Getenv("XDG_CONFIG_HOME")
+	if xdg == "" || !filepath.IsAbs(xdg) {
+		return home, "home", nil
+	}
+	path = filepath.Join(xdg, FileName)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if _, err := os.Stat(home); err == nil {
+			return home, "home", nil
+		}
+	}
+	return path, "XDG_CONFIG_HOME", nil
+}
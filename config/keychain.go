@@ -0,0 +1,166 @@
+package config
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// keychainService is the service the credentials are filed under in the
+// system keychain, one entry per account
+const keychainService = "clix"
+
+// KeychainAvailable reports whether the system keychain can be used: the
+// macOS Keychain through security, or the Secret Service (GNOME Keyring,
+// KWallet) through secret-tool
+func KeychainAvailable() bool {
+	return CheckKeychain() == nil
+}
+
+// CheckKeychain returns why the system keychain cannot be used, if it
+// cannot
+func CheckKeychain() error {
+	_, err := keychainTool()
+	return err
+}
+
+func keychainTool() (string, error) {
+	name := "secret-tool"
+	if runtime.GOOS == "darwin" {
+		name = "security"
+	}
+	bin, err := exec.LookPath(name)
+	if err != nil {
+		if runtime.GOOS == "darwin" {
+			return "", fmt.Errorf("the keychain needs the security tool")
+		}
+		return "", fmt.Errorf("the keychain needs secret-tool; install libsecret-tools or store the credentials in the config file")
+	}
+	return bin, nil
+}
+
+// ErrNotInKeychain is returned for an account with no keychain entry
+var ErrNotInKeychain = errors.New("not in the keychain")
+
+func keychainGet(account string) (string, error) {
+	bin, err := keychainTool()
+	if err != nil {
+		return "", err
+	}
+	var cmd *exec.Cmd
+	if runtime.GOOS == "darwin" {
+		cmd = exec.Command(bin, "find-generic-password", "-s", keychainService, "-a", account, "-w")
+	} else {
+		cmd = exec.Command(bin, "lookup", "service", keychainService, "account", account)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		// security exits 44 for a missing item; secret-tool exits 1 saying
+		// nothing
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && (exitErr.ExitCode() == 44 || runtime.GOOS != "darwin" && stderr.Len() == 0) {
+			return "", ErrNotInKeychain
+		}
+		return "", fmt.Errorf("%s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if runtime.GOOS != "darwin" && len(out) == 0 {
+		return "", ErrNotInKeychain
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// keychainSet stores secret for account. The secret goes on stdin, never
+// on the command line where other users could see it.
+func keychainSet(account, secret string) error {
+	bin, err := keychainTool()
+	if err != nil {
+		return err
+	}
+	var cmd *exec.Cmd
+	if runtime.GOOS == "darwin" {
+		// security -i reads its commands from stdin; -X takes the secret
+		// in hex, so nothing needs quoting
+		cmd = exec.Command(bin, "-i")
+		cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %q -X %s\n",
+			keychainService, account, hex.EncodeToString([]byte(secret))))
+	} else {
+		cmd = exec.Command(bin, "store", "--label", "clix: "+account, "service", keychainService, "account", account)
+		cmd.Stdin = strings.NewReader(secret)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to store the credentials of %q in the keychain: %v: %s", account, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// DeleteFromKeychain removes the credentials of account from the keychain
+func DeleteFromKeychain(account string) error {
+	bin, err := keychainTool()
+	if err != nil {
+		return err
+	}
+	var cmd *exec.Cmd
+	if runtime.GOOS == "darwin" {
+		cmd = exec.Command(bin, "delete-generic-password", "-s", keychainService, "-a", account)
+	} else {
+		cmd = exec.Command(bin, "clear", "service", keychainService, "account", account)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to remove the credentials of %q from the keychain: %v: %s", account, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// LoadFromKeychain fills in the credentials of an account kept in the
+// keychain, once per run. It returns ErrNotInKeychain when the keychain
+// has none for the account.
+func (c *Credentials) LoadFromKeychain(account string) error {
+	if !c.Keychain || c.keychainCopy != "" {
+		return nil
+	}
+	secret, err := keychainGet(account)
+	if errors.Is(err, ErrNotInKeychain) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read the credentials of %q from the keychain: %w", account, err)
+	}
+	if err := json.Unmarshal([]byte(secret), c); err != nil {
+		return fmt.Errorf("the keychain entry of %q does not hold clix credentials: %w", account, err)
+	}
+	c.Keychain, c.keychainCopy = true, secret
+	return nil
+}
+
+// ForFile returns the credentials as the config file holds them. Those of
+// an account kept in the keychain are stored there when they changed, and
+// only the flag is left for the file.
+func (c *Credentials) ForFile(account string) (*Credentials, error) {
+	if !c.Keychain {
+		return c, nil
+	}
+	stored := *c
+	stored.Keychain = false
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return nil, err
+	}
+	if empty, _ := json.Marshal(Credentials{}); c.keychainCopy == "" && bytes.Equal(data, empty) {
+		// Never loaded in this run, so the keychain has them as they were
+		return &Credentials{Keychain: true}, nil
+	}
+	if string(data) != c.keychainCopy {
+		if err := keychainSet(account, string(data)); err != nil {
+			return nil, err
+		}
+		c.keychainCopy = string(data)
+	}
+	return &Credentials{Keychain: true}, nil
+}
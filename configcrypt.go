@@ -0,0 +1,68 @@
+package clix
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	clixconfig "github.com/voltycodes/clix/config"
+)
+
+// passphraseEnvVar holds the config passphrase for non-interactive use
+const passphraseEnvVar = clixconfig.PassphraseEnvVar
+
+// configPassphrase is the passphrase of an encrypted config, kept for the
+// rest of the invocation so saving re-encrypts and it is asked for once.
+// It is empty while the config is stored in plain text.
+var configPassphrase string
+
+// decryptConfig returns the plain config JSON from data, asking for the
+// passphrase of the named file if data is an encrypted config. Plain
+// configs are returned as they are.
+func decryptConfig(name string, data []byte) ([]byte, error) {
+	if !clixconfig.IsEncrypted(data) {
+		return data, nil
+	}
+	if configPassphrase != "" {
+		return clixconfig.Decrypt(data, configPassphrase)
+	}
+	passphrase, err := readPassphrase(trf("Passphrase for %s: ", name), false)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := clixconfig.Decrypt(data, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	configPassphrase = passphrase
+	return plain, nil
+}
+
+// readPassphrase takes the passphrase from $CLIX_PASSPHRASE or prompts for
+// it without echo. With confirm set, a prompted passphrase is asked twice.
+func readPassphrase(prompt string, confirm bool) (string, error) {
+	if passphrase := os.Getenv(passphraseEnvVar); passphrase != "" {
+		return passphrase, nil
+	}
+	if !stdinIsTerminal() {
+		return "", fmt.Errorf(tr("no terminal to read the config passphrase from; set $%s"), passphraseEnvVar)
+	}
+
+	passphrase, err := promptSecret(prompt)
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", errors.New(tr("empty passphrase"))
+	}
+	if confirm {
+		again, err := promptSecret("Repeat passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if again != passphrase {
+			return "", errors.New(tr("passphrases do not match"))
+		}
+	}
+	return passphrase, nil
+}
@@ -0,0 +1,41 @@
+package clix
+
+import (
+	"fmt"
+
+	"github.com/voltycodes/clix/compose"
+)
+
+// checkLength returns an error if text is over the tweet length limit
+func checkLength(text string) error {
+	if n := compose.Length(text); n > compose.MaxLength {
+		return withExitCode(exitValidation, fmt.Errorf(tr("tweet is %d characters, the limit is %d"), n, compose.MaxLength))
+	}
+	return nil
+}
+
+func runCount(args []string) error {
+	fs := newFlagSet("count", "count [text]  (reads stdin when no text is given)")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	text, err := readText(args)
+	if err != nil {
+		return err
+	}
+	n := compose.Length(text)
+	if machineReadable() {
+		printResult(struct {
+			Length int `json:"length"`
+			Limit  int `json:"limit"`
+		}{n, compose.MaxLength})
+	} else {
+		fmt.Printf("%d/%d\n", n, compose.MaxLength)
+	}
+	if n > compose.MaxLength {
+		return withExitCode(exitValidation, fmt.Errorf(tr("%d characters over the limit"), n-compose.MaxLength))
+	}
+	return nil
+}
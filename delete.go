@@ -0,0 +1,91 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/michimani/gotwi/tweet/managetweet"
+	"github.com/michimani/gotwi/tweet/managetweet/types"
+)
+
+// deleteTweet removes one of the authenticated user's tweets
+func (a *app) deleteTweet(ctx context.Context, id string) error {
+	if err := a.ensureToken(ctx); err != nil {
+		return err
+	}
+	start := time.Now()
+	res, err := managetweet.Delete(ctx, a.client, &types.DeleteInput{ID: id})
+	if err == nil && res.Data.Deleted != nil && !*res.Data.Deleted {
+		err = fmt.Errorf(tr("tweet %s was not deleted"), id)
+	}
+	a.stats.observe("deletes", "delete", start, err)
+	if err != nil {
+		return err
+	}
+
+	if err := markDeleted(id); err != nil {
+		fmt.Fprintln(os.Stderr, tr("Warning: tweet was deleted but history was not updated:"), err)
+	}
+	a.runHooks(hookDelete, id, "", nil)
+	return nil
+}
+
+func runDelete(args []string) error {
+	fs := newFlagSet("delete", "delete [--yes] <id|url> | --last")
+	last := fs.Bool("last", false, "delete the most recent tweet posted with clix")
+	yes := fs.Bool("yes", false, "do not ask for confirmation")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if *last == (len(args) == 1) || len(args) > 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	var id, text string
+	if *last {
+		entry, err := lastPosted()
+		if err != nil {
+			return err
+		}
+		id, text = entry.ID, entry.Text
+	} else if id, err = parseTweetID(args[0]); err != nil {
+		return err
+	}
+
+	if !*yes {
+		if !stdinIsTerminal() {
+			return withExitCode(exitRefused, errors.New(tr("refusing to delete without confirmation; pass --yes")))
+		}
+		question := trf("Delete tweet %s?", id)
+		if text != "" {
+			question = trf("Delete tweet %s (%q)?", id, text)
+		}
+		if !confirm(question) {
+			return errors.New(tr("aborted"))
+		}
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	if err := a.deleteTweet(rootCtx, id); err != nil {
+		return fmt.Errorf(tr("failed to delete tweet: %w"), err)
+	}
+
+	if machineReadable() {
+		return printResult(struct {
+			ID      string `json:"id"`
+			Deleted bool   `json:"deleted"`
+		}{id, true})
+	}
+	fmt.Printf(tr("Tweet deleted. [ID: %s]\n"), id)
+	return nil
+}
@@ -0,0 +1,151 @@
+package clix
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// destination is a network other than X that a post can be copied to with
+// --to. Each one has its own config section and length rules.
+type destination interface {
+	name() string
+	// check validates text and media against the network's limits
+	check(text string, media []*mediaFile) error
+	post(ctx context.Context, text string, media []*mediaFile) (crossPost, error)
+}
+
+// crossPost is the result of posting to one destination
+type crossPost struct {
+	Destination string `json:"destination"`
+	ID          string `json:"id,omitempty"`
+	URL         string `json:"url,omitempty"`
+	DryRun      bool   `json:"dry_run,omitempty"`
+}
+
+const xDestination = "x"
+
+// parseDestinations normalizes a --to list into destination names,
+// dropping duplicates
+func parseDestinations(to string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(to, ",") {
+		switch name = strings.ToLower(strings.TrimSpace(name)); name {
+		case "":
+			continue
+		case "twitter":
+			name = xDestination
+		case "bluesky":
+			name = "bsky"
+		case xDestination, "mastodon", "bsky":
+		default:
+			return nil, fmt.Errorf(tr("unknown destination %q, expected x, mastodon or bsky"), name)
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, errors.New(tr("--to needs at least one destination"))
+	}
+	return names, nil
+}
+
+// newDestination sets up a destination other than X from its config section
+func newDestination(name string, config *Config) (destination, error) {
+	switch name {
+	case "mastodon":
+		return newMastodon(config.Mastodon)
+	case "bsky":
+		return newBluesky(config.Bluesky)
+	}
+	return nil, fmt.Errorf(tr("unknown destination %q"), name)
+}
+
+// crossPostRequest checks that req only uses what every destination
+// supports: text and media
+func crossPostRequest(req *postRequest) error {
+	if req.replyTo != "" || req.quote != "" || len(req.poll) > 0 || req.split {
+		return errors.New(tr("--reply-to, --quote, --poll and --split only work when posting to x alone"))
+	}
+	return nil
+}
+
+// runCrossPost posts p to X through a, when a is not nil, and to every
+// other destination. Every destination is checked before anything is
+// posted; a failure on one does not stop the others. It returns what was
+// posted, none of it on a dry run.
+func runCrossPost(ctx context.Context, a *app, p *preparedPost, others []destination) ([]crossPost, error) {
+	for _, dest := range others {
+		if err := dest.check(p.parts[0], p.media); err != nil {
+			return nil, fmt.Errorf("%s: %w", dest.name(), err)
+		}
+	}
+	if globalOptions.dryRun {
+		results := []crossPost{}
+		would := "\nWould post to %s.\n"
+		if a != nil {
+			dryRunResults(p)
+			results = append(results, crossPost{Destination: xDestination, DryRun: true})
+			would = "\nWould also post to %s.\n"
+		} else if !machineReadable() {
+			fmt.Println(tr("Dry run, nothing was posted."))
+			fmt.Println()
+			for _, line := range strings.Split(p.parts[0], "\n") {
+				fmt.Println("  │ " + line)
+			}
+		}
+		for _, dest := range others {
+			results = append(results, crossPost{Destination: dest.name(), DryRun: true})
+			if !machineReadable() {
+				fmt.Printf(tr(would), dest.name())
+			}
+		}
+		if machineReadable() {
+			return nil, printResult(results)
+		}
+		return nil, nil
+	}
+
+	results := []crossPost{}
+	report := func(result crossPost) {
+		results = append(results, result)
+		if !machineReadable() {
+			fmt.Printf("%s: %s\n", result.Destination, cmp.Or(result.URL, result.ID))
+		}
+	}
+
+	var errs []error
+	if a != nil {
+		posted, err := a.publish(ctx, p, func(postResult) {})
+		for _, result := range posted {
+			report(crossPost{Destination: xDestination, ID: result.ID, URL: tweetURL("", result.ID)})
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", xDestination, err))
+		}
+	}
+	for _, dest := range others {
+		start := time.Now()
+		result, err := dest.post(ctx, p.parts[0], p.media)
+		verbosef("posted to %s in %s", dest.name(), time.Since(start).Round(time.Millisecond))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", dest.name(), err))
+			continue
+		}
+		report(result)
+	}
+
+	if machineReadable() {
+		printResult(results)
+	}
+	if len(errs) > 0 && len(results) > 0 && !machineReadable() {
+		fmt.Fprintln(os.Stderr, tr("Some destinations were posted to; retrying with the same --to would post there again."))
+	}
+	return results, errors.Join(errs...)
+}
@@ -0,0 +1,288 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/user/userlookup"
+	userlookuptypes "github.com/michimani/gotwi/user/userlookup/types"
+)
+
+// gotwi does not cover direct messages, so these endpoints are called
+// directly
+const (
+	dmSendEndpoint   = "https://api.twitter.com/2/dm_conversations/with/%s/messages"
+	dmEventsEndpoint = "https://api.twitter.com/2/dm_events"
+)
+
+// dmEvent is one message as returned by the dm_events endpoint
+type dmEvent struct {
+	ID             string    `json:"id"`
+	EventType      string    `json:"event_type"`
+	Text           string    `json:"text"`
+	SenderID       string    `json:"sender_id"`
+	ConversationID string    `json:"dm_conversation_id"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type dmEventsResponse struct {
+	Data     []dmEvent `json:"data"`
+	Includes struct {
+		Users []struct {
+			ID       string `json:"id"`
+			Name     string `json:"name"`
+			Username string `json:"username"`
+		} `json:"users"`
+	} `json:"includes"`
+}
+
+// dmView is a message with its sender resolved, as shown by `clix dm list`
+type dmView struct {
+	ID             string    `json:"id"`
+	ConversationID string    `json:"conversation_id"`
+	Text           string    `json:"text"`
+	SenderID       string    `json:"sender_id"`
+	SenderName     string    `json:"sender_name,omitempty"`
+	SenderUsername string    `json:"sender_username,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// trimHandle turns "@user" into "user"
+func trimHandle(username string) string {
+	return strings.TrimPrefix(strings.TrimSpace(username), "@")
+}
+
+// lookupUser resolves a username, with or without the @, to a user ID
+func (a *app) lookupUser(ctx context.Context, username string) (string, error) {
+	username = trimHandle(username)
+	if username == "" {
+		return "", errors.New(tr("no username given"))
+	}
+	if err := a.ensureToken(ctx); err != nil {
+		return "", err
+	}
+	res, err := userlookup.GetByUsername(ctx, a.client, &userlookuptypes.GetByUsernameInput{Username: username})
+	if err != nil {
+		return "", fmt.Errorf(tr("failed to look up @%s: %w"), username, err)
+	}
+	// An unknown user is an error in the body of a 200 response
+	if res.Data.ID == nil {
+		return "", withExitCode(exitNotFound, fmt.Errorf(tr("@%s does not exist or is suspended"), username))
+	}
+	return gotwi.StringValue(res.Data.ID), nil
+}
+
+// sendDM sends a direct message to a user, with an optional media file
+func (a *app) sendDM(ctx context.Context, userID, text string, media *mediaFile) (string, error) {
+	message := map[string]any{"text": text}
+	if media != nil {
+		ids, err := a.uploadMedia(ctx, []*mediaFile{media})
+		if err != nil {
+			return "", err
+		}
+		message["attachments"] = []map[string]string{{"media_id": ids[0]}}
+	}
+	body, err := json.Marshal(message)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := a.newSignedRequest(ctx, http.MethodPost, fmt.Sprintf(dmSendEndpoint, url.PathEscape(userID)), nil, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	var res struct {
+		Data struct {
+			EventID string `json:"dm_event_id"`
+		} `json:"data"`
+	}
+	start := time.Now()
+	err = a.doJSON(req, &res)
+	a.stats.observe("dms", "dm", start, err)
+	if err != nil {
+		return "", err
+	}
+	return res.Data.EventID, nil
+}
+
+// recentDMs returns the latest messages across all conversations
+func (a *app) recentDMs(ctx context.Context, count int) ([]dmView, error) {
+	query := url.Values{
+		"max_results":     {strconv.Itoa(min(max(count, 1), 100))},
+		"event_types":     {"MessageCreate"},
+		"dm_event.fields": {"id,text,sender_id,dm_conversation_id,created_at"},
+		"expansions":      {"sender_id"},
+		"user.fields":     {"name,username"},
+	}
+	req, err := a.newSignedRequest(ctx, http.MethodGet, dmEventsEndpoint, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	var res dmEventsResponse
+	if err := a.doJSON(req, &res); err != nil {
+		return nil, fmt.Errorf(tr("failed to fetch messages: %w"), err)
+	}
+
+	views := make([]dmView, 0, len(res.Data))
+	for _, event := range res.Data {
+		view := dmView{
+			ID: event.ID, ConversationID: event.ConversationID, Text: event.Text,
+			SenderID: event.SenderID, CreatedAt: event.CreatedAt,
+		}
+		for _, user := range res.Includes.Users {
+			if user.ID == event.SenderID {
+				view.SenderName, view.SenderUsername = user.Name, user.Username
+			}
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+// openDMMedia validates a DM attachment; DMs take a single file, uploaded
+// under the dm_ media categories
+func openDMMedia(path string) (*mediaFile, error) {
+	files, err := openMediaFiles([]string{path}, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	file := files[0]
+	file.category = strings.Replace(file.category, "tweet_", "dm_", 1)
+	return file, nil
+}
+
+func runDM(args []string) error {
+	if len(args) > 0 && isHelpArg(args[0]) {
+		fmt.Fprintln(os.Stderr, tr("Usage: clix dm [list|send @user <message>]"))
+		return nil
+	}
+	action := "list"
+	if len(args) > 0 && !isFlagArg(args[0]) {
+		action, args = args[0], args[1:]
+	}
+
+	switch action {
+	case "list":
+		return runDMList(args)
+	case "send":
+		return runDMSend(args)
+	default:
+		return withExitCode(exitUsage, fmt.Errorf(tr("unknown dm action %q"), action))
+	}
+}
+
+func runDMSend(args []string) error {
+	fs := newFlagSet("dm send", "dm send [--media path] @user [message]  (reads stdin when no message is given)")
+	mediaPath := fs.String("media", "", "attach an image, GIF or video")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	text, err := readText(args[1:])
+	if err != nil {
+		return err
+	}
+	var media *mediaFile
+	if *mediaPath != "" {
+		if media, err = openDMMedia(*mediaPath); err != nil {
+			return err
+		}
+	}
+	if text == "" && media == nil {
+		return errors.New(tr("nothing to send"))
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	ctx := rootCtx
+	userID, err := a.lookupUser(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	id, err := a.sendDM(ctx, userID, text, media)
+	if err != nil {
+		return fmt.Errorf(tr("failed to send message: %w"), err)
+	}
+
+	if machineReadable() {
+		return printResult(struct {
+			ID     string `json:"id"`
+			UserID string `json:"user_id"`
+			Text   string `json:"text"`
+		}{id, userID, text})
+	}
+	fmt.Printf(tr("Message sent. [ID: %s]\n"), id)
+	return nil
+}
+
+func runDMList(args []string) error {
+	fs := newFlagSet("dm list", "dm list [--count n] [--all]")
+	count := fs.Int("count", 50, "number of recent messages to look at, up to 100")
+	all := fs.Bool("all", false, "show every message instead of the latest per conversation")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	messages, err := a.recentDMs(rootCtx, *count)
+	if err != nil {
+		return err
+	}
+	if !*all {
+		// Events arrive newest first, so the first of each conversation is its latest
+		seen := map[string]bool{}
+		latest := messages[:0]
+		for _, message := range messages {
+			if !seen[message.ConversationID] {
+				seen[message.ConversationID] = true
+				latest = append(latest, message)
+			}
+		}
+		messages = latest
+	}
+
+	if machineReadable() {
+		return printResult(messages)
+	}
+	if len(messages) == 0 {
+		fmt.Println(tr("No messages."))
+		return nil
+	}
+	for _, message := range messages {
+		sender := message.SenderID
+		if message.SenderUsername != "" {
+			sender = strings.TrimSpace(message.SenderName + " @" + message.SenderUsername)
+		}
+		fmt.Printf("%s · %s\n", sender, formatTime(message.CreatedAt))
+		for _, line := range strings.Split(message.Text, "\n") {
+			fmt.Printf("  %s\n", line)
+		}
+		fmt.Println()
+	}
+	return nil
+}
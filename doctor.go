@@ -0,0 +1,323 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+const meEndpoint = "https://api.twitter.com/2/users/me"
+
+// doctorCheck is the outcome of one check of clix doctor
+type doctorCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"` // ok, warn or fail
+	Detail string `json:"detail"`
+}
+
+// doctor collects the checks as they are made
+type doctor struct {
+	checks []doctorCheck
+}
+
+// ok, warn and fail record a check, format being the English message to
+// translate
+func (d *doctor) ok(name, format string, args ...any) {
+	d.checks = append(d.checks, doctorCheck{name, "ok", trf(format, args...)})
+}
+
+func (d *doctor) warn(name, format string, args ...any) {
+	d.checks = append(d.checks, doctorCheck{name, "warn", trf(format, args...)})
+}
+
+func (d *doctor) fail(name, format string, args ...any) {
+	d.checks = append(d.checks, doctorCheck{name, "fail", trf(format, args...)})
+}
+
+// checkConfigFile checks that the config file can be read, has only keys
+// clix knows and is not readable by other users. It returns the config
+// read, or nil when there is none to go on with.
+func (d *doctor) checkConfigFile() *Config {
+	if envCredentialsSet() {
+		config, err := envConfig()
+		if err != nil {
+			d.fail("config", "%v", err)
+			return nil
+		}
+		d.ok("config", "credentials from the environment, no config file is read")
+		return config
+	}
+
+	path, source := configFileSource()
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		d.fail("config", "%s does not exist; run 'clix login' or any command to create it", path)
+		return nil
+	}
+	if err != nil {
+		d.fail("config", "%v", err)
+		return nil
+	}
+	config, err := readConfig(path)
+	if err != nil {
+		d.fail("config", "%s: %v", path, err)
+		return nil
+	}
+	d.ok("config", "%s (%s)", path, source)
+
+	if mode := info.Mode().Perm(); mode&0o044 != 0 {
+		d.warn("permissions", "%s is readable by other users (%04o); run chmod 600 %s", filepath.Base(path), mode, path)
+	} else {
+		d.ok("permissions", "%04o", mode)
+	}
+
+	// Decoding again, strictly this time, finds misspelled keys that are
+	// otherwise silently ignored
+	data, err := os.ReadFile(path)
+	if err == nil {
+		data, err = decryptConfig(filepath.Base(path), data)
+	}
+	if err == nil {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&Config{})
+	}
+	if err != nil {
+		d.warn("schema", "%v", err)
+	} else {
+		d.ok("schema", "no unknown keys")
+	}
+	return config
+}
+
+// checkSettings checks the values in the config that are only parsed when
+// they are used
+func (d *doctor) checkSettings(config *Config) {
+	problems := 0
+	problem := func(format string, args ...any) {
+		problems++
+		d.fail("settings", format, args...)
+	}
+	if config.DefaultAccount != "" {
+		if _, err := config.account(config.DefaultAccount, false); err != nil {
+			problem("default_account: %v", err)
+		}
+	}
+	for _, name := range config.AccountNames() {
+		if creds, _ := config.account(name, false); name != defaultAccountName && !creds.Complete() {
+			problem("account %q has incomplete credentials", name)
+		}
+	}
+	if _, err := undoDelay(config, ""); err != nil {
+		problem("undo_delay: %v", err)
+	}
+	if _, err := config.threadDelay(); err != nil {
+		problem("thread: %v", err)
+	}
+	if _, err := config.Duplicates.window(); err != nil {
+		problem("duplicates: %v", err)
+	}
+	if err := config.Review.check(); err != nil {
+		problem("review: %v", err)
+	}
+	if _, err := config.Theme.resolve(); err != nil {
+		problem("theme: %v", err)
+	}
+	if config.PostingWindow != nil {
+		if _, _, _, _, err := config.PostingWindow.bounds(); err != nil {
+			problem("posting_window: %v", err)
+		}
+	}
+	if _, err := newTransport(); err != nil {
+		problem("network: %v", err)
+	}
+	if _, err := callTimeout(); err != nil {
+		problem("network: %v", err)
+	}
+	if _, err := config.threadDecoration("", true); err != nil {
+		problem("%v", err)
+	}
+	if s := config.SafeMode; s != nil {
+		if _, err := s.rules(); err != nil {
+			problem("%v", err)
+		}
+	}
+	if l := config.Lint; l != nil {
+		if !l.NoSpelling {
+			if _, _, _, err := spellChecker(l.Language); err != nil {
+				problem("lint: %v", err)
+			}
+		}
+		if _, err := l.acceptedWords(); err != nil {
+			problem("lint: %v", err)
+		}
+	}
+	for _, l := range []string{config.Language, os.Getenv(languageEnvVar)} {
+		if _, err := parseLanguage(l); l != "" && err != nil {
+			problem("%v", err)
+		}
+	}
+	for _, t := range config.Transforms {
+		if err := t.check(); err != nil {
+			problem("%v", err)
+		}
+	}
+	if t := config.Tweet; t != nil {
+		if err := t.check(); err != nil {
+			problem("tweet: %v", err)
+		}
+		for _, name := range slices.Sorted(maps.Keys(t.Accounts)) {
+			if err := t.Accounts[name].check(); err != nil {
+				problem("tweet: accounts.%s: %v", name, err)
+			}
+		}
+	}
+	if s := config.Shortener; s != nil && (s.URL == "" || s.APIKey == "") {
+		problem("shortener: needs a url and an api_key")
+	}
+	if g := config.GIF; g != nil && (g.APIKey == "" || (g.Provider != "" && g.Provider != "giphy" && g.Provider != "tenor")) {
+		problem("gif: needs a provider of giphy or tenor and an api_key")
+	}
+	if m := config.Mastodon; m != nil && (m.Server == "" || m.AccessToken == "") {
+		problem("mastodon: needs a server and an access_token")
+	}
+	if b := config.Bluesky; b != nil && (b.Handle == "" || b.AppPassword == "") {
+		problem("bluesky: needs a handle and an app_password")
+	}
+	for i, hook := range config.Hooks {
+		if hook.Command == "" && hook.URL == "" {
+			problem("hooks[%d]: needs a command or a url", i)
+		}
+	}
+	if problems == 0 {
+		d.ok("settings", "valid")
+	}
+}
+
+// checkCredentials calls /2/users/me, which every tier may use, and
+// reports the rate limit it answers with
+func (d *doctor) checkCredentials(ctx context.Context, config *Config) {
+	creds, err := config.activeCredentials()
+	if err != nil {
+		d.fail("credentials", "%v", err)
+		return
+	}
+	if !creds.Complete() {
+		d.fail("credentials", "account %q is incomplete; run 'clix login' or 'clix config reset'", config.Active)
+		return
+	}
+	a, err := newApp(config)
+	if err != nil {
+		d.fail("credentials", "%v", err)
+		return
+	}
+	defer a.close()
+
+	query := url.Values{"user.fields": {"username"}}
+	req, err := a.newSignedRequest(ctx, http.MethodGet, meEndpoint, query, nil)
+	if err != nil {
+		d.fail("credentials", "%v", err)
+		return
+	}
+	res, err := a.client.Client.Do(req)
+	if err != nil {
+		d.fail("credentials", "could not reach the API: %v", err)
+		return
+	}
+	defer res.Body.Close()
+	data, _ := io.ReadAll(res.Body)
+
+	switch {
+	case res.StatusCode == http.StatusUnauthorized || res.StatusCode == http.StatusForbidden:
+		d.fail("credentials", "rejected by the API (%s); check the keys of account %q", res.Status, config.Active)
+		return
+	case res.StatusCode == http.StatusTooManyRequests:
+		d.warn("credentials", "not checked, the rate limit of /2/users/me is used up")
+	case res.StatusCode >= 300:
+		d.fail("credentials", "the API returned %s", res.Status)
+		return
+	default:
+		var me struct {
+			Data struct {
+				Username string `json:"username"`
+			} `json:"data"`
+		}
+		json.Unmarshal(data, &me)
+		method := "OAuth 1.0a"
+		if creds.HasOAuth2() {
+			method = "OAuth 2.0"
+		}
+		d.ok("credentials", "account %q is @%s (%s)", config.Active, me.Data.Username, method)
+	}
+
+	limit, ok := parseRateLimit(res.Header)
+	if !ok {
+		d.warn("rate limit", "the API sent no rate limit headers")
+		return
+	}
+	detail := trf("%d of %d requests to /2/users/me left, resets at %s; %s",
+		limit.remaining, limit.limit, limit.reset.Local().Format("15:04"), tr(guessTier(limit)))
+	if limit.remaining == 0 {
+		d.warn("rate limit", "%s", detail)
+	} else {
+		d.ok("rate limit", "%s", detail)
+	}
+}
+
+// guessTier infers the API access tier from the rate limit of /2/users/me,
+// since the API does not say which tier an app is on
+func guessTier(limit rateLimit) string {
+	// Free and Basic count per 24 hours, Pro and up per 15 minutes
+	daily := time.Until(limit.reset) > 15*time.Minute
+	switch {
+	case daily && limit.limit <= 25:
+		return "likely the Free tier"
+	case daily:
+		return "likely the Basic tier"
+	default:
+		return "likely the Pro tier or higher"
+	}
+}
+
+func runDoctor(args []string) error {
+	fs := newFlagSet("doctor", "doctor [--offline]  (checks the config, credentials and rate limit of the selected account)")
+	offline := fs.Bool("offline", false, "only check the config, without calling the API")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	d := &doctor{}
+	if config := d.checkConfigFile(); config != nil {
+		d.checkSettings(config)
+		if !*offline {
+			d.checkCredentials(rootCtx, config)
+		}
+	}
+
+	failed := 0
+	for _, check := range d.checks {
+		if check.Status == "fail" {
+			failed++
+		}
+	}
+	if machineReadable() {
+		printResult(d.checks)
+	} else {
+		for _, check := range d.checks {
+			fmt.Printf("%-4s  %-12s %s\n", check.Status, check.Name, check.Detail)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf(tr("%d of %d checks failed"), failed, len(d.checks))
+	}
+	return nil
+}
@@ -0,0 +1,380 @@
+package clix
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const draftsStateFile = "drafts.json"
+
+// tweetDraft is a tweet saved for later
+type tweetDraft struct {
+	Name    string   `json:"name"`
+	Text    string   `json:"text"`
+	Media   []string `json:"media,omitempty"`
+	Alt     []string `json:"alt,omitempty"`
+	ReplyTo string   `json:"reply_to,omitempty"`
+	Quote   string   `json:"quote,omitempty"`
+	// Planned is the day the draft is meant for, shown on the calendar
+	Planned   time.Time `json:"planned,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (d *tweetDraft) request() *postRequest {
+	return &postRequest{text: d.Text, media: d.Media, alt: d.Alt, replyTo: d.ReplyTo, quote: d.Quote}
+}
+
+// validate checks the draft could be posted. Over-length text is allowed
+// since it can still be posted with --split or edited later.
+func (d *tweetDraft) validate() error {
+	req := d.request()
+	req.split = true
+	_, err := req.prepare()
+	return err
+}
+
+// summary is the first line of the draft, shortened for listings
+func (d *tweetDraft) summary() string {
+	line, _, _ := strings.Cut(d.Text, "\n")
+	if runes := []rune(line); len(runes) > 60 {
+		line = string(runes[:57]) + "..."
+	}
+	return line
+}
+
+func loadDrafts() (map[string]*tweetDraft, error) {
+	drafts := map[string]*tweetDraft{}
+	if err := loadState(draftsStateFile, &drafts); err != nil {
+		return nil, err
+	}
+	return drafts, nil
+}
+
+func saveDrafts(drafts map[string]*tweetDraft) error {
+	return saveState(draftsStateFile, drafts)
+}
+
+// nextDraftName returns the lowest unused numeric name
+func nextDraftName(drafts map[string]*tweetDraft) string {
+	for n := 1; ; n++ {
+		if _, taken := drafts[strconv.Itoa(n)]; !taken {
+			return strconv.Itoa(n)
+		}
+	}
+}
+
+// parsePlan reads the day of --plan; "" and "none" are no day
+func parsePlan(s string) (time.Time, error) {
+	if s == "" || s == "none" {
+		return time.Time{}, nil
+	}
+	if _, err := time.Parse("2006-01-02", s); err != nil {
+		return time.Time{}, withExitCode(exitUsage, fmt.Errorf(tr("invalid day %q, expected YYYY-MM-DD"), s))
+	}
+	return parseDate(s)
+}
+
+// absPaths makes media paths absolute so a draft can be posted from any
+// directory
+func absPaths(paths []string) ([]string, error) {
+	abs := make([]string, 0, len(paths))
+	for _, path := range paths {
+		p, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		abs = append(abs, p)
+	}
+	return abs, nil
+}
+
+const draftEditHelp = `Edit the draft text above. Saving an empty draft leaves it unchanged.`
+
+func runDraft(args []string) error {
+	if len(args) > 0 && isHelpArg(args[0]) {
+		fmt.Fprintf(os.Stderr, tr("Usage: clix %s\n"), "draft [save|list|edit|post|delete] ...")
+		return nil
+	}
+	action := "list"
+	if len(args) > 0 && !isFlagArg(args[0]) {
+		action, args = args[0], args[1:]
+	}
+
+	switch action {
+	case "save":
+		return runDraftSave(args)
+	case "list":
+		return runDraftList(args)
+	case "edit":
+		return runDraftEdit(args)
+	case "post":
+		return runDraftPost(args)
+	case "delete":
+		return runDraftDelete(args)
+	default:
+		return withExitCode(exitUsage, fmt.Errorf(tr("unknown draft action %q"), action))
+	}
+}
+
+func runDraftSave(args []string) error {
+	fs := newFlagSet("draft save", "draft save [--name n] [--media path] [--reply-to id] [--quote id] [--plan YYYY-MM-DD] [text]")
+	name := fs.String("name", "", "name for the draft (defaults to the next free number)")
+	var media stringList
+	fs.Var(&media, "media", "attach a media file when posted (repeatable)")
+	var alts stringList
+	fs.Var(&alts, "alt", "alt text for the media, paired with each --media in order")
+	replyTo := fs.String("reply-to", "", "reply to this tweet when posted (ID or URL)")
+	quote := fs.String("quote", "", "quote this tweet when posted (ID or URL)")
+	plan := fs.String("plan", "", "the day the draft is meant for, shown on the calendar")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	planned, err := parsePlan(*plan)
+	if err != nil {
+		return err
+	}
+
+	text, err := readText(args)
+	if err != nil {
+		return err
+	}
+	paths, err := absPaths(media)
+	if err != nil {
+		return err
+	}
+	draft := &tweetDraft{Text: text, Media: paths, Alt: alts, ReplyTo: *replyTo, Quote: *quote, Planned: planned}
+	// Catch mistakes now rather than when the draft is finally posted
+	if err := draft.validate(); err != nil {
+		return err
+	}
+
+	drafts, err := loadDrafts()
+	if err != nil {
+		return err
+	}
+	draft.Name = *name
+	if draft.Name == "" {
+		draft.Name = nextDraftName(drafts)
+	} else if _, exists := drafts[draft.Name]; exists {
+		return fmt.Errorf(tr("draft %q already exists"), draft.Name)
+	}
+	draft.CreatedAt = time.Now()
+	draft.UpdatedAt = draft.CreatedAt
+	drafts[draft.Name] = draft
+	if err := saveDrafts(drafts); err != nil {
+		return err
+	}
+
+	if machineReadable() {
+		return printResult(draft)
+	}
+	fmt.Printf(tr("Draft saved as %q.\n"), draft.Name)
+	return nil
+}
+
+func runDraftList(args []string) error {
+	fs := newFlagSet("draft list", "draft list")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	drafts, err := loadDrafts()
+	if err != nil {
+		return err
+	}
+	list := make([]*tweetDraft, 0, len(drafts))
+	for _, draft := range drafts {
+		list = append(list, draft)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+
+	if machineReadable() {
+		return printResult(list)
+	}
+	if len(list) == 0 {
+		fmt.Println(tr("No drafts."))
+		return nil
+	}
+	for _, draft := range list {
+		extra := ""
+		if len(draft.Media) > 0 {
+			extra += fmt.Sprintf(tr(" [%d media]"), len(draft.Media))
+		}
+		if draft.ReplyTo != "" {
+			extra += tr(" [reply]")
+		}
+		if draft.Quote != "" {
+			extra += tr(" [quote]")
+		}
+		if !draft.Planned.IsZero() {
+			extra += fmt.Sprintf(tr(" [for %s]"), draft.Planned.Format("Mon 2 Jan"))
+		}
+		fmt.Printf("%-10s %-16s  %s%s\n", draft.Name, formatTime(draft.UpdatedAt), draft.summary(), extra)
+	}
+	return nil
+}
+
+func runDraftEdit(args []string) error {
+	fs := newFlagSet("draft edit", "draft edit <name> [--text t] [--media path] [--reply-to id] [--quote id] [--plan YYYY-MM-DD|none]  (opens $EDITOR without --text)")
+	text := fs.String("text", "", "replace the text instead of opening an editor")
+	var media stringList
+	fs.Var(&media, "media", "replace the attached media (repeatable)")
+	var alts stringList
+	fs.Var(&alts, "alt", "replace the alt text of the media, paired with each --media")
+	replyTo := fs.String("reply-to", "", "replace the reply target")
+	quote := fs.String("quote", "", "replace the quoted tweet")
+	plan := fs.String("plan", "", "plan the draft for this day, or none to take it off the calendar")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	drafts, err := loadDrafts()
+	if err != nil {
+		return err
+	}
+	draft, ok := drafts[args[0]]
+	if !ok {
+		return fmt.Errorf(tr("no draft named %q"), args[0])
+	}
+
+	edited := *draft
+	switch {
+	case *text != "":
+		edited.Text = *text
+	case len(media) == 0 && len(alts) == 0 && *replyTo == "" && *quote == "" && *plan == "":
+		updated, err := editText(draft.Text, tr(draftEditHelp))
+		if err != nil {
+			return err
+		}
+		if updated != "" {
+			edited.Text = updated
+		}
+	}
+	if len(media) > 0 {
+		if edited.Media, err = absPaths(media); err != nil {
+			return err
+		}
+		edited.Alt = nil
+	}
+	if len(alts) > 0 {
+		edited.Alt = alts
+	}
+	if *replyTo != "" {
+		edited.ReplyTo = *replyTo
+	}
+	if *quote != "" {
+		edited.Quote = *quote
+	}
+	if *plan != "" {
+		if edited.Planned, err = parsePlan(*plan); err != nil {
+			return err
+		}
+	}
+	if err := edited.validate(); err != nil {
+		return err
+	}
+
+	edited.UpdatedAt = time.Now()
+	drafts[edited.Name] = &edited
+	if err := saveDrafts(drafts); err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(&edited)
+	}
+	fmt.Printf(tr("Draft %q updated.\n"), edited.Name)
+	return nil
+}
+
+func runDraftPost(args []string) error {
+	fs := newFlagSet("draft post", "draft post <name> [--split] [--force]")
+	split := fs.Bool("split", false, "split an over-length draft into a thread")
+	force := fs.Bool("force", false, "post even outside the configured posting window or against the style rules")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	drafts, err := loadDrafts()
+	if err != nil {
+		return err
+	}
+	draft, ok := drafts[args[0]]
+	if !ok {
+		return fmt.Errorf(tr("no draft named %q"), args[0])
+	}
+	req := draft.request()
+	req.split = *split
+	config, err := readConfig(getConfigFilePath())
+	if err != nil {
+		return fmt.Errorf(tr("failed to load configuration: %w"), err)
+	}
+	if err := req.transform(config); err != nil {
+		return err
+	}
+	prepared, err := req.prepare()
+	if err != nil {
+		return err
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	prepared.force = prepared.force || *force
+	if ok, err := prepared.checks(a.config, a).run(rootCtx); !ok || err != nil {
+		return err
+	}
+	prepared.checked = true
+	if _, err := a.publishAndReport(rootCtx, prepared); err != nil || globalOptions.dryRun {
+		return err
+	}
+
+	delete(drafts, draft.Name)
+	if err := saveDrafts(drafts); err != nil {
+		fmt.Fprintln(os.Stderr, tr("Warning: draft was posted but not removed:"), err)
+	}
+	return nil
+}
+
+func runDraftDelete(args []string) error {
+	fs := newFlagSet("draft delete", "draft delete <name>...")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	drafts, err := loadDrafts()
+	if err != nil {
+		return err
+	}
+	for _, name := range args {
+		if _, ok := drafts[name]; !ok {
+			return fmt.Errorf(tr("no draft named %q"), name)
+		}
+		delete(drafts, name)
+	}
+	return saveDrafts(drafts)
+}
@@ -0,0 +1,118 @@
+package clix
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/voltycodes/clix/compose"
+)
+
+// errDryRun is returned by the HTTP transport for any request that would
+// change something while --dry-run is set
+type errDryRun struct {
+	method, path string
+}
+
+func (e *errDryRun) Error() string {
+	return fmt.Sprintf("dry run: not sending %s %s", e.method, e.path)
+}
+
+// dryRunBlocks reports whether req must not be sent under --dry-run. Reads
+// are let through so lookups still work.
+func dryRunBlocks(req *http.Request) bool {
+	return globalOptions.dryRun && req.Method != http.MethodGet && req.Method != http.MethodHead
+}
+
+// dryRunResults describes p the way publish would post it, without
+// posting anything. The description goes to stdout unless the results are
+// printed as machine-readable output instead.
+func dryRunResults(p *preparedPost) []postResult {
+	if !machineReadable() {
+		describePost(os.Stdout, p)
+	}
+	results := make([]postResult, 0, len(p.parts))
+	for _, part := range p.parts {
+		results = append(results, postResult{Text: part, DryRun: true})
+	}
+	return results
+}
+
+// describePost prints each tweet a post would create with its length and
+// attachments
+func describePost(w io.Writer, p *preparedPost) {
+	fmt.Fprintln(w, tr("Dry run, nothing was posted."))
+	describeTransforms(w, p.transformed)
+	describeSettings(w, p.settings)
+	for i, part := range p.parts {
+		describeTweet(w, i, len(p.parts), part)
+		if i > 0 {
+			fmt.Fprintln(w, tr("  reply to: the previous part"))
+			continue
+		}
+		if p.replyID != "" {
+			fmt.Fprintf(w, tr("  reply to: %s\n"), p.replyID)
+		}
+		if p.quoteID != "" {
+			fmt.Fprintf(w, tr("  quote: %s\n"), p.quoteID)
+		}
+		describeMedia(w, p.media)
+		if p.poll != nil {
+			fmt.Fprintf(w, tr("  poll: %s (%d minutes)\n"), strings.Join(p.poll.Options, " / "), *p.poll.DurationMinutes)
+		}
+	}
+}
+
+// describeSettings lists the settings the tweets get besides the API's
+// defaults
+func describeSettings(w io.Writer, s TweetSettings) {
+	if settings := s.describe(); len(settings) > 0 {
+		fmt.Fprintf(w, tr("Settings: %s\n"), strings.Join(settings, ", "))
+	}
+}
+
+// describeTransforms names the transforms from the config that changed
+// the text
+func describeTransforms(w io.Writer, applied []string) {
+	if len(applied) > 0 {
+		fmt.Fprintf(w, tr("Transformed by: %s\n"), strings.Join(applied, ", "))
+	}
+}
+
+// describeThread prints the parts of a thread, for --dry-run and --confirm
+func describeThread(w io.Writer, parts []string, media [][]*mediaFile, replyTo string) {
+	for i, part := range parts {
+		describeTweet(w, i, len(parts), part)
+		if i > 0 {
+			fmt.Fprintln(w, tr("  reply to: the previous part"))
+		} else if replyTo != "" {
+			fmt.Fprintf(w, tr("  reply to: %s\n"), replyTo)
+		}
+		if i < len(media) {
+			describeMedia(w, media[i])
+		}
+	}
+}
+
+func describeMedia(w io.Writer, files []*mediaFile) {
+	for _, file := range files {
+		if file.source != "" {
+			fmt.Fprintf(w, tr("  media: %s (%s, %d KB)\n"), file.source, file.mediaType, max(file.size>>10, 1))
+			fmt.Fprintf(w, "    %s\n", strings.Join(file.changes, "; "))
+		} else {
+			fmt.Fprintf(w, tr("  media: %s (%s, %d KB)\n"), file.path, file.mediaType, max(file.size>>10, 1))
+		}
+		if file.alt != "" {
+			fmt.Fprintf(w, tr("    alt: %s\n"), file.alt)
+		}
+	}
+}
+
+func describeTweet(w io.Writer, i, total int, text string) {
+	fmt.Fprintf(w, tr("\nTweet %d/%d (%d/%d characters)\n"), i+1, total, compose.Length(text), compose.MaxLength)
+	for _, line := range strings.Split(text, "\n") {
+		fmt.Fprintf(w, "  │ %s\n", line)
+	}
+}
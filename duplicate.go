@@ -0,0 +1,169 @@
+package clix
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+// recentPostsFile keeps a key for each recent post, so an identical one
+// soon after is caught
+const recentPostsFile = "recent_posts.json"
+
+// defaultDuplicateWindow is how long a post is remembered by default
+const defaultDuplicateWindow = 10 * time.Minute
+
+// DuplicatesConfig is the "duplicates" section of the config. A post the
+// same as one sent within the window is refused, so a script retrying
+// after a connection failed halfway does not post twice.
+type DuplicatesConfig struct {
+	// Window is how long a post is remembered, e.g. "1h"; "0" turns the
+	// check off. The default is 10m.
+	Window string `json:"window,omitempty"`
+	// Warn posts a duplicate after a warning rather than refusing it
+	Warn bool `json:"warn,omitempty"`
+}
+
+func (c *DuplicatesConfig) window() (time.Duration, error) {
+	if c == nil || c.Window == "" {
+		return defaultDuplicateWindow, nil
+	}
+	d, err := time.ParseDuration(c.Window)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf(tr("invalid window %q, expected a duration like 10m"), c.Window)
+	}
+	return d, nil
+}
+
+func (c *DuplicatesConfig) warns() bool {
+	return c != nil && c.Warn
+}
+
+// recentPost is a post sent within the window. Its ID is empty while it
+// is being sent, and stays so if it is not known whether it went out.
+type recentPost struct {
+	ID string    `json:"id,omitempty"`
+	At time.Time `json:"at"`
+}
+
+// idempotencyKey derives a key from what makes two posts the same: the
+// account, the text of each part, the media, poll and the tweets replied
+// to and quoted
+func (p *preparedPost) idempotencyKey(account string) string {
+	content := struct {
+		Account, ReplyTo, Quote string
+		Parts, Media, Poll      []string
+	}{Account: account, ReplyTo: p.replyID, Quote: p.quoteID, Parts: p.parts}
+	for _, m := range p.media {
+		content.Media = append(content.Media, cmp.Or(m.source, m.path), m.alt)
+	}
+	if p.poll != nil {
+		content.Poll = p.poll.Options
+	}
+	data, _ := json.Marshal(content)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16])
+}
+
+// claimPost refuses p, or warns about it, when the same post was sent
+// within the window, then records it as being sent. It returns
+// the key to settle once the post is done, empty when there is nothing
+// to settle.
+func (a *app) claimPost(p *preparedPost) (string, error) {
+	window, err := a.config.Duplicates.window()
+	if err != nil {
+		return "", fmt.Errorf(tr("duplicates: %w"), err)
+	}
+	if window == 0 {
+		return "", nil
+	}
+	key := p.idempotencyKey(a.config.Active)
+
+	unlock, err := lockState(recentPostsFile)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+	recent := map[string]recentPost{}
+	if err := loadState(recentPostsFile, &recent); err != nil {
+		return "", err
+	}
+	if previous, ok := recent[key]; ok && time.Since(previous.At) < window && !p.allowDuplicate {
+		var err error
+		if previous.ID != "" {
+			err = fmt.Errorf(tr("the same tweet was posted %s as %s"), formatTime(previous.At), tweetURL("", previous.ID))
+		} else {
+			err = fmt.Errorf(tr("the same tweet was sent %s and may have gone out before the connection failed; check your profile"), formatTime(previous.At))
+		}
+		if !a.config.Duplicates.warns() {
+			return "", withExitCode(exitRefused, fmt.Errorf(tr("%w (use --allow-duplicate to post it anyway)"), err))
+		}
+		fmt.Fprintln(os.Stderr, tr("Warning:"), err)
+	}
+	if globalOptions.dryRun {
+		return "", nil
+	}
+	for k, post := range recent {
+		if time.Since(post.At) >= window {
+			delete(recent, k)
+		}
+	}
+	recent[key] = recentPost{At: time.Now()}
+	return key, saveState(recentPostsFile, recent)
+}
+
+// settlePost records how the post claimed under key ended: posted as id,
+// or failed with err. A post the API turned down is forgotten, so trying
+// again is not refused; one that may have gone out is kept.
+func settlePost(key, id string, err error) {
+	switch {
+	case err == nil:
+		updateRecentPosts(key, func(recent map[string]recentPost) {
+			recent[key] = recentPost{ID: id, At: recent[key].At}
+		})
+	case !mayHavePosted(err):
+		forgetPost(key)
+	}
+}
+
+// forgetPost drops the claim on key of a post that was not sent
+func forgetPost(key string) {
+	updateRecentPosts(key, func(recent map[string]recentPost) { delete(recent, key) })
+}
+
+func updateRecentPosts(key string, update func(map[string]recentPost)) {
+	if key == "" {
+		return
+	}
+	unlock, err := lockState(recentPostsFile)
+	if err == nil {
+		defer unlock()
+		recent := map[string]recentPost{}
+		if err = loadState(recentPostsFile, &recent); err == nil {
+			update(recent)
+			err = saveState(recentPostsFile, recent)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, tr("Warning: could not record the post for the duplicate check:"), err)
+	}
+}
+
+// mayHavePosted reports whether a request that failed with err could have
+// reached X anyway: the connection broke or timed out after it was made.
+// An answer from the API, or a failure to connect at all, means it did
+// not.
+func mayHavePosted(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return false
+	}
+	return isNetworkError(err) || isTimeout(err) || errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,103 @@
+package clix
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/michimani/gotwi/tweet/managetweet/types"
+	clixclient "github.com/voltycodes/clix/client"
+	clixconfig "github.com/voltycodes/clix/config"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	base := func() *preparedPost {
+		return &preparedPost{parts: []string{"hello", "world"}, media: []*mediaFile{{path: "a.png", alt: "a cat"}}}
+	}
+	tests := []struct {
+		name    string
+		account string
+		change  func(p *preparedPost)
+		same    bool
+	}{
+		{"unchanged", "me", func(p *preparedPost) {}, true},
+		{"settings are not content", "me", func(p *preparedPost) { p.force, p.settings = true, TweetSettings{ReplyRestriction: "following"} }, true},
+		{"processed copy of the same file", "me", func(p *preparedPost) { p.media[0].path, p.media[0].source = "/tmp/a.jpg", "a.png" }, true},
+		{"other account", "you", func(p *preparedPost) {}, false},
+		{"other text", "me", func(p *preparedPost) { p.parts[1] = "there" }, false},
+		{"parts joined", "me", func(p *preparedPost) { p.parts = []string{"helloworld"} }, false},
+		{"reply", "me", func(p *preparedPost) { p.replyID = "1" }, false},
+		{"quote", "me", func(p *preparedPost) { p.quoteID = "1" }, false},
+		{"other media", "me", func(p *preparedPost) { p.media[0].path = "b.png" }, false},
+		{"other alt text", "me", func(p *preparedPost) { p.media[0].alt = "a dog" }, false},
+		{"poll", "me", func(p *preparedPost) { p.poll = &types.CreateInputPoll{Options: []string{"yes", "no"}} }, false},
+	}
+	want := base().idempotencyKey("me")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := base()
+			tt.change(p)
+			if got := p.idempotencyKey(tt.account); (got == want) != tt.same {
+				t.Errorf("idempotencyKey() = %s, base %s, want the same: %v", got, want, tt.same)
+			}
+		})
+	}
+}
+
+func TestClaimPost(t *testing.T) {
+	apiErr := &clixclient.StatusError{Method: "POST", Path: "/2/tweets", Status: "403 Forbidden", StatusCode: 403}
+	brokenErr := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	tests := []struct {
+		name       string
+		duplicates *DuplicatesConfig
+		id         string // the first post went out as this
+		err        error  // or failed with this
+		allow      bool
+		wantErr    string // from claiming the same post again
+	}{
+		{"posted", nil, "42", nil, false, "was posted"},
+		{"posted, allowed", nil, "42", nil, true, ""},
+		{"posted, warning", &DuplicatesConfig{Warn: true}, "42", nil, false, ""},
+		{"check off", &DuplicatesConfig{Window: "0"}, "42", nil, false, ""},
+		{"refused by the API", nil, "", apiErr, false, ""},
+		{"connection never made", nil, "", dialErr, false, ""},
+		{"connection broke", nil, "", brokenErr, false, "may have gone out"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inStateDir(t)
+			a := &app{config: &Config{Config: clixconfig.Config{Active: "me"}, Duplicates: tt.duplicates}}
+			key, err := a.claimPost(&preparedPost{parts: []string{"hello"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			settlePost(key, tt.id, tt.err)
+
+			_, err = a.claimPost(&preparedPost{parts: []string{"hello"}, allowDuplicate: tt.allow})
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("claiming it again: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("claiming it again: %v, want an error with %q", err, tt.wantErr)
+			case tt.wantErr != "" && exitCode(err) != exitRefused:
+				t.Errorf("exit code %d, want %d", exitCode(err), exitRefused)
+			}
+		})
+	}
+}
+
+func TestClaimPostWindowPasses(t *testing.T) {
+	inStateDir(t)
+	a := &app{config: &Config{Config: clixconfig.Config{Active: "me"}}}
+	p := &preparedPost{parts: []string{"hello"}}
+	old := map[string]recentPost{p.idempotencyKey("me"): {ID: "42", At: time.Now().Add(-defaultDuplicateWindow - time.Minute)}}
+	if err := saveState(recentPostsFile, old); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.claimPost(p); err != nil {
+		t.Fatalf("claimPost() after the window = %v, want nil", err)
+	}
+}
@@ -0,0 +1,59 @@
+package clix
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// editorScissors separates the text being edited from the instructions
+// below it, like git's commit template
+const editorScissors = "# ------------------------ >8 ------------------------"
+
+func editorCommand() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := os.Getenv(name); editor != "" {
+			return editor
+		}
+	}
+	return "vi"
+}
+
+// editText opens the user's editor on initial followed by help below the
+// scissors line, and returns what was written above it
+func editText(initial, help string) (string, error) {
+	file, err := os.CreateTemp("", "clix-*.txt")
+	if err != nil {
+		return "", err
+	}
+	path := file.Name()
+	defer os.Remove(path)
+
+	content := initial + "\n\n" + editorScissors + "\n# Do not modify or remove the line above.\n# Everything below it will be ignored.\n"
+	for _, line := range strings.Split(strings.TrimSpace(help), "\n") {
+		content += "# " + line + "\n"
+	}
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+
+	// The editor setting may carry arguments, e.g. "code --wait"
+	editor := strings.Fields(editorCommand())
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf(tr("editor failed: %w"), err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	text, _, _ := strings.Cut(string(data), editorScissors)
+	return strings.TrimSpace(text), nil
+}
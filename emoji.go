@@ -0,0 +1,148 @@
+package clix
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/voltycodes/clix/compose"
+)
+
+// shortcodePattern is a :shortcode:
+var shortcodePattern = regexp.MustCompile(`:[a-z0-9_+-]+:`)
+
+// expandShortcodes replaces the known :shortcode:s outside links with
+// their emoji, leaving unknown ones as typed
+func expandShortcodes(text string, extra map[string]string) string {
+	var b strings.Builder
+	for _, span := range compose.SplitURLs(text) {
+		if span.URL {
+			b.WriteString(span.Text)
+			continue
+		}
+		b.WriteString(shortcodePattern.ReplaceAllStringFunc(span.Text, func(code string) string {
+			name := strings.Trim(code, ":")
+			if emoji, ok := extra[name]; ok {
+				return emoji
+			}
+			if emoji, ok := emojiShortcodes[name]; ok {
+				return emoji
+			}
+			return code
+		}))
+	}
+	return b.String()
+}
+
+func isShortcodeRune(r rune) bool {
+	return r == '_' || r == '+' || r == '-' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9'
+}
+
+// shortcodePrefix returns where the partial :shortcode: text ends with
+// starts, or -1. A colon right after a letter or digit, as in 12:30, does
+// not start one.
+func shortcodePrefix(text []rune) int {
+	i := len(text)
+	for i > 0 && isShortcodeRune(text[i-1]) {
+		i--
+	}
+	if i == len(text) || i == 0 || text[i-1] != ':' {
+		return -1
+	}
+	if i > 1 && (unicode.IsLetter(text[i-2]) || unicode.IsDigit(text[i-2])) {
+		return -1
+	}
+	return i - 1
+}
+
+// shortcodes returns the built-in shortcodes with those the emoji
+// transforms of the active account add
+func (c *Config) shortcodes() map[string]string {
+	codes := maps.Clone(emojiShortcodes)
+	for _, t := range c.Transforms {
+		if t.Type == transformEmoji && (len(t.Accounts) == 0 || slices.Contains(t.Accounts, c.Active)) {
+			maps.Copy(codes, t.Emoji)
+		}
+	}
+	return codes
+}
+
+// emojiMatch is a result of `clix emoji search`
+type emojiMatch struct {
+	Emoji     string   `json:"emoji"`
+	Shortcode string   `json:"shortcode"`
+	Aliases   []string `json:"aliases,omitempty"`
+}
+
+// searchEmoji returns the emoji whose names or keywords have every word of
+// query in them, those with a name starting with it first; the emoji
+// itself finds its names
+func searchEmoji(query string) []emojiMatch {
+	words := strings.Fields(strings.ToLower(strings.ReplaceAll(query, ":", " ")))
+	var named, others []emojiMatch
+	for _, e := range emojiData {
+		names := strings.Fields(e.names)
+		match := emojiMatch{Emoji: e.emoji, Shortcode: names[0], Aliases: names[1:]}
+		if strings.TrimSpace(query) == e.emoji {
+			named = append(named, match)
+			continue
+		}
+		text := e.names + " " + e.keywords
+		if len(words) == 0 || slices.ContainsFunc(words, func(w string) bool { return !strings.Contains(text, w) }) {
+			continue
+		}
+		if slices.ContainsFunc(names, func(name string) bool { return strings.HasPrefix(name, strings.Join(words, "_")) }) {
+			named = append(named, match)
+		} else {
+			others = append(others, match)
+		}
+	}
+	return append(named, others...)
+}
+
+func runEmoji(args []string) error {
+	if len(args) == 0 || args[0] != "search" {
+		fmt.Fprintln(os.Stderr, tr(`Usage: clix emoji search "<words>|<emoji>" [--count 20]`))
+		if len(args) > 0 && isHelpArg(args[0]) {
+			return nil
+		}
+		return errUsage
+	}
+	fs := newFlagSet("emoji search", `emoji search "<words>|<emoji>" [--count 20]  (the :shortcodes: the repl and the emoji transform know)`)
+	count := fs.Int("count", 20, "the most emoji to list, 0 for all")
+	args, err := parseFlags(fs, args[1:])
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+	if *count < 0 {
+		return withExitCode(exitUsage, errors.New(tr("--count cannot be negative")))
+	}
+	matches := searchEmoji(strings.Join(args, " "))
+	if *count > 0 {
+		matches = matches[:min(len(matches), *count)]
+	}
+	if machineReadable() {
+		return printResult(matches)
+	}
+	if len(matches) == 0 {
+		fmt.Println(tr("No emoji found."))
+		return nil
+	}
+	for _, m := range matches {
+		line := fmt.Sprintf("%s  :%s:", m.Emoji, m.Shortcode)
+		if len(m.Aliases) > 0 {
+			line += "  also :" + strings.Join(m.Aliases, ":, :") + ":"
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
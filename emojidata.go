@@ -0,0 +1,366 @@
+package clix
+
+import "strings"
+
+// emojiInfo is an emoji with its :shortcode: names, the first the one
+// shown, and the words emoji search also finds it by
+type emojiInfo struct {
+	emoji    string
+	names    string
+	keywords string
+}
+
+// emojiData is the emoji clix knows, named as GitHub and Slack spell them
+var emojiData = []emojiInfo{
+	// Faces
+	{"😀", "grinning grinning_face", "happy smile"},
+	{"😃", "smiley", "happy smile joy"},
+	{"😄", "smile", "happy joy laugh pleased"},
+	{"😁", "grin", "happy smile teeth"},
+	{"😆", "laughing satisfied", "happy haha lol"},
+	{"😅", "sweat_smile", "relief phew nervous"},
+	{"🤣", "rofl rolling_on_the_floor_laughing", "lol haha funny"},
+	{"😂", "joy face_with_tears_of_joy", "lol haha funny laugh cry"},
+	{"🙂", "slightly_smiling_face", "smile fine"},
+	{"🙃", "upside_down_face", "silly sarcasm"},
+	{"😉", "wink", "flirt joke"},
+	{"😊", "blush", "happy shy proud"},
+	{"😇", "innocent halo", "angel good"},
+	{"🥰", "smiling_face_with_three_hearts", "love crush adore"},
+	{"😍", "heart_eyes", "love crush adore"},
+	{"🤩", "star_struck", "wow amazing excited"},
+	{"😘", "kissing_heart", "love kiss"},
+	{"😋", "yum", "tasty delicious food"},
+	{"😛", "stuck_out_tongue", "silly playful"},
+	{"😜", "stuck_out_tongue_winking_eye", "silly joke crazy"},
+	{"🤪", "zany_face", "crazy silly goofy"},
+	{"🤑", "money_mouth_face", "rich money dollar"},
+	{"🤗", "hugs hugging_face", "hug thanks love"},
+	{"🤭", "hand_over_mouth", "oops giggle secret"},
+	{"🤫", "shushing_face", "quiet secret hush"},
+	{"🤔", "thinking", "hmm wonder consider"},
+	{"🤐", "zipper_mouth_face", "secret quiet"},
+	{"🤨", "raised_eyebrow", "skeptical doubt suspicious"},
+	{"😐", "neutral_face", "meh blank"},
+	{"😑", "expressionless", "meh blank annoyed"},
+	{"😶", "no_mouth", "speechless silent"},
+	{"😏", "smirk", "smug sly"},
+	{"😒", "unamused", "meh annoyed bored"},
+	{"🙄", "roll_eyes", "eyeroll annoyed whatever"},
+	{"😬", "grimacing", "awkward nervous yikes"},
+	{"😌", "relieved", "calm phew content"},
+	{"😔", "pensive", "sad thoughtful"},
+	{"😪", "sleepy", "tired"},
+	{"😴", "sleeping", "sleep tired zzz"},
+	{"😷", "mask", "sick ill covid"},
+	{"🤒", "face_with_thermometer", "sick ill fever"},
+	{"🤢", "nauseated_face", "sick gross disgust"},
+	{"🤮", "vomiting_face", "sick gross disgust"},
+	{"🥵", "hot_face", "hot heat sweat summer"},
+	{"🥶", "cold_face", "cold freezing winter"},
+	{"🤯", "exploding_head", "mind_blown shocked wow"},
+	{"🥳", "partying_face", "party celebrate birthday"},
+	{"😎", "sunglasses", "cool summer"},
+	{"🤓", "nerd_face", "nerd geek smart"},
+	{"🧐", "monocle_face", "inspect curious"},
+	{"😕", "confused", "unsure puzzled"},
+	{"😟", "worried", "concern nervous"},
+	{"😮", "open_mouth", "surprise wow"},
+	{"😲", "astonished", "surprise shocked wow"},
+	{"😳", "flushed", "embarrassed blush shocked"},
+	{"🥺", "pleading_face", "please puppy eyes"},
+	{"😢", "cry", "sad tear"},
+	{"😭", "sob", "sad cry tears"},
+	{"😱", "scream", "scared shocked horror"},
+	{"😖", "confounded", "frustrated"},
+	{"😞", "disappointed", "sad"},
+	{"😓", "sweat", "hard work nervous"},
+	{"😩", "weary", "tired frustrated"},
+	{"😫", "tired_face", "tired exhausted"},
+	{"🥱", "yawning_face", "tired bored sleepy"},
+	{"😤", "triumph", "proud angry huff"},
+	{"😡", "rage pout", "angry mad"},
+	{"😠", "angry", "mad annoyed"},
+	{"🤬", "cursing_face", "angry swear"},
+	{"😈", "smiling_imp", "devil evil mischief"},
+	{"💀", "skull", "dead dying lol"},
+	{"💩", "poop hankey", "crap"},
+	{"🤡", "clown_face", "clown silly"},
+	{"👻", "ghost", "halloween spooky"},
+	{"👽", "alien", "ufo space"},
+	{"🤖", "robot", "bot ai machine"},
+	{"🙈", "see_no_evil", "monkey oops embarrassed"},
+	{"🙉", "hear_no_evil", "monkey"},
+	{"🙊", "speak_no_evil", "monkey oops secret"},
+	{"🤦", "facepalm", "ugh disbelief"},
+	{"🤷", "shrug", "whatever dunno"},
+
+	// Gestures and people
+	{"👋", "wave", "hello hi bye goodbye"},
+	{"🤚", "raised_back_of_hand", "stop"},
+	{"✋", "raised_hand hand", "stop high_five"},
+	{"🖖", "vulcan_salute", "spock star trek"},
+	{"👌", "ok_hand", "okay perfect good"},
+	{"🤌", "pinched_fingers", "italian what"},
+	{"✌️", "v victory", "peace"},
+	{"🤞", "crossed_fingers", "luck hope"},
+	{"🤟", "love_you_gesture", "love"},
+	{"🤘", "metal", "rock horns"},
+	{"🤙", "call_me_hand", "call shaka"},
+	{"👈", "point_left", "left"},
+	{"👉", "point_right", "right"},
+	{"👆", "point_up_2", "up above"},
+	{"👇", "point_down", "down below"},
+	{"☝️", "point_up", "up one"},
+	{"👍", "+1 thumbsup", "yes like approve agree good"},
+	{"👎", "-1 thumbsdown", "no dislike disagree bad"},
+	{"✊", "fist_raised fist", "power solidarity"},
+	{"👊", "fist_oncoming punch", "bump"},
+	{"👏", "clap", "applause bravo congrats well_done"},
+	{"🙌", "raised_hands", "hooray celebrate yay praise"},
+	{"👐", "open_hands", "hug"},
+	{"🤲", "palms_up_together", "pray please"},
+	{"🤝", "handshake", "deal agreement partnership"},
+	{"🙏", "pray", "please thanks hope namaste"},
+	{"✍️", "writing_hand", "write sign"},
+	{"💪", "muscle", "strong flex workout"},
+	{"🧠", "brain", "smart think mind"},
+	{"👀", "eyes", "look see watch"},
+	{"👁️", "eye", "look see"},
+	{"👶", "baby", "child newborn"},
+	{"🧑‍💻", "technologist", "developer coder programmer"},
+	{"👩‍💻", "woman_technologist", "developer coder programmer"},
+	{"👨‍💻", "man_technologist", "developer coder programmer"},
+	{"🏃", "runner running", "run exercise hurry"},
+	{"💃", "dancer", "dance party"},
+	{"🕺", "man_dancing", "dance party disco"},
+	{"🧘", "lotus_position", "yoga meditate calm"},
+
+	// Hearts and symbols
+	{"❤️", "heart red_heart", "love like"},
+	{"🧡", "orange_heart", "love"},
+	{"💛", "yellow_heart", "love friendship"},
+	{"💚", "green_heart", "love nature"},
+	{"💙", "blue_heart", "love"},
+	{"💜", "purple_heart", "love"},
+	{"🖤", "black_heart", "love dark"},
+	{"🤍", "white_heart", "love"},
+	{"💔", "broken_heart", "sad heartbreak"},
+	{"💕", "two_hearts", "love"},
+	{"💖", "sparkling_heart", "love"},
+	{"💯", "100 hundred", "perfect score percent"},
+	{"💢", "anger", "angry mad"},
+	{"💥", "boom bang collision", "explosion"},
+	{"💫", "dizzy", "star"},
+	{"💦", "sweat_drops", "water splash"},
+	{"💬", "speech_balloon", "comment chat talk"},
+	{"💭", "thought_balloon", "think idea"},
+	{"💤", "zzz", "sleep tired"},
+	{"💋", "kiss", "lips love"},
+	{"✨", "sparkles", "shiny new magic clean"},
+	{"⭐", "star", "favorite"},
+	{"🌟", "star2 glowing_star", "shine favorite"},
+	{"⚡", "zap high_voltage", "lightning fast electric"},
+	{"🔥", "fire", "hot lit flame trending"},
+	{"🌈", "rainbow", "pride colors"},
+	{"✅", "white_check_mark", "done yes check complete"},
+	{"✔️", "heavy_check_mark check", "done yes"},
+	{"❌", "x", "no cross wrong cancel"},
+	{"❓", "question", "what why ask"},
+	{"❗", "exclamation heavy_exclamation_mark", "important bang"},
+	{"⚠️", "warning", "caution danger"},
+	{"⛔", "no_entry", "stop forbidden"},
+	{"🚫", "no_entry_sign", "forbidden banned"},
+	{"♻️", "recycle", "green environment"},
+	{"🆕", "new", "fresh"},
+	{"🆒", "cool", "nice"},
+	{"🆗", "ok", "okay"},
+	{"🆓", "free", "gratis"},
+	{"🔝", "top", "best"},
+	{"🔴", "red_circle", "live record"},
+	{"🟢", "green_circle", "online go"},
+	{"⬆️", "arrow_up", "up"},
+	{"⬇️", "arrow_down", "down"},
+	{"⬅️", "arrow_left", "left back"},
+	{"➡️", "arrow_right", "right next"},
+	{"🔁", "repeat", "loop again retweet"},
+	{"🔄", "arrows_counterclockwise", "refresh sync reload"},
+	{"➕", "heavy_plus_sign plus", "add more"},
+	{"➖", "heavy_minus_sign minus", "less remove"},
+
+	// Celebration and objects
+	{"🎉", "tada party_popper", "party celebrate congrats hooray launch"},
+	{"🎊", "confetti_ball", "party celebrate"},
+	{"🎈", "balloon", "party birthday"},
+	{"🎂", "birthday birthday_cake", "party cake"},
+	{"🎁", "gift present", "birthday christmas surprise"},
+	{"🎄", "christmas_tree", "christmas holiday"},
+	{"🎃", "jack_o_lantern", "halloween pumpkin"},
+	{"🎆", "fireworks", "celebrate new_year"},
+	{"🏆", "trophy", "win award first champion"},
+	{"🥇", "1st_place_medal gold_medal", "first win gold"},
+	{"🏅", "medal_sports medal", "award win"},
+	{"🎯", "dart bullseye", "goal target focus"},
+	{"🎮", "video_game", "game gaming play"},
+	{"🎲", "game_die", "dice luck random"},
+	{"🎵", "musical_note", "music song"},
+	{"🎶", "notes", "music song"},
+	{"🎤", "microphone", "sing karaoke talk"},
+	{"🎧", "headphones", "music listen podcast"},
+	{"🎬", "clapper", "film movie action"},
+	{"📷", "camera", "photo picture"},
+	{"📸", "camera_flash", "photo picture selfie"},
+	{"🎥", "movie_camera", "film video"},
+	{"📺", "tv", "television show"},
+	{"💻", "computer laptop", "code work tech"},
+	{"🖥️", "desktop_computer", "computer screen"},
+	{"⌨️", "keyboard", "type computer"},
+	{"📱", "phone iphone mobile_phone", "mobile smartphone"},
+	{"☎️", "telephone", "phone call"},
+	{"🔋", "battery", "power energy"},
+	{"🔌", "electric_plug", "power plug"},
+	{"💡", "bulb", "idea tip light"},
+	{"🔦", "flashlight", "light torch"},
+	{"📚", "books", "read study library"},
+	{"📖", "book open_book", "read"},
+	{"📝", "memo pencil", "note write"},
+	{"✏️", "pencil2", "write draw edit"},
+	{"📌", "pushpin", "pin important"},
+	{"📎", "paperclip", "attach"},
+	{"📅", "calendar date", "schedule event"},
+	{"📆", "spiral_calendar", "schedule date"},
+	{"📈", "chart_with_upwards_trend", "growth up graph stats"},
+	{"📉", "chart_with_downwards_trend", "decline down graph stats"},
+	{"📊", "bar_chart", "stats graph data"},
+	{"📋", "clipboard", "list copy"},
+	{"📦", "package", "box ship release delivery"},
+	{"📣", "mega megaphone", "announce announcement loud"},
+	{"📢", "loudspeaker", "announce"},
+	{"🔔", "bell", "notification alert"},
+	{"🔕", "no_bell", "mute silent"},
+	{"📧", "email e-mail", "mail message"},
+	{"✉️", "envelope", "mail letter"},
+	{"📮", "postbox", "mail"},
+	{"🔗", "link", "url chain"},
+	{"🔒", "lock", "secure private"},
+	{"🔓", "unlock", "open"},
+	{"🔑", "key", "password access"},
+	{"🔍", "mag", "search find zoom"},
+	{"🔨", "hammer", "build tool"},
+	{"🔧", "wrench", "fix tool settings"},
+	{"🛠️", "hammer_and_wrench", "tools build fix"},
+	{"⚙️", "gear", "settings config"},
+	{"🧪", "test_tube", "test experiment science"},
+	{"🔬", "microscope", "science research"},
+	{"🧲", "magnet", "attract"},
+	{"💸", "money_with_wings", "spend money"},
+	{"💰", "moneybag", "money rich dollar"},
+	{"💵", "dollar", "money cash"},
+	{"🛒", "shopping_cart", "shop buy"},
+	{"⏰", "alarm_clock", "time wake morning"},
+	{"⌛", "hourglass", "time wait"},
+	{"⏳", "hourglass_flowing_sand", "time wait loading"},
+	{"⏱️", "stopwatch", "time timer"},
+	{"🚀", "rocket", "launch ship fast space"},
+	{"🛸", "flying_saucer", "ufo alien"},
+	{"✈️", "airplane", "travel flight"},
+	{"🚗", "car red_car", "drive"},
+	{"🚲", "bike", "bicycle cycling"},
+	{"🚢", "ship", "boat cruise"},
+	{"🚧", "construction", "wip work_in_progress"},
+	{"🚨", "rotating_light", "alert emergency siren breaking"},
+	{"🏠", "house home", "home"},
+	{"🏢", "office", "work building"},
+	{"🗺️", "world_map", "map travel"},
+
+	// Nature, weather and animals
+	{"☀️", "sun sunny", "weather summer bright"},
+	{"🌤️", "sun_behind_small_cloud", "weather"},
+	{"☁️", "cloud", "weather"},
+	{"🌧️", "cloud_with_rain", "weather rain"},
+	{"⛈️", "cloud_with_lightning_and_rain", "weather storm"},
+	{"❄️", "snowflake", "winter cold snow"},
+	{"☃️", "snowman_with_snow", "winter snow"},
+	{"🌊", "ocean", "wave sea water"},
+	{"🌙", "moon crescent_moon", "night"},
+	{"🌍", "earth_africa", "world globe"},
+	{"🌎", "earth_americas", "world globe"},
+	{"🌏", "earth_asia", "world globe"},
+	{"🌐", "globe_with_meridians", "internet web world"},
+	{"🌱", "seedling", "plant grow"},
+	{"🌲", "evergreen_tree", "tree forest"},
+	{"🌵", "cactus", "desert plant"},
+	{"🌸", "cherry_blossom", "flower spring"},
+	{"🌹", "rose", "flower love"},
+	{"🌻", "sunflower", "flower summer"},
+	{"🍀", "four_leaf_clover", "luck lucky"},
+	{"🍁", "maple_leaf", "autumn fall canada"},
+	{"🐶", "dog", "puppy pet"},
+	{"🐱", "cat", "kitten pet"},
+	{"🐭", "mouse", "animal"},
+	{"🐰", "rabbit", "bunny"},
+	{"🦊", "fox_face", "fox"},
+	{"🐻", "bear", "animal"},
+	{"🐼", "panda_face", "panda"},
+	{"🐨", "koala", "animal"},
+	{"🐯", "tiger", "animal"},
+	{"🦁", "lion", "animal"},
+	{"🐮", "cow", "animal"},
+	{"🐷", "pig", "animal"},
+	{"🐸", "frog", "animal"},
+	{"🐵", "monkey_face", "monkey"},
+	{"🐔", "chicken", "animal"},
+	{"🐧", "penguin", "linux"},
+	{"🐦", "bird", "tweet twitter"},
+	{"🦉", "owl", "night wise"},
+	{"🦄", "unicorn", "magic startup"},
+	{"🐝", "bee honeybee", "insect"},
+	{"🐛", "bug", "insect error defect"},
+	{"🦋", "butterfly", "insect pretty bluesky"},
+	{"🐢", "turtle", "slow"},
+	{"🐍", "snake", "python"},
+	{"🐙", "octopus", "github"},
+	{"🦀", "crab", "rust"},
+	{"🐳", "whale", "docker"},
+	{"🐬", "dolphin", "sea"},
+	{"🦈", "shark", "sea"},
+	{"🐘", "elephant", "mastodon"},
+
+	// Food and drink
+	{"🍎", "apple", "fruit"},
+	{"🍌", "banana", "fruit"},
+	{"🍓", "strawberry", "fruit"},
+	{"🍉", "watermelon", "fruit summer"},
+	{"🍋", "lemon", "fruit sour"},
+	{"🥑", "avocado", "fruit"},
+	{"🌶️", "hot_pepper", "spicy chili"},
+	{"🍕", "pizza", "food"},
+	{"🍔", "hamburger burger", "food"},
+	{"🍟", "fries", "food"},
+	{"🌮", "taco", "food mexican"},
+	{"🍣", "sushi", "food japanese"},
+	{"🍜", "ramen", "food noodles"},
+	{"🍩", "doughnut donut", "food sweet"},
+	{"🍪", "cookie", "food sweet"},
+	{"🍰", "cake", "food sweet dessert"},
+	{"🍫", "chocolate_bar", "food sweet"},
+	{"🍿", "popcorn", "movie snack drama"},
+	{"☕", "coffee", "drink morning cafe"},
+	{"🍵", "tea", "drink"},
+	{"🍺", "beer", "drink"},
+	{"🍻", "beers", "drink cheers"},
+	{"🍷", "wine_glass", "drink wine"},
+	{"🥂", "clinking_glasses", "cheers toast celebrate"},
+	{"🍾", "champagne", "celebrate bottle"},
+}
+
+// emojiShortcodes are the :shortcode: names of emojiData
+var emojiShortcodes = func() map[string]string {
+	codes := map[string]string{}
+	for _, e := range emojiData {
+		for _, name := range strings.Fields(e.names) {
+			codes[name] = e.emoji
+		}
+	}
+	return codes
+}()
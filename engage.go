@@ -0,0 +1,144 @@
+package clix
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/michimani/gotwi/tweet/like"
+	liketypes "github.com/michimani/gotwi/tweet/like/types"
+	"github.com/michimani/gotwi/tweet/retweet"
+	retweettypes "github.com/michimani/gotwi/tweet/retweet/types"
+)
+
+// like likes a tweet as the authenticated user
+func (a *app) like(ctx context.Context, tweetID string) error {
+	userID, err := a.me(ctx)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	_, err = like.Create(ctx, a.client, &liketypes.CreateInput{ID: userID, TweetID: tweetID})
+	a.stats.observe("likes", "like", start, err)
+	if err != nil {
+		return fmt.Errorf(tr("failed to like tweet: %w"), err)
+	}
+	return nil
+}
+
+// retweet retweets a tweet as the authenticated user
+func (a *app) retweet(ctx context.Context, tweetID string) error {
+	userID, err := a.me(ctx)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	_, err = retweet.Create(ctx, a.client, &retweettypes.CreateInput{ID: userID, TweetID: tweetID})
+	a.stats.observe("retweets", "retweet", start, err)
+	if err != nil {
+		return fmt.Errorf(tr("failed to retweet: %w"), err)
+	}
+	return nil
+}
+
+// unlike removes the authenticated user's like from a tweet
+func (a *app) unlike(ctx context.Context, tweetID string) error {
+	userID, err := a.me(ctx)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	_, err = like.Delete(ctx, a.client, &liketypes.DeleteInput{ID: userID, TweetID: tweetID})
+	a.stats.observe("unlikes", "unlike", start, err)
+	if err != nil {
+		return fmt.Errorf(tr("failed to unlike tweet: %w"), err)
+	}
+	return nil
+}
+
+// unretweet undoes the authenticated user's retweet of a tweet
+func (a *app) unretweet(ctx context.Context, tweetID string) error {
+	userID, err := a.me(ctx)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	_, err = retweet.Delete(ctx, a.client, &retweettypes.DeleteInput{ID: userID, SourceTweetID: tweetID})
+	a.stats.observe("unretweets", "unretweet", start, err)
+	if err != nil {
+		return fmt.Errorf(tr("failed to undo retweet: %w"), err)
+	}
+	return nil
+}
+
+// engagement is the result of an engagement command for one tweet
+type engagement struct {
+	ID     string `json:"id"`
+	Action string `json:"action"`
+}
+
+// runEngage runs action on every tweet given by ID or URL. done is the
+// English message shown once a tweet is handled, e.g. "Liked %s.\n".
+func runEngage(name, done string, action func(a *app, ctx context.Context, id string) error, args []string) error {
+	fs := newFlagSet(name, name+" <id|url>...")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+	ids := make([]string, 0, len(args))
+	for _, arg := range args {
+		id, err := parseTweetID(arg)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	ctx := rootCtx
+	results := []engagement{}
+	for _, id := range ids {
+		if err := action(a, ctx, id); err != nil {
+			if machineReadable() {
+				printResult(results)
+			}
+			if len(results) > 0 {
+				return withExitCode(exitPartial, err)
+			}
+			return err
+		}
+		results = append(results, engagement{ID: id, Action: name})
+		if !machineReadable() {
+			fmt.Printf(tr(done), id)
+		}
+	}
+	if machineReadable() {
+		return printResult(results)
+	}
+	return nil
+}
+
+func runLike(args []string) error {
+	return runEngage("like", "Liked %s.\n", (*app).like, args)
+}
+
+func runUnlike(args []string) error {
+	return runEngage("unlike", "Unliked %s.\n", (*app).unlike, args)
+}
+
+func runRetweet(args []string) error {
+	return runEngage("rt", "Retweeted %s.\n", (*app).retweet, args)
+}
+
+func runUnretweet(args []string) error {
+	return runEngage("unrt", "Undid retweet of %s.\n", (*app).unretweet, args)
+}
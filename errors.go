@@ -0,0 +1,102 @@
+package clix
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/michimani/gotwi"
+	clixclient "github.com/voltycodes/clix/client"
+)
+
+// Exit statuses. Scripts branch on them, so a status never changes its
+// meaning once released; new kinds of failure get new numbers.
+const (
+	exitFailure     = 1 // anything not covered below
+	exitUsage       = 2 // unknown command or flag
+	exitValidation  = 3 // the input cannot be posted: too long, a bad poll or media file
+	exitAuth        = 4 // missing or rejected credentials
+	exitRateLimited = 5 // the API said to slow down
+	exitNetwork     = 6 // X could not be reached
+	exitPartial     = 7 // some of a thread or batch was done before it failed
+	exitNotFound    = 8 // the tweet, user or list does not exist
+	exitRefused     = 9 // refused by X or by clix's own checks: safe mode, the posting window, lint, --yes
+	// exitTimeout is the same as timeout(1) uses
+	exitTimeout = 124
+)
+
+// exitError gives an error the exit status main ends with
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// withExitCode marks err to exit with code, keeping it for errors.Is and
+// errors.As. A nil err stays nil.
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code, err}
+}
+
+// exitCode picks the exit status for an error: the one it was marked with,
+// or else one told by the API's status code or the kind of network error
+func exitCode(err error) int {
+	var marked *exitError
+	var thread *threadError
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, errUsage):
+		return exitUsage
+	case errors.As(err, &marked):
+		return marked.code
+	case errors.As(err, &thread) && len(thread.posted) > 0:
+		return exitPartial
+	case isTimeout(err):
+		return exitTimeout
+	}
+
+	var gotwiErr *gotwi.GotwiError
+	var statusErr *clixclient.StatusError
+	switch {
+	case errors.As(err, &gotwiErr) && gotwiErr.OnAPI:
+		if code := statusExitCode(gotwiErr.StatusCode); code != 0 {
+			return code
+		}
+	case errors.As(err, &statusErr):
+		if code := statusExitCode(statusErr.StatusCode); code != 0 {
+			return code
+		}
+	}
+	if isNetworkError(err) {
+		return exitNetwork
+	}
+	return exitFailure
+}
+
+// statusExitCode maps the HTTP status of an API error to an exit status,
+// 0 for none in particular
+func statusExitCode(status int) int {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusRequestEntityTooLarge:
+		return exitValidation
+	case http.StatusUnauthorized:
+		return exitAuth
+	case http.StatusForbidden:
+		return exitRefused
+	case http.StatusNotFound, http.StatusGone:
+		return exitNotFound
+	case http.StatusTooManyRequests:
+		return exitRateLimited
+	}
+	return 0
+}
@@ -0,0 +1,350 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"html/template"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/tweet/bookmark/types"
+	"github.com/voltycodes/clix/compose"
+)
+
+// likedTweetsEndpoint takes the same parameters as bookmarksEndpoint, so
+// both are fetched with the bookmarks list input
+const likedTweetsEndpoint = "https://api.twitter.com/2/users/:id/liked_tweets"
+
+// exportTweetFields adds the URL entities, which carry the links behind
+// t.co, to the fields of clix show
+var exportTweetFields = append(slices.Clone(showTweetFields), fields.TweetFieldEntities)
+
+// exportFormats are the file formats of clix export
+var exportFormats = []string{"json", "csv", "html"}
+
+// exportOutput is a page of bookmarks or liked tweets with the users and
+// media they reference
+type exportOutput struct {
+	Data     []resources.Tweet        `json:"data"`
+	Meta     resources.PaginationMeta `json:"meta"`
+	Includes struct {
+		Users []resources.User  `json:"users,omitempty"`
+		Media []resources.Media `json:"media,omitempty"`
+	} `json:"includes,omitempty"`
+}
+
+func (r *exportOutput) HasPartialError() bool {
+	return false
+}
+
+// exportedTweet is a tweet as written by clix export
+type exportedTweet struct {
+	tweetView
+	Links []exportedLink `json:"links,omitempty"`
+}
+
+// exportedLink is a link in a tweet with where it leads, so the export
+// stays useful should t.co go away
+type exportedLink struct {
+	URL      string `json:"url"`
+	Resolved string `json:"resolved_url"`
+	Title    string `json:"title,omitempty"`
+}
+
+// exportTweets fetches every bookmark or liked tweet, newest first,
+// following pagination until the API has no more
+func (a *app) exportTweets(ctx context.Context, kind string) ([]exportedTweet, error) {
+	endpoint := bookmarksEndpoint
+	if kind == "likes" {
+		endpoint = likedTweetsEndpoint
+	}
+	userID, err := a.me(ctx)
+	if err != nil {
+		return nil, err
+	}
+	input := &types.ListInput{
+		ID:          userID,
+		MaxResults:  100,
+		TweetFields: exportTweetFields,
+		Expansions:  showExpand,
+		MediaFields: showMediaFields,
+		UserFields:  tweetViewUserFld,
+	}
+
+	exported := []exportedTweet{}
+	for {
+		if err := a.ensureToken(ctx); err != nil {
+			return nil, err
+		}
+		res := &exportOutput{}
+		if err := a.client.CallAPI(ctx, endpoint, "GET", input, res); err != nil {
+			return exported, fmt.Errorf(tr("failed to fetch %s after %d: %w"), kind, len(exported), err)
+		}
+		views := withMedia(newTweetViews(res.Data, res.Includes.Users), res.Data, res.Includes.Media)
+		for i, view := range views {
+			exported = append(exported, exportedTweet{tweetView: view, Links: tweetLinks(res.Data[i])})
+		}
+		if res.Meta.NextToken == nil || *res.Meta.NextToken == "" {
+			return exported, nil
+		}
+		input.PaginationToken = *res.Meta.NextToken
+		fmt.Fprintf(os.Stderr, tr("Fetched %d %s...\n"), len(exported), kind)
+	}
+}
+
+// tweetLinks returns the links in a tweet, resolved past t.co and any
+// redirects X followed. The links to the tweet's own media are left out as
+// the media are exported already.
+func tweetLinks(tweet resources.Tweet) []exportedLink {
+	if tweet.Entities == nil {
+		return nil
+	}
+	var links []exportedLink
+	for _, u := range tweet.Entities.URLs {
+		display := gotwi.StringValue(u.DisplayURL)
+		if strings.HasPrefix(display, "pic.x.com/") || strings.HasPrefix(display, "pic.twitter.com/") {
+			continue
+		}
+		link := exportedLink{
+			URL:      gotwi.StringValue(u.URL),
+			Resolved: gotwi.StringValue(u.UnwoundURL),
+			Title:    gotwi.StringValue(u.Title),
+		}
+		if link.Resolved == "" {
+			link.Resolved = gotwi.StringValue(u.ExpandedURL)
+		}
+		if link.Resolved == "" {
+			link.Resolved = link.URL
+		}
+		links = append(links, link)
+	}
+	return links
+}
+
+// writeExport writes the tweets to path in the given format, through a
+// temporary file so an interrupted export does not leave half a file
+func writeExport(path, format, kind string, tweets []exportedTweet) error {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(&buf)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(tweets)
+	case "csv":
+		err = writeExportCSV(&buf, tweets)
+	case "html":
+		err = exportHTML.Execute(&buf, map[string]any{
+			"Kind":     kind,
+			"Exported": time.Now().Format("2 January 2006 15:04"),
+			"Tweets":   tweets,
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if path == "-" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".clix-export-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// writeExportCSV writes one row per tweet. Links and media, of which a
+// tweet can have several, are joined with spaces.
+func writeExportCSV(buf *bytes.Buffer, tweets []exportedTweet) error {
+	w := csv.NewWriter(buf)
+	w.Write([]string{"id", "created_at", "author_username", "author_name", "text", "url", "links", "media", "replies", "retweets", "likes", "quotes"})
+	for _, t := range tweets {
+		var links, media []string
+		for _, link := range t.Links {
+			links = append(links, link.Resolved)
+		}
+		for _, m := range t.Media {
+			media = append(media, m.URL)
+		}
+		created := ""
+		if !t.CreatedAt.IsZero() {
+			created = t.CreatedAt.UTC().Format(time.RFC3339)
+		}
+		w.Write([]string{
+			t.ID, created, t.AuthorUsername, t.AuthorName, t.Text, t.URL,
+			strings.Join(links, " "), strings.Join(media, " "),
+			strconv.Itoa(t.Replies), strconv.Itoa(t.Retweets), strconv.Itoa(t.Likes), strconv.Itoa(t.Quotes),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// exportHTML is a page that reads without clix or X: the text with its
+// links resolved, and the media linked or shown inline
+var exportHTML = template.Must(template.New("export").Funcs(template.FuncMap{
+	"text": exportedText,
+	"date": func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format("2 Jan 2006 15:04")
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Kind}}, exported {{.Exported}}</title>
+<style>
+body { font-family: sans-serif; max-width: 40em; margin: 2em auto; line-height: 1.4; }
+article { border-bottom: 1px solid #ddd; padding: 1em 0; }
+.meta { color: #666; font-size: 0.9em; }
+.text { white-space: pre-wrap; }
+img { max-width: 100%; }
+</style>
+</head>
+<body>
+<h1>{{.Kind}}</h1>
+<p class="meta">{{len .Tweets}} tweets, exported {{.Exported}}</p>
+{{range .Tweets}}<article>
+<p class="meta">{{.AuthorName}} @{{.AuthorUsername}} · <a href="{{.URL}}">{{date .CreatedAt}}</a></p>
+<p class="text">{{text .}}</p>
+{{range .Media}}{{if eq .Type "photo"}}<p><a href="{{.URL}}"><img src="{{.URL}}" alt="{{.Alt}}"></a></p>
+{{else}}<p><a href="{{.URL}}">{{.Type}}</a>{{with .Alt}}: {{.}}{{end}}</p>
+{{end}}{{end}}<p class="meta">{{.Replies}} replies · {{.Retweets}} retweets · {{.Likes}} likes</p>
+</article>
+{{end}}</body>
+</html>
+`))
+
+// exportedText is the text of a tweet as HTML, with its t.co links
+// replaced by links to where they lead
+func exportedText(t exportedTweet) template.HTML {
+	resolved := map[string]exportedLink{}
+	for _, link := range t.Links {
+		resolved[link.URL] = link
+	}
+	var b strings.Builder
+	// The API escapes &, < and > in tweet text
+	for _, span := range compose.SplitURLs(html.UnescapeString(t.Text)) {
+		if !span.URL {
+			b.WriteString(template.HTMLEscapeString(span.Text))
+			continue
+		}
+		link, ok := resolved[span.Text]
+		if !ok {
+			// A link to the tweet's own media, which is shown below it
+			if strings.HasPrefix(span.Text, "https://t.co/") {
+				continue
+			}
+			link = exportedLink{URL: span.Text, Resolved: span.Text}
+		}
+		label := link.Resolved
+		if link.Title != "" {
+			label = link.Title
+		}
+		fmt.Fprintf(&b, `<a href="%s">%s</a>`, template.HTMLEscapeString(link.Resolved), template.HTMLEscapeString(label))
+	}
+	return template.HTML(b.String())
+}
+
+// exportFormat takes the format of clix export from --format, which
+// elsewhere is an output template, or else from the file's extension
+func exportFormat(path string) (string, error) {
+	format := globalOptions.format
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(path), ".")
+		if !slices.Contains(exportFormats, format) {
+			format = "json"
+		}
+	}
+	// The format is the file's, and not a template for what clix prints
+	globalOptions.format = ""
+	outputTemplate = nil
+	if !slices.Contains(exportFormats, format) {
+		return "", withExitCode(exitUsage, fmt.Errorf(tr("--format for export must be one of %s"), strings.Join(exportFormats, ", ")))
+	}
+	return format, nil
+}
+
+func runExport(args []string) error {
+	if len(args) > 0 && isHelpArg(args[0]) {
+		fmt.Fprintln(os.Stderr, tr("Usage: clix export bookmarks|likes [--format json|csv|html] [--output file]"))
+		return nil
+	}
+	if len(args) == 0 || isFlagArg(args[0]) {
+		fmt.Fprintln(os.Stderr, tr("Usage: clix export bookmarks|likes [--format json|csv|html] [--output file]"))
+		return errUsage
+	}
+	kind, args := args[0], args[1:]
+	if kind != "bookmarks" && kind != "likes" {
+		return withExitCode(exitUsage, fmt.Errorf(tr("unknown export action %q"), kind))
+	}
+
+	fs := newFlagSet("export "+kind, "export "+kind+" [--format json|csv|html] [--output file]  (writes all of them, with links resolved and media URLs)")
+	output := fs.String("output", "", "file to write, - for stdout (default "+kind+"-<date>.<format>)")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	format, err := exportFormat(*output)
+	if err != nil {
+		return err
+	}
+	path := *output
+	if path == "" {
+		path = fmt.Sprintf("%s-%s.%s", kind, time.Now().Format("2006-01-02"), format)
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	tweets, err := a.exportTweets(rootCtx, kind)
+	if err != nil {
+		if len(tweets) == 0 {
+			return err
+		}
+		// Keep what was fetched; running again starts over but loses nothing
+		if werr := writeExport(path, format, kind, tweets); werr != nil {
+			return werr
+		}
+		return withExitCode(exitPartial, fmt.Errorf(tr("%w; wrote the %d fetched so far to %s"), err, len(tweets), path))
+	}
+	if err := writeExport(path, format, kind, tweets); err != nil {
+		return err
+	}
+
+	if globalOptions.json && path != "-" {
+		return printResult(map[string]any{"kind": kind, "format": format, "path": path, "count": len(tweets)})
+	}
+	if path != "-" {
+		fmt.Fprintf(os.Stderr, tr("Exported %d %s to %s.\n"), len(tweets), kind, path)
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/user/follow"
+	"github.com/michimani/gotwi/user/follow/types"
+)
+
+var userViewFields = fields.UserFieldList{
+	fields.UserFieldName, fields.UserFieldUsername, fields.UserFieldDescription,
+	fields.UserFieldPublicMetrics, fields.UserFieldProtected, fields.UserFieldVerified,
+}
+
+// userView is the flattened form of a user that clix prints
+type userView struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Username    string `json:"username"`
+	Description string `json:"description,omitempty"`
+	Followers   int    `json:"followers"`
+	Following   int    `json:"following"`
+	Tweets      int    `json:"tweets"`
+	Protected   bool   `json:"protected,omitempty"`
+	Verified    bool   `json:"verified,omitempty"`
+}
+
+func newUserView(u resources.User) userView {
+	view := userView{
+		ID:          gotwi.StringValue(u.ID),
+		Name:        gotwi.StringValue(u.Name),
+		Username:    gotwi.StringValue(u.Username),
+		Description: gotwi.StringValue(u.Description),
+		Protected:   gotwi.BoolValue(u.Protected),
+		Verified:    gotwi.BoolValue(u.Verified),
+	}
+	if m := u.PublicMetrics; m != nil {
+		view.Followers = gotwi.IntValue(m.FollowersCount)
+		view.Following = gotwi.IntValue(m.FollowingCount)
+		view.Tweets = gotwi.IntValue(m.TweetCount)
+	}
+	return view
+}
+
+// follow follows a user as the authenticated user. It reports whether the
+// follow is pending, which is the case for protected accounts.
+func (a *app) follow(ctx context.Context, targetID string) (bool, error) {
+	userID, err := a.me(ctx)
+	if err != nil {
+		return false, err
+	}
+	start := time.Now()
+	res, err := follow.CreateFollowing(ctx, a.client, &types.CreateFollowingInput{ID: userID, TargetID: targetID})
+	a.stats.observe("follows", "follow", start, err)
+	if err != nil {
+		return false, fmt.Errorf(tr("failed to follow: %w"), err)
+	}
+	return res.Data.PendingFollow, nil
+}
+
+func (a *app) unfollow(ctx context.Context, targetID string) error {
+	userID, err := a.me(ctx)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	_, err = follow.DeleteFollowing(ctx, a.client, &types.DeleteFollowingInput{SourceUserID: userID, TargetID: targetID})
+	a.stats.observe("unfollows", "unfollow", start, err)
+	if err != nil {
+		return fmt.Errorf(tr("failed to unfollow: %w"), err)
+	}
+	return nil
+}
+
+// followList returns up to count followers of userID, or accounts it
+// follows, following pagination
+func (a *app) followList(ctx context.Context, userID string, followers bool, count int) ([]userView, error) {
+	pageSize := types.ListMaxResults(min(max(count, 1), 1000))
+	token := ""
+	views := []userView{}
+	for len(views) < count {
+		if err := a.ensureToken(ctx); err != nil {
+			return nil, err
+		}
+		var users []resources.User
+		var meta resources.PaginationMeta
+		if followers {
+			res, err := follow.ListFollowers(ctx, a.client, &types.ListFollowersInput{
+				ID: userID, MaxResults: pageSize, PaginationToken: token, UserFields: userViewFields,
+			})
+			if err != nil {
+				return nil, fmt.Errorf(tr("failed to fetch followers: %w"), err)
+			}
+			users, meta = res.Data, res.Meta
+		} else {
+			res, err := follow.ListFollowings(ctx, a.client, &types.ListFollowingsInput{
+				ID: userID, MaxResults: pageSize, PaginationToken: token, UserFields: userViewFields,
+			})
+			if err != nil {
+				return nil, fmt.Errorf(tr("failed to fetch followed accounts: %w"), err)
+			}
+			users, meta = res.Data, res.Meta
+		}
+		for _, u := range users {
+			views = append(views, newUserView(u))
+		}
+		if meta.NextToken == nil || *meta.NextToken == "" {
+			break
+		}
+		token = *meta.NextToken
+	}
+	if len(views) > count {
+		views = views[:count]
+	}
+	return views, nil
+}
+
+// relationship is the result of follow or unfollow for one user
+type relationship struct {
+	Username string `json:"username"`
+	ID       string `json:"id"`
+	Action   string `json:"action"`
+	Pending  bool   `json:"pending,omitempty"`
+}
+
+func runFollow(args []string) error {
+	return runRelationship("follow", args)
+}
+
+func runUnfollow(args []string) error {
+	return runRelationship("unfollow", args)
+}
+
+func runRelationship(name string, args []string) error {
+	fs := newFlagSet(name, name+" @user...")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	ctx := rootCtx
+	results := []relationship{}
+	for _, username := range args {
+		result, err := a.changeRelationship(ctx, name, username)
+		if err != nil {
+			if machineReadable() {
+				printResult(results)
+			}
+			if len(results) > 0 {
+				return withExitCode(exitPartial, err)
+			}
+			return err
+		}
+		results = append(results, result)
+		if !machineReadable() {
+			switch {
+			case result.Pending:
+				fmt.Printf(tr("Follow request sent to @%s.\n"), result.Username)
+			case name == "follow":
+				fmt.Printf(tr("Followed @%s.\n"), result.Username)
+			default:
+				fmt.Printf(tr("Unfollowed @%s.\n"), result.Username)
+			}
+		}
+	}
+	if machineReadable() {
+		return printResult(results)
+	}
+	return nil
+}
+
+func (a *app) changeRelationship(ctx context.Context, action, username string) (relationship, error) {
+	id, err := a.lookupUser(ctx, username)
+	if err != nil {
+		return relationship{}, err
+	}
+	result := relationship{Username: trimHandle(username), ID: id, Action: action}
+	if action == "follow" {
+		result.Pending, err = a.follow(ctx, id)
+	} else {
+		err = a.unfollow(ctx, id)
+	}
+	return result, err
+}
+
+func runFollowers(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "snapshot":
+			return runFollowersSnapshot(args[1:])
+		case "diff":
+			return runFollowersDiff(args[1:])
+		}
+	}
+	return runFollowList("followers", true, args)
+}
+
+func runFollowing(args []string) error {
+	return runFollowList("following", false, args)
+}
+
+func runFollowList(name string, followers bool, args []string) error {
+	fs := newFlagSet(name, name+" [--count n] [@user]  (defaults to your own account)")
+	count := fs.Int("count", 100, "number of accounts to show")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 1 {
+		fs.Usage()
+		return errUsage
+	}
+	if *count < 1 {
+		return errors.New(tr("--count must be at least 1"))
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	ctx := rootCtx
+	var userID string
+	if len(args) == 1 {
+		userID, err = a.lookupUser(ctx, args[0])
+	} else {
+		userID, err = a.me(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	views, err := a.followList(ctx, userID, followers, *count)
+	if err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(views)
+	}
+	if len(views) == 0 {
+		fmt.Println(tr("No accounts."))
+		return nil
+	}
+	for _, view := range views {
+		fmt.Printf("@%-16s %s\n", view.Username, view.Name)
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package clix
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	clixclient "github.com/voltycodes/clix/client"
+)
+
+const (
+	giphySearchEndpoint = "https://api.giphy.com/v1/gifs/search"
+	tenorSearchEndpoint = "https://tenor.googleapis.com/v2/search"
+
+	gifResults     = 10
+	gifTimeout     = 30 * time.Second
+	gifDownloadDir = "gifs"
+)
+
+// GIFConfig is the "gif" section of the config, for post --gif
+type GIFConfig struct {
+	Provider string `json:"provider"` // giphy or tenor
+	APIKey   string `json:"api_key"`
+	// Rating caps how explicit results may be, e.g. "pg" (giphy) or
+	// "medium" (tenor's contentfilter)
+	Rating string `json:"rating,omitempty"`
+}
+
+// gifResult is one GIF a search found
+type gifResult struct {
+	ID      string
+	Title   string
+	URL     string // of the file to attach
+	Size    int
+	Preview string // of a small version, for inline previews
+}
+
+func (c *GIFConfig) search(ctx context.Context, query string) ([]gifResult, error) {
+	if c == nil || c.APIKey == "" {
+		return nil, errors.New(tr(`--gif needs a "gif" section in the config with a provider and an api_key`))
+	}
+	switch c.Provider {
+	case "giphy", "":
+		return c.searchGiphy(ctx, query)
+	case "tenor":
+		return c.searchTenor(ctx, query)
+	}
+	return nil, fmt.Errorf(tr("unknown GIF provider %q, expected giphy or tenor"), c.Provider)
+}
+
+func (c *GIFConfig) searchGiphy(ctx context.Context, query string) ([]gifResult, error) {
+	params := url.Values{"api_key": {c.APIKey}, "q": {query}, "limit": {strconv.Itoa(gifResults)}}
+	if c.Rating != "" {
+		params.Set("rating", c.Rating)
+	}
+	type image struct {
+		URL  string `json:"url"`
+		Size string `json:"size"`
+	}
+	var res struct {
+		Data []struct {
+			ID     string `json:"id"`
+			Title  string `json:"title"`
+			Images struct {
+				Original  image `json:"original"`
+				Downsized image `json:"downsized"`
+				Preview   image `json:"fixed_height_small"`
+			} `json:"images"`
+		} `json:"data"`
+	}
+	if err := c.get(ctx, giphySearchEndpoint, params, &res); err != nil {
+		return nil, err
+	}
+	results := make([]gifResult, 0, len(res.Data))
+	for _, gif := range res.Data {
+		// The original can be larger than X accepts
+		file := gif.Images.Original
+		if size, _ := strconv.Atoi(file.Size); size > maxGIFBytes || file.URL == "" {
+			file = gif.Images.Downsized
+		}
+		size, _ := strconv.Atoi(file.Size)
+		results = append(results, gifResult{ID: gif.ID, Title: gif.Title, URL: file.URL, Size: size, Preview: gif.Images.Preview.URL})
+	}
+	return results, nil
+}
+
+func (c *GIFConfig) searchTenor(ctx context.Context, query string) ([]gifResult, error) {
+	params := url.Values{
+		"key": {c.APIKey}, "q": {query}, "limit": {strconv.Itoa(gifResults)},
+		"media_filter": {"gif,tinygif"}, "client_key": {"clix"},
+	}
+	if c.Rating != "" {
+		params.Set("contentfilter", c.Rating)
+	}
+	type format struct {
+		URL  string `json:"url"`
+		Size int    `json:"size"`
+	}
+	var res struct {
+		Results []struct {
+			ID          string `json:"id"`
+			Description string `json:"content_description"`
+			Formats     struct {
+				GIF     format `json:"gif"`
+				TinyGIF format `json:"tinygif"`
+			} `json:"media_formats"`
+		} `json:"results"`
+	}
+	if err := c.get(ctx, tenorSearchEndpoint, params, &res); err != nil {
+		return nil, err
+	}
+	results := make([]gifResult, 0, len(res.Results))
+	for _, gif := range res.Results {
+		file := gif.Formats.GIF
+		if file.Size > maxGIFBytes || file.URL == "" {
+			file = gif.Formats.TinyGIF
+		}
+		results = append(results, gifResult{ID: gif.ID, Title: gif.Description, URL: file.URL, Size: file.Size, Preview: gif.Formats.TinyGIF.URL})
+	}
+	return results, nil
+}
+
+func (c *GIFConfig) get(ctx context.Context, endpoint string, params url.Values, out any) error {
+	ctx, cancel := context.WithTimeout(ctx, gifTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if err := clixclient.DoJSON(newDirectHTTPClient(), req, out); err != nil {
+		// The error would show the API key in the query
+		return fmt.Errorf(tr("GIF search failed: %s"), strings.ReplaceAll(err.Error(), c.APIKey, "***"))
+	}
+	return nil
+}
+
+// fetchGIF downloads u, returning at most limit bytes
+func fetchGIF(ctx context.Context, u string, limit int64) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, gifTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := newDirectHTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(tr("failed to download GIF: %w"), err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(tr("failed to download GIF: %s"), res.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(res.Body, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf(tr("failed to download GIF: %w"), err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf(tr("the GIF is over %d MB"), limit>>20)
+	}
+	return data, nil
+}
+
+// pickGIF searches for query and downloads the GIF chosen from the
+// results, or the pick-th one when pick is set. It returns the path of the
+// file and its title, to use as alt text. The file is kept in the data
+// directory so a queued or scheduled tweet can still attach it later.
+func pickGIF(ctx context.Context, config *GIFConfig, query string, pick int) (string, string, error) {
+	results, err := config.search(ctx, query)
+	if err != nil {
+		return "", "", err
+	}
+	if len(results) == 0 {
+		return "", "", fmt.Errorf(tr("no GIFs found for %q"), query)
+	}
+
+	if pick == 0 {
+		if !stdinIsTerminal() {
+			return "", "", errors.New(tr("--gif needs a terminal to choose on; pass --gif-pick n to take the nth result"))
+		}
+		iterm := imageProtocol() == "iterm"
+		for i, gif := range results {
+			fmt.Printf(tr("%2d. %s (%d KB)\n"), i+1, cmp.Or(gif.Title, gif.ID), max(gif.Size>>10, 1))
+			if iterm && gif.Preview != "" {
+				if data, err := fetchGIF(ctx, gif.Preview, maxGIFBytes); err == nil {
+					printInlineImage(os.Stdout, "iterm", data)
+				}
+			}
+		}
+		answer, err := promptLine(trf("GIF to attach [1-%d]: ", len(results)))
+		if err != nil {
+			return "", "", err
+		}
+		if pick, err = strconv.Atoi(answer); err != nil {
+			return "", "", errors.New(tr("no GIF chosen"))
+		}
+	}
+	if pick < 1 || pick > len(results) {
+		return "", "", fmt.Errorf(tr("pick a GIF between 1 and %d"), len(results))
+	}
+	gif := results[pick-1]
+
+	data, err := fetchGIF(ctx, gif.URL, maxGIFBytes)
+	if err != nil {
+		return "", "", err
+	}
+	dir, err := getDataDir()
+	if err != nil {
+		return "", "", err
+	}
+	dir = filepath.Join(dir, gifDownloadDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", "", fmt.Errorf(tr("failed to save GIF: %w"), err)
+	}
+	path := filepath.Join(dir, url.PathEscape(gif.ID)+".gif")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", "", fmt.Errorf(tr("failed to save GIF: %w"), err)
+	}
+	return path, gif.Title, nil
+}
@@ -0,0 +1,269 @@
+package clix
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/michimani/gotwi/tweet/timeline/types"
+)
+
+// handlesStateFile maps account names to the handles offered when typing
+// a mention
+const handlesStateFile = "handles.json"
+
+const (
+	// handleCacheFollowing is how many followed accounts a refresh fetches
+	handleCacheFollowing = 1000
+	// handleCacheMaxAge is when the repl and tui suggest refreshing
+	handleCacheMaxAge = 30 * 24 * time.Hour
+)
+
+// Where a cached handle came from
+const (
+	handleSourceMention   = "mention"
+	handleSourceFollowing = "following"
+)
+
+type cachedHandle struct {
+	Username string `json:"username"`
+	Name     string `json:"name,omitempty"`
+	Source   string `json:"source"`
+}
+
+// handleCache is the handles of one account, people who recently
+// mentioned it first and then those it follows
+type handleCache struct {
+	UpdatedAt time.Time      `json:"updated_at"`
+	Handles   []cachedHandle `json:"handles"`
+}
+
+// refreshHandles fetches the accounts the user follows and recent mentions
+// and saves them as the account's handle cache
+func (a *app) refreshHandles(ctx context.Context) (handleCache, error) {
+	userID, err := a.me(ctx)
+	if err != nil {
+		return handleCache{}, err
+	}
+	mentions, _, err := a.listMentions(ctx, &types.ListMentionsInput{
+		ID:          userID,
+		MaxResults:  100,
+		TweetFields: tweetViewFields,
+		Expansions:  tweetViewExpand,
+		UserFields:  tweetViewUserFld,
+	})
+	if err != nil {
+		return handleCache{}, err
+	}
+	following, err := a.followList(ctx, userID, false, handleCacheFollowing)
+	if err != nil {
+		return handleCache{}, err
+	}
+
+	cache := handleCache{UpdatedAt: time.Now().UTC(), Handles: []cachedHandle{}}
+	seen := map[string]bool{}
+	add := func(username, name, source string) {
+		if username == "" || seen[strings.ToLower(username)] {
+			return
+		}
+		seen[strings.ToLower(username)] = true
+		cache.Handles = append(cache.Handles, cachedHandle{username, name, source})
+	}
+	for _, tweet := range mentions {
+		add(tweet.AuthorUsername, tweet.AuthorName, handleSourceMention)
+	}
+	for _, user := range following {
+		add(user.Username, user.Name, handleSourceFollowing)
+	}
+
+	unlock, err := lockState(handlesStateFile)
+	if err != nil {
+		return handleCache{}, err
+	}
+	defer unlock()
+	caches := map[string]handleCache{}
+	if err := loadState(handlesStateFile, &caches); err != nil {
+		return handleCache{}, err
+	}
+	caches[a.config.Active] = cache
+	if err := saveState(handlesStateFile, caches); err != nil {
+		return handleCache{}, err
+	}
+	return cache, nil
+}
+
+// loadHandles returns the handle cache of an account, empty if it was
+// never refreshed
+func loadHandles(account string) (handleCache, error) {
+	caches := map[string]handleCache{}
+	if err := loadState(handlesStateFile, &caches); err != nil {
+		return handleCache{}, err
+	}
+	return caches[account], nil
+}
+
+// handleCompleter returns the completions of a partial mention for the
+// line editor and the tui, warning when there is nothing to complete from
+func handleCompleter(account string) func(prefix string) []string {
+	cache, err := loadHandles(account)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, tr("Warning:"), err)
+	}
+	switch {
+	case len(cache.Handles) == 0:
+		fmt.Fprintln(os.Stderr, tr("Run `clix handles refresh` to complete @mentions with Tab."))
+	case time.Since(cache.UpdatedAt) > handleCacheMaxAge:
+		fmt.Fprintln(os.Stderr, tr("The handles for @mention completion are over a month old; `clix handles refresh` updates them."))
+	}
+	return func(prefix string) []string {
+		return cache.complete(prefix)
+	}
+}
+
+// complete returns the handles, with their proper case, that start with
+// prefix or whose display name has a word starting with it. Handle matches
+// come first.
+func (c handleCache) complete(prefix string) []string {
+	prefix = strings.ToLower(strings.TrimPrefix(prefix, "@"))
+	var byHandle, byName []string
+	for _, h := range c.Handles {
+		switch {
+		case strings.HasPrefix(strings.ToLower(h.Username), prefix):
+			byHandle = append(byHandle, h.Username)
+		case prefix != "" && slices.ContainsFunc(strings.Fields(strings.ToLower(h.Name)), func(word string) bool {
+			return strings.HasPrefix(word, prefix)
+		}):
+			byName = append(byName, h.Username)
+		}
+	}
+	return append(byHandle, byName...)
+}
+
+// mentionPrefix returns where the partial mention ending at the end of
+// text starts, or -1 when text does not end in one
+func mentionPrefix(text []rune) int {
+	i := len(text)
+	for i > 0 && isHandleRune(text[i-1]) {
+		i--
+	}
+	if i == 0 || text[i-1] != '@' {
+		return -1
+	}
+	// An @ inside a word is an email address rather than a mention
+	if i > 1 && (isHandleRune(text[i-2]) || text[i-2] == '@') {
+		return -1
+	}
+	return i - 1
+}
+
+func isHandleRune(r rune) bool {
+	return r == '_' || r < 128 && (r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+}
+
+// commonPrefix returns the longest prefix the handles starting with typed
+// share, ignoring case and taking it from the first. Matches by display
+// name are left out as they rarely share anything.
+func commonPrefix(typed string, handles []string) string {
+	names := slices.DeleteFunc(slices.Clone(handles), func(h string) bool {
+		return len(h) < len(typed) || !strings.EqualFold(h[:len(typed)], typed)
+	})
+	if len(names) == 0 {
+		return ""
+	}
+	prefix := names[0]
+	for _, name := range names[1:] {
+		n := 0
+		for n < len(prefix) && n < len(name) && strings.EqualFold(prefix[n:n+1], name[n:n+1]) {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+	return prefix
+}
+
+func runHandles(args []string) error {
+	if len(args) > 0 && isHelpArg(args[0]) {
+		fmt.Fprintln(os.Stderr, tr("Usage: clix handles [list [prefix]|refresh]"))
+		return nil
+	}
+	action := "list"
+	if len(args) > 0 && !isFlagArg(args[0]) {
+		action, args = args[0], args[1:]
+	}
+
+	switch action {
+	case "list":
+		return runHandlesList(args)
+	case "refresh":
+		return runHandlesRefresh(args)
+	default:
+		return withExitCode(exitUsage, fmt.Errorf(tr("unknown handles action %q"), action))
+	}
+}
+
+func runHandlesList(args []string) error {
+	fs := newFlagSet("handles list", "handles list [prefix]  (the handles Tab completes after @ in the repl and tui)")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 1 {
+		fs.Usage()
+		return errUsage
+	}
+	config, err := readConfig(getConfigFilePath())
+	if err != nil {
+		return err
+	}
+	cache, err := loadHandles(config.Active)
+	if err != nil {
+		return err
+	}
+	handles := cache.Handles
+	if len(args) == 1 {
+		matches := cache.complete(args[0])
+		handles = slices.DeleteFunc(slices.Clone(handles), func(h cachedHandle) bool { return !slices.Contains(matches, h.Username) })
+	}
+
+	if machineReadable() {
+		if handles == nil {
+			handles = []cachedHandle{}
+		}
+		return printResult(handles)
+	}
+	if len(cache.Handles) == 0 {
+		fmt.Println(tr("No handles cached; run `clix handles refresh`."))
+		return nil
+	}
+	for _, h := range handles {
+		fmt.Printf("@%-16s %-10s %s\n", h.Username, h.Source, h.Name)
+	}
+	fmt.Printf(tr("%d of %d handles, refreshed %s.\n"), len(handles), len(cache.Handles), formatTime(cache.UpdatedAt))
+	return nil
+}
+
+func runHandlesRefresh(args []string) error {
+	fs := newFlagSet("handles refresh", "handles refresh  (fetches the accounts you follow and recent mentions)")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	cache, err := a.refreshHandles(rootCtx)
+	if err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(cache)
+	}
+	fmt.Printf(tr("Cached %d handles for @mention completion.\n"), len(cache.Handles))
+	return nil
+}
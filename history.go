@@ -0,0 +1,334 @@
+package clix
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/voltycodes/clix/store"
+)
+
+const historyFileName = store.HistoryFileName
+
+// historyEntry is one tweet posted through clix
+type historyEntry = store.HistoryEntry
+
+// getDataDir returns the directory holding clix's local state, creating
+// it if needed. $CLIX_STATE_DIR overrides it; with credentials from the
+// environment it defaults to the user's cache directory, as there is no
+// config directory in use, rather than a shared temporary directory
+// another user could have made first. Mock mode keeps its state in mock/
+// within it.
+func getDataDir() (string, error) {
+	dir := os.Getenv(stateDirEnvVar)
+	switch {
+	case dir != "":
+	case envCredentialsSet():
+		cache, err := os.UserCacheDir()
+		if err != nil {
+			return "", fmt.Errorf(tr("no cache directory to keep clix's state in; set $%s: %w"), stateDirEnvVar, err)
+		}
+		dir = filepath.Join(cache, "clix")
+	default:
+		// State is per user even when a project config is in use
+		userConfig, _ := userConfigFileSource()
+		dir = filepath.Join(filepath.Dir(userConfig), "clix")
+	}
+	if mocking() {
+		dir = filepath.Join(dir, "mock")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf(tr("failed to create data directory: %w"), err)
+	}
+	return dir, nil
+}
+
+// appendHistory records a posted tweet
+func appendHistory(entry historyEntry) error {
+	unlock, err := lockState(historyFileName)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+	return s.AppendHistory(entry)
+}
+
+// loadHistory returns every recorded tweet, oldest first
+func loadHistory() ([]historyEntry, error) {
+	s, err := openStore()
+	if err != nil {
+		return nil, err
+	}
+	return s.LoadHistory()
+}
+
+// saveHistory replaces the history with entries
+func saveHistory(entries []historyEntry) error {
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+	return s.SaveHistory(entries)
+}
+
+// lastPosted returns the most recent tweet that has not been deleted
+func lastPosted() (*historyEntry, error) {
+	entries, err := loadHistory()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].DeletedAt == nil {
+			return &entries[i], nil
+		}
+	}
+	return nil, errors.New(tr("no tweets in history"))
+}
+
+// markDeleted records that a tweet was deleted, if it is in the history
+func markDeleted(id string) error {
+	unlock, err := lockState(historyFileName)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, err := loadHistory()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	found := false
+	for i := range entries {
+		if entries[i].ID == id && entries[i].DeletedAt == nil {
+			entries[i].DeletedAt = &now
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+	return saveHistory(entries)
+}
+
+// historyFilter selects entries for `clix history` and `clix history undo`
+type historyFilter struct {
+	account        string
+	search         string
+	since, until   time.Time
+	includeDeleted bool
+}
+
+func (f historyFilter) match(entry historyEntry) bool {
+	switch {
+	case entry.DeletedAt != nil && !f.includeDeleted:
+		return false
+	// Entries recorded before clix tracked accounts match any account
+	case f.account != "" && entry.Account != "" && entry.Account != f.account:
+		return false
+	case f.search != "" && !strings.Contains(strings.ToLower(entry.Text), strings.ToLower(f.search)):
+		return false
+	case !f.since.IsZero() && entry.PostedAt.Before(f.since):
+		return false
+	case !f.until.IsZero() && !entry.PostedAt.Before(f.until):
+		return false
+	}
+	return true
+}
+
+// findHistory returns up to count matching entries, newest first
+func findHistory(f historyFilter, count int) ([]historyEntry, error) {
+	entries, err := loadHistory()
+	if err != nil {
+		return nil, err
+	}
+	found := []historyEntry{}
+	for i := len(entries) - 1; i >= 0 && len(found) < count; i-- {
+		if f.match(entries[i]) {
+			found = append(found, entries[i])
+		}
+	}
+	return found, nil
+}
+
+// parseHistoryTime reads a --since or --until value: a date, a local time
+// or a duration back from now such as 48h or 7d
+func parseHistoryTime(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	t, err := parseScheduleTime(s, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(tr(`invalid time %q, expected "YYYY-MM-DD", "YYYY-MM-DD HH:MM" or a duration like 48h or 7d`), s)
+	}
+	return t, nil
+}
+
+// explicitAccount returns the account chosen with --account or
+// $CLIX_ACCOUNT, or "" when none was chosen
+func explicitAccount() string {
+	if globalOptions.account != "" {
+		return globalOptions.account
+	}
+	return os.Getenv(accountEnvVar)
+}
+
+func runHistory(args []string) error {
+	if len(args) > 0 && isHelpArg(args[0]) {
+		fmt.Fprintf(os.Stderr, tr("Usage: clix %s\n"), "history [list|undo [n]]")
+		return nil
+	}
+	action := "list"
+	if len(args) > 0 && !isFlagArg(args[0]) {
+		action, args = args[0], args[1:]
+	}
+
+	switch action {
+	case "list":
+		return runHistoryList(args)
+	case "undo":
+		return runHistoryUndo(args)
+	default:
+		return withExitCode(exitUsage, fmt.Errorf(tr("unknown history action %q"), action))
+	}
+}
+
+func runHistoryList(args []string) error {
+	fs := newFlagSet("history list", "history list [--search text] [--since t] [--until t] [--deleted] [--count n]  (--account limits it to one account)")
+	search := fs.String("search", "", "only show tweets containing this text")
+	since := fs.String("since", "", "only show tweets posted after this date, time or duration ago")
+	until := fs.String("until", "", "only show tweets posted before this date, time or duration ago")
+	deleted := fs.Bool("deleted", false, "include deleted tweets")
+	count := fs.Int("count", 20, "maximum number of tweets to show")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *count < 1 {
+		return errors.New(tr("--count must be at least 1"))
+	}
+
+	filter := historyFilter{account: explicitAccount(), search: *search, includeDeleted: *deleted}
+	now := time.Now()
+	var err error
+	if *since != "" {
+		if filter.since, err = parseHistoryTime(*since, now); err != nil {
+			return err
+		}
+	}
+	if *until != "" {
+		if filter.until, err = parseHistoryTime(*until, now); err != nil {
+			return err
+		}
+	}
+
+	entries, err := findHistory(filter, *count)
+	if err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(entries)
+	}
+	if len(entries) == 0 {
+		fmt.Println(tr("No tweets in history."))
+		return nil
+	}
+	colors := colorsFor(os.Stdout)
+	for _, entry := range entries {
+		line, _, _ := strings.Cut(entry.Text, "\n")
+		note := ""
+		if entry.DeletedAt != nil {
+			note = " (deleted)"
+		}
+		fmt.Printf("%s %s  %s %s%s\n", entry.ID, paint(colors.time, fmt.Sprintf("%-16s", formatTime(entry.PostedAt))),
+			paint(colors.author, fmt.Sprintf("%-10s", entry.Account)), line, paint(colors.metrics, note))
+		for _, link := range entry.Links {
+			fmt.Printf("    %s -> %s\n", link.Short, link.Long)
+		}
+	}
+	return nil
+}
+
+func runHistoryUndo(args []string) error {
+	fs := newFlagSet("history undo", "history undo [--yes] [n]  (deletes your last n tweets, default 1)")
+	yes := fs.Bool("yes", false, "do not ask for confirmation")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 1 {
+		fs.Usage()
+		return errUsage
+	}
+	n := 1
+	if len(args) == 1 {
+		if n, err = strconv.Atoi(args[0]); err != nil || n < 1 {
+			return fmt.Errorf(tr("invalid number of tweets %q"), args[0])
+		}
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	entries, err := findHistory(historyFilter{account: a.config.Active}, n)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return errors.New(tr("no tweets in history"))
+	}
+	if len(entries) < n {
+		fmt.Fprintf(os.Stderr, tr("History has only %d of your tweets.\n"), len(entries))
+	}
+
+	if !*yes {
+		if !stdinIsTerminal() {
+			return withExitCode(exitRefused, errors.New(tr("refusing to delete without confirmation; pass --yes")))
+		}
+		for _, entry := range entries {
+			line, _, _ := strings.Cut(entry.Text, "\n")
+			fmt.Printf("  %s  %s\n", entry.ID, line)
+		}
+		if !confirm(trf("Delete these %d tweets?", len(entries))) {
+			return errors.New(tr("aborted"))
+		}
+	}
+
+	ctx := rootCtx
+	deleted := []string{}
+	for _, entry := range entries {
+		if err := a.deleteTweet(ctx, entry.ID); err != nil {
+			if machineReadable() {
+				printResult(deleted)
+			}
+			return fmt.Errorf(tr("failed to delete tweet %s: %w"), entry.ID, err)
+		}
+		deleted = append(deleted, entry.ID)
+		if !machineReadable() {
+			fmt.Printf(tr("Tweet deleted. [ID: %s]\n"), entry.ID)
+		}
+	}
+	if machineReadable() {
+		return printResult(deleted)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"time"
+)
+
+// Hook events
+const (
+	hookPost       = "post"
+	hookDelete     = "delete"
+	hookPostFailed = "post_failed"
+	hookMention    = "mention"
+	hookFollowed   = "followed"
+	hookUnfollowed = "unfollowed"
+	hookSearch     = "search"
+	hookReply      = "reply"
+)
+
+// hookTimeout bounds each hook, so a hung webhook cannot hold up clix
+const hookTimeout = 10 * time.Second
+
+// HookConfig is an entry of the "hooks" config section: a shell command
+// that gets the event JSON on stdin, or a URL it is POSTed to
+type HookConfig struct {
+	Events  []string `json:"events,omitempty"` // post, delete, post_failed, mention, followed, unfollowed, search and reply; all of them when empty
+	Command string   `json:"command,omitempty"`
+	URL     string   `json:"url,omitempty"`
+}
+
+// hookEvent is the JSON a hook receives. Text is a one-line summary, which
+// is what Slack and similar incoming webhooks display.
+type hookEvent struct {
+	Event     string     `json:"event"`
+	Account   string     `json:"account,omitempty"`
+	Tweet     *hookTweet `json:"tweet,omitempty"`
+	User      *hookUser  `json:"user,omitempty"`
+	Search    string     `json:"search,omitempty"`      // the saved search a tweet was found by
+	InReplyTo string     `json:"in_reply_to,omitempty"` // the tracked tweet a reply answers
+	Error     string     `json:"error,omitempty"`
+	Time      time.Time  `json:"time"`
+	Text      string     `json:"text"`
+}
+
+type hookTweet struct {
+	ID     string `json:"id,omitempty"`
+	Text   string `json:"text,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Author string `json:"author,omitempty"`
+}
+
+// hookUser is who followed or unfollowed
+type hookUser struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name,omitempty"`
+}
+
+// runHooks fires the hooks configured for event. Hooks are best-effort:
+// a failing hook is reported as a warning and never fails the command.
+func (a *app) runHooks(event, id, text string, cause error) {
+	e := hookEvent{Event: event, Account: a.config.Active, Time: time.Now()}
+	if id != "" || text != "" {
+		e.Tweet = &hookTweet{ID: id, Text: text}
+		if id != "" {
+			e.Tweet.URL = tweetURL("", id)
+		}
+	}
+	switch event {
+	case hookPost:
+		e.Text = "Posted " + e.Tweet.URL
+	case hookDelete:
+		e.Text = "Deleted tweet " + id
+	case hookPostFailed:
+		e.Error = cause.Error()
+		e.Text = "Failed to post: " + e.Error
+	}
+	a.fireHooks(e, nil)
+}
+
+// fireHooks sends e to the configured hooks for its event and to extra,
+// hooks given on the command line
+func (a *app) fireHooks(e hookEvent, extra []HookConfig) {
+	hooks := extra
+	for _, hook := range a.config.Hooks {
+		if len(hook.Events) == 0 || slices.Contains(hook.Events, e.Event) {
+			hooks = append(hooks, hook)
+		}
+	}
+	if len(hooks) == 0 {
+		return
+	}
+	body, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+
+	for _, hook := range hooks {
+		start := time.Now()
+		if err := runHook(hook, e.Event, body); err != nil {
+			fmt.Fprintf(os.Stderr, tr("Warning: %s hook failed: %v\n"), e.Event, err)
+		}
+		verbosef("ran %s hook in %s", e.Event, time.Since(start).Round(time.Millisecond))
+	}
+}
+
+func runHook(hook HookConfig, event string, body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+
+	switch {
+	case hook.Command != "":
+		var cmd *exec.Cmd
+		if runtime.GOOS == "windows" {
+			cmd = exec.CommandContext(ctx, "cmd", "/C", hook.Command)
+		} else {
+			cmd = exec.CommandContext(ctx, "sh", "-c", hook.Command)
+		}
+		cmd.Stdin = bytes.NewReader(body)
+		cmd.Stdout, cmd.Stderr = os.Stderr, os.Stderr
+		cmd.Env = append(os.Environ(), "CLIX_EVENT="+event)
+		return cmd.Run()
+	case hook.URL != "":
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		res, err := newDirectHTTPClient().Do(req)
+		if err != nil {
+			return err
+		}
+		res.Body.Close()
+		if res.StatusCode >= 300 {
+			return fmt.Errorf(tr("%s returned %s"), hook.URL, res.Status)
+		}
+		return nil
+	default:
+		return errors.New(tr("hook has neither a command nor a url"))
+	}
+}
@@ -0,0 +1,92 @@
+package clix
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// languageEnvVar picks the language of clix's messages, over the
+// "language" of the config
+const languageEnvVar = "CLIX_LANG"
+
+// languages are those with a catalog, English being the messages as
+// written in the code
+var languages = []string{"en", "es", "ja"}
+
+// language is the one messages are shown in
+var language = "en"
+
+// parseLanguage takes a language code such as "es" or a locale such as
+// "es_ES.UTF-8", and returns the catalog's name for it
+func parseLanguage(s string) (string, error) {
+	code := strings.ToLower(s)
+	if i := strings.IndexAny(code, "_-.@"); i >= 0 {
+		code = code[:i]
+	}
+	if !slices.Contains(languages, code) {
+		return "", fmt.Errorf(tr("language %q is not available; use one of %s"), s, strings.Join(languages, ", "))
+	}
+	return code, nil
+}
+
+// setLanguage switches messages to the language of $CLIX_LANG, or else
+// of the config. A language without a catalog leaves them in English,
+// which doctor points out.
+func setLanguage(configured string) {
+	if env := os.Getenv(languageEnvVar); env != "" {
+		configured = env
+	}
+	if configured == "" {
+		return
+	}
+	if code, err := parseLanguage(configured); err == nil {
+		language = code
+	}
+}
+
+// peekConfigLanguage reads the language from the config before anything
+// else loads it, so usage and help are translated too. An encrypted config
+// is left alone, as reading it would mean asking for the passphrase.
+func peekConfigLanguage() string {
+	data, err := os.ReadFile(getConfigFilePath())
+	if err != nil {
+		return ""
+	}
+	var peek struct {
+		Language string `json:"language"`
+	}
+	if json.Unmarshal(data, &peek) != nil {
+		return ""
+	}
+	return peek.Language
+}
+
+// tr returns the message in the current language. Messages are looked up
+// by their English text, and stay English where no translation exists.
+func tr(message string) string {
+	if translated, ok := catalogs[language][message]; ok {
+		return translated
+	}
+	return message
+}
+
+// trf formats a translated message
+func trf(format string, args ...any) string {
+	return fmt.Sprintf(tr(format), args...)
+}
+
+// yesAnswers are what confirm takes for yes, besides y and yes
+var yesAnswers = map[string][]string{
+	"es": {"s", "si", "sí"},
+	"ja": {"はい"},
+}
+
+// catalogs map English messages to their translations, kept in a file
+// per language
+var catalogs = map[string]map[string]string{
+	"es": catalogES,
+	"ja": catalogJA,
+}
@@ -0,0 +1,1335 @@
+package clix
+
+// catalogES maps English messages to their Spanish translations
+var catalogES = map[string]string{
+	// Usage
+	"Usage: clix [--account name] [--json|--format tmpl] [command] [arguments]": "Uso: clix [--account nombre] [--json|--format plantilla] [comando] [argumentos]",
+	"Commands:": "Comandos:",
+	"Run 'clix help <command>' for details about a command.": "Ejecuta 'clix help <comando>' para ver los detalles de un comando.",
+	"Post a tweet": "Publicar un tweet",
+	"Serve an HTTP API for local tools to post through":   "Servir una API HTTP para que otras herramientas locales publiquen",
+	"Answer JSON requests on stdin, for editor plugins":   "Responder peticiones JSON por stdin, para plugins de editores",
+	"Quote a tweet with a comment":                        "Citar un tweet con un comentario",
+	"Post a thread of tweets":                             "Publicar un hilo de tweets",
+	"Delete a tweet":                                      "Borrar un tweet",
+	"Delete your tweets by age or pattern":                "Borrar tus tweets por antigüedad o patrón",
+	"List or undo tweets posted with clix":                "Listar o deshacer los tweets publicados con clix",
+	"Open a tweet or profile in the browser":              "Abrir un tweet o un perfil en el navegador",
+	"Show impressions, likes and retweets of your tweets": "Mostrar impresiones, me gusta y retweets de tus tweets",
+	"Save, edit and post drafts":                          "Guardar, editar y publicar borradores",
+	"Propose a tweet for someone else to approve":         "Proponer un tweet para que otra persona lo apruebe",
+	"Review and post proposed tweets":                     "Revisar y publicar los tweets propuestos",
+	"Save tweet templates with variables":                 "Guardar plantillas de tweets con variables",
+	"Schedule a tweet to post later":                      "Programar un tweet para más tarde",
+	"Show scheduled posts and planned drafts by day":      "Mostrar por día los posts programados y los borradores planificados",
+	"Show when your tweets do best":                       "Mostrar cuándo funcionan mejor tus tweets",
+	"Post or schedule tweets from a CSV or JSONL file":    "Publicar o programar tweets desde un archivo CSV o JSONL",
+	"Post scheduled tweets when they are due":             "Publicar los tweets programados a su hora",
+	"List or post tweets queued while offline":            "Listar o publicar los tweets en cola sin conexión",
+	"Like tweets":                  "Dar me gusta a tweets",
+	"Remove your like from tweets": "Quitar tu me gusta de tweets",
+	"Retweet tweets":               "Retuitear tweets",
+	"Undo retweets":                "Deshacer retweets",
+	"Show a tweet, or with --thread its whole conversation": "Mostrar un tweet, o con --thread toda su conversación",
+	"Show a user's profile and recent tweets":               "Mostrar el perfil y los tweets recientes de un usuario",
+	"Follow users":                                     "Seguir a usuarios",
+	"Unfollow users":                                   "Dejar de seguir a usuarios",
+	"List followers":                                   "Listar seguidores",
+	"List followed accounts":                           "Listar las cuentas que sigues",
+	"Cache the handles Tab completes after @":          "Guardar los nombres que Tab completa tras @",
+	"Mute users":                                       "Silenciar usuarios",
+	"Unmute users":                                     "Dejar de silenciar usuarios",
+	"List muted accounts":                              "Listar las cuentas silenciadas",
+	"Block users":                                      "Bloquear usuarios",
+	"Unblock users":                                    "Desbloquear usuarios",
+	"List blocked accounts":                            "Listar las cuentas bloqueadas",
+	"Create, fill and read X Lists":                    "Crear, llenar y leer listas de X",
+	"List, add and remove bookmarks":                   "Listar, añadir y quitar elementos guardados",
+	"Write all bookmarks or likes to a file":           "Escribir todos los guardados o me gusta en un archivo",
+	"Download your profile, tweets and media":          "Descargar tu perfil, tweets y archivos multimedia",
+	"Send and read direct messages":                    "Enviar y leer mensajes directos",
+	"Count characters the way X does":                  "Contar caracteres como lo hace X",
+	"Show your home timeline":                          "Mostrar tu cronología",
+	"Show recent mentions of you":                      "Mostrar tus menciones recientes",
+	"Notify about new mentions as they come in":        "Avisar de las menciones nuevas según llegan",
+	"Follow the replies to your tweets":                "Seguir las respuestas a tus tweets",
+	"Stop following the replies to a tweet":            "Dejar de seguir las respuestas a un tweet",
+	"Search recent tweets":                             "Buscar tweets recientes",
+	"Suggest hashtags and cashtags for a draft":        "Sugerir hashtags y cashtags para un borrador",
+	"List trending topics":                             "Listar los temas del momento",
+	"Search for places to tag tweets with":             "Buscar lugares con los que etiquetar tweets",
+	"Look up emoji shortcodes":                         "Buscar códigos de emoji",
+	"Show the rate limits left and when they reset":    "Mostrar los límites de uso restantes y cuándo se reinician",
+	"Stream tweets matching filter rules live":         "Recibir en directo los tweets que cumplen las reglas de filtro",
+	"Set up an account step by step":                   "Configurar una cuenta paso a paso",
+	"Show or change the configuration":                 "Mostrar o cambiar la configuración",
+	"Check the config, credentials and rate limit":     "Comprobar la configuración, las credenciales y el límite de uso",
+	"Manage account profiles":                          "Gestionar perfiles de cuenta",
+	"Log in with OAuth 2.0 in the browser":             "Iniciar sesión con OAuth 2.0 en el navegador",
+	"Full-screen interface for reading and posting":    "Interfaz a pantalla completa para leer y publicar",
+	"Post tweets from an interactive prompt (default)": "Publicar tweets desde un indicador interactivo (predeterminado)",
+	"Print a shell completion script":                  "Imprimir un script de autocompletado para la shell",
+	"Update clix to the latest release":                "Actualizar clix a la última versión",
+	"Print the version of clix":                        "Imprimir la versión de clix",
+	"Show help for clix or a command":                  "Mostrar la ayuda de clix o de un comando",
+	"clix - cli for x":                                 "clix - cli para x",
+	"unknown command %q":                               "comando desconocido %q",
+
+	// Prompts
+	"[y/N]":                   "[s/N]",
+	"Post anyway?":            "¿Publicar de todos modos?",
+	"Post it?":                "¿Publicarlo?",
+	"Load more?":              "¿Cargar más?",
+	"Post these %d tweets?":   "¿Publicar estos %d tweets?",
+	"Resume from part %d?":    "¿Continuar desde la parte %d?",
+	"Delete tweet %s?":        "¿Borrar el tweet %s?",
+	"Delete tweet %s (%q)?":   "¿Borrar el tweet %s (%q)?",
+	"Delete these %d tweets?": "¿Borrar estos %d tweets?",
+	"Delete %d tweets? Run with --dry-run to list them first.":     "¿Borrar %d tweets? Usa --dry-run para verlos antes.",
+	"Could not reach X. Queue the tweet to post when back online?": "No se pudo conectar con X. ¿Poner el tweet en cola para publicarlo al volver la conexión?",
+	"Outside the posting window. Schedule it for %s instead?":      "Fuera del horario de publicación. ¿Programarlo para %s?",
+	"failed to read the input: %w":                                 "no se pudo leer la entrada: %w",
+
+	// Messages
+	"Error:":                             "Error:",
+	"Interrupted.":                       "Interrumpido.",
+	"Not posted.":                        "No se ha publicado.",
+	"Dry run, nothing was posted.":       "Simulación, no se ha publicado nada.",
+	"\nTweet %d/%d (%d/%d characters)\n": "\nTweet %d/%d (%d/%d caracteres)\n",
+	"Settings: %s\n":                     "Ajustes: %s\n",
+	"Transformed by: %s\n":               "Transformado por: %s\n",
+	"Attached %s to the next tweet.\n":   "%s adjuntado al próximo tweet.\n",
+	"Type a tweet and end it with an empty line or Ctrl-D; /media <path> attaches a file to the next one.":     "Escribe un tweet y termínalo con una línea vacía o Ctrl-D; /media <ruta> adjunta un archivo al siguiente.",
+	"Up and down recall earlier input, Ctrl-R searches it and Tab completes @mentions and :emoji: shortcodes.": "Arriba y abajo recuperan lo escrito antes, Ctrl-R lo busca y Tab completa las @menciones y los códigos :emoji:.",
+	"Type a tweet and press enter to post it; /media <path> attaches a file to the next one.":                  "Escribe un tweet y pulsa Intro para publicarlo; /media <ruta> adjunta un archivo al siguiente.",
+	"tweet: ":                 "tweet: ",
+	"Goodbye!":                "¡Hasta luego!",
+	"Error attaching media:":  "Error al adjuntar el archivo:",
+	"Not posting:":            "No se publica:",
+	"Error scheduling tweet:": "Error al programar el tweet:",
+	"Error posting tweet:":    "Error al publicar el tweet:",
+	"Error queueing tweet:":   "Error al poner el tweet en cola:",
+	"Tweet posted successfully! [ID: %s]\n\n": "¡Tweet publicado! [ID: %s]\n\n",
+
+	// Terminal interface
+	"Timeline":                        "Cronología",
+	"Mentions":                        "Menciones",
+	"What's happening?":               "¿Qué está pasando?",
+	"Compose":                         "Redactar",
+	"Replying to @%s":                 "Respondiendo a @%s",
+	"Loading...":                      "Cargando...",
+	"Posting...":                      "Publicando...",
+	"Loaded %d tweets":                "%d tweets cargados",
+	"Posted %s":                       "Publicado %s",
+	"Liked %s":                        "Me gusta %s",
+	"Retweeted %s":                    "Retuiteado %s",
+	"Dry run: %d tweet(s) not posted": "Simulación: %d tweet(s) sin publicar",
+	"Nothing here yet.":               "Aún no hay nada.",
+	"1/2 pane · j/k move · c compose · r reply · l like · t retweet · R refresh · q quit": "1/2 panel · j/k mover · c redactar · r responder · l me gusta · t retuitear · R recargar · q salir",
+	"ctrl+s post · tab complete @mention · esc back to the list":                          "ctrl+s publicar · tab completar @mención · esc volver a la lista",
+	"tui  (full-screen interface; `clix repl` is the lightweight alternative)":            "tui  (interfaz a pantalla completa; `clix repl` es la alternativa ligera)",
+	"clix tui needs a terminal": "clix tui necesita una terminal",
+
+	// Metrics
+	"Warning: metrics disabled:": "Aviso: métricas desactivadas:",
+
+	// Command usage
+	"Usage: clix %s\n": "Uso: clix %s\n",
+
+	// Global flags
+	"use this account profile (or set $CLIX_ACCOUNT)":                                                   "usar este perfil de cuenta (o definir $CLIX_ACCOUNT)",
+	"print results as JSON":                                                                             "imprimir los resultados como JSON",
+	"print results with a Go template, e.g. '{{.ID}}'":                                                  "imprimir los resultados con una plantilla de Go, p. ej. '{{.ID}}'",
+	"report API requests and remaining rate limits on stderr":                                           "informar en stderr de las peticiones a la API y de los límites de uso restantes",
+	"like --verbose, adding request and response headers with secrets redacted":                         "como --verbose, añadiendo las cabeceras de petición y respuesta con los secretos ocultos",
+	"validate and show what would be posted without sending it":                                         "validar y mostrar lo que se publicaría sin enviarlo",
+	"answer API calls with canned responses and record them instead of calling X (or set $CLIX_MOCK=1)": "responder las llamadas a la API con respuestas predefinidas y registrarlas en lugar de llamar a X (o definir $CLIX_MOCK=1)",
+	"wait for the rate limit to reset instead of failing":                                               "esperar a que se reinicie el límite de uso en lugar de fallar",
+	"print without colors (or set $NO_COLOR)":                                                           "imprimir sin colores (o definir $NO_COLOR)",
+	"show times in UTC rather than the local timezone":                                                  "mostrar las horas en UTC en lugar de la zona horaria local",
+	"show times as relative (\"3h ago\", the default), absolute, iso or a Go layout":                    "mostrar las horas como relativas (\"hace 3h\", la opción predeterminada), absolutas, iso o con un formato de Go",
+	"send requests through this http:// or socks5:// proxy":                                             "enviar las peticiones a través de este proxy http:// o socks5://",
+	"also trust the CA certificates in this PEM file":                                                   "confiar también en los certificados de CA de este archivo PEM",
+	"give up on a request attempt after this long (default 30s)":                                        "abandonar un intento de petición pasado este tiempo (30s por defecto)",
+	"give up on an API call, retries and rate limit waits included, after this long and exit with 124":  "abandonar una llamada a la API, con reintentos y esperas por el límite de uso, pasado este tiempo y salir con 124",
+
+	// Drafts
+	"invalid day %q, expected YYYY-MM-DD":                       "día no válido %q, se esperaba AAAA-MM-DD",
+	"unknown draft action %q":                                   "acción de borrador desconocida %q",
+	"name for the draft (defaults to the next free number)":     "nombre del borrador (por defecto, el siguiente número libre)",
+	"attach a media file when posted (repeatable)":              "adjuntar un archivo multimedia al publicarlo (repetible)",
+	"alt text for the media, paired with each --media in order": "texto alternativo de los archivos, emparejado en orden con cada --media",
+	"reply to this tweet when posted (ID or URL)":               "responder a este tweet al publicarlo (ID o URL)",
+	"quote this tweet when posted (ID or URL)":                  "citar este tweet al publicarlo (ID o URL)",
+	"the day the draft is meant for, shown on the calendar":     "el día para el que es el borrador, que se muestra en el calendario",
+	"draft %q already exists":                                   "el borrador %q ya existe",
+	"Draft saved as %q.\n":                                      "Borrador guardado como %q.\n",
+	"No drafts.":                                                "No hay borradores.",
+	" [%d media]":                                               " [%d archivos]",
+	" [reply]":                                                  " [respuesta]",
+	" [quote]":                                                  " [cita]",
+	" [for %s]":                                                 " [para el %s]",
+	"Edit the draft text above. Saving an empty draft leaves it unchanged.":      "Edita el texto del borrador de arriba. Guardar un borrador vacío lo deja sin cambios.",
+	"replace the text instead of opening an editor":                              "reemplazar el texto en lugar de abrir un editor",
+	"replace the attached media (repeatable)":                                    "reemplazar los archivos adjuntos (repetible)",
+	"replace the alt text of the media, paired with each --media":                "reemplazar el texto alternativo de los archivos, emparejado con cada --media",
+	"replace the reply target":                                                   "reemplazar el tweet al que se responde",
+	"replace the quoted tweet":                                                   "reemplazar el tweet citado",
+	"plan the draft for this day, or none to take it off the calendar":           "planificar el borrador para este día, o none para quitarlo del calendario",
+	"no draft named %q":                                                          "no hay ningún borrador llamado %q",
+	"Draft %q updated.\n":                                                        "Borrador %q actualizado.\n",
+	"split an over-length draft into a thread":                                   "dividir en un hilo un borrador demasiado largo",
+	"post even outside the configured posting window or against the style rules": "publicar aunque sea fuera del horario de publicación configurado o en contra de las reglas de estilo",
+	"failed to load configuration: %w":                                           "no se pudo cargar la configuración: %w",
+	"Warning: draft was posted but not removed:":                                 "Aviso: el borrador se publicó pero no se eliminó:",
+	"draft edit <name> [--text t] [--media path] [--reply-to id] [--quote id] [--plan YYYY-MM-DD|none]  (opens $EDITOR without --text)": "draft edit <nombre> [--text t] [--media ruta] [--reply-to id] [--quote id] [--plan AAAA-MM-DD|none]  (abre $EDITOR sin --text)",
+
+	// Schedule
+	"invalid duration %q": "duración no válida %q",
+	"invalid time %q, expected \"YYYY-MM-DD HH:MM\", RFC 3339 or +duration":               "hora no válida %q, se esperaba \"AAAA-MM-DD HH:MM\", RFC 3339 o +duración",
+	"Scheduled as %s; 'clix scheduler run' will post it.\n":                               "Programado como %s; 'clix scheduler run' lo publicará.\n",
+	"when to post: \"YYYY-MM-DD HH:MM\" local time, RFC 3339 or +duration":                "cuándo publicar: \"AAAA-MM-DD HH:MM\" en hora local, RFC 3339 o +duración",
+	"post in the coming week's hour your tracked tweets did best in (see insights times)": "publicar en la hora de la próxima semana en que mejor funcionaron tus tweets seguidos (ver insights times)",
+	"attach a media file (repeatable)":                                                    "adjuntar un archivo multimedia (repetible)",
+	"reply to this tweet (ID or URL)":                                                     "responder a este tweet (ID o URL)",
+	"quote this tweet (ID or URL)":                                                        "citar este tweet (ID o URL)",
+	"split an over-length tweet into a thread":                                            "dividir en un hilo un tweet demasiado largo",
+	"schedule even outside the configured posting window or against the style rules":      "programar aunque sea fuera del horario de publicación configurado o en contra de las reglas de estilo",
+	"%w (use --force to schedule anyway)":                                                 "%w (usa --force para programarlo de todos modos)",
+	"\nWould be scheduled for %s.\n":                                                      "\nSe programaría para %s.\n",
+	"Scheduled %s for %s.\n":                                                              "%s programado para %s.\n",
+	"%s is in the past":                                                                   "%s ya ha pasado",
+	"a part of the thread (repeat for each part)":                                         "una parte del hilo (repetir para cada parte)",
+	"read parts from a file, separated by lines containing ---":                           "leer las partes de un archivo, separadas por líneas con ---",
+	"post the first part as a reply to this tweet (ID or URL)":                            "publicar la primera parte como respuesta a este tweet (ID o URL)",
+	"number the parts as 1/n: prefix, suffix or none (default from config)":               "numerar las partes como 1/n: prefix, suffix o none (por defecto, según la configuración)",
+	"leave out the account's signature from the config":                                   "omitir la firma de la cuenta de la configuración",
+	"post the parts as given, without the transforms from the config":                     "publicar las partes tal cual, sin las transformaciones de la configuración",
+	"use only one of --tweet and --file":                                                  "usa solo uno de --tweet y --file",
+	"failed to read thread file: %w":                                                      "no se pudo leer el archivo del hilo: %w",
+	"nothing to post":                                                                     "no hay nada que publicar",
+	"part %d: %w, including its numbering and signature":                                  "parte %d: %w, contando su numeración y su firma",
+	"part %d: %w": "parte %d: %w",
+	"Scheduled %s, a thread of %d tweets, for %s.\n":                 "%s, un hilo de %d tweets, programado para %s.\n",
+	"include posted, failed and cancelled posts":                     "incluir los posts publicados, fallidos y cancelados",
+	"Nothing scheduled.":                                             "No hay nada programado.",
+	"[thread of %d] %s":                                              "[hilo de %d] %s",
+	"scheduled post %s is already %s":                                "el post programado %s ya está %s",
+	"no scheduled post %s":                                           "no hay ningún post programado %s",
+	"%s scheduled post %s failed: %v\n":                              "%s el post programado %s falló: %v\n",
+	"%s posted scheduled post %s as %s\n":                            "%s publicado el post programado %s como %s\n",
+	"thread stopped after %d of %d parts: %w":                        "el hilo se detuvo tras %d de %d partes: %w",
+	"post whatever is due and exit, for cron":                        "publicar lo pendiente y salir, para cron",
+	"how often to check for due posts":                               "cada cuánto buscar posts pendientes",
+	"Scheduler running, checking every %s.\n":                        "Programador en marcha, comprobando cada %s.\n",
+	"Scheduler stopped.":                                             "Programador detenido.",
+	"Not scheduled.":                                                 "No se ha programado.",
+	"%d of the due scheduled posts failed; see 'clix schedule list'": "%d de los posts programados pendientes fallaron; consulta 'clix schedule list'",
+	"check spelling, spacing, brackets, links and mentions now, before scheduling (default from config)":      "revisar ortografía, espacios, paréntesis, enlaces y menciones ahora, antes de programar (por defecto, según la configuración)",
+	"skip the lint checks, now and when it is posted, even with lint in the config":                           "omitir las comprobaciones de lint, ahora y al publicarse, aunque lint esté en la configuración",
+	"schedule thread --at \"YYYY-MM-DD HH:MM\" | --auto [flags]  (reads parts from --tweet, --file or stdin)": "schedule thread --at \"AAAA-MM-DD HH:MM\" | --auto [opciones]  (lee las partes de --tweet, --file o stdin)",
+	"       clix scheduler install [--interval 30s] [--force]":                                                "       clix scheduler install [--interval 30s] [--force]",
+	"       clix scheduler status | logs [-n 50] [-f] | uninstall":                                            "       clix scheduler status | logs [-n 50] [-f] | uninstall",
+
+	// History
+	"failed to create data directory: %w":                     "no se pudo crear el directorio de datos: %w",
+	"no cache directory to keep clix's state in; set $%s: %w": "no hay directorio de caché donde guardar el estado de clix; define $%s: %w",
+	"no tweets in history":                                    "no hay tweets en el historial",
+	"invalid time %q, expected \"YYYY-MM-DD\", \"YYYY-MM-DD HH:MM\" or a duration like 48h or 7d": "hora no válida %q, se esperaba \"AAAA-MM-DD\", \"AAAA-MM-DD HH:MM\" o una duración como 48h o 7d",
+	"unknown history action %q":                                      "acción de historial desconocida %q",
+	"only show tweets containing this text":                          "mostrar solo los tweets que contienen este texto",
+	"only show tweets posted after this date, time or duration ago":  "mostrar solo los tweets publicados después de esta fecha, hora o duración atrás",
+	"only show tweets posted before this date, time or duration ago": "mostrar solo los tweets publicados antes de esta fecha, hora o duración atrás",
+	"include deleted tweets":                                         "incluir los tweets borrados",
+	"maximum number of tweets to show":                               "número máximo de tweets que mostrar",
+	"--count must be at least 1":                                     "--count debe ser al menos 1",
+	"No tweets in history.":                                          "No hay tweets en el historial.",
+	"do not ask for confirmation":                                    "no pedir confirmación",
+	"invalid number of tweets %q":                                    "número de tweets no válido %q",
+	"History has only %d of your tweets.\n":                          "El historial solo tiene %d de tus tweets.\n",
+	"refusing to delete without confirmation; pass --yes":            "no se borra sin confirmación; usa --yes",
+	"aborted":                       "cancelado",
+	"failed to delete tweet %s: %w": "no se pudo borrar el tweet %s: %w",
+	"Tweet deleted. [ID: %s]\n":     "Tweet borrado. [ID: %s]\n",
+	"history list [--search text] [--since t] [--until t] [--deleted] [--count n]  (--account limits it to one account)": "history list [--search texto] [--since t] [--until t] [--deleted] [--count n]  (--account lo limita a una cuenta)",
+	"history undo [--yes] [n]  (deletes your last n tweets, default 1)":                                                  "history undo [--yes] [n]  (borra tus últimos n tweets, 1 por defecto)",
+
+	// Accessibility
+	"refuse to post when the accessibility checks warn (default from config)":                             "no publicar si las comprobaciones de accesibilidad avisan (por defecto, según la configuración)",
+	"Accessibility: part %d: %s\n":                                                                        "Accesibilidad: parte %d: %s\n",
+	"Accessibility: %s\n":                                                                                 "Accesibilidad: %s\n",
+	"refusing to post with accessibility warnings; fix them or leave out --strict-a11y":                   "no se publica con avisos de accesibilidad; corrígelos o quita --strict-a11y",
+	"refusing to post with accessibility warnings; fix them or set strict to false in a11y in the config": "no se publica con avisos de accesibilidad; corrígelos o pon strict a false en a11y en la configuración",
+	"%s has no alt text; describe it with --alt":                                                          "%s no tiene texto alternativo; descríbelo con --alt",
+	"%s is read as one word by screen readers; capitalize each word, like #ReadMeAloud":                   "los lectores de pantalla leen %s como una sola palabra; empieza cada palabra con mayúscula, como #ReadMeAloud",
+	"%d emoji in a row; screen readers read out the name of each, more than %d gets tiring":               "%d emojis seguidos; los lectores de pantalla leen el nombre de cada uno, y más de %d cansa",
+
+	// Accounts
+	"account %q already exists":                                               "la cuenta %q ya existe",
+	"Enter credentials for account %q\n":                                      "Introduce las credenciales de la cuenta %q\n",
+	"the %q account cannot be removed; use 'clix config reset' to replace it": "la cuenta %q no se puede quitar; usa 'clix config reset' para sustituirla",
+	"unknown account %q":                                                      "cuenta desconocida %q",
+	"Warning:":                                                                "Aviso:",
+	"unknown accounts action %q":                                              "acción de accounts desconocida %q",
+
+	// Archive
+	"failed to parse %s: %w": "no se pudo interpretar %s: %w",
+	"failed to fetch your tweets; if the saved page token expired, run again with --restart: %w": "no se pudieron obtener tus tweets; si el token de página guardado caducó, vuelve a ejecutarlo con --restart: %w",
+	"failed to fetch your tweets: %w":                             "no se pudieron obtener tus tweets: %w",
+	"Archived %d tweets...\n":                                     "%d tweets archivados...\n",
+	"Warning: media of tweet %s: %v\n":                            "Aviso: multimedia del tweet %s: %v\n",
+	"failed to download: %w":                                      "no se pudo descargar: %w",
+	"failed to download %s: %s":                                   "no se pudo descargar %s: %s",
+	"failed to download %s: %w":                                   "no se pudo descargar %s: %w",
+	"directory to archive into (default clix-archive-<username>)": "directorio donde archivar (por defecto clix-archive-<usuario>)",
+	"do not download photos and videos":                           "no descargar fotos ni vídeos",
+	"walk the whole timeline again instead of resuming, keeping the tweets archived so far":                                        "recorrer de nuevo toda la cronología en vez de continuar, conservando los tweets ya archivados",
+	"failed to look up the authenticated user: %w":                                                                                 "no se pudo consultar el usuario autenticado: %w",
+	"%s is the archive of @%s, not @%s; use another --dir":                                                                         "%s es el archivo de @%s, no de @%s; usa otro --dir",
+	"%w; %d tweets were archived, and running again resumes":                                                                       "%w; se archivaron %d tweets, y al volver a ejecutarlo se continúa",
+	"Archived %d tweets of @%s in %s (%d fetched this run).\n":                                                                     "%d tweets de @%s archivados en %s (%d obtenidos en esta ejecución).\n",
+	"archive [--dir path] [--no-media] [--restart]  (your profile, tweets and media; running again resumes, then adds new tweets)": "archive [--dir ruta] [--no-media] [--restart]  (tu perfil, tweets y multimedia; al volver a ejecutarlo continúa y luego añade los tweets nuevos)",
+
+	// Bluesky
+	"bluesky is not configured; add \"bluesky\": {\"handle\": \"you.bsky.social\", \"app_password\": \"...\"} to %s": "bluesky no está configurado; añade \"bluesky\": {\"handle\": \"you.bsky.social\", \"app_password\": \"...\"} a %s",
+	"post is %d characters, the limit is %d": "el post tiene %d caracteres y el límite es %d",
+	"at most %d images can be attached":      "se pueden adjuntar como mucho %d imágenes",
+	"%s: only images can be cross-posted":    "%s: solo se pueden publicar imágenes en otras redes",
+	"%s is over the 1 MB image limit":        "%s supera el límite de 1 MB por imagen",
+	"failed to log in: %w":                   "no se pudo iniciar sesión: %w",
+	"failed to post: %w":                     "no se pudo publicar: %w",
+	"failed to upload %s: %w":                "no se pudo subir %s: %w",
+
+	// Bookmarks
+	"failed to fetch bookmarks: %w":                                  "no se pudieron obtener los marcadores: %w",
+	"failed to bookmark tweet: %w":                                   "no se pudo guardar el tweet en marcadores: %w",
+	"failed to remove bookmark: %w":                                  "no se pudo quitar el marcador: %w",
+	"Usage: clix bookmark [list|add <id|url>...|remove <id|url>...]": "Uso: clix bookmark [list|add <id|url>...|remove <id|url>...]",
+	"unknown bookmark action %q":                                     "acción de bookmark desconocida %q",
+	"number of bookmarks to show, up to 800":                         "número de marcadores que mostrar, hasta 800",
+	"open the nth bookmark of the list in the browser":               "abrir en el navegador el marcador n de la lista",
+	"--open must be a position in the list, starting at 1":           "--open debe ser una posición de la lista, empezando por 1",
+	"there are only %d bookmarks":                                    "solo hay %d marcadores",
+	"Could not open a browser:":                                      "No se pudo abrir un navegador:",
+	"No bookmarks.":                                                  "No hay marcadores.",
+
+	// Calendar
+	"that would be in the past":                                   "eso quedaría en el pasado",
+	"%s is no longer scheduled":                                   "%s ya no está programado",
+	"+%d more":                                                    "+%d más",
+	"show the month instead of the week":                          "mostrar el mes en vez de la semana",
+	"show the week or month of this day (default today)":          "mostrar la semana o el mes de este día (por defecto hoy)",
+	"browse the calendar full-screen and move posts between days": "recorrer el calendario a pantalla completa y mover posts entre días",
+	"clix calendar --tui needs a terminal":                        "clix calendar --tui necesita una terminal",
+	"%s %d scheduled posts are within %d minutes of another on the same account.\n": "%s %d posts programados están a menos de %d minutos de otro de la misma cuenta.\n",
+	"1 day with nothing planned.":     "1 día sin nada planificado.",
+	"%d days with nothing planned.\n": "%d días sin nada planificado.\n",
+	"Draft %s has no day; plan it with 'clix draft edit %s --plan YYYY-MM-DD'.\n":         "El borrador %s no tiene día; planifícalo con 'clix draft edit %s --plan AAAA-MM-DD'.\n",
+	"%d drafts have no day; plan them with 'clix draft edit <name> --plan YYYY-MM-DD'.\n": "%d borradores no tienen día; planifícalos con 'clix draft edit <nombre> --plan AAAA-MM-DD'.\n",
+	"Moved %s %s to %s": "%s %s movido al %s",
+	"Week of %s":        "Semana del %s",
+	"calendar [--month] [--from YYYY-MM-DD] [--tui]  (scheduled posts and planned drafts by day)": "calendar [--month] [--from AAAA-MM-DD] [--tui]  (posts programados y borradores planificados por día)",
+	"←→↑↓ day · tab item · H/L/K/J move item a day or week · w week/month · t today · q quit":     "←→↑↓ día · tab elemento · H/L/K/J mover el elemento un día o una semana · w semana/mes · t hoy · q salir",
+
+	// Followers
+	"the most followers to fetch": "el máximo de seguidores que obtener",
+	"also POST each follow and unfollow as a hook event to this URL (repeatable)":                                                      "enviar también cada nuevo seguidor y cada baja como evento de hook a esta URL con POST (repetible)",
+	"Recorded %d followers; the next snapshot shows who came and went.\n":                                                              "%d seguidores registrados; la próxima instantánea muestra quién llegó y quién se fue.\n",
+	"compare with the last snapshot taken before this date, time or duration ago":                                                      "comparar con la última instantánea anterior a esta fecha, hora o duración atrás",
+	"a diff needs two snapshots of %q's followers, and there are %d; take them with 'clix followers snapshot'":                         "una comparación necesita dos instantáneas de los seguidores de %q y hay %d; tómalas con 'clix followers snapshot'",
+	"From %s to %s: %d to %d followers, %d new and %d gone.\n":                                                                         "Del %s al %s: de %d a %d seguidores, %d nuevos y %d se fueron.\n",
+	"Warning: a snapshot stopped at --count, so some of those gone may only have been left out":                                        "Aviso: una instantánea se detuvo en --count, así que algunos de los que se fueron quizá solo quedaron fuera",
+	"followers snapshot [--count 10000] [--webhook url]  (records your followers and shows who came and went since the last snapshot)": "followers snapshot [--count 10000] [--webhook url]  (registra tus seguidores y muestra quién llegó y quién se fue desde la última instantánea)",
+	"followers diff [--since 7d]  (who followed and unfollowed between the last two snapshots, or since the snapshot before --since)":  "followers diff [--since 7d]  (quién te siguió y dejó de seguirte entre las dos últimas instantáneas, o desde la anterior a --since)",
+
+	// Client
+	"Warning: could not post queued tweets:":   "Aviso: no se pudieron publicar los tweets en cola:",
+	"Warning: could not save the rate limits:": "Aviso: no se pudieron guardar los límites de uso:",
+
+	// Clipboard
+	"no clipboard tool found; install wl-clipboard, xclip or xsel": "no se encontró ninguna herramienta de portapapeles; instala wl-clipboard, xclip o xsel",
+	"failed to read the clipboard with %s: %w":                     "no se pudo leer el portapapeles con %s: %w",
+	"failed to set the clipboard with %s: %w":                      "no se pudo escribir en el portapapeles con %s: %w",
+	"Warning: could not copy the link:":                            "Aviso: no se pudo copiar el enlace:",
+	"Copied":                                                       "Copiado",
+
+	// Completion
+	"unsupported shell %q, expected bash, zsh, fish or powershell": "shell no admitida %q; se esperaba bash, zsh, fish o powershell",
+
+	// Config
+	"$%s is not set; credentials from the environment need all of $CLIX_CONSUMER_KEY, $CLIX_CONSUMER_SECRET, $CLIX_ACCESS_TOKEN and $CLIX_ACCESS_SECRET": "$%s no está definida; las credenciales del entorno necesitan $CLIX_CONSUMER_KEY, $CLIX_CONSUMER_SECRET, $CLIX_ACCESS_TOKEN y $CLIX_ACCESS_SECRET",
+	"Error getting home directory:":                                                       "Error al obtener el directorio personal:",
+	"unknown account %q (see 'clix accounts list')":                                       "cuenta desconocida %q (consulta 'clix accounts list')",
+	"--confirm and --no-confirm cannot be combined":                                       "--confirm y --no-confirm no se pueden combinar",
+	"configuration for account %q is incomplete; run 'clix login' or 'clix config reset'": "la configuración de la cuenta %q está incompleta; ejecuta 'clix login' o 'clix config reset'",
+	"failed to create config directory: %w":                                               "no se pudo crear el directorio de configuración: %w",
+	"Configuration file not found. Creating a new one...":                                 "No se encontró el archivo de configuración. Creando uno nuevo...",
+	"Configuration for account %q is incomplete. Prompting for missing values...\n":       "La configuración de la cuenta %q está incompleta. Se pedirán los valores que faltan...\n",
+	"failed to write config file: %w":                                                     "no se pudo escribir el archivo de configuración: %w",
+	"%s keeps no credentials; encrypt the user config from another directory":             "%s no guarda credenciales; cifra la configuración de usuario desde otro directorio",
+	"the config file is already encrypted":                                                "el archivo de configuración ya está cifrado",
+	"New passphrase: ":                                                                    "Nueva frase de contraseña: ",
+	"Config encrypted. clix will ask for the passphrase, or read it from $%s.\n":          "Configuración cifrada. clix pedirá la frase de contraseña o la leerá de $%s.\n",
+	"the config file is not encrypted":                                                    "el archivo de configuración no está cifrado",
+	"Config decrypted.":                                                                   "Configuración descifrada.",
+	"Config file:":                                                                        "Archivo de configuración:",
+	"Credentials file:":                                                                   "Archivo de credenciales:",
+	"Encrypted: yes":                                                                      "Cifrado: sí",
+	"Account:":                                                                            "Cuenta:",
+	"Credentials: in the keychain":                                                        "Credenciales: en el llavero",
+	"usage: clix config set <key> <value>":                                                "uso: clix config set <clave> <valor>",
+	"unknown config key %q":                                                               "clave de configuración desconocida %q",
+	"unknown config action %q":                                                            "acción de config desconocida %q",
+	"config [show|path|trust|untrust|set <key> <value>|reset|encrypt|decrypt]  (applies to the selected account)": "config [show|path|trust|untrust|set <clave> <valor>|reset|encrypt|decrypt]  (se aplica a la cuenta seleccionada)",
+	"Enter Consumer Key: ":    "Introduce la Consumer Key: ",
+	"Enter Consumer Secret: ": "Introduce el Consumer Secret: ",
+	"Enter Access Token: ":    "Introduce el Access Token: ",
+	"Enter Access Secret: ":   "Introduce el Access Secret: ",
+
+	// Config encryption
+	"Passphrase for %s: ": "Frase de contraseña de %s: ",
+	"no terminal to read the config passphrase from; set $%s": "no hay terminal para leer la frase de contraseña de la configuración; define $%s",
+	"empty passphrase":         "frase de contraseña vacía",
+	"passphrases do not match": "las frases de contraseña no coinciden",
+
+	// Count
+	"tweet is %d characters, the limit is %d":           "el tweet tiene %d caracteres y el límite es %d",
+	"%d characters over the limit":                      "%d caracteres por encima del límite",
+	"count [text]  (reads stdin when no text is given)": "count [texto]  (lee stdin si no se da texto)",
+
+	// Delete
+	"tweet %s was not deleted":                                "el tweet %s no se borró",
+	"Warning: tweet was deleted but history was not updated:": "Aviso: el tweet se borró pero el historial no se actualizó:",
+	"delete the most recent tweet posted with clix":           "borrar el último tweet publicado con clix",
+	"failed to delete tweet: %w":                              "no se pudo borrar el tweet: %w",
+
+	// Destinations
+	"unknown destination %q, expected x, mastodon or bsky":                                  "destino desconocido %q; se esperaba x, mastodon o bsky",
+	"--to needs at least one destination":                                                   "--to necesita al menos un destino",
+	"unknown destination %q":                                                                "destino desconocido %q",
+	"--reply-to, --quote, --poll and --split only work when posting to x alone":             "--reply-to, --quote, --poll y --split solo funcionan al publicar solo en x",
+	"\nWould post to %s.\n":                                                                 "\nSe publicaría en %s.\n",
+	"\nWould also post to %s.\n":                                                            "\nTambién se publicaría en %s.\n",
+	"Some destinations were posted to; retrying with the same --to would post there again.": "Ya se publicó en algunos destinos; reintentar con el mismo --to volvería a publicar en ellos.",
+
+	// Direct messages
+	"no username given":                                         "no se indicó ningún usuario",
+	"failed to look up @%s: %w":                                 "no se pudo consultar @%s: %w",
+	"@%s does not exist or is suspended":                        "@%s no existe o está suspendida",
+	"failed to fetch messages: %w":                              "no se pudieron obtener los mensajes: %w",
+	"Usage: clix dm [list|send @user <message>]":                "Uso: clix dm [list|send @usuario <mensaje>]",
+	"unknown dm action %q":                                      "acción de dm desconocida %q",
+	"attach an image, GIF or video":                             "adjuntar una imagen, un GIF o un vídeo",
+	"nothing to send":                                           "no hay nada que enviar",
+	"failed to send message: %w":                                "no se pudo enviar el mensaje: %w",
+	"Message sent. [ID: %s]\n":                                  "Mensaje enviado. [ID: %s]\n",
+	"number of recent messages to look at, up to 100":           "número de mensajes recientes que revisar, hasta 100",
+	"show every message instead of the latest per conversation": "mostrar todos los mensajes en vez del último de cada conversación",
+	"No messages.":                                              "No hay mensajes.",
+	"dm send [--media path] @user [message]  (reads stdin when no message is given)": "dm send [--media ruta] @usuario [mensaje]  (lee stdin si no se da mensaje)",
+
+	// Doctor
+	"credentials from the environment, no config file is read":        "credenciales del entorno; no se lee ningún archivo de configuración",
+	"%s does not exist; run 'clix login' or any command to create it": "%s no existe; ejecuta 'clix login' o cualquier comando para crearlo",
+	"%s is readable by other users (%04o); run chmod 600 %s":          "otros usuarios pueden leer %s (%04o); ejecuta chmod 600 %s",
+	"no unknown keys":                       "ninguna clave desconocida",
+	"default_account: %v":                   "default_account: %v",
+	"account %q has incomplete credentials": "la cuenta %q tiene credenciales incompletas",
+	"undo_delay: %v":                        "undo_delay: %v",
+	"thread: %v":                            "thread: %v",
+	"duplicates: %v":                        "duplicates: %v",
+	"review: %v":                            "review: %v",
+	"theme: %v":                             "theme: %v",
+	"posting_window: %v":                    "posting_window: %v",
+	"network: %v":                           "network: %v",
+	"lint: %v":                              "lint: %v",
+	"tweet: %v":                             "tweet: %v",
+	"tweet: accounts.%s: %v":                "tweet: accounts.%s: %v",
+	"shortener: needs a url and an api_key": "shortener: necesita url y api_key",
+	"gif: needs a provider of giphy or tenor and an api_key":            "gif: necesita un provider giphy o tenor y una api_key",
+	"mastodon: needs a server and an access_token":                      "mastodon: necesita server y access_token",
+	"bluesky: needs a handle and an app_password":                       "bluesky: necesita handle y app_password",
+	"hooks[%d]: needs a command or a url":                               "hooks[%d]: necesita command o url",
+	"valid":                                                             "válida",
+	"account %q is incomplete; run 'clix login' or 'clix config reset'": "la cuenta %q está incompleta; ejecuta 'clix login' o 'clix config reset'",
+	"could not reach the API: %v":                                       "no se pudo conectar con la API: %v",
+	"rejected by the API (%s); check the keys of account %q":            "rechazada por la API (%s); revisa las claves de la cuenta %q",
+	"not checked, the rate limit of /2/users/me is used up":             "sin comprobar; se agotó el límite de uso de /2/users/me",
+	"the API returned %s":                                               "la API devolvió %s",
+	"account %q is @%s (%s)":                                            "la cuenta %q es @%s (%s)",
+	"the API sent no rate limit headers":                                "la API no envió cabeceras de límite de uso",
+	"%d of %d requests to /2/users/me left, resets at %s; %s":           "quedan %d de %d peticiones a /2/users/me, se restablece a las %s; %s",
+	"only check the config, without calling the API":                    "comprobar solo la configuración, sin llamar a la API",
+	"%d of %d checks failed":                                            "fallaron %d de %d comprobaciones",
+	"doctor [--offline]  (checks the config, credentials and rate limit of the selected account)": "doctor [--offline]  (comprueba la configuración, las credenciales y el límite de peticiones de la cuenta seleccionada)",
+	"likely the Free tier":          "probablemente el plan Free",
+	"likely the Basic tier":         "probablemente el plan Basic",
+	"likely the Pro tier or higher": "probablemente el plan Pro o superior",
+
+	// Dry run
+	"  reply to: the previous part": "  respuesta a: la parte anterior",
+	"  reply to: %s\n":              "  respuesta a: %s\n",
+	"  quote: %s\n":                 "  cita: %s\n",
+	"  poll: %s (%d minutes)\n":     "  encuesta: %s (%d minutos)\n",
+	"  media: %s (%s, %d KB)\n":     "  multimedia: %s (%s, %d KB)\n",
+	"    alt: %s\n":                 "    texto alternativo: %s\n",
+
+	// Duplicates
+	"invalid window %q, expected a duration like 10m": "ventana no válida %q; se esperaba una duración como 10m",
+	"duplicates: %w":                     "duplicates: %w",
+	"the same tweet was posted %s as %s": "el mismo tweet se publicó %s como %s",
+	"the same tweet was sent %s and may have gone out before the connection failed; check your profile": "el mismo tweet se envió %s y puede que saliera antes de que fallara la conexión; revisa tu perfil",
+	"%w (use --allow-duplicate to post it anyway)":                                                      "%w (usa --allow-duplicate para publicarlo igualmente)",
+	"Warning: could not record the post for the duplicate check:":                                       "Aviso: no se pudo registrar el post para la comprobación de duplicados:",
+
+	// Editor
+	"editor failed: %w": "falló el editor: %w",
+
+	// Emoji
+	"Usage: clix emoji search \"<words>|<emoji>\" [--count 20]": "Uso: clix emoji search \"<palabras>|<emoji>\" [--count 20]",
+	"the most emoji to list, 0 for all":                         "el máximo de emojis que listar, 0 para todos",
+	"--count cannot be negative":                                "--count no puede ser negativo",
+	"No emoji found.":                                           "No se encontró ningún emoji.",
+	"emoji search \"<words>|<emoji>\" [--count 20]  (the :shortcodes: the repl and the emoji transform know)": "emoji search \"<palabras>|<emoji>\" [--count 20]  (los :códigos: que conocen el repl y la transformación emoji)",
+
+	// Engagement
+	"failed to like tweet: %w":   "no se pudo dar me gusta al tweet: %w",
+	"failed to retweet: %w":      "no se pudo retuitear: %w",
+	"failed to unlike tweet: %w": "no se pudo quitar el me gusta: %w",
+	"failed to undo retweet: %w": "no se pudo deshacer el retweet: %w",
+	"Liked %s.\n":                "Me gusta dado a %s.\n",
+	"Unliked %s.\n":              "Me gusta quitado de %s.\n",
+	"Retweeted %s.\n":            "Retuiteado %s.\n",
+	"Undid retweet of %s.\n":     "Retweet de %s deshecho.\n",
+	"Bookmarked %s.\n":           "%s guardado en marcadores.\n",
+	"Removed bookmark of %s.\n":  "Marcador de %s quitado.\n",
+
+	// Export
+	"failed to fetch %s after %d: %w":       "no se pudieron obtener %s después de %d: %w",
+	"Fetched %d %s...\n":                    "%d %s obtenidos...\n",
+	"--format for export must be one of %s": "--format de export debe ser uno de %s",
+	"Usage: clix export bookmarks|likes [--format json|csv|html] [--output file]": "Uso: clix export bookmarks|likes [--format json|csv|html] [--output archivo]",
+	"unknown export action %q":              "acción de export desconocida %q",
+	"%w; wrote the %d fetched so far to %s": "%w; se escribieron los %d obtenidos hasta ahora en %s",
+	"Exported %d %s to %s.\n":               "%d %s exportados a %s.\n",
+
+	// Follow
+	"failed to follow: %w":                  "no se pudo seguir: %w",
+	"failed to unfollow: %w":                "no se pudo dejar de seguir: %w",
+	"failed to fetch followers: %w":         "no se pudieron obtener los seguidores: %w",
+	"failed to fetch followed accounts: %w": "no se pudieron obtener las cuentas seguidas: %w",
+	"Follow request sent to @%s.\n":         "Solicitud de seguimiento enviada a @%s.\n",
+	"Followed @%s.\n":                       "Ahora sigues a @%s.\n",
+	"Unfollowed @%s.\n":                     "Ya no sigues a @%s.\n",
+	"number of accounts to show":            "número de cuentas que mostrar",
+	"No accounts.":                          "No hay cuentas.",
+
+	// GIFs
+	"--gif needs a \"gif\" section in the config with a provider and an api_key": "--gif necesita una sección \"gif\" en la configuración con provider y api_key",
+	"unknown GIF provider %q, expected giphy or tenor":                           "proveedor de GIF desconocido %q; se esperaba giphy o tenor",
+	"GIF search failed: %s":      "falló la búsqueda de GIF: %s",
+	"failed to download GIF: %w": "no se pudo descargar el GIF: %w",
+	"failed to download GIF: %s": "no se pudo descargar el GIF: %s",
+	"the GIF is over %d MB":      "el GIF pasa de %d MB",
+	"no GIFs found for %q":       "no se encontraron GIF para %q",
+	"--gif needs a terminal to choose on; pass --gif-pick n to take the nth result": "--gif necesita una terminal para elegir; usa --gif-pick n para quedarte con el resultado n",
+	"%2d. %s (%d KB)\n":           "%2d. %s (%d KB)\n",
+	"GIF to attach [1-%d]: ":      "GIF que adjuntar [1-%d]: ",
+	"no GIF chosen":               "no se eligió ningún GIF",
+	"pick a GIF between 1 and %d": "elige un GIF entre 1 y %d",
+	"failed to save GIF: %w":      "no se pudo guardar el GIF: %w",
+
+	// Handles
+	"Run `clix handles refresh` to complete @mentions with Tab.":                                     "Ejecuta `clix handles refresh` para completar las @menciones con Tab.",
+	"The handles for @mention completion are over a month old; `clix handles refresh` updates them.": "Los usuarios para completar @menciones tienen más de un mes; `clix handles refresh` los actualiza.",
+	"Usage: clix handles [list [prefix]|refresh]":                                                    "Uso: clix handles [list [prefijo]|refresh]",
+	"unknown handles action %q":                                                                      "acción de handles desconocida %q",
+	"No handles cached; run `clix handles refresh`.":                                                 "No hay usuarios guardados; ejecuta `clix handles refresh`.",
+	"%d of %d handles, refreshed %s.\n":                                                              "%d de %d usuarios, actualizados %s.\n",
+	"Cached %d handles for @mention completion.\n":                                                   "%d usuarios guardados para completar @menciones.\n",
+	"handles list [prefix]  (the handles Tab completes after @ in the repl and tui)":                 "handles list [prefijo]  (los nombres que Tab completa tras @ en el repl y la tui)",
+	"handles refresh  (fetches the accounts you follow and recent mentions)":                         "handles refresh  (obtiene las cuentas que sigues y las menciones recientes)",
+
+	// Hooks
+	"Warning: %s hook failed: %v\n":        "Aviso: falló el hook %s: %v\n",
+	"%s returned %s":                       "%s devolvió %s",
+	"hook has neither a command nor a url": "el hook no tiene command ni url",
+
+	// Languages
+	"language %q is not available; use one of %s": "el idioma %q no está disponible; usa uno de %s",
+
+	// Image preparation
+	"converted from %s to %s":                            "convertida de %s a %s",
+	"%s could not convert %s: %s":                        "%s no pudo convertir %s: %s",
+	"%s: converting %s images needs one of %s installed": "%s: para convertir imágenes %s hace falta tener instalado uno de %s",
+	"not a JPEG file":                                    "no es un archivo JPEG",
+	"corrupt JPEG file":                                  "archivo JPEG dañado",
+	"removed EXIF metadata":                              "metadatos EXIF eliminados",
+	", including the GPS location":                       ", incluida la ubicación GPS",
+	"failed to decode JPEG: %w":                          "no se pudo decodificar el JPEG: %w",
+	"; applied its rotation to the pixels":               "; se aplicó su rotación a los píxeles",
+	"removed EXIF and text metadata":                     "metadatos EXIF y de texto eliminados",
+	"failed to decode image to shrink it: %w":            "no se pudo decodificar la imagen para reducirla: %w",
+	"saved as JPEG (%d KB) to fit the %d MB limit":       "guardada como JPEG (%d KB) para no pasar del límite de %d MB",
+	"resized to %dx%d and %s":                            "redimensionada a %dx%d y %s",
+	"failed to save processed image: %w":                 "no se pudo guardar la imagen procesada: %w",
+
+	// Import
+	"failed to read CSV: %w":            "no se pudo leer el CSV: %w",
+	"unknown CSV column %q":             "columna CSV desconocida %q",
+	"invalid JSON: %w":                  "JSON no válido: %w",
+	"failed to read JSONL: %w":          "no se pudo leer el JSONL: %w",
+	"%w (use --force to import anyway)": "%w (usa --force para importar de todos modos)",
+	"import [--force] <file.csv|file.jsonl>  (rows with a time are scheduled, the rest posted now; --dry-run only checks them)": "import [--force] <archivo.csv|archivo.jsonl>  (las filas con hora se programan y el resto se publica ya; --dry-run solo las comprueba)",
+	"csv or jsonl (default from the file extension)":                                          "csv o jsonl (por defecto, según la extensión del archivo)",
+	"post and schedule even outside the configured posting window or against the style rules": "publicar y programar incluso fuera de la franja de publicación configurada o contra las reglas de estilo",
+	"failed to open import file: %w":                                                          "no se pudo abrir el archivo a importar: %w",
+	"cannot tell the format of %s; pass --type csv or --type jsonl":                           "no se sabe el formato de %s; pasa --type csv o --type jsonl",
+	"%s has no rows":                        "%s no tiene filas",
+	"line %d: %s\n":                         "línea %d: %s\n",
+	"line %d: scheduled as %s for %s\n":     "línea %d: programado como %s para %s\n",
+	"line %d: posted %s\n":                  "línea %d: publicado %s\n",
+	"%d of %d rows are valid.\n":            "%d de %d filas son válidas.\n",
+	"%d posted, %d scheduled, %d failed.\n": "%d publicados, %d programados, %d fallidos.\n",
+	"%d of %d rows failed":                  "fallaron %d de %d filas",
+
+	// Setup
+	"name of the account to set up (asked when not given)":                                         "nombre de la cuenta a configurar (se pregunta si no se da)",
+	"where to keep the credentials: file or keychain (asked when not given)":                       "dónde guardar las credenciales: file o keychain (se pregunta si no se da)",
+	"save the credentials without checking them with the API":                                      "guardar las credenciales sin comprobarlas con la API",
+	"--storage must be file or keychain":                                                           "--storage debe ser file o keychain",
+	"init needs a terminal to ask on; use 'clix config set' or the $CLIX_* variables in scripts":   "init necesita una terminal donde preguntar; en scripts usa 'clix config set' o las variables $CLIX_*",
+	"credentials are set in the environment, which take the place of the config; unset them first": "hay credenciales en el entorno, que sustituyen a la configuración; quítalas primero",
+	"clix posts with the keys of an app you create in the X developer portal:":                     "clix publica con las claves de una app que creas en el portal de desarrolladores de X:",
+	"Give the app Read and Write permissions under User authentication settings,":                  "Dale a la app permisos de lectura y escritura en User authentication settings,",
+	"then copy the API Key and Secret and generate the Access Token and Secret":                    "luego copia la API Key y su Secret y genera el Access Token y su Secret",
+	"under Keys and tokens. Secrets are not shown as you type or paste them.":                      "en Keys and tokens. Los secretos no se muestran al escribirlos o pegarlos.",
+	"Profile name [%s]: ":                                                         "Nombre del perfil [%s]: ",
+	"Use letters, digits, dots, dashes and underscores.":                          "Usa letras, dígitos, puntos, guiones y guiones bajos.",
+	"profile name %q may only have letters, digits, dots, dashes and underscores": "el nombre de perfil %q solo puede tener letras, dígitos, puntos, guiones y guiones bajos",
+	"Profile %q is set up already. Replace its credentials?":                      "El perfil %q ya está configurado. ¿Sustituir sus credenciales?",
+	"Checking the credentials... ":                                                "Comprobando las credenciales... ",
+	"they work for @%s.\n":                                                        "funcionan para @%s.\n",
+	"failed.":                                                                     "han fallado.",
+	"Enter them again?":                                                           "¿Introducirlas de nuevo?",
+	"the credentials were not saved":                                              "las credenciales no se guardaron",
+	"Keep the credentials in the config file or the system keychain? [file/keychain] ": "¿Guardar las credenciales en el archivo de configuración o en el llavero del sistema? [file/keychain] ",
+	"Saved profile %q in %s.\n": "Perfil %q guardado en %s.\n",
+	"the keychain":              "el llavero",
+	"Use it with --account %s, or make it the default with 'clix accounts default %s'.\n":                                                           "Úsalo con --account %s, o hazlo el predeterminado con 'clix accounts default %s'.\n",
+	"%w\nX did not accept them; check they were copied whole and that the access token was generated after the app's permissions were last changed": "%w\nX no las aceptó; comprueba que se copiaron enteras y que el access token se generó después del último cambio de permisos de la app",
+	"%w\nX could not be reached; check the network, or run init with --no-verify":                                                                   "%w\nno se pudo conectar con X; comprueba la red o ejecuta init con --no-verify",
+	"API Key (consumer key)":           "API Key (consumer key)",
+	"API Key Secret (consumer secret)": "API Key Secret (consumer secret)",
+	"Access Token":                     "Access Token",
+	"Access Token Secret":              "Access Token Secret",
+
+	// Insights
+	"--auto needs the metrics of at least %d tweets and has %d; record them with 'clix stats track'":                                    "--auto necesita las métricas de al menos %d tweets y tiene %d; regístralas con 'clix stats track'",
+	"no free hour in the next %d days; pick one with --at":                                                                              "no hay ninguna hora libre en los próximos %d días; elige una con --at",
+	"Usage: clix insights times [--since 90d] [--metric engagement|impressions]":                                                        "Uso: clix insights times [--since 90d] [--metric engagement|impressions]",
+	"insights times [--since 90d] [--metric engagement|impressions]  (how your tweets did by the hour they went out, from stats track)": "insights times [--since 90d] [--metric engagement|impressions]  (cómo funcionaron tus tweets según la hora de publicación, a partir de stats track)",
+	"only tweets posted after this date, time or duration ago":                                                                          "solo los tweets publicados después de esta fecha, hora o tiempo atrás",
+	"what counts: engagement (likes, retweets, replies, quotes and bookmarks) or impressions":                                           "qué cuenta: engagement (me gusta, retweets, respuestas, citas y marcadores) o impressions",
+	"--metric must be one of %s": "--metric debe ser uno de %s",
+	"No tracked tweets in that time; record their metrics with 'clix stats track'.":          "No hay tweets registrados en ese periodo; registra sus métricas con 'clix stats track'.",
+	"Average %s per tweet, over %d tweets: %.1f\n":                                           "Media de %s por tweet, en %d tweets: %.1f\n",
+	"%d. %s %02d:00  %.1f over %d tweets\n":                                                  "%d. %s %02d:00  %.1f en %d tweets\n",
+	"Warning: with fewer than %d tweets this says little; keep running 'clix stats track'\n": "Aviso: con menos de %d tweets esto dice poco; sigue ejecutando 'clix stats track'\n",
+	"    · no tweets  %s low to high\n":                                                      "    · sin tweets  %s de menos a más\n",
+
+	// Janitor
+	"Pausing until %s to stay under the delete rate limit.\n":                                                                 "En pausa hasta las %s para no pasar del límite de borrados.\n",
+	"janitor --older-than t|--all [--pattern regexp] [--from timeline|history] [--yes]  (--dry-run lists the tweets instead)": "janitor --older-than t|--all [--pattern regexp] [--from timeline|history] [--yes]  (--dry-run en cambio lista los tweets)",
+	"only delete tweets older than this, e.g. 90d or 2024-01-01":                                                              "borrar solo los tweets más antiguos que esto, p. ej. 90d o 2024-01-01",
+	"with --pattern, delete matching tweets however recent they are":                                                          "con --pattern, borrar los tweets que coincidan por recientes que sean",
+	"only delete tweets matching this regular expression":                                                                     "borrar solo los tweets que coincidan con esta expresión regular",
+	"where to find tweets: timeline (your last ~3200 tweets) or history (those posted with clix)":                             "dónde buscar los tweets: timeline (tus ~3200 últimos tweets) o history (los publicados con clix)",
+	"delete at most this many tweets, oldest first (0 for all)":                                                               "borrar como mucho este número de tweets, los más antiguos primero (0 para todos)",
+	"--all and --older-than cannot be combined":                                                                               "--all y --older-than no se pueden combinar",
+	"--all needs --pattern; the janitor does not delete everything":                                                           "--all necesita --pattern; el janitor no lo borra todo",
+	"give --older-than, or --all with --pattern to delete matching tweets of any age":                                         "indica --older-than, o --all con --pattern para borrar los tweets que coincidan de cualquier antigüedad",
+	"--limit cannot be negative":                                                                                              "--limit no puede ser negativo",
+	"invalid --pattern: %w":                                                                                                   "--pattern no válido: %w",
+	"unknown --from %q, expected timeline or history":                                                                         "--from %q desconocido, se esperaba timeline o history",
+	"Dry run, would delete %d tweets.\n":                                                                                      "Simulación, se borrarían %d tweets.\n",
+	"No tweets to delete.":                                                                                                    "No hay tweets que borrar.",
+	"%d tweets match.\n":                                                                                                      "Coinciden %d tweets.\n",
+	"The API allows %d deletes every 15 minutes, so this takes about %s.\n":                                                   "La API permite %d borrados cada 15 minutos, así que esto tarda unos %s.\n",
+	"[%d/%d] Failed to delete %s: %v\n":                                                                                       "[%d/%d] No se pudo borrar %s: %v\n",
+	"[%d/%d] Deleted %s from %s\n":                                                                                            "[%d/%d] Borrado %s del %s\n",
+	"Deleted %d of %d tweets.\n":                                                                                              "Borrados %d de %d tweets.\n",
+	"failed to delete %d of %d tweets":                                                                                        "no se pudieron borrar %d de %d tweets",
+
+	// Keychain
+	"the credentials of account %q are not in the keychain; run 'clix init --profile %s'": "las credenciales de la cuenta %q no están en el llavero; ejecuta 'clix init --profile %s'",
+
+	// Limits
+	"limits [--low]  (the rate limits the API last reported for the account)":        "limits [--low]  (los límites de peticiones que la API informó por última vez para la cuenta)",
+	"only endpoints with less than a tenth of their limit left":                      "solo los endpoints a los que les queda menos de una décima parte de su límite",
+	"No rate limits recorded for %s yet; they are noted as commands call the API.\n": "Aún no hay límites registrados para %s; se anotan a medida que los comandos llaman a la API.\n",
+
+	// Line editing
+	"Warning: failed to save the repl history:": "Aviso: no se pudo guardar el historial del repl:",
+	"  (%d more)":              "  (%d más)",
+	"(%d more)":                "(%d más)",
+	"(reverse-i-search)`%s': ": "(búsqueda inversa)`%s': ",
+
+	// Link cards
+	"is not a valid URL": "no es una URL válida",
+	"does not load: %v":  "no carga: %v",
+	"returns %s":         "devuelve %s",
+	"has no Twitter Card or OpenGraph tags, so it shows as a plain link": "no tiene etiquetas Twitter Card ni OpenGraph, así que se muestra como un enlace simple",
+	"has no title in its card tags":                                      "no tiene título en sus etiquetas de tarjeta",
+	"has no image in its card tags":                                      "no tiene imagen en sus etiquetas de tarjeta",
+	"card image %s":                                                      "la imagen de la tarjeta %s",
+	"▢ no image":                                                         "▢ sin imagen",
+	"▢ image %s":                                                         "▢ imagen %s",
+	"▣ image":                                                            "▣ imagen",
+	"no title":                                                           "sin título",
+	"link card for %s %s":                                                "tarjeta del enlace %s %s",
+
+	// Lint
+	"--lint and --no-lint cannot be combined":                                      "--lint y --no-lint no se pueden combinar",
+	"refusing to post with lint warnings; fix them or pass --no-lint":              "no se publica con avisos de lint; corrígelos o pasa --no-lint",
+	"refusing to post with lint warnings; fix them or turn lint off in the config": "no se publica con avisos de lint; corrígelos o desactiva lint en la configuración",
+	"Lint: part %d: %s\n": "Lint: parte %d: %s\n",
+	"Lint: %s\n":          "Lint: %s\n",
+	"double space":        "espacio doble",
+	"%d double spaces":    "%d espacios dobles",
+	"unmatched %q":        "%q sin pareja",
+	"unclosed %q":         "%q sin cerrar",
+	"Warning: %s failed, skipping the spell check: %v\n": "Aviso: %s falló, se omite la revisión ortográfica: %v\n",
+	"possible misspelling: ":                             "posible falta de ortografía: ",
+	"spell checking needs hunspell or aspell installed; set no_spelling in the lint config to skip it": "la revisión ortográfica necesita hunspell o aspell; pon no_spelling en la configuración de lint para omitirla",
+	"failed to read the lint dictionary: %w":                                                           "no se pudo leer el diccionario de lint: %w",
+	"link %s %s":                                                                                       "el enlace %s %s",
+	"@%s is longer than %d characters, so it is not a handle":                                          "@%s tiene más de %d caracteres, así que no es un nombre de usuario",
+	"Warning: could not check the mentions:":                                                           "Aviso: no se pudieron comprobar las menciones:",
+
+	// Lists
+	"failed to fetch your lists: %w":        "no se pudieron obtener tus listas: %w",
+	"failed to look up list %s: %w":         "no se pudo buscar la lista %s: %w",
+	"you have no list named %q":             "no tienes ninguna lista llamada %q",
+	"failed to create list: %w":             "no se pudo crear la lista: %w",
+	"failed to fetch list members: %w":      "no se pudieron obtener los miembros de la lista: %w",
+	"failed to fetch the list timeline: %w": "no se pudo obtener la cronología de la lista: %w",
+	"Usage: clix list [list|create <name>|add <list> @user...|remove <list> @user...|show <list>|timeline <list>]": "Uso: clix list [list|create <nombre>|add <lista> @usuario...|remove <lista> @usuario...|show <lista>|timeline <lista>]",
+	"A <list> is its ID, its URL or the name of one of your lists.":                                                "Una <lista> es su ID, su URL o el nombre de una de tus listas.",
+	"unknown list action %q":            "acción de lista desconocida %q",
+	"list list  (the lists you own)":    "list list  (las listas que tienes)",
+	"No lists.":                         "No hay listas.",
+	" (private)":                        " (privada)",
+	"%-20s %-25s %4d members%s\n":       "%-20s %-25s %4d miembros%s\n",
+	"what the list is about":            "de qué trata la lista",
+	"only you can see the list":         "solo tú puedes ver la lista",
+	"Created list %s. [ID: %s]\n":       "Lista %s creada. [ID: %s]\n",
+	"Added @%s to %s.\n":                "@%s añadido a %s.\n",
+	"Removed @%s from %s.\n":            "@%s quitado de %s.\n",
+	"failed to add @%s: %w":             "no se pudo añadir a @%s: %w",
+	"failed to remove @%s: %w":          "no se pudo quitar a @%s: %w",
+	"number of members to show":         "número de miembros a mostrar",
+	", private":                         ", privada",
+	"%s (%d members, %d followers%s)\n": "%s (%d miembros, %d seguidores%s)\n",
+	"number of tweets to show per page": "número de tweets a mostrar por página",
+
+	// Logging
+	"Warning: invalid log level %q, logging at info\n": "Aviso: nivel de log %q no válido, se usa info\n",
+	"Warning: not logging to a file:":                  "Aviso: no se registra en un archivo:",
+	"Warning: failed to rotate the log file:":          "Aviso: no se pudo rotar el archivo de log:",
+
+	// Login
+	"token request failed: %s %s %s":                                     "la petición del token falló: %s %s %s",
+	"failed to parse token response: %w":                                 "no se pudo interpretar la respuesta del token: %w",
+	"OAuth 2.0 token for account %q has expired; run 'clix login' again": "el token OAuth 2.0 de la cuenta %q ha caducado; vuelve a ejecutar 'clix login'",
+	"failed to refresh OAuth 2.0 token (run 'clix login' again): %w":     "no se pudo renovar el token OAuth 2.0 (vuelve a ejecutar 'clix login'): %w",
+	"authorization response has an unexpected state":                     "la respuesta de autorización tiene un state inesperado",
+	"authorization denied: %s":                                           "autorización denegada: %s",
+	"authorization response has no code":                                 "la respuesta de autorización no tiene código",
+	"clix is logged in. You can close this window.":                      "clix ha iniciado sesión. Puedes cerrar esta ventana.",
+	"timed out waiting for authorization":                                "se agotó el tiempo esperando la autorización",
+	"OAuth 2.0 client ID from the developer portal":                      "client ID de OAuth 2.0 del portal de desarrolladores",
+	"OAuth 2.0 client secret, for confidential clients":                  "client secret de OAuth 2.0, para clientes confidenciales",
+	"local port for the redirect URI http://127.0.0.1:<port>/callback":   "puerto local para la URI de redirección http://127.0.0.1:<puerto>/callback",
+	"space separated scopes to request":                                  "scopes a pedir, separados por espacios",
+	"print the authorization URL instead of opening a browser":           "mostrar la URL de autorización en vez de abrir un navegador",
+	"Enter OAuth 2.0 Client ID: ":                                        "Introduce el Client ID de OAuth 2.0: ",
+	"a client ID is required":                                            "hace falta un client ID",
+	"failed to start callback server: %w":                                "no se pudo iniciar el servidor de callback: %w",
+	"Authorize clix in your browser:":                                    "Autoriza a clix en tu navegador:",
+	"Could not open a browser, open the URL above manually.":             "No se pudo abrir un navegador; abre la URL de arriba a mano.",
+	"Logged in account %q.\n":                                            "Sesión iniciada en la cuenta %q.\n",
+
+	// Mastodon
+	"mastodon is not configured; add \"mastodon\": {\"server\": \"https://mastodon.social\", \"access_token\": \"...\"} to %s": "mastodon no está configurado; añade \"mastodon\": {\"server\": \"https://mastodon.social\", \"access_token\": \"...\"} a %s",
+	"at most %d media files can be attached": "se pueden adjuntar como mucho %d archivos multimedia",
+	"%s is still processing after 2 minutes": "%s sigue procesándose tras 2 minutos",
+	"failed to check processing of %s: %w":   "no se pudo comprobar el procesamiento de %s: %w",
+
+	// Media
+	"%s: unsupported media type %q":                       "%s: tipo multimedia %q no admitido",
+	"%s is a directory":                                   "%s es un directorio",
+	"%s is %d MB, the limit for this media type is %d MB": "%s ocupa %d MB, el límite para este tipo es %d MB",
+	"got %d --alt descriptions for %d media files":        "se recibieron %d descripciones --alt para %d archivos multimedia",
+	"alt text for %s is %d characters, the limit is %d":   "el texto alternativo de %s tiene %d caracteres, el límite es %d",
+	"a GIF or video must be the only attachment":          "un GIF o un vídeo debe ser el único adjunto",
+	"at most %d images can be attached, got %d":           "se pueden adjuntar como mucho %d imágenes, hay %d",
+	"failed to set alt text for %s: %w":                   "no se pudo poner el texto alternativo de %s: %w",
+	"Resuming the upload of %s after %d MB.\n":            "Reanudando la subida de %s tras %d MB.\n",
+	"could not save the upload progress: %v":              "no se pudo guardar el progreso de la subida: %v",
+	"media processing failed: %s":                         "el procesamiento multimedia falló: %s",
+	"media processing failed":                             "el procesamiento multimedia falló",
+
+	// Mentions
+	"failed to fetch mentions: %w":            "no se pudieron obtener las menciones: %w",
+	"only show mentions since the last check": "mostrar solo las menciones desde la última comprobación",
+	"maximum number of mentions to show":      "número máximo de menciones a mostrar",
+	"Warning: could not save mention state:":  "Aviso: no se pudo guardar el estado de las menciones:",
+	"No new mentions.":                        "No hay menciones nuevas.",
+	"No mentions.":                            "No hay menciones.",
+
+	// Mock
+	"failed to read $%s: %w":                      "no se pudo leer $%s: %w",
+	"mock mode: not sending %s %s":                "modo mock: no se envía %s %s",
+	"Warning: could not record the mock request:": "Aviso: no se pudo registrar la petición mock:",
+
+	// Moderation
+	"failed to fetch blocked accounts: %w":                        "no se pudieron obtener las cuentas bloqueadas: %w",
+	"failed to fetch muted accounts: %w":                          "no se pudieron obtener las cuentas silenciadas: %w",
+	"failed to read handles: %w":                                  "no se pudieron leer los nombres de usuario: %w",
+	"read handles from this file, one per line (\"-\" for stdin)": "leer los nombres de usuario de este archivo, uno por línea (\"-\" para stdin)",
+	"failed to %s %d of %d accounts":                              "no se pudo %s %d de %d cuentas",
+	"Usage: clix %s [list]\n":                                     "Uso: clix %s [list]\n",
+	"unknown %s action %q":                                        "acción de %s desconocida %q",
+	"Muted @%s.\n":                                                "@%s silenciado.\n",
+	"Failed to mute @%s: %v\n":                                    "No se pudo silenciar a @%s: %v\n",
+	"Unmuted @%s.\n":                                              "@%s ya no está silenciado.\n",
+	"Failed to unmute @%s: %v\n":                                  "No se pudo dejar de silenciar a @%s: %v\n",
+	"Blocked @%s.\n":                                              "@%s bloqueado.\n",
+	"Failed to block @%s: %v\n":                                   "No se pudo bloquear a @%s: %v\n",
+	"Unblocked @%s.\n":                                            "@%s desbloqueado.\n",
+	"Failed to unblock @%s: %v\n":                                 "No se pudo desbloquear a @%s: %v\n",
+
+	// Network
+	"invalid timeout %q, expected a duration like 1m": "tiempo de espera %q no válido, se esperaba una duración como 1m",
+
+	// Open
+	"open the most recent tweet posted with clix": "abrir el último tweet publicado con clix",
+	"open the profile of the account":             "abrir el perfil de la cuenta",
+	"%q is not a username":                        "%q no es un nombre de usuario",
+
+	// Output
+	"invalid --format template: %w": "plantilla de --format no válida: %w",
+	"failed to format output: %w":   "no se pudo dar formato a la salida: %w",
+
+	// Places
+	"coordinates %q are not \"lat,lon\", e.g. \"51.5072,-0.1276\"":                                                                  "las coordenadas %q no son \"lat,lon\", p. ej. \"51.5072,-0.1276\"",
+	"coordinates %q are out of range: the latitude is within ±90 and the longitude within ±180":                                     "las coordenadas %q están fuera de rango: la latitud va de -90 a 90 y la longitud de -180 a 180",
+	"%w (looking up places needs access to the v1.1 geo API, which not every tier has; tag tweets with a known --place-id instead)": "%w (buscar lugares requiere acceso a la API geo v1.1, que no tienen todos los planes; etiqueta los tweets con un --place-id conocido)",
+	"failed to search places: %w":                                            "no se pudieron buscar lugares: %w",
+	"failed to find the place for --geo: %w":                                 "no se encontró el lugar para --geo: %w",
+	"X knows no place near %s":                                               "X no conoce ningún lugar cerca de %s",
+	"Usage: clix places search [\"city\"] [--near \"lat,lon\"] [--count 10]": "Uso: clix places search [\"ciudad\"] [--near \"lat,lon\"] [--count 10]",
+	"places search [\"city\"] [--near \"lat,lon\"] [--count 10]  (place IDs to tag tweets with, with post --place-id)": "places search [\"ciudad\"] [--near \"lat,lon\"] [--count 10]  (IDs de lugares para etiquetar tweets con post --place-id)",
+	"look around \"lat,lon\", without a name the places there":                                                         "buscar alrededor de \"lat,lon\"; sin nombre, los lugares de allí",
+	"the most places to list, up to 20":                                                                                "el máximo de lugares a listar, hasta 20",
+	"--count must be between 1 and 20":                                                                                 "--count debe estar entre 1 y 20",
+	"No places found.":                                                                                                 "No se encontraron lugares.",
+
+	// Post
+	"Warning: posting the links unshortened:":                                                        "Aviso: se publican los enlaces sin acortar:",
+	"Warning: tweet was posted but not recorded in history:":                                         "Aviso: el tweet se publicó pero no se registró en el historial:",
+	"failed to read stdin: %w":                                                                       "no se pudo leer stdin: %w",
+	"%w (use --split to post it as a thread)":                                                        "%w (usa --split para publicarlo como hilo)",
+	"a poll cannot be combined with media or a quote":                                                "una encuesta no se puede combinar con multimedia ni con una cita",
+	"a poll needs %d to %d options, got %d":                                                          "una encuesta necesita de %d a %d opciones, hay %d",
+	"poll duration must be between %d and %d minutes":                                                "la duración de la encuesta debe estar entre %d y %d minutos",
+	"poll options cannot be empty":                                                                   "las opciones de la encuesta no pueden estar vacías",
+	"poll option %q is %d characters, the limit is %d":                                               "la opción %q tiene %d caracteres, el límite es %d",
+	"failed to post tweet: %w":                                                                       "no se pudo publicar el tweet: %w",
+	"split tweet stopped after %d of %d parts: %w":                                                   "el tweet dividido se detuvo tras %d de %d partes: %w",
+	"the clipboard holds no tweet URL: %q":                                                           "el portapapeles no contiene la URL de un tweet: %q",
+	"read the tweet from a file":                                                                     "leer el tweet de un archivo",
+	"compose the tweet in $EDITOR, starting from any text given":                                     "escribir el tweet en $EDITOR, partiendo del texto dado",
+	"fill in a saved template instead of giving the text":                                            "rellenar una plantilla guardada en vez de dar el texto",
+	"a template variable as key=value (repeatable)":                                                  "una variable de la plantilla como clave=valor (se puede repetir)",
+	"attach an image, GIF or video (repeat for up to 4 images)":                                      "adjuntar una imagen, GIF o vídeo (repetir para hasta 4 imágenes)",
+	"leave the EXIF metadata, such as the GPS location, in attached images":                          "dejar los metadatos EXIF, como la ubicación GPS, en las imágenes adjuntas",
+	"search the configured GIF provider and attach the GIF chosen from the results":                  "buscar en el proveedor de GIF configurado y adjuntar el GIF elegido de los resultados",
+	"with --gif, attach the nth result without asking":                                               "con --gif, adjuntar el resultado n sin preguntar",
+	"quote the tweet whose URL is on the clipboard, taking all arguments as the comment":             "citar el tweet cuya URL está en el portapapeles, tomando todos los argumentos como comentario",
+	"post the text on the clipboard; with --edit, start from it":                                     "publicar el texto del portapapeles; con --edit, partir de él",
+	"copy the URL of the posted tweet to the clipboard":                                              "copiar la URL del tweet publicado al portapapeles",
+	"open the posted tweet in the browser":                                                           "abrir el tweet publicado en el navegador",
+	"post even if the same tweet was sent within the duplicates window":                              "publicar aunque el mismo tweet se enviara dentro del periodo de duplicados",
+	"split an over-length tweet into a thread at word boundaries":                                    "dividir un tweet demasiado largo en un hilo por los límites de palabra",
+	"add a poll option (repeat for 2 to 4 options)":                                                  "añadir una opción de encuesta (repetir para 2 a 4 opciones)",
+	"minutes the poll stays open, up to 7 days":                                                      "minutos que la encuesta sigue abierta, hasta 7 días",
+	"comma-separated networks to post to: x, mastodon, bsky":                                         "redes en las que publicar, separadas por comas: x, mastodon, bsky",
+	"show a preview of the tweet and ask before posting it (default from config)":                    "mostrar una vista previa del tweet y preguntar antes de publicarlo (por defecto, según la configuración)",
+	"post without asking, even with confirm_before_post in the config":                               "publicar sin preguntar, aunque confirm_before_post esté en la configuración",
+	"post the next line of this file, keeping track of the lines posted":                             "publicar la siguiente línea de este archivo, llevando la cuenta de las publicadas",
+	"with --from-rotation, post a random line instead of the next one":                               "con --from-rotation, publicar una línea al azar en vez de la siguiente",
+	"with --from-rotation, never post a line twice":                                                  "con --from-rotation, no publicar nunca dos veces la misma línea",
+	"hold the tweet this long so it can be undone, e.g. 10s (default from config, 0 for none)":       "retener el tweet este tiempo para poder deshacerlo, p. ej. 10s (por defecto, según la configuración; 0 para ninguno)",
+	"check spelling, spacing, brackets, links and mentions before posting (default from config)":     "revisar la ortografía, los espacios, los paréntesis, los enlaces y las menciones antes de publicar (por defecto, según la configuración)",
+	"skip the lint checks, even with lint in the config":                                             "omitir las comprobaciones de lint, aunque lint esté en la configuración",
+	"post the text as given, without the transforms from the config":                                 "publicar el texto tal cual, sin las transformaciones de la configuración",
+	"--random and --no-repeat need --from-rotation":                                                  "--random y --no-repeat necesitan --from-rotation",
+	"--from-rotation cannot be combined with --template, --file, --edit or text arguments":           "--from-rotation no se puede combinar con --template, --file, --edit ni argumentos de texto",
+	"--from-clipboard cannot be combined with --from-rotation, --template, --file or text arguments": "--from-clipboard no se puede combinar con --from-rotation, --template, --file ni argumentos de texto",
+	"aborting post due to empty tweet":                                                               "se cancela la publicación porque el tweet está vacío",
+	"--var needs --template":                                                                         "--var necesita --template",
+	"--template cannot be combined with --file or text arguments":                                    "--template no se puede combinar con --file ni argumentos de texto",
+	"--file cannot be combined with --edit or text arguments":                                        "--file no se puede combinar con --edit ni argumentos de texto",
+	"failed to read tweet file: %w":                                                                  "no se pudo leer el archivo del tweet: %w",
+	"--gif-pick needs --gif":                                                                         "--gif-pick necesita --gif",
+	"%w (use --force to post anyway)":                                                                "%w (usa --force para publicarlo de todos modos)",
+	"Write the tweet above. Saving an empty tweet aborts.\nTweets are limited to 280 characters; use --split to post longer text as a thread.": "Escribe el tweet arriba. Guardar un tweet vacío lo cancela.\nLos tweets tienen un límite de 280 caracteres; usa --split para publicar un texto más largo como hilo.",
+	"post [flags] [text]  (reads stdin when no text is given)":                                                                                 "post [opciones] [texto]  (lee stdin si no se da texto)",
+	"quote [flags] <tweet> [text]  (the tweet is an ID or URL; reads the comment from stdin when no text is given)":                            "quote [opciones] <tweet> [texto]  (el tweet es un ID o una URL; lee el comentario de stdin si no se da texto)",
+
+	// Preview
+	"↩ Replying to the previous part":      "↩ En respuesta a la parte anterior",
+	"↩ Replying to":                        "↩ En respuesta a",
+	"%s %s (%d KB)":                        "%s %s (%d KB)",
+	" · alt: ":                             " · alt: ",
+	" · no alt text":                       " · sin texto alternativo",
+	"Poll closes after %s":                 "La encuesta se cierra tras %s",
+	"No card: %s %v":                       "Sin tarjeta: %s %v",
+	"%d/%d characters":                     "%d/%d caracteres",
+	"Tweet %d/%d · ":                       "Tweet %d/%d · ",
+	"%d day(s)":                            "%d día(s)",
+	"%d hour(s)":                           "%d hora(s)",
+	"%d minute(s)":                         "%d minuto(s)",
+	"--confirm needs a terminal to ask on": "--confirm necesita una terminal donde preguntar",
+
+	// Project config
+	"failed to open config file: %w":     "no se pudo abrir el archivo de configuración: %w",
+	"failed to decrypt config file: %w":  "no se pudo descifrar el archivo de configuración: %w",
+	"failed to parse config file %s: %w": "no se pudo interpretar el archivo de configuración %s: %w",
+	"Warning: %s is not trusted, so only its formatting and check settings apply; run 'clix config trust' if you wrote it.\n": "Aviso: %s no es de confianza, así que solo se aplican sus ajustes de formato y de comprobación; ejecuta 'clix config trust' si lo escribiste tú.\n",
+	"failed to read %s: %w":                                                  "no se pudo leer %s: %w",
+	"there is no %s in the current directory":                                "no hay ningún %s en el directorio actual",
+	"No longer trusting %s; only its formatting and check settings apply.\n": "%s ya no es de confianza; solo se aplican sus ajustes de formato y de comprobación.\n",
+	"Its hooks will run:":                                                    "Se ejecutarán sus hooks:",
+	"Trust %s?":                                                              "¿Confiar en %s?",
+	"Trusted %s until it changes.\n":                                         "%s es de confianza hasta que cambie.\n",
+
+	// Queue
+	"Queued; it will be posted the next time clix reaches X, or run 'clix queue flush'.": "En cola; se publicará la próxima vez que clix llegue a X, o ejecuta 'clix queue flush'.",
+	"Warning: queued tweet %s was only partly posted: %v\n":                              "Aviso: el tweet en cola %s solo se publicó en parte: %v\n",
+	"queued tweet %s: %w":                                 "tweet en cola %s: %w",
+	"Posted queued tweet %s as %s\n":                      "Tweet en cola %s publicado como %s\n",
+	"Usage: clix queue [list|flush|drop <key>...]":        "Uso: clix queue [list|flush|drop <clave>...]",
+	"unknown queue action %q":                             "acción de cola desconocida %q",
+	"The queue is empty.":                                 "La cola está vacía.",
+	"  last error: %s\n":                                  "  último error: %s\n",
+	"queue flush  (posts queued tweets of every account)": "queue flush  (publica los tweets en cola de todas las cuentas)",
+	"account %q: %w":                                      "cuenta %q: %w",
+	"no queued tweet %s":                                  "no hay ningún tweet en cola %s",
+
+	// Rate limits
+	"no answer within the timeout of %s: %w":                                                     "sin respuesta en el tiempo de espera de %s: %w",
+	"cannot retry %s %s: request body is not replayable":                                         "no se puede reintentar %s %s: el cuerpo de la petición no se puede repetir",
+	"Warning: rate limit reached for %s, it resets at %s (use --wait-on-limit to wait for it)\n": "Aviso: se alcanzó el límite de peticiones de %s, se restablece a las %s (usa --wait-on-limit para esperarlo)\n",
+	"Rate limited; waiting until %s.\n":                                                          "Límite de peticiones alcanzado; esperando hasta las %s.\n",
+
+	// Repl
+	"Alt text for %s (enter to skip): ": "Texto alternativo para %s (enter para omitir): ",
+	"failed to read input: %w":          "no se pudo leer la entrada: %w",
+	"Usage: /suggest <draft text>":      "Uso: /suggest <texto del borrador>",
+
+	// Review
+	"no proposals directory; pass --dir or set dir in the review section of the config":                      "no hay directorio de propuestas; pasa --dir o pon dir en la sección review de la configuración",
+	"trusted key of %q is not a public key printed by clix propose --key":                                    "la clave de confianza de %q no es una clave pública mostrada por clix propose --key",
+	"%s is not a trusted proposer; to trust them, add %q: %q to trusted in the review section of the config": "%s no es un proponente de confianza; para confiar en esa persona, añade %q: %q a trusted en la sección review de la configuración",
+	"the signature does not match; the proposal was changed after %s signed it":                              "la firma no coincide; la propuesta se cambió después de que %s la firmara",
+	"the proposal's media checksums do not match its media":                                                  "las sumas de comprobación multimedia de la propuesta no coinciden con su multimedia",
+	"%s was changed after %s signed the proposal":                                                            "%s se cambió después de que %s firmara la propuesta",
+	"this proposal was already approved by %s %s, as %s":                                                     "esta propuesta ya la aprobó %s %s, como %s",
+	"%s is approving this proposal since %s":                                                                 "%s está aprobando esta propuesta desde %s",
+	"the proposal was claimed by another approve while this one waited; not posted":                          "otro approve tomó la propuesta mientras este esperaba; no se publicó",
+	"failed to read media: %w":                                                                               "no se pudo leer el multimedia: %w",
+	"%s does not hold a signing key":                                                                         "%s no contiene una clave de firma",
+	"failed to copy media: %w":                                                                               "no se pudo copiar el multimedia: %w",
+	"failed to save the proposal: %w":                                                                        "no se pudo guardar la propuesta: %w",
+	"failed to read the proposal: %w":                                                                        "no se pudo leer la propuesta: %w",
+	"%s is not a proposal: %w":                                                                               "%s no es una propuesta: %w",
+	"propose [flags] [text]  |  propose --key  (reads stdin when no text is given)":                          "propose [opciones] [texto]  |  propose --key  (lee stdin si no se da texto)",
+	"the shared proposals directory (default from the review section of the config)":                         "el directorio compartido de propuestas (por defecto, según la sección review de la configuración)",
+	"a note for the reviewer, which is not posted":                                                           "una nota para quien revisa, que no se publica",
+	"print the public key reviewers trust your proposals by":                                                 "mostrar la clave pública con la que se confía en tus propuestas",
+	"Dry run, nothing was proposed.":                                                                         "Simulación, no se propuso nada.",
+	"failed to create the proposals directory: %w":                                                           "no se pudo crear el directorio de propuestas: %w",
+	"Proposed in": "Propuesto en",
+	"approve [--yes] [--force] <file>  |  approve [--dir d]  (lists the proposals waiting)":         "approve [--yes] [--force] <archivo>  |  approve [--dir d]  (lista las propuestas pendientes)",
+	"with no file, the proposals directory to list (default from the review section of the config)": "sin archivo, el directorio de propuestas a listar (por defecto, según la sección review de la configuración)",
+	"post without showing the proposal and asking":                                                  "publicar sin mostrar la propuesta ni preguntar",
+	"No proposals waiting.":                                        "No hay propuestas pendientes.",
+	"already approved by %s %s":                                    "ya aprobada por %s %s",
+	"proposed for account %q, not %q; use --account %s":            "propuesta para la cuenta %q, no %q; usa --account %s",
+	"Warning: could not release the claim on the proposal:":        "Aviso: no se pudo liberar la reserva de la propuesta:",
+	"refusing to approve without showing the proposal; pass --yes": "no se aprueba sin mostrar la propuesta; pasa --yes",
+	"Proposed by %s %s\n":                                          "Propuesto por %s %s\n",
+	"Note:":                                                        "Nota:",
+	"Warning: posted, but could not record the approval:":          "Aviso: publicado, pero no se pudo registrar la aprobación:",
+	"Warning: posted, but could not mark the proposal approved:":   "Aviso: publicado, pero no se pudo marcar la propuesta como aprobada:",
+
+	// Rotation
+	"failed to read rotation file: %w":                                 "no se pudo leer el archivo de rotación: %w",
+	"no lines to post in %s":                                           "no hay líneas que publicar en %s",
+	"every line of %s has been posted; drop --no-repeat to start over": "ya se publicaron todas las líneas de %s; quita --no-repeat para empezar de nuevo",
+	"Warning: failed to save the rotation progress:":                   "Aviso: no se pudo guardar el progreso de la rotación:",
+
+	// RPC
+	"query is required":        "hace falta query",
+	"count must be at least 1": "count debe ser al menos 1",
+	"invalid request: %v":      "petición no válida: %v",
+	"unknown method %q":        "método desconocido %q",
+	"rpc  (answers JSON requests on stdin, one per line, with JSON responses on stdout)": "rpc  (responde a peticiones JSON por stdin, una por línea, con respuestas JSON por stdout)",
+	"failed to read a request: %w": "no se pudo leer una petición: %w",
+
+	// Safe mode
+	"invalid safe_mode pattern %q: %w": "patrón de safe_mode %q no válido: %w",
+	"matches `%s`":                     "coincide con `%s`",
+	"safe mode refused to post %q, which %s; edit the tweet or change safe_mode in the config": "el modo seguro no publicó %q, que %s; edita el tweet o cambia safe_mode en la configuración",
+	"looks like an AWS access key":    "parece una clave de acceso de AWS",
+	"looks like a GitHub token":       "parece un token de GitHub",
+	"looks like a Slack token":        "parece un token de Slack",
+	"looks like a Google API key":     "parece una clave de API de Google",
+	"looks like a secret API key":     "parece una clave de API secreta",
+	"looks like a private key":        "parece una clave privada",
+	"looks like an internal hostname": "parece un nombre de host interno",
+
+	// Saved searches
+	"what to call the search, for search run":                                      "cómo llamar a la búsqueda, para search run",
+	"only tweets in this language (e.g. en)":                                       "solo tweets en este idioma (p. ej. en)",
+	"only tweets from this user":                                                   "solo tweets de este usuario",
+	"replace a saved search of the same name":                                      "sustituir una búsqueda guardada del mismo nombre",
+	"search name %q may only have letters, digits, dots, dashes and underscores":   "el nombre de búsqueda %q solo puede tener letras, dígitos, puntos, guiones y guiones bajos",
+	"there is a saved search %q already; use --force to replace it":                "ya hay una búsqueda guardada %q; usa --force para sustituirla",
+	"Saved search %q for %s; run it with 'clix search run %s'.\n":                  "Búsqueda %q guardada para %s; ejecútala con 'clix search run %s'.\n",
+	"No saved searches; save one with 'clix search save \"<query>\" --name name'.": "No hay búsquedas guardadas; guarda una con 'clix search save \"<consulta>\" --name nombre'.",
+	"no saved search %q":         "no hay ninguna búsqueda guardada %q",
+	"Removed saved search %q.\n": "Búsqueda guardada %q eliminada.\n",
+	"search run [name...] [--notify] [--webhook url] [--count 50]  (new tweets for saved searches, all of them without a name; for cron)": "search run [nombre...] [--notify] [--webhook url] [--count 50]  (tweets nuevos de las búsquedas guardadas, de todas si no hay nombre; para cron)",
+	"show a desktop notification when there are new tweets":                                                                               "mostrar una notificación de escritorio cuando haya tweets nuevos",
+	"also POST each new tweet as a hook event to this URL (repeatable)":                                                                   "enviar también por POST cada tweet nuevo como evento de hook a esta URL (se puede repetir)",
+	"the most new tweets to report per search":                                                                                            "el máximo de tweets nuevos a informar por búsqueda",
+	"no saved searches; save one with 'clix search save \"<query>\" --name name'":                                                         "no hay búsquedas guardadas; guarda una con 'clix search save \"<consulta>\" --name nombre'",
+	"saved search %q: %w":                     "búsqueda guardada %q: %w",
+	"%d new tweets for %s":                    "%d tweets nuevos para %s",
+	"A new tweet for %s":                      "Un tweet nuevo para %s",
+	"Warning: could not show a notification:": "Aviso: no se pudo mostrar una notificación:",
+	"No new tweets.":                          "No hay tweets nuevos.",
+
+	// Search
+	"invalid date %q, expected YYYY-MM-DD or RFC 3339": "fecha %q no válida, se esperaba AAAA-MM-DD o RFC 3339",
+	"search failed: %w":                                     "la búsqueda falló: %w",
+	"maximum number of tweets to return":                    "número máximo de tweets a devolver",
+	"only tweets after this date (YYYY-MM-DD or RFC 3339)":  "solo tweets posteriores a esta fecha (AAAA-MM-DD o RFC 3339)",
+	"only tweets before this date (YYYY-MM-DD or RFC 3339)": "solo tweets anteriores a esta fecha (AAAA-MM-DD o RFC 3339)",
+	"No tweets found.":                                      "No se encontraron tweets.",
+
+	// Serve
+	"invalid parameters: %w": "parámetros no válidos: %w",
+	"at is required":         "hace falta at",
+	"serve [--listen 127.0.0.1:8787] [--print-token]  (an HTTP API for local tools to post through)": "serve [--listen 127.0.0.1:8787] [--print-token]  (una API HTTP para que otras herramientas locales publiquen)",
+	"address to listen on":                                                          "dirección en la que escuchar",
+	"allow a --listen address other machines can reach":                             "permitir una dirección --listen accesible desde otras máquinas",
+	"print the bearer token clients send and exit":                                  "mostrar el token bearer que envían los clientes y salir",
+	"%s can be reached from other machines; listen on 127.0.0.1 or pass --public":   "%s es accesible desde otras máquinas; escucha en 127.0.0.1 o pasa --public",
+	"Serving the clix API for %s on http://%s (token: clix serve --print-token).\n": "Sirviendo la API de clix para %s en http://%s (token: clix serve --print-token).\n",
+
+	// Scheduler service
+	"no systemd here; run 'clix scheduler run --once' from cron instead":                                                        "aquí no hay systemd; ejecuta 'clix scheduler run --once' desde cron",
+	"installing the scheduler is not supported on %s; run 'clix scheduler run --once' from the system's task scheduler instead": "instalar el programador no es posible en %s; ejecuta 'clix scheduler run --once' desde el programador de tareas del sistema",
+	"Would run:": "Se ejecutaría:",
+	"how often the scheduler checks for due posts":                        "cada cuánto busca el programador los posts pendientes",
+	"replace a scheduler service that is installed already":               "sustituir un servicio del programador ya instalado",
+	"--interval must be at least 1s":                                      "--interval debe ser al menos 1s",
+	"the scheduler is installed already at %s; use --force to replace it": "el programador ya está instalado en %s; usa --force para sustituirlo",
+	"Would write %s:\n\n%s\n":                                             "Se escribiría %s:\n\n%s\n",
+	"failed to write %s: %w":                                              "no se pudo escribir %s: %w",
+	"Installed the scheduler as a %s service in %s, checking every %s.\n": "Programador instalado como servicio de %s en %s, comprobando cada %s.\n",
+	"Warning: the service needs %s, which it cannot ask for; put them in %s as NAME=value lines, readable by you alone\n": "Aviso: el servicio necesita %s, que no puede pedir; ponlas en %s como líneas NOMBRE=valor, legibles solo por ti\n",
+	"Warning: the service needs %s, which it cannot ask for; give them to it with 'launchctl setenv'\n":                   "Aviso: el servicio necesita %s, que no puede pedir; dáselas con 'launchctl setenv'\n",
+	"%q cannot be written into a systemd unit":                                          "%q no se puede escribir en una unidad de systemd",
+	"To keep it running while you are logged out, run 'loginctl enable-linger'.":        "Para que siga funcionando sin sesión iniciada, ejecuta 'loginctl enable-linger'.",
+	"See it with 'clix scheduler status' and 'clix scheduler logs'.":                    "Consúltalo con 'clix scheduler status' y 'clix scheduler logs'.",
+	"the scheduler is not installed":                                                    "el programador no está instalado",
+	"Would remove":                                                                      "Se eliminaría",
+	"Uninstalled the scheduler; scheduled posts are kept for when it runs again.":       "Programador desinstalado; los posts programados se conservan para cuando vuelva a funcionar.",
+	"The scheduler is not installed; set it up with 'clix scheduler install'.":          "El programador no está instalado; configúralo con 'clix scheduler install'.",
+	"The scheduler is running (%s, %s).\n":                                              "El programador está en marcha (%s, %s).\n",
+	"The scheduler is installed but not running (%s, %s); see 'clix scheduler logs'.\n": "El programador está instalado pero no en marcha (%s, %s); mira 'clix scheduler logs'.\n",
+	"1 post scheduled, due %s.\n":                                                       "1 post programado, para %s.\n",
+	"%d posts scheduled, the next due %s.\n":                                            "%d posts programados, el siguiente para %s.\n",
+	"the scheduler is not running":                                                      "el programador no está en marcha",
+	"how many of the last lines to show":                                                "cuántas de las últimas líneas mostrar",
+	"keep showing new lines as they are written":                                        "seguir mostrando las líneas nuevas según se escriben",
+	"the scheduler has not written %s yet":                                              "el programador aún no ha escrito %s",
+	"failed to show the scheduler's logs: %w":                                           "no se pudieron mostrar los logs del programador: %w",
+
+	// Shortener
+	"the shortener config needs a url and an api_key": "la configuración del acortador necesita url y api_key",
+	"failed to shorten %s: %w":                        "no se pudo acortar %s: %w",
+	"failed to shorten %s: %s %s":                     "no se pudo acortar %s: %s %s",
+	"unexpected response from the shortener for %s":   "respuesta inesperada del acortador para %s",
+
+	// Show
+	"failed to fetch tweet %s: %w": "no se pudo obtener el tweet %s: %w",
+	"Warning: the thread is older than 7 days and search only reaches that far back, so replies may be missing": "Aviso: el hilo tiene más de 7 días y la búsqueda solo llega hasta ahí, así que pueden faltar respuestas",
+	"show the whole conversation the tweet is part of, in order":                                                "mostrar en orden toda la conversación de la que forma parte el tweet",
+	"with --thread, the most replies to show":                                                                   "con --thread, el máximo de respuestas a mostrar",
+
+	// Snapshots
+	"stats track [--since 30d] [--count 100]  (records the metrics of your recent tweets; run it from cron)": "stats track [--since 30d] [--count 100]  (registra las métricas de tus tweets recientes; ejecútalo desde cron)",
+	"track your tweets posted after this date, time or duration ago":                                         "registrar tus tweets publicados después de esta fecha, hora o tiempo atrás",
+	"the most tweets to track":                                        "el máximo de tweets a registrar",
+	"Recorded the metrics of %d tweets.\n":                            "Registradas las métricas de %d tweets.\n",
+	"report snapshots taken after this date, time or duration ago":    "informar de las instantáneas tomadas después de esta fecha, hora o tiempo atrás",
+	"file to write with --format, - for stdout (the default)":         "archivo en el que escribir con --format, - para stdout (por defecto)",
+	"--format for stats report must be one of %s":                     "--format de stats report debe ser uno de %s",
+	"No snapshots in that time; record them with 'clix stats track'.": "No hay instantáneas en ese periodo; regístralas con 'clix stats track'.",
+
+	// State
+	"unknown $%s %q, expected %s": "$%s %q desconocido, se esperaba %s",
+
+	// Stats
+	"failed to fetch tweet metrics: %w": "no se pudieron obtener las métricas de los tweets: %w",
+	"failed to fetch tweet metrics: %s": "no se pudieron obtener las métricas de los tweets: %s",
+	"Warning: no metrics for %s: %s\n":  "Aviso: no hay métricas de %s: %s\n",
+	"Total (%d tweets)":                 "Total (%d tweets)",
+	"impressions %s\n":                  "impresiones %s\n",
+	"likes       %s\n":                  "me gusta    %s\n",
+	"retweets    %s\n":                  "retweets    %s\n",
+	"show your latest tweet":            "mostrar tu último tweet",
+	"show your tweets posted after this date, time or duration ago, e.g. 7d": "mostrar tus tweets publicados después de esta fecha, hora o tiempo atrás, p. ej. 7d",
+	"with --since, the most tweets to show":                                  "con --since, el máximo de tweets a mostrar",
+	"at most 100 tweets at a time":                                           "como mucho 100 tweets cada vez",
+	"No tweets.":                                                             "No hay tweets.",
+
+	// Stream
+	"the filtered stream needs the app's consumer key and secret (see 'clix config set')": "el stream filtrado necesita la consumer key y el secret de la app (mira 'clix config set')",
+	"failed to get an app token: %w":    "no se pudo obtener un token de la app: %w",
+	"failed to list stream rules: %w":   "no se pudieron listar las reglas del stream: %w",
+	"failed to add stream rules: %w":    "no se pudieron añadir las reglas del stream: %w",
+	"invalid stream rule: %s":           "regla de stream no válida: %s",
+	"failed to delete stream rules: %w": "no se pudieron borrar las reglas del stream: %w",
+	"failed to delete stream rules: %s": "no se pudieron borrar las reglas del stream: %s",
+	"stream disconnected":               "stream desconectado",
+	"stream [--rule \"<query>\"]... [--count n]  (or: stream rules list|add|delete)":     "stream [--rule \"<consulta>\"]... [--count n]  (o: stream rules list|add|delete)",
+	"a filter rule, for this run only (repeatable); without it the saved rules are used": "una regla de filtro, solo para esta ejecución (se puede repetir); sin ella se usan las reglas guardadas",
+	"stop after this many tweets":                                                              "parar tras este número de tweets",
+	"no stream rules; pass --rule or add one with 'clix stream rules add'":                     "no hay reglas de stream; pasa --rule o añade una con 'clix stream rules add'",
+	"Streaming; press Ctrl-C to stop.":                                                         "Transmitiendo; pulsa Ctrl-C para parar.",
+	"Stream error: %v; reconnecting in %s\n":                                                   "Error del stream: %v; reconectando en %s\n",
+	"Usage: clix stream rules [list|add [--tag t] \"<query>\"...|delete <id>...|delete --all]": "Uso: clix stream rules [list|add [--tag t] \"<consulta>\"...|delete <id>...|delete --all]",
+	"unknown stream rules action %q":                                                           "acción de stream rules desconocida %q",
+	"No stream rules.":                                                                         "No hay reglas de stream.",
+	"a label for the rules, shown by rules list":                                               "una etiqueta para las reglas, que muestra rules list",
+	"Added rule %s: %s\n":                                                                      "Regla %s añadida: %s\n",
+	"delete every rule":                                                                        "borrar todas las reglas",
+	"Deleted %d rule(s).\n":                                                                    "%d regla(s) borrada(s).\n",
+
+	// Style
+	"posts from the %q template need %s": "los posts de la plantilla %q necesitan %s",
+	"Style: part %d: %s\n":               "Estilo: parte %d: %s\n",
+	"Style: %s\n":                        "Estilo: %s\n",
+	"refusing to post against the style rules; fix the text or pass --force": "no se publica contra las reglas de estilo; corrige el texto o pasa --force",
+	"%q is a banned word":                   "%q es una palabra prohibida",
+	"%d hashtags, more than the %d allowed": "%d hashtags, más de los %d permitidos",
+	"%q is in ALL CAPS":                     "%q está todo EN MAYÚSCULAS",
+
+	// Suggestions
+	"the draft has no words to search for":                                  "el borrador no tiene palabras que buscar",
+	"Warning: could not count tweets for the reach:":                        "Aviso: no se pudieron contar los tweets para el alcance:",
+	"No tags found in recent tweets like this one.":                         "No se encontraron etiquetas en tweets recientes parecidos.",
+	"suggest [flags] \"<draft text>\"  (reads stdin when no text is given)": "suggest [opciones] \"<texto del borrador>\"  (lee stdin si no se da texto)",
+	"maximum number of tags to suggest":                                     "número máximo de etiquetas a sugerir",
+	"only look at tweets in this language (e.g. en)":                        "mirar solo tweets en este idioma (p. ej. en)",
+
+	// Templates
+	"invalid template: %w":                                                        "plantilla no válida: %w",
+	"invalid --var %q, expected key=value":                                        "--var %q no válido, se esperaba clave=valor",
+	"no template named %q (see 'clix template list')":                             "no hay ninguna plantilla llamada %q (mira 'clix template list')",
+	"template %q needs --var for %s":                                              "la plantilla %q necesita --var para %s",
+	"Warning: template %q does not use --var %s\n":                                "Aviso: la plantilla %q no usa --var %s\n",
+	"failed to fill in template: %w":                                              "no se pudo rellenar la plantilla: %w",
+	"Usage: clix template [list|save <name> <text>|show <name>|delete <name>...]": "Uso: clix template [list|save <nombre> <texto>|show <nombre>|delete <nombre>...]",
+	"unknown template action %q":                                                  "acción de plantilla desconocida %q",
+	"template save <name> [text]  (reads stdin when no text is given)":            "template save <nombre> [texto]  (lee stdin si no se da texto)",
+	"empty template": "plantilla vacía",
+	"Template %q saved, with variables %s.\n": "Plantilla %q guardada, con las variables %s.\n",
+	"Template %q saved.\n":                    "Plantilla %q guardada.\n",
+	"No templates.":                           "No hay plantillas.",
+	"no template named %q":                    "no hay ninguna plantilla llamada %q",
+	"\nVariables:":                            "\nVariables:",
+
+	// Themes
+	"unknown theme %q, use one of %s":                     "tema desconocido %q, usa uno de %s",
+	"%s: %q is not a color, expected 0 to 255 or #rrggbb": "%s: %q no es un color, se esperaba de 0 a 255 o #rrggbb",
+
+	// Threads
+	"unknown thread numbering %q, expected prefix, suffix or none": "numeración de hilo desconocida %q, se esperaba prefix, suffix o none",
+	"thread number_format %q has no {n}":                           "el number_format de hilo %q no tiene {n}",
+	"invalid thread delay %q, expected a duration like 5s":         "retraso de hilo %q no válido, se esperaba una duración como 5s",
+	"Enter each part of the thread; an empty line finishes.":       "Escribe cada parte del hilo; una línea vacía termina.",
+	"part %d: ":                           "parte %d: ",
+	"posted %d parts, part %d failed: %v": "se publicaron %d partes, la parte %d falló: %v",
+	"thread [flags]  (prompts for parts when no --tweet, --file or --from-markdown is given)":   "thread [opciones]  (pide las partes si no se da --tweet, --file ni --from-markdown)",
+	"compile a markdown document into a thread, attaching its images":                           "convertir un documento markdown en un hilo, adjuntando sus imágenes",
+	"with --from-markdown, leave the EXIF metadata, such as the GPS location, in images":        "con --from-markdown, dejar los metadatos EXIF, como la ubicación GPS, en las imágenes",
+	"skip parts before this one, e.g. after a partial failure":                                  "omitir las partes anteriores a esta, p. ej. tras un fallo parcial",
+	"hold the thread this long so it can be undone, e.g. 10s (default from config, 0 for none)": "retener el hilo este tiempo para poder deshacerlo, p. ej. 10s (por defecto, según la configuración; 0 para ninguno)",
+	"show the thread and ask before posting it (default from config)":                           "mostrar el hilo y preguntar antes de publicarlo (por defecto, según la configuración)",
+	"open the first tweet of the thread in the browser once it is posted":                       "abrir el primer tweet del hilo en el navegador una vez publicado",
+	"use only one of --tweet, --file and --from-markdown":                                       "usa solo uno de --tweet, --file y --from-markdown",
+	"failed to read markdown file: %w":                                                          "no se pudo leer el archivo markdown: %w",
+	"--resume-at must be between 1 and %d":                                                      "--resume-at debe estar entre 1 y %d",
+	"Posted %d of %d parts; part %d failed: %v\n":                                               "Publicadas %d de %d partes; la parte %d falló: %v\n",
+	"To resume later, run the same command with %s\n":                                           "Para continuar más tarde, ejecuta el mismo comando con %s\n",
+
+	// Times
+	"%s ago":   "hace %s",
+	"in %s":    "en %s",
+	"just now": "ahora mismo",
+
+	// Timeline
+	"failed to fetch timeline: %w":        "no se pudo obtener la cronología: %w",
+	"only show tweets newer than this ID": "mostrar solo tweets más nuevos que este ID",
+
+	// Tracking
+	"%s and %d more":               "%s y %d más",
+	"1 new reply to %q":            "1 respuesta nueva a %q",
+	"1 new reply to %q from %s":    "1 respuesta nueva a %q de %s",
+	"%d new replies to %q":         "%d respuestas nuevas a %q",
+	"%d new replies to %q from %s": "%d respuestas nuevas a %q de %s",
+	"Usage: clix track <id|url>...  |  track check [<id|url>...] [--notify] [--webhook url] [--watch]  |  track list":                                "Uso: clix track <id|url>...  |  track check [<id|url>...] [--notify] [--webhook url] [--watch]  |  track list",
+	"track <id|url>...  (watch replies to your tweets; track check shows the new ones)":                                                              "track <id|url>...  (sigue las respuestas a tus tweets; track check muestra las nuevas)",
+	"Warning: %s is not a tweet of yours; tracking it anyway\n":                                                                                      "Aviso: %s no es un tweet tuyo; se sigue de todos modos\n",
+	"Tracking replies to %s (%d so far); 'clix track check' shows new ones.\n":                                                                       "Siguiendo las respuestas a %s (%d hasta ahora); 'clix track check' muestra las nuevas.\n",
+	"No tracked tweets; track one with 'clix track <id|url>'.":                                                                                       "No hay tweets seguidos; sigue uno con 'clix track <id|url>'.",
+	"%-20s %-10s %4d replies  checked %-10s %s\n":                                                                                                    "%-20s %-10s %4d respuestas  revisado %-10s %s\n",
+	"track check [<id|url>...] [--notify] [--webhook url] [--watch [--interval 5m]]  (new replies to the tracked tweets, all of them without an ID)": "track check [<id|url>...] [--notify] [--webhook url] [--watch [--interval 5m]]  (respuestas nuevas a los tweets seguidos, de todos si no hay ID)",
+	"show a desktop notification when there are new replies":                                                                                         "mostrar una notificación de escritorio cuando haya respuestas nuevas",
+	"also POST each new reply as a hook event to this URL (repeatable)":                                                                              "enviar también por POST cada respuesta nueva como evento de hook a esta URL (se puede repetir)",
+	"keep checking until interrupted":                                                                                                                "seguir comprobando hasta que se interrumpa",
+	"with --watch, how often to check, at least 15s; a rate limit stretches it until the limit resets":                                               "con --watch, cada cuánto comprobar, al menos 15s; un límite de peticiones lo alarga hasta que se restablezca",
+	"--interval must be at least %s":                                                                                                                 "--interval debe ser al menos %s",
+	"no tracked tweets; track one with 'clix track <id|url>'":                                                                                        "no hay tweets seguidos; sigue uno con 'clix track <id|url>'",
+	"tweet %s is not tracked": "el tweet %s no se está siguiendo",
+	"%d tweets":               "%d tweets",
+	"Watching replies to %s, checking every %s.\n": "Vigilando las respuestas a %s, comprobando cada %s.\n",
+	"Rate limited; next check at %s.\n":            "Límite de peticiones alcanzado; próxima comprobación a las %s.\n",
+	"replies to %s: %w":                            "respuestas a %s: %w",
+	"No new replies.":                              "No hay respuestas nuevas.",
+	"stop tracking every tweet":                    "dejar de seguir todos los tweets",
+	"Stopped tracking %s.\n":                       "Se dejó de seguir %s.\n",
+	"Stopped tracking %d tweets.\n":                "Se dejaron de seguir %d tweets.\n",
+
+	// Transforms
+	"transforms: unknown type %q, use one of %s": "transforms: tipo desconocido %q, usa uno de %s",
+	"transforms: append_hashtags needs hashtags": "transforms: append_hashtags necesita hashtags",
+	"transforms: %q is not a hashtag":            "transforms: %q no es un hashtag",
+
+	// Trends
+	"unknown place %q; use --woeid, or one of %s":                  "lugar desconocido %q; usa --woeid o uno de %s",
+	"failed to fetch trends: %w":                                   "no se pudieron obtener las tendencias: %w",
+	"list the trends of this city or country":                      "listar las tendencias de esta ciudad o país",
+	"list the trends of this where-on-earth ID":                    "listar las tendencias de este ID where-on-earth",
+	"number of trends to show, up to 50":                           "número de tendencias a mostrar, hasta 50",
+	"open the search for the nth trend of the list in the browser": "abrir en el navegador la búsqueda de la tendencia n de la lista",
+	"--place and --woeid cannot be combined":                       "--place y --woeid no se pueden combinar",
+	"--count must be between 1 and 50":                             "--count debe estar entre 1 y 50",
+	"--woeid must be positive":                                     "--woeid debe ser positivo",
+	"there are only %d trends":                                     "solo hay %d tendencias",
+	"No trends.":                                                   "No hay tendencias.",
+	"%3d. %-40s %d tweets\n":                                       "%3d. %-40s %d tweets\n",
+
+	// Tweet IDs
+	"%q is not a tweet ID or URL": "%q no es un ID ni una URL de tweet",
+	"%q is not a tweet URL":       "%q no es una URL de tweet",
+
+	// Tweet settings
+	"unknown reply restriction %q, use one of %s":   "restricción de respuestas desconocida %q, usa una de %s",
+	"a place ID and coordinates cannot be combined": "un ID de lugar y unas coordenadas no se pueden combinar",
+	"tweet: %w":            "tweet: %w",
+	"replies: %s":          "respuestas: %s",
+	"super followers only": "solo superseguidores",
+	"place: nearest to %s": "lugar: el más cercano a %s",
+	"place: %s":            "lugar: %s",
+	"who can reply: everyone, mentioned or following (default from config)":                           "quién puede responder: everyone, mentioned o following (por defecto, según la configuración)",
+	"show the tweet to your super followers alone; =false overrides the config":                       "mostrar el tweet solo a tus superseguidores; =false anula la configuración",
+	"tag the tweet with this place, from clix places search; none for no place (default from config)": "etiquetar el tweet con este lugar, de clix places search; none para ninguno (por defecto, según la configuración)",
+	"tag the tweet with the place nearest to \"lat,lon\"":                                             "etiquetar el tweet con el lugar más cercano a \"lat,lon\"",
+
+	// Undo
+	"invalid undo delay %q, expected a duration like 10s":           "retraso de deshacer %q no válido, se esperaba una duración como 10s",
+	"\r\u001b[KPosting in %s; press u to undo or enter to post now": "\r\u001b[KSe publica en %s; pulsa u para deshacer o enter para publicar ya",
+
+	// Update
+	"failed to check for a new release: %w":                                         "no se pudo comprobar si hay una versión nueva: %w",
+	"%s is over %d MB":                                                              "%s pasa de %d MB",
+	"release %s has no %s to verify the download with":                              "la versión %s no tiene %s con el que verificar la descarga",
+	"this build has an invalid release key":                                         "esta compilación tiene una clave de versión no válida",
+	"release %s is not signed":                                                      "la versión %s no está firmada",
+	"the signature of %s does not match; not updating":                              "la firma de %s no coincide; no se actualiza",
+	"the checksum of %s does not match; not updating":                               "la suma de comprobación de %s no coincide; no se actualiza",
+	"%s has no checksum for %s":                                                     "%s no tiene suma de comprobación para %s",
+	"%s has no %s in it":                                                            "%s no contiene %s",
+	"cannot write to %s; run the update with the permissions it was installed with": "no se puede escribir en %s; ejecuta la actualización con los permisos con que se instaló",
+	"A new version of clix is available: %s (you have %s). Run 'clix update' to install it.\n":                             "Hay una versión nueva de clix: %s (tienes la %s). Ejecuta 'clix update' para instalarla.\n",
+	"update [--check] [--force]  (replaces this binary with the latest release)":                                           "update [--check] [--force]  (sustituye este binario por la última versión)",
+	"only report whether a newer release is out":                                                                           "solo informar de si hay una versión más nueva",
+	"install the latest release even over a development build or a newer version":                                          "instalar la última versión incluso sobre una compilación de desarrollo o una versión más nueva",
+	"clix %s is out (you have %s): %s\n":                                                                                   "clix %s ya está disponible (tienes la %s): %s\n",
+	"clix %s is the latest release (you have %s).\n":                                                                       "clix %s es la última versión (tienes la %s).\n",
+	"this is a development build; pass --force to replace it with %s":                                                      "esta es una compilación de desarrollo; pasa --force para sustituirla por %s",
+	"clix %s is up to date.\n":                                                                                             "clix %s está al día.\n",
+	"release %s has no build for %s/%s":                                                                                    "la versión %s no tiene compilación para %s/%s",
+	"this build has no release key to check the signature of %s with, so it cannot update itself; get the release from %s": "esta compilación no tiene clave de versión con la que comprobar la firma de %s, así que no puede actualizarse sola; descarga la versión de %s",
+	"Dry run, would install %s from %s.\n":                                                                                 "Simulación, se instalaría %s desde %s.\n",
+	"Downloading %s (%d KB)...\n":                                                                                          "Descargando %s (%d KB)...\n",
+	"failed to install the update: %w":                                                                                     "no se pudo instalar la actualización: %w",
+	"Updated %s from %s to %s.\n":                                                                                          "%s actualizado de %s a %s.\n",
+
+	// Upload
+	"chunk %d failed, retrying in %s":                    "el fragmento %d falló, se reintenta en %s",
+	"Uploading %s: %d%%\n":                               "Subiendo %s: %d%%\n",
+	"\r\u001b[KUploading %s [%s%s] %3d%% %.1f/%.1f MB%s": "\r\u001b[KSubiendo %s [%s%s] %3d%% %.1f/%.1f MB%s",
+	"Upload:":            "Subida:",
+	"Uploading %s: %s\n": "Subiendo %s: %s\n",
+
+	// Users
+	"failed to fetch tweets of @%s: %w":           "no se pudieron obtener los tweets de @%s: %w",
+	"number of recent tweets to show, 0 for none": "número de tweets recientes a mostrar, 0 para ninguno",
+	"%d following · %d followers · %d tweets\n":   "%d siguiendo · %d seguidores · %d tweets\n",
+	"\nPinned:": "\nFijado:",
+	"\nRecent:": "\nRecientes:",
+
+	// Watch
+	"notify-send is not installed":         "notify-send no está instalado",
+	"%s failed: %v %s":                     "%s falló: %v %s",
+	"Warning: could not save watch state:": "Aviso: no se pudo guardar el estado de watch:",
+	"Usage: clix watch mentions [--interval 1m] [--no-notify] [--webhook url] [--once]": "Uso: clix watch mentions [--interval 1m] [--no-notify] [--webhook url] [--once]",
+	"unknown watch action %q": "acción de watch desconocida %q",
+	"watch mentions [--interval 1m] [--no-notify] [--webhook url] [--once]  (mentions and replies as they come in)": "watch mentions [--interval 1m] [--no-notify] [--webhook url] [--once]  (menciones y respuestas según llegan)",
+	"how often to check, at least 15s; a rate limit stretches it until the limit resets":                            "cada cuánto comprobar, al menos 15s; un límite de peticiones lo alarga hasta que se restablezca",
+	"print new mentions without desktop notifications":                                                              "mostrar las menciones nuevas sin notificaciones de escritorio",
+	"also POST each mention as a hook event to this URL (repeatable)":                                               "enviar también por POST cada mención como evento de hook a esta URL (se puede repetir)",
+	"check once and exit, for cron":                                                                                 "comprobar una vez y salir, para cron",
+	"Watching mentions, checking every %s.\n":                                                                       "Vigilando las menciones, comprobando cada %s.\n",
+
+	// Posting window
+	"invalid time %q, expected HH:MM":                      "hora %q no válida, se esperaba HH:MM",
+	"posting_window start: %w":                             "posting_window start: %w",
+	"posting_window end: %w":                               "posting_window end: %w",
+	"posting_window start and end must differ":             "el start y el end de posting_window deben ser distintos",
+	"posting_window timezone: %w":                          "posting_window timezone: %w",
+	"posting_window: unknown weekday %q":                   "posting_window: día de la semana desconocido %q",
+	"posting_window never opens":                           "posting_window nunca se abre",
+	"posting is restricted to %s; next allowed time is %s": "la publicación está limitada a %s; la próxima hora permitida es %s",
+}
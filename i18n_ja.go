@@ -0,0 +1,1335 @@
+package clix
+
+// catalogJA maps English messages to their Japanese translations
+var catalogJA = map[string]string{
+	// Usage
+	"Usage: clix [--account name] [--json|--format tmpl] [command] [arguments]": "使い方: clix [--account 名前] [--json|--format テンプレート] [コマンド] [引数]",
+	"Commands:": "コマンド:",
+	"Run 'clix help <command>' for details about a command.": "コマンドの詳細は 'clix help <コマンド>' で表示できます。",
+	"Post a tweet": "ツイートを投稿する",
+	"Serve an HTTP API for local tools to post through":   "ローカルのツールから投稿するための HTTP API を提供する",
+	"Answer JSON requests on stdin, for editor plugins":   "エディタのプラグイン向けに標準入力の JSON リクエストに応答する",
+	"Quote a tweet with a comment":                        "コメント付きでツイートを引用する",
+	"Post a thread of tweets":                             "スレッドを投稿する",
+	"Delete a tweet":                                      "ツイートを削除する",
+	"Delete your tweets by age or pattern":                "古さやパターンで自分のツイートを削除する",
+	"List or undo tweets posted with clix":                "clix で投稿したツイートを一覧・取り消しする",
+	"Open a tweet or profile in the browser":              "ツイートやプロフィールをブラウザで開く",
+	"Show impressions, likes and retweets of your tweets": "自分のツイートのインプレッション・いいね・リツイートを表示する",
+	"Save, edit and post drafts":                          "下書きを保存・編集・投稿する",
+	"Propose a tweet for someone else to approve":         "他の人に承認してもらうツイートを提案する",
+	"Review and post proposed tweets":                     "提案されたツイートを確認して投稿する",
+	"Save tweet templates with variables":                 "変数付きのツイートテンプレートを保存する",
+	"Schedule a tweet to post later":                      "ツイートを予約投稿する",
+	"Show scheduled posts and planned drafts by day":      "予約投稿と予定した下書きを日ごとに表示する",
+	"Show when your tweets do best":                       "ツイートの反応がよい時間帯を表示する",
+	"Post or schedule tweets from a CSV or JSONL file":    "CSV や JSONL ファイルからツイートを投稿・予約する",
+	"Post scheduled tweets when they are due":             "予約したツイートを時刻どおりに投稿する",
+	"List or post tweets queued while offline":            "オフライン中にキューに入れたツイートを一覧・投稿する",
+	"Like tweets":                  "ツイートにいいねする",
+	"Remove your like from tweets": "ツイートのいいねを取り消す",
+	"Retweet tweets":               "リツイートする",
+	"Undo retweets":                "リツイートを取り消す",
+	"Show a tweet, or with --thread its whole conversation": "ツイートを表示する。--thread で会話全体を表示する",
+	"Show a user's profile and recent tweets":               "ユーザーのプロフィールと最近のツイートを表示する",
+	"Follow users":                                     "ユーザーをフォローする",
+	"Unfollow users":                                   "ユーザーのフォローを解除する",
+	"List followers":                                   "フォロワーを一覧する",
+	"List followed accounts":                           "フォロー中のアカウントを一覧する",
+	"Cache the handles Tab completes after @":          "@ の後に Tab で補完するユーザー名を保存する",
+	"Mute users":                                       "ユーザーをミュートする",
+	"Unmute users":                                     "ユーザーのミュートを解除する",
+	"List muted accounts":                              "ミュート中のアカウントを一覧する",
+	"Block users":                                      "ユーザーをブロックする",
+	"Unblock users":                                    "ユーザーのブロックを解除する",
+	"List blocked accounts":                            "ブロック中のアカウントを一覧する",
+	"Create, fill and read X Lists":                    "X のリストを作成・編集・閲覧する",
+	"List, add and remove bookmarks":                   "ブックマークを一覧・追加・削除する",
+	"Write all bookmarks or likes to a file":           "すべてのブックマークやいいねをファイルに書き出す",
+	"Download your profile, tweets and media":          "自分のプロフィール・ツイート・メディアをダウンロードする",
+	"Send and read direct messages":                    "ダイレクトメッセージを送受信する",
+	"Count characters the way X does":                  "X と同じ方法で文字数を数える",
+	"Show your home timeline":                          "ホームタイムラインを表示する",
+	"Show recent mentions of you":                      "最近のメンションを表示する",
+	"Notify about new mentions as they come in":        "新しいメンションが届いたら通知する",
+	"Follow the replies to your tweets":                "自分のツイートへの返信を追う",
+	"Stop following the replies to a tweet":            "ツイートへの返信を追うのをやめる",
+	"Search recent tweets":                             "最近のツイートを検索する",
+	"Suggest hashtags and cashtags for a draft":        "下書きに合うハッシュタグとキャッシュタグを提案する",
+	"List trending topics":                             "トレンドのトピックを一覧表示する",
+	"Search for places to tag tweets with":             "ツイートに付ける場所を検索する",
+	"Look up emoji shortcodes":                         "絵文字のコードを調べる",
+	"Show the rate limits left and when they reset":    "残りのレート制限とリセット時刻を表示する",
+	"Stream tweets matching filter rules live":         "フィルタールールに合うツイートをリアルタイムで受信する",
+	"Set up an account step by step":                   "アカウントを順を追って設定する",
+	"Show or change the configuration":                 "設定を表示・変更する",
+	"Check the config, credentials and rate limit":     "設定・認証情報・レート制限を確認する",
+	"Manage account profiles":                          "アカウントのプロファイルを管理する",
+	"Log in with OAuth 2.0 in the browser":             "ブラウザで OAuth 2.0 ログインする",
+	"Full-screen interface for reading and posting":    "閲覧と投稿のための全画面インターフェース",
+	"Post tweets from an interactive prompt (default)": "対話型プロンプトからツイートを投稿する (既定)",
+	"Print a shell completion script":                  "シェル補完スクリプトを出力する",
+	"Update clix to the latest release":                "clix を最新版に更新する",
+	"Print the version of clix":                        "clix のバージョンを表示する",
+	"Show help for clix or a command":                  "clix やコマンドのヘルプを表示する",
+	"clix - cli for x":                                 "clix - X のための CLI",
+	"unknown command %q":                               "不明なコマンド %q",
+
+	// Prompts
+	"[y/N]":                   "[y/N] (はい/いいえ)",
+	"Post anyway?":            "それでも投稿しますか?",
+	"Post it?":                "投稿しますか?",
+	"Load more?":              "さらに読み込みますか?",
+	"Post these %d tweets?":   "これら %d 件のツイートを投稿しますか?",
+	"Resume from part %d?":    "パート %d から再開しますか?",
+	"Delete tweet %s?":        "ツイート %s を削除しますか?",
+	"Delete tweet %s (%q)?":   "ツイート %s (%q) を削除しますか?",
+	"Delete these %d tweets?": "これら %d 件のツイートを削除しますか?",
+	"Delete %d tweets? Run with --dry-run to list them first.":     "%d 件のツイートを削除しますか? 先に --dry-run で一覧を確認できます。",
+	"Could not reach X. Queue the tweet to post when back online?": "X に接続できませんでした。オンラインに戻ったら投稿するようキューに入れますか?",
+	"Outside the posting window. Schedule it for %s instead?":      "投稿可能な時間帯の外です。代わりに %s に予約しますか?",
+	"failed to read the input: %w":                                 "入力を読み込めませんでした: %w",
+
+	// Messages
+	"Error:":                             "エラー:",
+	"Interrupted.":                       "中断しました。",
+	"Not posted.":                        "投稿しませんでした。",
+	"Dry run, nothing was posted.":       "ドライランのため、何も投稿していません。",
+	"\nTweet %d/%d (%d/%d characters)\n": "\nツイート %d/%d (%d/%d 文字)\n",
+	"Settings: %s\n":                     "設定: %s\n",
+	"Transformed by: %s\n":               "変換: %s\n",
+	"Attached %s to the next tweet.\n":   "%s を次のツイートに添付しました。\n",
+	"Type a tweet and end it with an empty line or Ctrl-D; /media <path> attaches a file to the next one.":     "ツイートを入力し、空行か Ctrl-D で終えてください。/media <パス> で次のツイートにファイルを添付します。",
+	"Up and down recall earlier input, Ctrl-R searches it and Tab completes @mentions and :emoji: shortcodes.": "上下キーで過去の入力を呼び出し、Ctrl-R で検索、Tab で @メンションと :emoji: コードを補完します。",
+	"Type a tweet and press enter to post it; /media <path> attaches a file to the next one.":                  "ツイートを入力して Enter で投稿します。/media <パス> で次のツイートにファイルを添付します。",
+	"tweet: ":                 "ツイート: ",
+	"Goodbye!":                "さようなら!",
+	"Error attaching media:":  "メディアを添付できませんでした:",
+	"Not posting:":            "投稿しません:",
+	"Error scheduling tweet:": "ツイートを予約できませんでした:",
+	"Error posting tweet:":    "ツイートを投稿できませんでした:",
+	"Error queueing tweet:":   "ツイートをキューに入れられませんでした:",
+	"Tweet posted successfully! [ID: %s]\n\n": "ツイートを投稿しました! [ID: %s]\n\n",
+
+	// Terminal interface
+	"Timeline":                        "タイムライン",
+	"Mentions":                        "メンション",
+	"What's happening?":               "いまどうしてる?",
+	"Compose":                         "ツイートを作成",
+	"Replying to @%s":                 "@%s への返信",
+	"Loading...":                      "読み込み中...",
+	"Posting...":                      "投稿中...",
+	"Loaded %d tweets":                "%d 件のツイートを読み込みました",
+	"Posted %s":                       "%s を投稿しました",
+	"Liked %s":                        "%s にいいねしました",
+	"Retweeted %s":                    "%s をリツイートしました",
+	"Dry run: %d tweet(s) not posted": "ドライラン: %d 件のツイートは投稿していません",
+	"Nothing here yet.":               "まだ何もありません。",
+	"1/2 pane · j/k move · c compose · r reply · l like · t retweet · R refresh · q quit": "1/2 ペイン · j/k 移動 · c 作成 · r 返信 · l いいね · t リツイート · R 更新 · q 終了",
+	"ctrl+s post · tab complete @mention · esc back to the list":                          "ctrl+s 投稿 · tab @メンション補完 · esc 一覧に戻る",
+	"tui  (full-screen interface; `clix repl` is the lightweight alternative)":            "tui  (全画面のインターフェース。軽量な代わりは `clix repl`)",
+	"clix tui needs a terminal": "clix tui には端末が必要です",
+
+	// Metrics
+	"Warning: metrics disabled:": "警告: メトリクスを無効にしました:",
+
+	// Command usage
+	"Usage: clix %s\n": "使い方: clix %s\n",
+
+	// Global flags
+	"use this account profile (or set $CLIX_ACCOUNT)":                                                   "このアカウントプロファイルを使う ($CLIX_ACCOUNT でも指定可)",
+	"print results as JSON":                                                                             "結果を JSON で出力する",
+	"print results with a Go template, e.g. '{{.ID}}'":                                                  "結果を Go テンプレートで出力する (例: '{{.ID}}')",
+	"report API requests and remaining rate limits on stderr":                                           "API リクエストと残りのレート制限を stderr に表示する",
+	"like --verbose, adding request and response headers with secrets redacted":                         "--verbose に加え、秘密情報を伏せたリクエストとレスポンスのヘッダーを表示する",
+	"validate and show what would be posted without sending it":                                         "送信せずに検証し、投稿される内容を表示する",
+	"answer API calls with canned responses and record them instead of calling X (or set $CLIX_MOCK=1)": "X を呼ばずに API 呼び出しへ用意された応答を返し、記録する ($CLIX_MOCK=1 でも指定可)",
+	"wait for the rate limit to reset instead of failing":                                               "失敗せずにレート制限のリセットを待つ",
+	"print without colors (or set $NO_COLOR)":                                                           "色なしで出力する ($NO_COLOR でも指定可)",
+	"show times in UTC rather than the local timezone":                                                  "時刻をローカルのタイムゾーンではなく UTC で表示する",
+	"show times as relative (\"3h ago\", the default), absolute, iso or a Go layout":                    "時刻を相対 (\"3時間前\"、既定)、絶対、iso、または Go のレイアウトで表示する",
+	"send requests through this http:// or socks5:// proxy":                                             "この http:// または socks5:// プロキシ経由でリクエストを送る",
+	"also trust the CA certificates in this PEM file":                                                   "この PEM ファイルの CA 証明書も信頼する",
+	"give up on a request attempt after this long (default 30s)":                                        "リクエストの試行をこの時間で打ち切る (既定 30s)",
+	"give up on an API call, retries and rate limit waits included, after this long and exit with 124":  "再試行とレート制限の待ちを含め、API 呼び出しをこの時間で打ち切り、124 で終了する",
+
+	// Drafts
+	"invalid day %q, expected YYYY-MM-DD":                       "日付 %q が無効です。YYYY-MM-DD で指定してください",
+	"unknown draft action %q":                                   "不明な下書きの操作 %q",
+	"name for the draft (defaults to the next free number)":     "下書きの名前 (既定は次の空き番号)",
+	"attach a media file when posted (repeatable)":              "投稿時にメディアファイルを添付する (繰り返し可)",
+	"alt text for the media, paired with each --media in order": "メディアの代替テキスト (各 --media と順に対応)",
+	"reply to this tweet when posted (ID or URL)":               "投稿時にこのツイートへ返信する (ID または URL)",
+	"quote this tweet when posted (ID or URL)":                  "投稿時にこのツイートを引用する (ID または URL)",
+	"the day the draft is meant for, shown on the calendar":     "下書きを予定する日 (カレンダーに表示)",
+	"draft %q already exists":                                   "下書き %q はすでにあります",
+	"Draft saved as %q.\n":                                      "下書きを %q として保存しました。\n",
+	"No drafts.":                                                "下書きはありません。",
+	" [%d media]":                                               " [メディア %d 件]",
+	" [reply]":                                                  " [返信]",
+	" [quote]":                                                  " [引用]",
+	" [for %s]":                                                 " [%s 予定]",
+	"Edit the draft text above. Saving an empty draft leaves it unchanged.":      "上の下書きを編集してください。空のまま保存すると変更されません。",
+	"replace the text instead of opening an editor":                              "エディターを開かずに本文を置き換える",
+	"replace the attached media (repeatable)":                                    "添付メディアを置き換える (繰り返し可)",
+	"replace the alt text of the media, paired with each --media":                "メディアの代替テキストを置き換える (各 --media と対応)",
+	"replace the reply target":                                                   "返信先を置き換える",
+	"replace the quoted tweet":                                                   "引用するツイートを置き換える",
+	"plan the draft for this day, or none to take it off the calendar":           "下書きをこの日に予定する (none でカレンダーから外す)",
+	"no draft named %q":                                                          "%q という下書きはありません",
+	"Draft %q updated.\n":                                                        "下書き %q を更新しました。\n",
+	"split an over-length draft into a thread":                                   "長すぎる下書きをスレッドに分割する",
+	"post even outside the configured posting window or against the style rules": "設定された投稿時間帯の外やスタイルルールに反していても投稿する",
+	"failed to load configuration: %w":                                           "設定を読み込めませんでした: %w",
+	"Warning: draft was posted but not removed:":                                 "警告: 下書きは投稿されましたが削除されませんでした:",
+	"draft edit <name> [--text t] [--media path] [--reply-to id] [--quote id] [--plan YYYY-MM-DD|none]  (opens $EDITOR without --text)": "draft edit <名前> [--text t] [--media パス] [--reply-to id] [--quote id] [--plan YYYY-MM-DD|none]  (--text がなければ $EDITOR を開きます)",
+
+	// Schedule
+	"invalid duration %q": "期間 %q が無効です",
+	"invalid time %q, expected \"YYYY-MM-DD HH:MM\", RFC 3339 or +duration":               "時刻 %q が無効です。\"YYYY-MM-DD HH:MM\"、RFC 3339 または +期間 で指定してください",
+	"Scheduled as %s; 'clix scheduler run' will post it.\n":                               "%s として予約しました。'clix scheduler run' が投稿します。\n",
+	"when to post: \"YYYY-MM-DD HH:MM\" local time, RFC 3339 or +duration":                "投稿する時刻: ローカル時刻の \"YYYY-MM-DD HH:MM\"、RFC 3339 または +期間",
+	"post in the coming week's hour your tracked tweets did best in (see insights times)": "追跡中のツイートの反応が最もよかった、来週の時間帯に投稿する (insights times を参照)",
+	"attach a media file (repeatable)":                                                    "メディアファイルを添付する (繰り返し可)",
+	"reply to this tweet (ID or URL)":                                                     "このツイートに返信する (ID または URL)",
+	"quote this tweet (ID or URL)":                                                        "このツイートを引用する (ID または URL)",
+	"split an over-length tweet into a thread":                                            "長すぎるツイートをスレッドに分割する",
+	"schedule even outside the configured posting window or against the style rules":      "設定された投稿時間帯の外やスタイルルールに反していても予約する",
+	"%w (use --force to schedule anyway)":                                                 "%w (それでも予約するには --force を使ってください)",
+	"\nWould be scheduled for %s.\n":                                                      "\n%s に予約されます。\n",
+	"Scheduled %s for %s.\n":                                                              "%s を %s に予約しました。\n",
+	"%s is in the past":                                                                   "%s は過去の時刻です",
+	"a part of the thread (repeat for each part)":                                         "スレッドの一部 (部分ごとに繰り返す)",
+	"read parts from a file, separated by lines containing ---":                           "--- の行で区切られた各部分をファイルから読む",
+	"post the first part as a reply to this tweet (ID or URL)":                            "最初の部分をこのツイートへの返信として投稿する (ID または URL)",
+	"number the parts as 1/n: prefix, suffix or none (default from config)":               "各部分に 1/n の番号を付ける: prefix、suffix または none (既定は設定どおり)",
+	"leave out the account's signature from the config":                                   "設定にあるアカウントの署名を付けない",
+	"post the parts as given, without the transforms from the config":                     "設定の変換を行わず、各部分をそのまま投稿する",
+	"use only one of --tweet and --file":                                                  "--tweet と --file はどちらか一方だけを使ってください",
+	"failed to read thread file: %w":                                                      "スレッドのファイルを読めませんでした: %w",
+	"nothing to post":                                                                     "投稿する内容がありません",
+	"part %d: %w, including its numbering and signature":                                  "部分 %d: %w (番号と署名を含む)",
+	"part %d: %w": "部分 %d: %w",
+	"Scheduled %s, a thread of %d tweets, for %s.\n":                 "%s (%d 件のスレッド) を %s に予約しました。\n",
+	"include posted, failed and cancelled posts":                     "投稿済み、失敗、取り消し済みのものも含める",
+	"Nothing scheduled.":                                             "予約はありません。",
+	"[thread of %d] %s":                                              "[%d 件のスレッド] %s",
+	"scheduled post %s is already %s":                                "予約 %s はすでに %s です",
+	"no scheduled post %s":                                           "予約 %s はありません",
+	"%s scheduled post %s failed: %v\n":                              "%s 予約 %s に失敗しました: %v\n",
+	"%s posted scheduled post %s as %s\n":                            "%s 予約 %s を %s として投稿しました\n",
+	"thread stopped after %d of %d parts: %w":                        "スレッドは %d / %d 件で止まりました: %w",
+	"post whatever is due and exit, for cron":                        "期限の来たものを投稿して終了する (cron 用)",
+	"how often to check for due posts":                               "期限の来た投稿を確認する間隔",
+	"Scheduler running, checking every %s.\n":                        "スケジューラーを実行中です。%s ごとに確認します。\n",
+	"Scheduler stopped.":                                             "スケジューラーを停止しました。",
+	"Not scheduled.":                                                 "予約しませんでした。",
+	"%d of the due scheduled posts failed; see 'clix schedule list'": "予定時刻を過ぎた予約のうち %d 件に失敗しました。'clix schedule list' を確認してください",
+	"check spelling, spacing, brackets, links and mentions now, before scheduling (default from config)":      "予約する前に、スペル、スペース、括弧、リンク、メンションを今チェックする(既定値は設定から)",
+	"skip the lint checks, now and when it is posted, even with lint in the config":                           "設定に lint があっても、今も投稿時も lint チェックを省く",
+	"schedule thread --at \"YYYY-MM-DD HH:MM\" | --auto [flags]  (reads parts from --tweet, --file or stdin)": "schedule thread --at \"YYYY-MM-DD HH:MM\" | --auto [フラグ]  (パートを --tweet、--file、標準入力から読みます)",
+	"       clix scheduler install [--interval 30s] [--force]":                                                "       clix scheduler install [--interval 30s] [--force]",
+	"       clix scheduler status | logs [-n 50] [-f] | uninstall":                                            "       clix scheduler status | logs [-n 50] [-f] | uninstall",
+
+	// History
+	"failed to create data directory: %w":                     "データディレクトリを作成できませんでした: %w",
+	"no cache directory to keep clix's state in; set $%s: %w": "clix の状態を保存するキャッシュディレクトリがありません。$%s を設定してください: %w",
+	"no tweets in history":                                    "履歴にツイートがありません",
+	"invalid time %q, expected \"YYYY-MM-DD\", \"YYYY-MM-DD HH:MM\" or a duration like 48h or 7d": "時刻 %q が無効です。\"YYYY-MM-DD\"、\"YYYY-MM-DD HH:MM\"、または 48h や 7d のような期間で指定してください",
+	"unknown history action %q":                                      "不明な履歴の操作 %q",
+	"only show tweets containing this text":                          "この文字列を含むツイートだけを表示する",
+	"only show tweets posted after this date, time or duration ago":  "この日付、時刻、または期間前より後に投稿したツイートだけを表示する",
+	"only show tweets posted before this date, time or duration ago": "この日付、時刻、または期間前より前に投稿したツイートだけを表示する",
+	"include deleted tweets":                                         "削除したツイートも含める",
+	"maximum number of tweets to show":                               "表示するツイートの最大数",
+	"--count must be at least 1":                                     "--count は 1 以上にしてください",
+	"No tweets in history.":                                          "履歴にツイートがありません。",
+	"do not ask for confirmation":                                    "確認を求めない",
+	"invalid number of tweets %q":                                    "ツイート数 %q が無効です",
+	"History has only %d of your tweets.\n":                          "履歴にはあなたのツイートが %d 件しかありません。\n",
+	"refusing to delete without confirmation; pass --yes":            "確認なしでは削除しません。--yes を指定してください",
+	"aborted":                       "中止しました",
+	"failed to delete tweet %s: %w": "ツイート %s を削除できませんでした: %w",
+	"Tweet deleted. [ID: %s]\n":     "ツイートを削除しました。[ID: %s]\n",
+	"history list [--search text] [--since t] [--until t] [--deleted] [--count n]  (--account limits it to one account)": "history list [--search テキスト] [--since t] [--until t] [--deleted] [--count n]  (--account で1つのアカウントに絞ります)",
+	"history undo [--yes] [n]  (deletes your last n tweets, default 1)":                                                  "history undo [--yes] [n]  (直近 n 件のツイートを削除します。既定値は1)",
+
+	// Accessibility
+	"refuse to post when the accessibility checks warn (default from config)":                             "アクセシビリティチェックで警告が出たら投稿しない(既定値は設定から)",
+	"Accessibility: part %d: %s\n":                                                                        "アクセシビリティ: パート %d: %s\n",
+	"Accessibility: %s\n":                                                                                 "アクセシビリティ: %s\n",
+	"refusing to post with accessibility warnings; fix them or leave out --strict-a11y":                   "アクセシビリティの警告があるため投稿しません。修正するか --strict-a11y を外してください",
+	"refusing to post with accessibility warnings; fix them or set strict to false in a11y in the config": "アクセシビリティの警告があるため投稿しません。修正するか、設定の a11y で strict を false にしてください",
+	"%s has no alt text; describe it with --alt":                                                          "%s に代替テキストがありません。--alt で説明を付けてください",
+	"%s is read as one word by screen readers; capitalize each word, like #ReadMeAloud":                   "%s はスクリーンリーダーで一語として読まれます。#ReadMeAloud のように各単語の頭を大文字にしてください",
+	"%d emoji in a row; screen readers read out the name of each, more than %d gets tiring":               "絵文字が %d 個続いています。スクリーンリーダーは一つずつ名前を読み上げるため、%d 個を超えると聞き疲れします",
+
+	// Accounts
+	"account %q already exists":                                               "アカウント %q はすでにあります",
+	"Enter credentials for account %q\n":                                      "アカウント %q の認証情報を入力してください\n",
+	"the %q account cannot be removed; use 'clix config reset' to replace it": "%q アカウントは削除できません。置き換えるには 'clix config reset' を使ってください",
+	"unknown account %q":                                                      "不明なアカウント %q",
+	"Warning:":                                                                "警告:",
+	"unknown accounts action %q":                                              "不明な accounts の操作 %q",
+
+	// Archive
+	"failed to parse %s: %w": "%s を解析できませんでした: %w",
+	"failed to fetch your tweets; if the saved page token expired, run again with --restart: %w": "ツイートを取得できませんでした。保存したページトークンが期限切れなら --restart を付けて再実行してください: %w",
+	"failed to fetch your tweets: %w":                             "ツイートを取得できませんでした: %w",
+	"Archived %d tweets...\n":                                     "%d 件のツイートをアーカイブしました...\n",
+	"Warning: media of tweet %s: %v\n":                            "警告: ツイート %s のメディア: %v\n",
+	"failed to download: %w":                                      "ダウンロードできませんでした: %w",
+	"failed to download %s: %s":                                   "%s をダウンロードできませんでした: %s",
+	"failed to download %s: %w":                                   "%s をダウンロードできませんでした: %w",
+	"directory to archive into (default clix-archive-<username>)": "アーカイブ先のディレクトリ(既定は clix-archive-<ユーザー名>)",
+	"do not download photos and videos":                           "写真と動画をダウンロードしない",
+	"walk the whole timeline again instead of resuming, keeping the tweets archived so far":                                        "続きからではなくタイムライン全体をたどり直す(アーカイブ済みのツイートは残す)",
+	"failed to look up the authenticated user: %w":                                                                                 "認証済みユーザーを取得できませんでした: %w",
+	"%s is the archive of @%s, not @%s; use another --dir":                                                                         "%s は @%s のアーカイブで、@%s のものではありません。別の --dir を指定してください",
+	"%w; %d tweets were archived, and running again resumes":                                                                       "%w。%d 件のツイートをアーカイブ済みで、再実行すると続きから再開します",
+	"Archived %d tweets of @%s in %s (%d fetched this run).\n":                                                                     "%d 件の @%s のツイートを %s にアーカイブしました(今回の取得は %d 件)。\n",
+	"archive [--dir path] [--no-media] [--restart]  (your profile, tweets and media; running again resumes, then adds new tweets)": "archive [--dir パス] [--no-media] [--restart]  (プロフィール、ツイート、メディア。再実行すると続きから再開し、新しいツイートを追加します)",
+
+	// Bluesky
+	"bluesky is not configured; add \"bluesky\": {\"handle\": \"you.bsky.social\", \"app_password\": \"...\"} to %s": "bluesky が設定されていません。%s に \"bluesky\": {\"handle\": \"you.bsky.social\", \"app_password\": \"...\"} を追加してください",
+	"post is %d characters, the limit is %d": "投稿は %d 文字で、上限は %d 文字です",
+	"at most %d images can be attached":      "添付できる画像は最大 %d 枚です",
+	"%s: only images can be cross-posted":    "%s: 他のネットワークに投稿できるのは画像だけです",
+	"%s is over the 1 MB image limit":        "%s は画像の上限 1 MB を超えています",
+	"failed to log in: %w":                   "ログインできませんでした: %w",
+	"failed to post: %w":                     "投稿できませんでした: %w",
+	"failed to upload %s: %w":                "%s をアップロードできませんでした: %w",
+
+	// Bookmarks
+	"failed to fetch bookmarks: %w":                                  "ブックマークを取得できませんでした: %w",
+	"failed to bookmark tweet: %w":                                   "ツイートをブックマークできませんでした: %w",
+	"failed to remove bookmark: %w":                                  "ブックマークを削除できませんでした: %w",
+	"Usage: clix bookmark [list|add <id|url>...|remove <id|url>...]": "使い方: clix bookmark [list|add <id|url>...|remove <id|url>...]",
+	"unknown bookmark action %q":                                     "不明な bookmark の操作 %q",
+	"number of bookmarks to show, up to 800":                         "表示するブックマークの数(最大 800)",
+	"open the nth bookmark of the list in the browser":               "一覧の n 番目のブックマークをブラウザで開く",
+	"--open must be a position in the list, starting at 1":           "--open には 1 から始まる一覧の位置を指定してください",
+	"there are only %d bookmarks":                                    "ブックマークは %d 件しかありません",
+	"Could not open a browser:":                                      "ブラウザを開けませんでした:",
+	"No bookmarks.":                                                  "ブックマークはありません。",
+
+	// Calendar
+	"that would be in the past":                                   "それは過去の日時になります",
+	"%s is no longer scheduled":                                   "%s はもう予約されていません",
+	"+%d more":                                                    "ほか %d 件",
+	"show the month instead of the week":                          "週ではなく月を表示する",
+	"show the week or month of this day (default today)":          "この日を含む週または月を表示する(既定は今日)",
+	"browse the calendar full-screen and move posts between days": "カレンダーを全画面で表示し、投稿を別の日に移す",
+	"clix calendar --tui needs a terminal":                        "clix calendar --tui には端末が必要です",
+	"%s %d scheduled posts are within %d minutes of another on the same account.\n": "%s 予約のうち %d 件が、同じアカウントの別の予約と %d 分以内です。\n",
+	"1 day with nothing planned.":     "予定のない日が 1 日あります。",
+	"%d days with nothing planned.\n": "予定のない日が %d 日あります。\n",
+	"Draft %s has no day; plan it with 'clix draft edit %s --plan YYYY-MM-DD'.\n":         "下書き %s に日付がありません。'clix draft edit %s --plan YYYY-MM-DD' で予定を入れてください。\n",
+	"%d drafts have no day; plan them with 'clix draft edit <name> --plan YYYY-MM-DD'.\n": "日付のない下書きが %d 件あります。'clix draft edit <名前> --plan YYYY-MM-DD' で予定を入れてください。\n",
+	"Moved %s %s to %s": "%s %s を %s に移しました",
+	"Week of %s":        "%s の週",
+	"calendar [--month] [--from YYYY-MM-DD] [--tui]  (scheduled posts and planned drafts by day)": "calendar [--month] [--from YYYY-MM-DD] [--tui]  (日ごとの予約投稿と予定の下書き)",
+	"←→↑↓ day · tab item · H/L/K/J move item a day or week · w week/month · t today · q quit":     "←→↑↓ 日 · tab 項目 · H/L/K/J 項目を1日/1週間移動 · w 週/月 · t 今日 · q 終了",
+
+	// Followers
+	"the most followers to fetch": "取得するフォロワーの最大数",
+	"also POST each follow and unfollow as a hook event to this URL (repeatable)":                                                      "フォローとフォロー解除をそれぞれフックのイベントとしてこの URL にも POST する(複数指定可)",
+	"Recorded %d followers; the next snapshot shows who came and went.\n":                                                              "%d 人のフォロワーを記録しました。次のスナップショットで増減がわかります。\n",
+	"compare with the last snapshot taken before this date, time or duration ago":                                                      "この日時(または期間前)より前の最後のスナップショットと比べる",
+	"a diff needs two snapshots of %q's followers, and there are %d; take them with 'clix followers snapshot'":                         "差分には %q のフォロワーのスナップショットが 2 つ必要ですが、%d つしかありません。'clix followers snapshot' で取ってください",
+	"From %s to %s: %d to %d followers, %d new and %d gone.\n":                                                                         "%s から %s まで: フォロワー %d 人 → %d 人、新規 %d 人、解除 %d 人。\n",
+	"Warning: a snapshot stopped at --count, so some of those gone may only have been left out":                                        "警告: スナップショットが --count で打ち切られたため、解除に見える人の一部は取得から漏れただけかもしれません",
+	"followers snapshot [--count 10000] [--webhook url]  (records your followers and shows who came and went since the last snapshot)": "followers snapshot [--count 10000] [--webhook url]  (フォロワーを記録し、前回のスナップショット以降の増減を表示します)",
+	"followers diff [--since 7d]  (who followed and unfollowed between the last two snapshots, or since the snapshot before --since)":  "followers diff [--since 7d]  (直近2つのスナップショット間、または --since より前のスナップショット以降にフォロー・フォロー解除した人)",
+
+	// Client
+	"Warning: could not post queued tweets:":   "警告: キューのツイートを投稿できませんでした:",
+	"Warning: could not save the rate limits:": "警告: レート制限を保存できませんでした:",
+
+	// Clipboard
+	"no clipboard tool found; install wl-clipboard, xclip or xsel": "クリップボードのツールが見つかりません。wl-clipboard、xclip、xsel のいずれかをインストールしてください",
+	"failed to read the clipboard with %s: %w":                     "%s でクリップボードを読めませんでした: %w",
+	"failed to set the clipboard with %s: %w":                      "%s でクリップボードに書き込めませんでした: %w",
+	"Warning: could not copy the link:":                            "警告: リンクをコピーできませんでした:",
+	"Copied":                                                       "コピーしました",
+
+	// Completion
+	"unsupported shell %q, expected bash, zsh, fish or powershell": "対応していないシェル %q です。bash、zsh、fish、powershell のいずれかを指定してください",
+
+	// Config
+	"$%s is not set; credentials from the environment need all of $CLIX_CONSUMER_KEY, $CLIX_CONSUMER_SECRET, $CLIX_ACCESS_TOKEN and $CLIX_ACCESS_SECRET": "$%s が設定されていません。環境変数の認証情報には $CLIX_CONSUMER_KEY、$CLIX_CONSUMER_SECRET、$CLIX_ACCESS_TOKEN、$CLIX_ACCESS_SECRET がすべて必要です",
+	"Error getting home directory:":                                                       "ホームディレクトリを取得できませんでした:",
+	"unknown account %q (see 'clix accounts list')":                                       "不明なアカウント %q('clix accounts list' を参照)",
+	"--confirm and --no-confirm cannot be combined":                                       "--confirm と --no-confirm は同時に使えません",
+	"configuration for account %q is incomplete; run 'clix login' or 'clix config reset'": "アカウント %q の設定が不完全です。'clix login' か 'clix config reset' を実行してください",
+	"failed to create config directory: %w":                                               "設定ディレクトリを作成できませんでした: %w",
+	"Configuration file not found. Creating a new one...":                                 "設定ファイルが見つかりません。新しく作成します...",
+	"Configuration for account %q is incomplete. Prompting for missing values...\n":       "アカウント %q の設定が不完全です。足りない値を入力してください...\n",
+	"failed to write config file: %w":                                                     "設定ファイルを書き込めませんでした: %w",
+	"%s keeps no credentials; encrypt the user config from another directory":             "%s には認証情報がありません。別のディレクトリからユーザー設定を暗号化してください",
+	"the config file is already encrypted":                                                "設定ファイルはすでに暗号化されています",
+	"New passphrase: ":                                                                    "新しいパスフレーズ: ",
+	"Config encrypted. clix will ask for the passphrase, or read it from $%s.\n":          "設定を暗号化しました。clix はパスフレーズを尋ねるか、$%s から読み取ります。\n",
+	"the config file is not encrypted":                                                    "設定ファイルは暗号化されていません",
+	"Config decrypted.":                                                                   "設定の暗号化を解除しました。",
+	"Config file:":                                                                        "設定ファイル:",
+	"Credentials file:":                                                                   "認証情報ファイル:",
+	"Encrypted: yes":                                                                      "暗号化: あり",
+	"Account:":                                                                            "アカウント:",
+	"Credentials: in the keychain":                                                        "認証情報: キーチェーン内",
+	"usage: clix config set <key> <value>":                                                "使い方: clix config set <キー> <値>",
+	"unknown config key %q":                                                               "不明な設定キー %q",
+	"unknown config action %q":                                                            "不明な config の操作 %q",
+	"config [show|path|trust|untrust|set <key> <value>|reset|encrypt|decrypt]  (applies to the selected account)": "config [show|path|trust|untrust|set <キー> <値>|reset|encrypt|decrypt]  (選択中のアカウントに適用されます)",
+	"Enter Consumer Key: ":    "Consumer Key を入力: ",
+	"Enter Consumer Secret: ": "Consumer Secret を入力: ",
+	"Enter Access Token: ":    "Access Token を入力: ",
+	"Enter Access Secret: ":   "Access Secret を入力: ",
+
+	// Config encryption
+	"Passphrase for %s: ": "%s のパスフレーズ: ",
+	"no terminal to read the config passphrase from; set $%s": "設定のパスフレーズを読む端末がありません。$%s を設定してください",
+	"empty passphrase":         "パスフレーズが空です",
+	"passphrases do not match": "パスフレーズが一致しません",
+
+	// Count
+	"tweet is %d characters, the limit is %d":           "ツイートは %d 文字で、上限は %d 文字です",
+	"%d characters over the limit":                      "上限を %d 文字超えています",
+	"count [text]  (reads stdin when no text is given)": "count [テキスト]  (テキストがなければ標準入力から読みます)",
+
+	// Delete
+	"tweet %s was not deleted":                                "ツイート %s は削除されませんでした",
+	"Warning: tweet was deleted but history was not updated:": "警告: ツイートは削除しましたが、履歴を更新できませんでした:",
+	"delete the most recent tweet posted with clix":           "clix で最後に投稿したツイートを削除する",
+	"failed to delete tweet: %w":                              "ツイートを削除できませんでした: %w",
+
+	// Destinations
+	"unknown destination %q, expected x, mastodon or bsky":                                  "不明な投稿先 %q です。x、mastodon、bsky のいずれかを指定してください",
+	"--to needs at least one destination":                                                   "--to には投稿先が 1 つ以上必要です",
+	"unknown destination %q":                                                                "不明な投稿先 %q",
+	"--reply-to, --quote, --poll and --split only work when posting to x alone":             "--reply-to、--quote、--poll、--split は x だけに投稿するときにしか使えません",
+	"\nWould post to %s.\n":                                                                 "\n%s に投稿します。\n",
+	"\nWould also post to %s.\n":                                                            "\n%s にも投稿します。\n",
+	"Some destinations were posted to; retrying with the same --to would post there again.": "一部の投稿先には投稿済みです。同じ --to で再試行すると、そこにもう一度投稿されます。",
+
+	// Direct messages
+	"no username given":                                         "ユーザー名が指定されていません",
+	"failed to look up @%s: %w":                                 "@%s を取得できませんでした: %w",
+	"@%s does not exist or is suspended":                        "@%s は存在しないか凍結されています",
+	"failed to fetch messages: %w":                              "メッセージを取得できませんでした: %w",
+	"Usage: clix dm [list|send @user <message>]":                "使い方: clix dm [list|send @ユーザー <メッセージ>]",
+	"unknown dm action %q":                                      "不明な dm の操作 %q",
+	"attach an image, GIF or video":                             "画像、GIF、動画を添付する",
+	"nothing to send":                                           "送るものがありません",
+	"failed to send message: %w":                                "メッセージを送れませんでした: %w",
+	"Message sent. [ID: %s]\n":                                  "メッセージを送りました。[ID: %s]\n",
+	"number of recent messages to look at, up to 100":           "確認する最近のメッセージの数(最大 100)",
+	"show every message instead of the latest per conversation": "会話ごとの最新だけでなくすべてのメッセージを表示する",
+	"No messages.":                                              "メッセージはありません。",
+	"dm send [--media path] @user [message]  (reads stdin when no message is given)": "dm send [--media パス] @ユーザー [メッセージ]  (メッセージがなければ標準入力から読みます)",
+
+	// Doctor
+	"credentials from the environment, no config file is read":        "環境変数の認証情報を使用中で、設定ファイルは読みません",
+	"%s does not exist; run 'clix login' or any command to create it": "%s がありません。'clix login' などのコマンドを実行すると作成されます",
+	"%s is readable by other users (%04o); run chmod 600 %s":          "%s は他のユーザーから読めます(%04o)。chmod 600 %s を実行してください",
+	"no unknown keys":                       "不明なキーはありません",
+	"default_account: %v":                   "default_account: %v",
+	"account %q has incomplete credentials": "アカウント %q の認証情報が不完全です",
+	"undo_delay: %v":                        "undo_delay: %v",
+	"thread: %v":                            "thread: %v",
+	"duplicates: %v":                        "duplicates: %v",
+	"review: %v":                            "review: %v",
+	"theme: %v":                             "theme: %v",
+	"posting_window: %v":                    "posting_window: %v",
+	"network: %v":                           "network: %v",
+	"lint: %v":                              "lint: %v",
+	"tweet: %v":                             "tweet: %v",
+	"tweet: accounts.%s: %v":                "tweet: accounts.%s: %v",
+	"shortener: needs a url and an api_key": "shortener: url と api_key が必要です",
+	"gif: needs a provider of giphy or tenor and an api_key":            "gif: provider(giphy か tenor)と api_key が必要です",
+	"mastodon: needs a server and an access_token":                      "mastodon: server と access_token が必要です",
+	"bluesky: needs a handle and an app_password":                       "bluesky: handle と app_password が必要です",
+	"hooks[%d]: needs a command or a url":                               "hooks[%d]: command か url が必要です",
+	"valid":                                                             "有効",
+	"account %q is incomplete; run 'clix login' or 'clix config reset'": "アカウント %q は不完全です。'clix login' か 'clix config reset' を実行してください",
+	"could not reach the API: %v":                                       "API に接続できませんでした: %v",
+	"rejected by the API (%s); check the keys of account %q":            "API に拒否されました(%s)。アカウント %q のキーを確認してください",
+	"not checked, the rate limit of /2/users/me is used up":             "未確認です。/2/users/me のレート制限を使い切っています",
+	"the API returned %s":                                               "API が %s を返しました",
+	"account %q is @%s (%s)":                                            "アカウント %q は @%s です(%s)",
+	"the API sent no rate limit headers":                                "API がレート制限のヘッダーを返しませんでした",
+	"%d of %d requests to /2/users/me left, resets at %s; %s":           "/2/users/me へのリクエストは残り %d / %d、%s にリセット。%s",
+	"only check the config, without calling the API":                    "API を呼ばずに設定だけを確認する",
+	"%d of %d checks failed":                                            "%d / %d 件のチェックに失敗しました",
+	"doctor [--offline]  (checks the config, credentials and rate limit of the selected account)": "doctor [--offline]  (選択中のアカウントの設定、認証情報、レート制限を確認します)",
+	"likely the Free tier":          "おそらく Free プラン",
+	"likely the Basic tier":         "おそらく Basic プラン",
+	"likely the Pro tier or higher": "おそらく Pro プラン以上",
+
+	// Dry run
+	"  reply to: the previous part": "  返信先: 前のパート",
+	"  reply to: %s\n":              "  返信先: %s\n",
+	"  quote: %s\n":                 "  引用: %s\n",
+	"  poll: %s (%d minutes)\n":     "  投票: %s(%d 分)\n",
+	"  media: %s (%s, %d KB)\n":     "  メディア: %s(%s、%d KB)\n",
+	"    alt: %s\n":                 "    代替テキスト: %s\n",
+
+	// Duplicates
+	"invalid window %q, expected a duration like 10m": "無効な期間 %q です。10m のような長さを指定してください",
+	"duplicates: %w":                     "duplicates: %w",
+	"the same tweet was posted %s as %s": "同じツイートが %s に %s として投稿されています",
+	"the same tweet was sent %s and may have gone out before the connection failed; check your profile": "同じツイートを %s に送信しており、接続が切れる前に投稿された可能性があります。プロフィールを確認してください",
+	"%w (use --allow-duplicate to post it anyway)":                                                      "%w(それでも投稿するには --allow-duplicate を使ってください)",
+	"Warning: could not record the post for the duplicate check:":                                       "警告: 重複チェック用に投稿を記録できませんでした:",
+
+	// Editor
+	"editor failed: %w": "エディタが失敗しました: %w",
+
+	// Emoji
+	"Usage: clix emoji search \"<words>|<emoji>\" [--count 20]": "使い方: clix emoji search \"<単語>|<絵文字>\" [--count 20]",
+	"the most emoji to list, 0 for all":                         "一覧にする絵文字の最大数(0 ですべて)",
+	"--count cannot be negative":                                "--count に負の値は使えません",
+	"No emoji found.":                                           "絵文字が見つかりませんでした。",
+	"emoji search \"<words>|<emoji>\" [--count 20]  (the :shortcodes: the repl and the emoji transform know)": "emoji search \"<単語>|<絵文字>\" [--count 20]  (repl と emoji 変換が認識する :ショートコード:)",
+
+	// Engagement
+	"failed to like tweet: %w":   "ツイートにいいねできませんでした: %w",
+	"failed to retweet: %w":      "リツイートできませんでした: %w",
+	"failed to unlike tweet: %w": "いいねを取り消せませんでした: %w",
+	"failed to undo retweet: %w": "リツイートを取り消せませんでした: %w",
+	"Liked %s.\n":                "%s にいいねしました。\n",
+	"Unliked %s.\n":              "%s のいいねを取り消しました。\n",
+	"Retweeted %s.\n":            "%s をリツイートしました。\n",
+	"Undid retweet of %s.\n":     "%s のリツイートを取り消しました。\n",
+	"Bookmarked %s.\n":           "%s をブックマークしました。\n",
+	"Removed bookmark of %s.\n":  "%s のブックマークを削除しました。\n",
+
+	// Export
+	"failed to fetch %s after %d: %w":       "%s を %d 件取得した後で失敗しました: %w",
+	"Fetched %d %s...\n":                    "%d 件の %s を取得しました...\n",
+	"--format for export must be one of %s": "export の --format は %s のいずれかです",
+	"Usage: clix export bookmarks|likes [--format json|csv|html] [--output file]": "使い方: clix export bookmarks|likes [--format json|csv|html] [--output ファイル]",
+	"unknown export action %q":              "不明な export の操作 %q",
+	"%w; wrote the %d fetched so far to %s": "%w。ここまでに取得した %d 件を %s に書き出しました",
+	"Exported %d %s to %s.\n":               "%d 件の %s を %s に書き出しました。\n",
+
+	// Follow
+	"failed to follow: %w":                  "フォローできませんでした: %w",
+	"failed to unfollow: %w":                "フォローを解除できませんでした: %w",
+	"failed to fetch followers: %w":         "フォロワーを取得できませんでした: %w",
+	"failed to fetch followed accounts: %w": "フォロー中のアカウントを取得できませんでした: %w",
+	"Follow request sent to @%s.\n":         "@%s にフォローリクエストを送りました。\n",
+	"Followed @%s.\n":                       "@%s をフォローしました。\n",
+	"Unfollowed @%s.\n":                     "@%s のフォローを解除しました。\n",
+	"number of accounts to show":            "表示するアカウントの数",
+	"No accounts.":                          "アカウントはありません。",
+
+	// GIFs
+	"--gif needs a \"gif\" section in the config with a provider and an api_key": "--gif には設定に provider と api_key を含む \"gif\" セクションが必要です",
+	"unknown GIF provider %q, expected giphy or tenor":                           "不明な GIF プロバイダ %q です。giphy か tenor を指定してください",
+	"GIF search failed: %s":      "GIF の検索に失敗しました: %s",
+	"failed to download GIF: %w": "GIF をダウンロードできませんでした: %w",
+	"failed to download GIF: %s": "GIF をダウンロードできませんでした: %s",
+	"the GIF is over %d MB":      "GIF が %d MB を超えています",
+	"no GIFs found for %q":       "%q の GIF は見つかりませんでした",
+	"--gif needs a terminal to choose on; pass --gif-pick n to take the nth result": "--gif で選ぶには端末が必要です。n 番目の結果を使うには --gif-pick n を指定してください",
+	"%2d. %s (%d KB)\n":           "%2d. %s(%d KB)\n",
+	"GIF to attach [1-%d]: ":      "添付する GIF [1-%d]: ",
+	"no GIF chosen":               "GIF が選ばれていません",
+	"pick a GIF between 1 and %d": "1 から %d の GIF を選んでください",
+	"failed to save GIF: %w":      "GIF を保存できませんでした: %w",
+
+	// Handles
+	"Run `clix handles refresh` to complete @mentions with Tab.":                                     "`clix handles refresh` を実行すると Tab で @メンションを補完できます。",
+	"The handles for @mention completion are over a month old; `clix handles refresh` updates them.": "@メンション補完用のハンドルが 1 か月以上前のものです。`clix handles refresh` で更新できます。",
+	"Usage: clix handles [list [prefix]|refresh]":                                                    "使い方: clix handles [list [接頭辞]|refresh]",
+	"unknown handles action %q":                                                                      "不明な handles の操作 %q",
+	"No handles cached; run `clix handles refresh`.":                                                 "キャッシュされたハンドルはありません。`clix handles refresh` を実行してください。",
+	"%d of %d handles, refreshed %s.\n":                                                              "%d / %d 件のハンドル(%s に更新)。\n",
+	"Cached %d handles for @mention completion.\n":                                                   "@メンション補完用に %d 件のハンドルをキャッシュしました。\n",
+	"handles list [prefix]  (the handles Tab completes after @ in the repl and tui)":                 "handles list [接頭辞]  (repl と tui で @ の後に Tab が補完するユーザー名)",
+	"handles refresh  (fetches the accounts you follow and recent mentions)":                         "handles refresh  (フォロー中のアカウントと最近のメンションを取得します)",
+
+	// Hooks
+	"Warning: %s hook failed: %v\n":        "警告: フック %s が失敗しました: %v\n",
+	"%s returned %s":                       "%s が %s を返しました",
+	"hook has neither a command nor a url": "フックに command も url もありません",
+
+	// Languages
+	"language %q is not available; use one of %s": "言語 %q は使えません。%s のいずれかを指定してください",
+
+	// Image preparation
+	"converted from %s to %s":                            "%s から %s に変換しました",
+	"%s could not convert %s: %s":                        "%s で %s を変換できませんでした: %s",
+	"%s: converting %s images needs one of %s installed": "%s: %s 画像の変換には %s のいずれかのインストールが必要です",
+	"not a JPEG file":                                    "JPEG ファイルではありません",
+	"corrupt JPEG file":                                  "JPEG ファイルが壊れています",
+	"removed EXIF metadata":                              "EXIF メタデータを削除しました",
+	", including the GPS location":                       "(GPS の位置情報を含む)",
+	"failed to decode JPEG: %w":                          "JPEG をデコードできませんでした: %w",
+	"; applied its rotation to the pixels":               "。回転をピクセルに適用しました",
+	"removed EXIF and text metadata":                     "EXIF とテキストのメタデータを削除しました",
+	"failed to decode image to shrink it: %w":            "縮小するための画像のデコードに失敗しました: %w",
+	"saved as JPEG (%d KB) to fit the %d MB limit":       "JPEG (%d KB) で保存しました(上限 %d MB に収めるため)",
+	"resized to %dx%d and %s":                            "%dx%d にリサイズし、%s",
+	"failed to save processed image: %w":                 "処理した画像を保存できませんでした: %w",
+
+	// Import
+	"failed to read CSV: %w":            "CSV を読み込めませんでした: %w",
+	"unknown CSV column %q":             "不明な CSV の列 %q",
+	"invalid JSON: %w":                  "無効な JSON: %w",
+	"failed to read JSONL: %w":          "JSONL を読み込めませんでした: %w",
+	"%w (use --force to import anyway)": "%w (それでもインポートするには --force を指定してください)",
+	"import [--force] <file.csv|file.jsonl>  (rows with a time are scheduled, the rest posted now; --dry-run only checks them)": "import [--force] <file.csv|file.jsonl>  (時刻のある行は予約し、残りはすぐ投稿します。--dry-run は確認のみ)",
+	"csv or jsonl (default from the file extension)":                                          "csv または jsonl (既定値はファイルの拡張子から)",
+	"post and schedule even outside the configured posting window or against the style rules": "設定された投稿時間帯の外やスタイルルールに反していても投稿・予約する",
+	"failed to open import file: %w":                                                          "インポートするファイルを開けませんでした: %w",
+	"cannot tell the format of %s; pass --type csv or --type jsonl":                           "%s の形式が判別できません。--type csv か --type jsonl を指定してください",
+	"%s has no rows":                        "%s に行がありません",
+	"line %d: %s\n":                         "%d 行目: %s\n",
+	"line %d: scheduled as %s for %s\n":     "%d 行目: %s として %s に予約しました\n",
+	"line %d: posted %s\n":                  "%d 行目: %s を投稿しました\n",
+	"%d of %d rows are valid.\n":            "%d / %d 行が有効です。\n",
+	"%d posted, %d scheduled, %d failed.\n": "投稿 %d 件、予約 %d 件、失敗 %d 件。\n",
+	"%d of %d rows failed":                  "%d / %d 行が失敗しました",
+
+	// Setup
+	"name of the account to set up (asked when not given)":                                         "設定するアカウントの名前(指定がなければ尋ねます)",
+	"where to keep the credentials: file or keychain (asked when not given)":                       "認証情報の保存先: file または keychain(指定がなければ尋ねます)",
+	"save the credentials without checking them with the API":                                      "API で確認せずに認証情報を保存する",
+	"--storage must be file or keychain":                                                           "--storage は file か keychain にしてください",
+	"init needs a terminal to ask on; use 'clix config set' or the $CLIX_* variables in scripts":   "init には質問するための端末が必要です。スクリプトでは 'clix config set' か $CLIX_* 変数を使ってください",
+	"credentials are set in the environment, which take the place of the config; unset them first": "環境変数に認証情報が設定されていて設定ファイルより優先されます。先に解除してください",
+	"clix posts with the keys of an app you create in the X developer portal:":                     "clix は X の開発者ポータルで作成したアプリのキーで投稿します:",
+	"Give the app Read and Write permissions under User authentication settings,":                  "User authentication settings でアプリに Read and Write 権限を与え、",
+	"then copy the API Key and Secret and generate the Access Token and Secret":                    "API Key と Secret をコピーし、Access Token と Secret を",
+	"under Keys and tokens. Secrets are not shown as you type or paste them.":                      "Keys and tokens で生成してください。シークレットは入力や貼り付けの際に表示されません。",
+	"Profile name [%s]: ":                                                         "プロファイル名 [%s]: ",
+	"Use letters, digits, dots, dashes and underscores.":                          "英字、数字、ドット、ハイフン、アンダースコアを使ってください。",
+	"profile name %q may only have letters, digits, dots, dashes and underscores": "プロファイル名 %q には英字、数字、ドット、ハイフン、アンダースコアしか使えません",
+	"Profile %q is set up already. Replace its credentials?":                      "プロファイル %q は設定済みです。認証情報を置き換えますか?",
+	"Checking the credentials... ":                                                "認証情報を確認しています... ",
+	"they work for @%s.\n":                                                        "@%s として使えます。\n",
+	"failed.":                                                                     "失敗しました。",
+	"Enter them again?":                                                           "もう一度入力しますか?",
+	"the credentials were not saved":                                              "認証情報は保存されませんでした",
+	"Keep the credentials in the config file or the system keychain? [file/keychain] ": "認証情報を設定ファイルとシステムのキーチェーンのどちらに保存しますか? [file/keychain] ",
+	"Saved profile %q in %s.\n": "プロファイル %q を %s に保存しました。\n",
+	"the keychain":              "キーチェーン",
+	"Use it with --account %s, or make it the default with 'clix accounts default %s'.\n":                                                           "--account %s で使うか、'clix accounts default %s' で既定にしてください。\n",
+	"%w\nX did not accept them; check they were copied whole and that the access token was generated after the app's permissions were last changed": "%w\nX に受け付けられませんでした。全体がコピーされているか、アプリの権限を最後に変更した後に access token を生成したか確認してください",
+	"%w\nX could not be reached; check the network, or run init with --no-verify":                                                                   "%w\nX に接続できませんでした。ネットワークを確認するか、--no-verify を付けて init を実行してください",
+	"API Key (consumer key)":           "API Key (consumer key)",
+	"API Key Secret (consumer secret)": "API Key Secret (consumer secret)",
+	"Access Token":                     "Access Token",
+	"Access Token Secret":              "Access Token Secret",
+
+	// Insights
+	"--auto needs the metrics of at least %d tweets and has %d; record them with 'clix stats track'":                                    "--auto には少なくとも %d 件のツイートの指標が必要ですが、%d 件しかありません。'clix stats track' で記録してください",
+	"no free hour in the next %d days; pick one with --at":                                                                              "今後 %d 日間に空いている時間がありません。--at で指定してください",
+	"Usage: clix insights times [--since 90d] [--metric engagement|impressions]":                                                        "使い方: clix insights times [--since 90d] [--metric engagement|impressions]",
+	"insights times [--since 90d] [--metric engagement|impressions]  (how your tweets did by the hour they went out, from stats track)": "insights times [--since 90d] [--metric engagement|impressions]  (stats track の記録から、投稿した時間帯ごとのツイートの反応)",
+	"only tweets posted after this date, time or duration ago":                                                                          "この日付・時刻・期間より後に投稿されたツイートのみ",
+	"what counts: engagement (likes, retweets, replies, quotes and bookmarks) or impressions":                                           "数える指標: engagement(いいね、リツイート、返信、引用、ブックマーク)または impressions",
+	"--metric must be one of %s": "--metric は %s のいずれかにしてください",
+	"No tracked tweets in that time; record their metrics with 'clix stats track'.":          "その期間に記録されたツイートはありません。'clix stats track' で指標を記録してください。",
+	"Average %s per tweet, over %d tweets: %.1f\n":                                           "ツイートあたりの平均 %s(%d 件): %.1f\n",
+	"%d. %s %02d:00  %.1f over %d tweets\n":                                                  "%d. %s %02d:00  %.1f(%d 件)\n",
+	"Warning: with fewer than %d tweets this says little; keep running 'clix stats track'\n": "警告: ツイートが %d 件未満ではあまり参考になりません。'clix stats track' を続けてください\n",
+	"    · no tweets  %s low to high\n":                                                      "    · ツイートなし  %s 低→高\n",
+
+	// Janitor
+	"Pausing until %s to stay under the delete rate limit.\n":                                                                 "削除のレート制限を超えないよう %s まで待機します。\n",
+	"janitor --older-than t|--all [--pattern regexp] [--from timeline|history] [--yes]  (--dry-run lists the tweets instead)": "janitor --older-than t|--all [--pattern regexp] [--from timeline|history] [--yes]  (--dry-run では代わりにツイートを一覧表示します)",
+	"only delete tweets older than this, e.g. 90d or 2024-01-01":                                                              "これより古いツイートだけを削除する(例: 90d、2024-01-01)",
+	"with --pattern, delete matching tweets however recent they are":                                                          "--pattern と組み合わせ、一致するツイートを新しさに関係なく削除する",
+	"only delete tweets matching this regular expression":                                                                     "この正規表現に一致するツイートだけを削除する",
+	"where to find tweets: timeline (your last ~3200 tweets) or history (those posted with clix)":                             "ツイートの探し先: timeline(直近約3200件)または history(clix で投稿したもの)",
+	"delete at most this many tweets, oldest first (0 for all)":                                                               "最大でこの件数まで古い順に削除する(0 ですべて)",
+	"--all and --older-than cannot be combined":                                                                               "--all と --older-than は同時に指定できません",
+	"--all needs --pattern; the janitor does not delete everything":                                                           "--all には --pattern が必要です。janitor はすべてを削除することはしません",
+	"give --older-than, or --all with --pattern to delete matching tweets of any age":                                         "--older-than を指定するか、--all と --pattern で古さを問わず一致するツイートを削除してください",
+	"--limit cannot be negative":                                                                                              "--limit に負の値は指定できません",
+	"invalid --pattern: %w":                                                                                                   "無効な --pattern: %w",
+	"unknown --from %q, expected timeline or history":                                                                         "不明な --from %q です。timeline か history を指定してください",
+	"Dry run, would delete %d tweets.\n":                                                                                      "ドライラン: %d 件のツイートを削除します。\n",
+	"No tweets to delete.":                                                                                                    "削除するツイートはありません。",
+	"%d tweets match.\n":                                                                                                      "%d 件のツイートが一致しました。\n",
+	"The API allows %d deletes every 15 minutes, so this takes about %s.\n":                                                   "API で削除できるのは15分ごとに %d 件なので、約 %s かかります。\n",
+	"[%d/%d] Failed to delete %s: %v\n":                                                                                       "[%d/%d] %s を削除できませんでした: %v\n",
+	"[%d/%d] Deleted %s from %s\n":                                                                                            "[%d/%d] %s(%s)を削除しました\n",
+	"Deleted %d of %d tweets.\n":                                                                                              "%d / %d 件のツイートを削除しました。\n",
+	"failed to delete %d of %d tweets":                                                                                        "%d / %d 件のツイートを削除できませんでした",
+
+	// Keychain
+	"the credentials of account %q are not in the keychain; run 'clix init --profile %s'": "アカウント %q の認証情報がキーチェーンにありません。'clix init --profile %s' を実行してください",
+
+	// Limits
+	"limits [--low]  (the rate limits the API last reported for the account)":        "limits [--low]  (API が最後に報告したアカウントのレート制限)",
+	"only endpoints with less than a tenth of their limit left":                      "残りが上限の10分の1未満のエンドポイントのみ",
+	"No rate limits recorded for %s yet; they are noted as commands call the API.\n": "%s のレート制限はまだ記録されていません。コマンドが API を呼ぶたびに記録されます。\n",
+
+	// Line editing
+	"Warning: failed to save the repl history:": "警告: repl の履歴を保存できませんでした:",
+	"  (%d more)":              "  (他 %d 件)",
+	"(%d more)":                "(他 %d 件)",
+	"(reverse-i-search)`%s': ": "(逆方向検索)`%s': ",
+
+	// Link cards
+	"is not a valid URL": "は有効な URL ではありません",
+	"does not load: %v":  "を読み込めません: %v",
+	"returns %s":         "が %s を返します",
+	"has no Twitter Card or OpenGraph tags, so it shows as a plain link": "に Twitter Card や OpenGraph のタグがないため、ただのリンクとして表示されます",
+	"has no title in its card tags":                                      "のカードのタグにタイトルがありません",
+	"has no image in its card tags":                                      "のカードのタグに画像がありません",
+	"card image %s":                                                      "カード画像 %s",
+	"▢ no image":                                                         "▢ 画像なし",
+	"▢ image %s":                                                         "▢ 画像 %s",
+	"▣ image":                                                            "▣ 画像",
+	"no title":                                                           "タイトルなし",
+	"link card for %s %s":                                                "%s のリンクカード %s",
+
+	// Lint
+	"--lint and --no-lint cannot be combined":                                      "--lint と --no-lint は同時に指定できません",
+	"refusing to post with lint warnings; fix them or pass --no-lint":              "lint の警告があるため投稿しません。修正するか --no-lint を指定してください",
+	"refusing to post with lint warnings; fix them or turn lint off in the config": "lint の警告があるため投稿しません。修正するか設定で lint をオフにしてください",
+	"Lint: part %d: %s\n": "Lint: パート %d: %s\n",
+	"Lint: %s\n":          "Lint: %s\n",
+	"double space":        "二重スペース",
+	"%d double spaces":    "二重スペース %d 個",
+	"unmatched %q":        "対応のない %q",
+	"unclosed %q":         "閉じていない %q",
+	"Warning: %s failed, skipping the spell check: %v\n": "警告: %s が失敗したため、スペルチェックを省略します: %v\n",
+	"possible misspelling: ":                             "つづりの誤りの可能性: ",
+	"spell checking needs hunspell or aspell installed; set no_spelling in the lint config to skip it": "スペルチェックには hunspell か aspell が必要です。省略するには lint の設定で no_spelling を指定してください",
+	"failed to read the lint dictionary: %w":                                                           "lint の辞書を読み込めませんでした: %w",
+	"link %s %s":                                                                                       "リンク %s %s",
+	"@%s is longer than %d characters, so it is not a handle":                                          "@%s は %d 文字を超えているため、ユーザー名ではありません",
+	"Warning: could not check the mentions:":                                                           "警告: メンションを確認できませんでした:",
+
+	// Lists
+	"failed to fetch your lists: %w":        "リストを取得できませんでした: %w",
+	"failed to look up list %s: %w":         "リスト %s を検索できませんでした: %w",
+	"you have no list named %q":             "%q という名前のリストはありません",
+	"failed to create list: %w":             "リストを作成できませんでした: %w",
+	"failed to fetch list members: %w":      "リストのメンバーを取得できませんでした: %w",
+	"failed to fetch the list timeline: %w": "リストのタイムラインを取得できませんでした: %w",
+	"Usage: clix list [list|create <name>|add <list> @user...|remove <list> @user...|show <list>|timeline <list>]": "使い方: clix list [list|create <名前>|add <リスト> @ユーザー...|remove <リスト> @ユーザー...|show <リスト>|timeline <リスト>]",
+	"A <list> is its ID, its URL or the name of one of your lists.":                                                "<リスト> には ID、URL、または自分のリストの名前を指定します。",
+	"unknown list action %q":            "不明な list の操作 %q",
+	"list list  (the lists you own)":    "list list  (自分が所有するリスト)",
+	"No lists.":                         "リストはありません。",
+	" (private)":                        "(非公開)",
+	"%-20s %-25s %4d members%s\n":       "%-20s %-25s %4d メンバー%s\n",
+	"what the list is about":            "リストの説明",
+	"only you can see the list":         "自分だけがリストを見られるようにする",
+	"Created list %s. [ID: %s]\n":       "リスト %s を作成しました。[ID: %s]\n",
+	"Added @%s to %s.\n":                "@%s を %s に追加しました。\n",
+	"Removed @%s from %s.\n":            "@%s を %s から削除しました。\n",
+	"failed to add @%s: %w":             "@%s を追加できませんでした: %w",
+	"failed to remove @%s: %w":          "@%s を削除できませんでした: %w",
+	"number of members to show":         "表示するメンバーの数",
+	", private":                         "、非公開",
+	"%s (%d members, %d followers%s)\n": "%s(メンバー %d、フォロワー %d%s)\n",
+	"number of tweets to show per page": "1ページに表示するツイートの数",
+
+	// Logging
+	"Warning: invalid log level %q, logging at info\n": "警告: 無効なログレベル %q のため info で記録します\n",
+	"Warning: not logging to a file:":                  "警告: ファイルにログを記録しません:",
+	"Warning: failed to rotate the log file:":          "警告: ログファイルをローテーションできませんでした:",
+
+	// Login
+	"token request failed: %s %s %s":                                     "トークンのリクエストに失敗しました: %s %s %s",
+	"failed to parse token response: %w":                                 "トークンの応答を解析できませんでした: %w",
+	"OAuth 2.0 token for account %q has expired; run 'clix login' again": "アカウント %q の OAuth 2.0 トークンの有効期限が切れました。もう一度 'clix login' を実行してください",
+	"failed to refresh OAuth 2.0 token (run 'clix login' again): %w":     "OAuth 2.0 トークンを更新できませんでした(もう一度 'clix login' を実行してください): %w",
+	"authorization response has an unexpected state":                     "認可の応答の state が想定外です",
+	"authorization denied: %s":                                           "認可が拒否されました: %s",
+	"authorization response has no code":                                 "認可の応答にコードがありません",
+	"clix is logged in. You can close this window.":                      "clix にログインしました。このウィンドウは閉じてかまいません。",
+	"timed out waiting for authorization":                                "認可の待機がタイムアウトしました",
+	"OAuth 2.0 client ID from the developer portal":                      "開発者ポータルの OAuth 2.0 クライアント ID",
+	"OAuth 2.0 client secret, for confidential clients":                  "OAuth 2.0 クライアントシークレット(コンフィデンシャルクライアント用)",
+	"local port for the redirect URI http://127.0.0.1:<port>/callback":   "リダイレクト URI http://127.0.0.1:<port>/callback のローカルポート",
+	"space separated scopes to request":                                  "要求するスコープ(スペース区切り)",
+	"print the authorization URL instead of opening a browser":           "ブラウザを開かずに認可 URL を表示する",
+	"Enter OAuth 2.0 Client ID: ":                                        "OAuth 2.0 クライアント ID を入力: ",
+	"a client ID is required":                                            "クライアント ID が必要です",
+	"failed to start callback server: %w":                                "コールバックサーバーを起動できませんでした: %w",
+	"Authorize clix in your browser:":                                    "ブラウザで clix を認可してください:",
+	"Could not open a browser, open the URL above manually.":             "ブラウザを開けませんでした。上の URL を手動で開いてください。",
+	"Logged in account %q.\n":                                            "アカウント %q でログインしました。\n",
+
+	// Mastodon
+	"mastodon is not configured; add \"mastodon\": {\"server\": \"https://mastodon.social\", \"access_token\": \"...\"} to %s": "mastodon が設定されていません。%s に \"mastodon\": {\"server\": \"https://mastodon.social\", \"access_token\": \"...\"} を追加してください",
+	"at most %d media files can be attached": "添付できるメディアファイルは最大 %d 個です",
+	"%s is still processing after 2 minutes": "%s は2分経ってもまだ処理中です",
+	"failed to check processing of %s: %w":   "%s の処理状況を確認できませんでした: %w",
+
+	// Media
+	"%s: unsupported media type %q":                       "%s: 対応していないメディアの種類 %q",
+	"%s is a directory":                                   "%s はディレクトリです",
+	"%s is %d MB, the limit for this media type is %d MB": "%s は %d MB です。この種類のメディアの上限は %d MB です",
+	"got %d --alt descriptions for %d media files":        "%d 個の --alt の説明に対してメディアファイルが %d 個あります",
+	"alt text for %s is %d characters, the limit is %d":   "%s の代替テキストは %d 文字です。上限は %d 文字です",
+	"a GIF or video must be the only attachment":          "GIF や動画は単独で添付する必要があります",
+	"at most %d images can be attached, got %d":           "添付できる画像は最大 %d 枚ですが、%d 枚あります",
+	"failed to set alt text for %s: %w":                   "%s の代替テキストを設定できませんでした: %w",
+	"Resuming the upload of %s after %d MB.\n":            "%s のアップロードを %d MB から再開します。\n",
+	"could not save the upload progress: %v":              "アップロードの進行状況を保存できませんでした: %v",
+	"media processing failed: %s":                         "メディアの処理に失敗しました: %s",
+	"media processing failed":                             "メディアの処理に失敗しました",
+
+	// Mentions
+	"failed to fetch mentions: %w":            "メンションを取得できませんでした: %w",
+	"only show mentions since the last check": "前回の確認以降のメンションだけを表示する",
+	"maximum number of mentions to show":      "表示するメンションの最大数",
+	"Warning: could not save mention state:":  "警告: メンションの状態を保存できませんでした:",
+	"No new mentions.":                        "新しいメンションはありません。",
+	"No mentions.":                            "メンションはありません。",
+
+	// Mock
+	"failed to read $%s: %w":                      "$%s を読み込めませんでした: %w",
+	"mock mode: not sending %s %s":                "モックモード: %s %s を送信しません",
+	"Warning: could not record the mock request:": "警告: モックのリクエストを記録できませんでした:",
+
+	// Moderation
+	"failed to fetch blocked accounts: %w":                        "ブロック中のアカウントを取得できませんでした: %w",
+	"failed to fetch muted accounts: %w":                          "ミュート中のアカウントを取得できませんでした: %w",
+	"failed to read handles: %w":                                  "ユーザー名を読み込めませんでした: %w",
+	"read handles from this file, one per line (\"-\" for stdin)": "このファイルからユーザー名を1行に1つずつ読む(\"-\" で標準入力)",
+	"failed to %s %d of %d accounts":                              "%s に失敗しました(%d / %d アカウント)",
+	"Usage: clix %s [list]\n":                                     "使い方: clix %s [list]\n",
+	"unknown %s action %q":                                        "不明な %s の操作 %q",
+	"Muted @%s.\n":                                                "@%s をミュートしました。\n",
+	"Failed to mute @%s: %v\n":                                    "@%s をミュートできませんでした: %v\n",
+	"Unmuted @%s.\n":                                              "@%s のミュートを解除しました。\n",
+	"Failed to unmute @%s: %v\n":                                  "@%s のミュートを解除できませんでした: %v\n",
+	"Blocked @%s.\n":                                              "@%s をブロックしました。\n",
+	"Failed to block @%s: %v\n":                                   "@%s をブロックできませんでした: %v\n",
+	"Unblocked @%s.\n":                                            "@%s のブロックを解除しました。\n",
+	"Failed to unblock @%s: %v\n":                                 "@%s のブロックを解除できませんでした: %v\n",
+
+	// Network
+	"invalid timeout %q, expected a duration like 1m": "無効なタイムアウト %q です。1m のような期間を指定してください",
+
+	// Open
+	"open the most recent tweet posted with clix": "clix で最後に投稿したツイートを開く",
+	"open the profile of the account":             "アカウントのプロフィールを開く",
+	"%q is not a username":                        "%q はユーザー名ではありません",
+
+	// Output
+	"invalid --format template: %w": "無効な --format テンプレート: %w",
+	"failed to format output: %w":   "出力を整形できませんでした: %w",
+
+	// Places
+	"coordinates %q are not \"lat,lon\", e.g. \"51.5072,-0.1276\"":                                                                  "座標 %q が \"lat,lon\" の形式ではありません(例: \"51.5072,-0.1276\")",
+	"coordinates %q are out of range: the latitude is within ±90 and the longitude within ±180":                                     "座標 %q が範囲外です。緯度は ±90、経度は ±180 以内です",
+	"%w (looking up places needs access to the v1.1 geo API, which not every tier has; tag tweets with a known --place-id instead)": "%w (場所の検索には v1.1 geo API へのアクセスが必要で、すべてのプランにあるわけではありません。代わりに既知の --place-id でタグ付けしてください)",
+	"failed to search places: %w":                                            "場所を検索できませんでした: %w",
+	"failed to find the place for --geo: %w":                                 "--geo の場所が見つかりませんでした: %w",
+	"X knows no place near %s":                                               "X は %s の近くに場所を知りません",
+	"Usage: clix places search [\"city\"] [--near \"lat,lon\"] [--count 10]": "使い方: clix places search [\"都市\"] [--near \"lat,lon\"] [--count 10]",
+	"places search [\"city\"] [--near \"lat,lon\"] [--count 10]  (place IDs to tag tweets with, with post --place-id)": "places search [\"都市\"] [--near \"lat,lon\"] [--count 10]  (post --place-id でツイートにタグ付けする場所の ID)",
+	"look around \"lat,lon\", without a name the places there":                                                         "\"lat,lon\" の周辺を探す(名前がなければその場所)",
+	"the most places to list, up to 20":                                                                                "一覧にする場所の最大数(20まで)",
+	"--count must be between 1 and 20":                                                                                 "--count は1から20の間にしてください",
+	"No places found.":                                                                                                 "場所が見つかりませんでした。",
+
+	// Post
+	"Warning: posting the links unshortened:":                                                        "警告: リンクを短縮せずに投稿します:",
+	"Warning: tweet was posted but not recorded in history:":                                         "警告: ツイートは投稿されましたが履歴に記録されませんでした:",
+	"failed to read stdin: %w":                                                                       "標準入力を読み込めませんでした: %w",
+	"%w (use --split to post it as a thread)":                                                        "%w (スレッドとして投稿するには --split を指定してください)",
+	"a poll cannot be combined with media or a quote":                                                "投票はメディアや引用と組み合わせられません",
+	"a poll needs %d to %d options, got %d":                                                          "投票には %d〜%d 個の選択肢が必要ですが、%d 個あります",
+	"poll duration must be between %d and %d minutes":                                                "投票の期間は %d〜%d 分にしてください",
+	"poll options cannot be empty":                                                                   "投票の選択肢は空にできません",
+	"poll option %q is %d characters, the limit is %d":                                               "選択肢 %q は %d 文字です。上限は %d 文字です",
+	"failed to post tweet: %w":                                                                       "ツイートを投稿できませんでした: %w",
+	"split tweet stopped after %d of %d parts: %w":                                                   "分割したツイートは %d / %d パートで止まりました: %w",
+	"the clipboard holds no tweet URL: %q":                                                           "クリップボードにツイートの URL がありません: %q",
+	"read the tweet from a file":                                                                     "ファイルからツイートを読む",
+	"compose the tweet in $EDITOR, starting from any text given":                                     "$EDITOR でツイートを作成する(指定したテキストから始める)",
+	"fill in a saved template instead of giving the text":                                            "テキストの代わりに保存したテンプレートを埋める",
+	"a template variable as key=value (repeatable)":                                                  "テンプレート変数を key=value で指定(繰り返し可)",
+	"attach an image, GIF or video (repeat for up to 4 images)":                                      "画像、GIF、動画を添付する(画像は繰り返して4枚まで)",
+	"leave the EXIF metadata, such as the GPS location, in attached images":                          "添付画像の EXIF メタデータ(GPS の位置情報など)を残す",
+	"search the configured GIF provider and attach the GIF chosen from the results":                  "設定した GIF プロバイダーで検索し、結果から選んだ GIF を添付する",
+	"with --gif, attach the nth result without asking":                                               "--gif と組み合わせ、n 番目の結果を尋ねずに添付する",
+	"quote the tweet whose URL is on the clipboard, taking all arguments as the comment":             "クリップボードの URL のツイートを引用し、引数すべてをコメントにする",
+	"post the text on the clipboard; with --edit, start from it":                                     "クリップボードのテキストを投稿する(--edit ではそこから始める)",
+	"copy the URL of the posted tweet to the clipboard":                                              "投稿したツイートの URL をクリップボードにコピーする",
+	"open the posted tweet in the browser":                                                           "投稿したツイートをブラウザで開く",
+	"post even if the same tweet was sent within the duplicates window":                              "重複チェックの期間内に同じツイートを送っていても投稿する",
+	"split an over-length tweet into a thread at word boundaries":                                    "長すぎるツイートを単語の区切りでスレッドに分割する",
+	"add a poll option (repeat for 2 to 4 options)":                                                  "投票の選択肢を追加する(繰り返して2〜4個)",
+	"minutes the poll stays open, up to 7 days":                                                      "投票を受け付ける分数(7日まで)",
+	"comma-separated networks to post to: x, mastodon, bsky":                                         "投稿先のネットワーク(カンマ区切り): x, mastodon, bsky",
+	"show a preview of the tweet and ask before posting it (default from config)":                    "投稿前にツイートのプレビューを表示して確認する(既定値は設定から)",
+	"post without asking, even with confirm_before_post in the config":                               "設定に confirm_before_post があっても尋ねずに投稿する",
+	"post the next line of this file, keeping track of the lines posted":                             "このファイルの次の行を投稿し、投稿済みの行を記録する",
+	"with --from-rotation, post a random line instead of the next one":                               "--from-rotation と組み合わせ、次の行ではなくランダムな行を投稿する",
+	"with --from-rotation, never post a line twice":                                                  "--from-rotation と組み合わせ、同じ行を二度投稿しない",
+	"hold the tweet this long so it can be undone, e.g. 10s (default from config, 0 for none)":       "取り消せるようにツイートをこの時間保留する(例: 10s。既定値は設定から、0 で保留なし)",
+	"check spelling, spacing, brackets, links and mentions before posting (default from config)":     "投稿前にスペル、空白、括弧、リンク、メンションを確認する(既定値は設定から)",
+	"skip the lint checks, even with lint in the config":                                             "設定に lint があっても lint のチェックを省略する",
+	"post the text as given, without the transforms from the config":                                 "設定の変換を行わず、テキストをそのまま投稿する",
+	"--random and --no-repeat need --from-rotation":                                                  "--random と --no-repeat には --from-rotation が必要です",
+	"--from-rotation cannot be combined with --template, --file, --edit or text arguments":           "--from-rotation は --template、--file、--edit、テキストの引数と組み合わせられません",
+	"--from-clipboard cannot be combined with --from-rotation, --template, --file or text arguments": "--from-clipboard は --from-rotation、--template、--file、テキストの引数と組み合わせられません",
+	"aborting post due to empty tweet":                                                               "ツイートが空のため投稿を中止します",
+	"--var needs --template":                                                                         "--var には --template が必要です",
+	"--template cannot be combined with --file or text arguments":                                    "--template は --file やテキストの引数と組み合わせられません",
+	"--file cannot be combined with --edit or text arguments":                                        "--file は --edit やテキストの引数と組み合わせられません",
+	"failed to read tweet file: %w":                                                                  "ツイートのファイルを読み込めませんでした: %w",
+	"--gif-pick needs --gif":                                                                         "--gif-pick には --gif が必要です",
+	"%w (use --force to post anyway)":                                                                "%w (それでも投稿するには --force を指定してください)",
+	"Write the tweet above. Saving an empty tweet aborts.\nTweets are limited to 280 characters; use --split to post longer text as a thread.": "上にツイートを書いてください。空のまま保存すると中止します。\nツイートは280文字までです。長いテキストをスレッドで投稿するには --split を使ってください。",
+	"post [flags] [text]  (reads stdin when no text is given)":                                                                                 "post [フラグ] [テキスト]  (テキストがなければ標準入力から読みます)",
+	"quote [flags] <tweet> [text]  (the tweet is an ID or URL; reads the comment from stdin when no text is given)":                            "quote [フラグ] <ツイート> [テキスト]  (ツイートは ID か URL。テキストがなければコメントを標準入力から読みます)",
+
+	// Preview
+	"↩ Replying to the previous part":      "↩ 前のパートへの返信",
+	"↩ Replying to":                        "↩ 返信先",
+	"%s %s (%d KB)":                        "%s %s (%d KB)",
+	" · alt: ":                             " · 代替テキスト: ",
+	" · no alt text":                       " · 代替テキストなし",
+	"Poll closes after %s":                 "投票は %s 後に締め切られます",
+	"No card: %s %v":                       "カードなし: %s %v",
+	"%d/%d characters":                     "%d/%d 文字",
+	"Tweet %d/%d · ":                       "ツイート %d/%d · ",
+	"%d day(s)":                            "%d 日",
+	"%d hour(s)":                           "%d 時間",
+	"%d minute(s)":                         "%d 分",
+	"--confirm needs a terminal to ask on": "--confirm には質問するための端末が必要です",
+
+	// Project config
+	"failed to open config file: %w":     "設定ファイルを開けませんでした: %w",
+	"failed to decrypt config file: %w":  "設定ファイルを復号できませんでした: %w",
+	"failed to parse config file %s: %w": "設定ファイル %s を解析できませんでした: %w",
+	"Warning: %s is not trusted, so only its formatting and check settings apply; run 'clix config trust' if you wrote it.\n": "警告: %s は信頼されていないため、書式とチェックの設定だけが適用されます。自分で書いたものなら 'clix config trust' を実行してください。\n",
+	"failed to read %s: %w":                                                  "%s を読み込めませんでした: %w",
+	"there is no %s in the current directory":                                "現在のディレクトリに %s がありません",
+	"No longer trusting %s; only its formatting and check settings apply.\n": "%s を信頼しなくなりました。書式とチェックの設定だけが適用されます。\n",
+	"Its hooks will run:":                                                    "次のフックが実行されます:",
+	"Trust %s?":                                                              "%s を信頼しますか?",
+	"Trusted %s until it changes.\n":                                         "%s を変更されるまで信頼します。\n",
+
+	// Queue
+	"Queued; it will be posted the next time clix reaches X, or run 'clix queue flush'.": "キューに入れました。次に clix が X に接続したときに投稿されます。'clix queue flush' を実行することもできます。",
+	"Warning: queued tweet %s was only partly posted: %v\n":                              "警告: キューのツイート %s は一部しか投稿されませんでした: %v\n",
+	"queued tweet %s: %w":                                 "キューのツイート %s: %w",
+	"Posted queued tweet %s as %s\n":                      "キューのツイート %s を %s として投稿しました\n",
+	"Usage: clix queue [list|flush|drop <key>...]":        "使い方: clix queue [list|flush|drop <キー>...]",
+	"unknown queue action %q":                             "不明な queue の操作 %q",
+	"The queue is empty.":                                 "キューは空です。",
+	"  last error: %s\n":                                  "  直近のエラー: %s\n",
+	"queue flush  (posts queued tweets of every account)": "queue flush  (すべてのアカウントのキューのツイートを投稿します)",
+	"account %q: %w":                                      "アカウント %q: %w",
+	"no queued tweet %s":                                  "キューにツイート %s はありません",
+
+	// Rate limits
+	"no answer within the timeout of %s: %w":                                                     "タイムアウト %s 以内に応答がありません: %w",
+	"cannot retry %s %s: request body is not replayable":                                         "%s %s を再試行できません: リクエストの本文を再送できません",
+	"Warning: rate limit reached for %s, it resets at %s (use --wait-on-limit to wait for it)\n": "警告: %s のレート制限に達しました。%s にリセットされます(待つには --wait-on-limit を指定してください)\n",
+	"Rate limited; waiting until %s.\n":                                                          "レート制限中です。%s まで待ちます。\n",
+
+	// Repl
+	"Alt text for %s (enter to skip): ": "%s の代替テキスト(Enter で省略): ",
+	"failed to read input: %w":          "入力を読み込めませんでした: %w",
+	"Usage: /suggest <draft text>":      "使い方: /suggest <下書きのテキスト>",
+
+	// Review
+	"no proposals directory; pass --dir or set dir in the review section of the config":                      "提案のディレクトリがありません。--dir を指定するか、設定の review セクションに dir を設定してください",
+	"trusted key of %q is not a public key printed by clix propose --key":                                    "%q の信頼済みキーが clix propose --key で表示された公開鍵ではありません",
+	"%s is not a trusted proposer; to trust them, add %q: %q to trusted in the review section of the config": "%s は信頼された提案者ではありません。信頼するには、設定の review セクションの trusted に %q: %q を追加してください",
+	"the signature does not match; the proposal was changed after %s signed it":                              "署名が一致しません。%s が署名した後に提案が変更されました",
+	"the proposal's media checksums do not match its media":                                                  "提案のメディアのチェックサムがメディアと一致しません",
+	"%s was changed after %s signed the proposal":                                                            "%s は %s が提案に署名した後に変更されました",
+	"this proposal was already approved by %s %s, as %s":                                                     "この提案は %s が %s にすでに承認しています(%s)",
+	"%s is approving this proposal since %s":                                                                 "%s が %s からこの提案を承認しています",
+	"the proposal was claimed by another approve while this one waited; not posted":                          "待機中に別の approve が提案を確保したため、投稿しませんでした",
+	"failed to read media: %w":                                                                               "メディアを読み込めませんでした: %w",
+	"%s does not hold a signing key":                                                                         "%s に署名鍵がありません",
+	"failed to copy media: %w":                                                                               "メディアをコピーできませんでした: %w",
+	"failed to save the proposal: %w":                                                                        "提案を保存できませんでした: %w",
+	"failed to read the proposal: %w":                                                                        "提案を読み込めませんでした: %w",
+	"%s is not a proposal: %w":                                                                               "%s は提案ではありません: %w",
+	"propose [flags] [text]  |  propose --key  (reads stdin when no text is given)":                          "propose [フラグ] [テキスト]  |  propose --key  (テキストがなければ標準入力から読みます)",
+	"the shared proposals directory (default from the review section of the config)":                         "共有の提案ディレクトリ(既定値は設定の review セクションから)",
+	"a note for the reviewer, which is not posted":                                                           "レビュー担当者へのメモ(投稿されません)",
+	"print the public key reviewers trust your proposals by":                                                 "レビュー担当者があなたの提案を信頼するための公開鍵を表示する",
+	"Dry run, nothing was proposed.":                                                                         "ドライランのため、何も提案していません。",
+	"failed to create the proposals directory: %w":                                                           "提案のディレクトリを作成できませんでした: %w",
+	"Proposed in": "提案先:",
+	"approve [--yes] [--force] <file>  |  approve [--dir d]  (lists the proposals waiting)":         "approve [--yes] [--force] <ファイル>  |  approve [--dir d]  (保留中の提案を一覧表示します)",
+	"with no file, the proposals directory to list (default from the review section of the config)": "ファイルがない場合に一覧表示する提案ディレクトリ(既定値は設定の review セクションから)",
+	"post without showing the proposal and asking":                                                  "提案を表示して確認せずに投稿する",
+	"No proposals waiting.":                                        "保留中の提案はありません。",
+	"already approved by %s %s":                                    "%s が %s に承認済みです",
+	"proposed for account %q, not %q; use --account %s":            "提案の対象はアカウント %q で、%q ではありません。--account %s を指定してください",
+	"Warning: could not release the claim on the proposal:":        "警告: 提案の確保を解除できませんでした:",
+	"refusing to approve without showing the proposal; pass --yes": "提案を表示せずには承認しません。--yes を指定してください",
+	"Proposed by %s %s\n":                                          "%s が %s に提案\n",
+	"Note:":                                                        "メモ:",
+	"Warning: posted, but could not record the approval:":          "警告: 投稿しましたが、承認を記録できませんでした:",
+	"Warning: posted, but could not mark the proposal approved:":   "警告: 投稿しましたが、提案を承認済みにできませんでした:",
+
+	// Rotation
+	"failed to read rotation file: %w":                                 "ローテーションのファイルを読み込めませんでした: %w",
+	"no lines to post in %s":                                           "%s に投稿する行がありません",
+	"every line of %s has been posted; drop --no-repeat to start over": "%s の行はすべて投稿済みです。最初からやり直すには --no-repeat を外してください",
+	"Warning: failed to save the rotation progress:":                   "警告: ローテーションの進行状況を保存できませんでした:",
+
+	// RPC
+	"query is required":        "query が必要です",
+	"count must be at least 1": "count は1以上にしてください",
+	"invalid request: %v":      "無効なリクエスト: %v",
+	"unknown method %q":        "不明なメソッド %q",
+	"rpc  (answers JSON requests on stdin, one per line, with JSON responses on stdout)": "rpc  (標準入力の1行ごとの JSON リクエストに、標準出力の JSON で応答します)",
+	"failed to read a request: %w": "リクエストを読み込めませんでした: %w",
+
+	// Safe mode
+	"invalid safe_mode pattern %q: %w": "無効な safe_mode のパターン %q: %w",
+	"matches `%s`":                     "`%s` に一致します",
+	"safe mode refused to post %q, which %s; edit the tweet or change safe_mode in the config": "セーフモードで %q の投稿を拒否しました(%s)。ツイートを編集するか、設定の safe_mode を変更してください",
+	"looks like an AWS access key":    "AWS のアクセスキーのようです",
+	"looks like a GitHub token":       "GitHub のトークンのようです",
+	"looks like a Slack token":        "Slack のトークンのようです",
+	"looks like a Google API key":     "Google の API キーのようです",
+	"looks like a secret API key":     "秘密の API キーのようです",
+	"looks like a private key":        "秘密鍵のようです",
+	"looks like an internal hostname": "内部のホスト名のようです",
+
+	// Saved searches
+	"what to call the search, for search run":                                      "search run で使う検索の名前",
+	"only tweets in this language (e.g. en)":                                       "この言語のツイートのみ(例: en)",
+	"only tweets from this user":                                                   "このユーザーのツイートのみ",
+	"replace a saved search of the same name":                                      "同じ名前の保存済み検索を置き換える",
+	"search name %q may only have letters, digits, dots, dashes and underscores":   "検索名 %q には英字、数字、ドット、ハイフン、アンダースコアしか使えません",
+	"there is a saved search %q already; use --force to replace it":                "保存済みの検索 %q がすでにあります。置き換えるには --force を指定してください",
+	"Saved search %q for %s; run it with 'clix search run %s'.\n":                  "検索 %q を %s として保存しました。'clix search run %s' で実行できます。\n",
+	"No saved searches; save one with 'clix search save \"<query>\" --name name'.": "保存済みの検索はありません。'clix search save \"<クエリ>\" --name 名前' で保存してください。",
+	"no saved search %q":         "保存済みの検索 %q はありません",
+	"Removed saved search %q.\n": "保存済みの検索 %q を削除しました。\n",
+	"search run [name...] [--notify] [--webhook url] [--count 50]  (new tweets for saved searches, all of them without a name; for cron)": "search run [名前...] [--notify] [--webhook url] [--count 50]  (保存済み検索の新しいツイート。名前がなければすべて。cron 向け)",
+	"show a desktop notification when there are new tweets":                                                                               "新しいツイートがあればデスクトップ通知を表示する",
+	"also POST each new tweet as a hook event to this URL (repeatable)":                                                                   "新しいツイートごとにフックのイベントとしてこの URL にも POST する(繰り返し可)",
+	"the most new tweets to report per search":                                                                                            "検索ごとに報告する新しいツイートの最大数",
+	"no saved searches; save one with 'clix search save \"<query>\" --name name'":                                                         "保存済みの検索はありません。'clix search save \"<クエリ>\" --name 名前' で保存してください",
+	"saved search %q: %w":                     "保存済みの検索 %q: %w",
+	"%d new tweets for %s":                    "%d 件の新しいツイート(%s)",
+	"A new tweet for %s":                      "新しいツイート(%s)",
+	"Warning: could not show a notification:": "警告: 通知を表示できませんでした:",
+	"No new tweets.":                          "新しいツイートはありません。",
+
+	// Search
+	"invalid date %q, expected YYYY-MM-DD or RFC 3339": "無効な日付 %q です。YYYY-MM-DD か RFC 3339 で指定してください",
+	"search failed: %w":                                     "検索に失敗しました: %w",
+	"maximum number of tweets to return":                    "返すツイートの最大数",
+	"only tweets after this date (YYYY-MM-DD or RFC 3339)":  "この日付より後のツイートのみ(YYYY-MM-DD または RFC 3339)",
+	"only tweets before this date (YYYY-MM-DD or RFC 3339)": "この日付より前のツイートのみ(YYYY-MM-DD または RFC 3339)",
+	"No tweets found.":                                      "ツイートが見つかりませんでした。",
+
+	// Serve
+	"invalid parameters: %w": "無効なパラメーター: %w",
+	"at is required":         "at が必要です",
+	"serve [--listen 127.0.0.1:8787] [--print-token]  (an HTTP API for local tools to post through)": "serve [--listen 127.0.0.1:8787] [--print-token]  (ローカルのツールが投稿に使う HTTP API)",
+	"address to listen on":                                                          "待ち受けるアドレス",
+	"allow a --listen address other machines can reach":                             "他のマシンから到達できる --listen のアドレスを許可する",
+	"print the bearer token clients send and exit":                                  "クライアントが送る Bearer トークンを表示して終了する",
+	"%s can be reached from other machines; listen on 127.0.0.1 or pass --public":   "%s は他のマシンから到達できます。127.0.0.1 で待ち受けるか --public を指定してください",
+	"Serving the clix API for %s on http://%s (token: clix serve --print-token).\n": "%s の clix API を http://%s で提供しています(トークン: clix serve --print-token)。\n",
+
+	// Scheduler service
+	"no systemd here; run 'clix scheduler run --once' from cron instead":                                                        "systemd がありません。代わりに cron から 'clix scheduler run --once' を実行してください",
+	"installing the scheduler is not supported on %s; run 'clix scheduler run --once' from the system's task scheduler instead": "%s ではスケジューラーをインストールできません。代わりにシステムのタスクスケジューラーから 'clix scheduler run --once' を実行してください",
+	"Would run:": "実行するコマンド:",
+	"how often the scheduler checks for due posts":                        "スケジューラーが予定時刻の投稿を確認する間隔",
+	"replace a scheduler service that is installed already":               "インストール済みのスケジューラーのサービスを置き換える",
+	"--interval must be at least 1s":                                      "--interval は1秒以上にしてください",
+	"the scheduler is installed already at %s; use --force to replace it": "スケジューラーは %s にインストール済みです。置き換えるには --force を指定してください",
+	"Would write %s:\n\n%s\n":                                             "%s に書き込む内容:\n\n%s\n",
+	"failed to write %s: %w":                                              "%s に書き込めませんでした: %w",
+	"Installed the scheduler as a %s service in %s, checking every %s.\n": "スケジューラーを %s のサービスとして %s にインストールしました。%s ごとに確認します。\n",
+	"Warning: the service needs %s, which it cannot ask for; put them in %s as NAME=value lines, readable by you alone\n": "警告: サービスには %s が必要ですが、尋ねることができません。%s に NAME=value の行として書き、自分だけが読めるようにしてください\n",
+	"Warning: the service needs %s, which it cannot ask for; give them to it with 'launchctl setenv'\n":                   "警告: サービスには %s が必要ですが、尋ねることができません。'launchctl setenv' で渡してください\n",
+	"%q cannot be written into a systemd unit":                                          "%q は systemd ユニットに書き込めません",
+	"To keep it running while you are logged out, run 'loginctl enable-linger'.":        "ログアウト中も動かし続けるには 'loginctl enable-linger' を実行してください。",
+	"See it with 'clix scheduler status' and 'clix scheduler logs'.":                    "'clix scheduler status' と 'clix scheduler logs' で確認できます。",
+	"the scheduler is not installed":                                                    "スケジューラーはインストールされていません",
+	"Would remove":                                                                      "削除するもの:",
+	"Uninstalled the scheduler; scheduled posts are kept for when it runs again.":       "スケジューラーをアンインストールしました。予約投稿は次に動かすときのために残してあります。",
+	"The scheduler is not installed; set it up with 'clix scheduler install'.":          "スケジューラーはインストールされていません。'clix scheduler install' で設定してください。",
+	"The scheduler is running (%s, %s).\n":                                              "スケジューラーは動作中です(%s、%s)。\n",
+	"The scheduler is installed but not running (%s, %s); see 'clix scheduler logs'.\n": "スケジューラーはインストール済みですが動作していません(%s、%s)。'clix scheduler logs' を確認してください。\n",
+	"1 post scheduled, due %s.\n":                                                       "予約投稿 1 件、予定 %s。\n",
+	"%d posts scheduled, the next due %s.\n":                                            "予約投稿 %d 件、次は %s。\n",
+	"the scheduler is not running":                                                      "スケジューラーは動作していません",
+	"how many of the last lines to show":                                                "表示する末尾の行数",
+	"keep showing new lines as they are written":                                        "書き込まれた新しい行を表示し続ける",
+	"the scheduler has not written %s yet":                                              "スケジューラーはまだ %s を書き込んでいません",
+	"failed to show the scheduler's logs: %w":                                           "スケジューラーのログを表示できませんでした: %w",
+
+	// Shortener
+	"the shortener config needs a url and an api_key": "短縮サービスの設定には url と api_key が必要です",
+	"failed to shorten %s: %w":                        "%s を短縮できませんでした: %w",
+	"failed to shorten %s: %s %s":                     "%s を短縮できませんでした: %s %s",
+	"unexpected response from the shortener for %s":   "%s に対する短縮サービスの応答が想定外です",
+
+	// Show
+	"failed to fetch tweet %s: %w": "ツイート %s を取得できませんでした: %w",
+	"Warning: the thread is older than 7 days and search only reaches that far back, so replies may be missing": "警告: スレッドが7日より古く、検索はそこまでしか遡れないため、返信が欠けている可能性があります",
+	"show the whole conversation the tweet is part of, in order":                                                "ツイートを含む会話全体を順に表示する",
+	"with --thread, the most replies to show":                                                                   "--thread と組み合わせ、表示する返信の最大数",
+
+	// Snapshots
+	"stats track [--since 30d] [--count 100]  (records the metrics of your recent tweets; run it from cron)": "stats track [--since 30d] [--count 100]  (最近のツイートの指標を記録します。cron から実行してください)",
+	"track your tweets posted after this date, time or duration ago":                                         "この日付・時刻・期間より後に投稿したツイートを記録する",
+	"the most tweets to track":                                        "記録するツイートの最大数",
+	"Recorded the metrics of %d tweets.\n":                            "%d 件のツイートの指標を記録しました。\n",
+	"report snapshots taken after this date, time or duration ago":    "この日付・時刻・期間より後に取ったスナップショットを報告する",
+	"file to write with --format, - for stdout (the default)":         "--format で書き込むファイル。- で標準出力(既定)",
+	"--format for stats report must be one of %s":                     "stats report の --format は %s のいずれかにしてください",
+	"No snapshots in that time; record them with 'clix stats track'.": "その期間のスナップショットはありません。'clix stats track' で記録してください。",
+
+	// State
+	"unknown $%s %q, expected %s": "不明な $%s %q です。%s を指定してください",
+
+	// Stats
+	"failed to fetch tweet metrics: %w": "ツイートの指標を取得できませんでした: %w",
+	"failed to fetch tweet metrics: %s": "ツイートの指標を取得できませんでした: %s",
+	"Warning: no metrics for %s: %s\n":  "警告: %s の指標がありません: %s\n",
+	"Total (%d tweets)":                 "合計(%d 件)",
+	"impressions %s\n":                  "インプレッション %s\n",
+	"likes       %s\n":                  "いいね           %s\n",
+	"retweets    %s\n":                  "リツイート       %s\n",
+	"show your latest tweet":            "最新のツイートを表示する",
+	"show your tweets posted after this date, time or duration ago, e.g. 7d": "この日付・時刻・期間より後に投稿したツイートを表示する(例: 7d)",
+	"with --since, the most tweets to show":                                  "--since と組み合わせ、表示するツイートの最大数",
+	"at most 100 tweets at a time":                                           "一度に100件まで",
+	"No tweets.":                                                             "ツイートはありません。",
+
+	// Stream
+	"the filtered stream needs the app's consumer key and secret (see 'clix config set')": "フィルターストリームにはアプリの consumer key と secret が必要です('clix config set' を参照)",
+	"failed to get an app token: %w":    "アプリのトークンを取得できませんでした: %w",
+	"failed to list stream rules: %w":   "ストリームのルールを一覧できませんでした: %w",
+	"failed to add stream rules: %w":    "ストリームのルールを追加できませんでした: %w",
+	"invalid stream rule: %s":           "無効なストリームのルール: %s",
+	"failed to delete stream rules: %w": "ストリームのルールを削除できませんでした: %w",
+	"failed to delete stream rules: %s": "ストリームのルールを削除できませんでした: %s",
+	"stream disconnected":               "ストリームが切断されました",
+	"stream [--rule \"<query>\"]... [--count n]  (or: stream rules list|add|delete)":     "stream [--rule \"<クエリ>\"]... [--count n]  (または: stream rules list|add|delete)",
+	"a filter rule, for this run only (repeatable); without it the saved rules are used": "今回の実行だけのフィルタールール(繰り返し可)。なければ保存済みのルールを使う",
+	"stop after this many tweets":                                                              "この件数のツイートで停止する",
+	"no stream rules; pass --rule or add one with 'clix stream rules add'":                     "ストリームのルールがありません。--rule を指定するか 'clix stream rules add' で追加してください",
+	"Streaming; press Ctrl-C to stop.":                                                         "ストリーミング中です。Ctrl-C で停止します。",
+	"Stream error: %v; reconnecting in %s\n":                                                   "ストリームのエラー: %v。%s 後に再接続します\n",
+	"Usage: clix stream rules [list|add [--tag t] \"<query>\"...|delete <id>...|delete --all]": "使い方: clix stream rules [list|add [--tag t] \"<クエリ>\"...|delete <id>...|delete --all]",
+	"unknown stream rules action %q":                                                           "不明な stream rules の操作 %q",
+	"No stream rules.":                                                                         "ストリームのルールはありません。",
+	"a label for the rules, shown by rules list":                                               "ルールのラベル(rules list で表示)",
+	"Added rule %s: %s\n":                                                                      "ルール %s を追加しました: %s\n",
+	"delete every rule":                                                                        "すべてのルールを削除する",
+	"Deleted %d rule(s).\n":                                                                    "%d 件のルールを削除しました。\n",
+
+	// Style
+	"posts from the %q template need %s": "テンプレート %q の投稿には %s が必要です",
+	"Style: part %d: %s\n":               "スタイル: パート %d: %s\n",
+	"Style: %s\n":                        "スタイル: %s\n",
+	"refusing to post against the style rules; fix the text or pass --force": "スタイルルールに反するため投稿しません。テキストを修正するか --force を指定してください",
+	"%q is a banned word":                   "%q は禁止語です",
+	"%d hashtags, more than the %d allowed": "ハッシュタグが %d 個あり、許容の %d 個を超えています",
+	"%q is in ALL CAPS":                     "%q がすべて大文字です",
+
+	// Suggestions
+	"the draft has no words to search for":                                  "下書きに検索する単語がありません",
+	"Warning: could not count tweets for the reach:":                        "警告: リーチのためのツイート数を数えられませんでした:",
+	"No tags found in recent tweets like this one.":                         "似た最近のツイートにタグは見つかりませんでした。",
+	"suggest [flags] \"<draft text>\"  (reads stdin when no text is given)": "suggest [フラグ] \"<下書きのテキスト>\"  (テキストがなければ標準入力から読みます)",
+	"maximum number of tags to suggest":                                     "提案するタグの最大数",
+	"only look at tweets in this language (e.g. en)":                        "この言語のツイートだけを見る(例: en)",
+
+	// Templates
+	"invalid template: %w":                                                        "無効なテンプレート: %w",
+	"invalid --var %q, expected key=value":                                        "無効な --var %q です。key=value で指定してください",
+	"no template named %q (see 'clix template list')":                             "%q という名前のテンプレートはありません('clix template list' を参照)",
+	"template %q needs --var for %s":                                              "テンプレート %q には %s の --var が必要です",
+	"Warning: template %q does not use --var %s\n":                                "警告: テンプレート %q は --var %s を使いません\n",
+	"failed to fill in template: %w":                                              "テンプレートを埋められませんでした: %w",
+	"Usage: clix template [list|save <name> <text>|show <name>|delete <name>...]": "使い方: clix template [list|save <名前> <テキスト>|show <名前>|delete <名前>...]",
+	"unknown template action %q":                                                  "不明な template の操作 %q",
+	"template save <name> [text]  (reads stdin when no text is given)":            "template save <名前> [テキスト]  (テキストがなければ標準入力から読みます)",
+	"empty template": "テンプレートが空です",
+	"Template %q saved, with variables %s.\n": "テンプレート %q を保存しました。変数: %s。\n",
+	"Template %q saved.\n":                    "テンプレート %q を保存しました。\n",
+	"No templates.":                           "テンプレートはありません。",
+	"no template named %q":                    "%q という名前のテンプレートはありません",
+	"\nVariables:":                            "\n変数:",
+
+	// Themes
+	"unknown theme %q, use one of %s":                     "不明なテーマ %q です。%s のいずれかを使ってください",
+	"%s: %q is not a color, expected 0 to 255 or #rrggbb": "%s: %q は色ではありません。0〜255 か #rrggbb で指定してください",
+
+	// Threads
+	"unknown thread numbering %q, expected prefix, suffix or none": "不明なスレッドの番号付け %q です。prefix、suffix、none のいずれかを指定してください",
+	"thread number_format %q has no {n}":                           "スレッドの number_format %q に {n} がありません",
+	"invalid thread delay %q, expected a duration like 5s":         "無効なスレッドの間隔 %q です。5s のような期間を指定してください",
+	"Enter each part of the thread; an empty line finishes.":       "スレッドの各パートを入力してください。空行で終了します。",
+	"part %d: ":                           "パート %d: ",
+	"posted %d parts, part %d failed: %v": "%d パートを投稿し、パート %d で失敗しました: %v",
+	"thread [flags]  (prompts for parts when no --tweet, --file or --from-markdown is given)":   "thread [フラグ]  (--tweet、--file、--from-markdown がなければパートを尋ねます)",
+	"compile a markdown document into a thread, attaching its images":                           "Markdown の文書をスレッドにまとめ、画像を添付する",
+	"with --from-markdown, leave the EXIF metadata, such as the GPS location, in images":        "--from-markdown と組み合わせ、画像の EXIF メタデータ(GPS の位置情報など)を残す",
+	"skip parts before this one, e.g. after a partial failure":                                  "このパートより前を飛ばす(例: 途中で失敗した後)",
+	"hold the thread this long so it can be undone, e.g. 10s (default from config, 0 for none)": "取り消せるようにスレッドをこの時間保留する(例: 10s。既定値は設定から、0 で保留なし)",
+	"show the thread and ask before posting it (default from config)":                           "投稿前にスレッドを表示して確認する(既定値は設定から)",
+	"open the first tweet of the thread in the browser once it is posted":                       "投稿後にスレッドの最初のツイートをブラウザで開く",
+	"use only one of --tweet, --file and --from-markdown":                                       "--tweet、--file、--from-markdown のどれか1つだけを使ってください",
+	"failed to read markdown file: %w":                                                          "Markdown ファイルを読み込めませんでした: %w",
+	"--resume-at must be between 1 and %d":                                                      "--resume-at は1から %d の間にしてください",
+	"Posted %d of %d parts; part %d failed: %v\n":                                               "%d / %d パートを投稿しました。パート %d で失敗しました: %v\n",
+	"To resume later, run the same command with %s\n":                                           "後で再開するには、同じコマンドに %s を付けて実行してください\n",
+
+	// Times
+	"%s ago":   "%s前",
+	"in %s":    "%s後",
+	"just now": "たった今",
+
+	// Timeline
+	"failed to fetch timeline: %w":        "タイムラインを取得できませんでした: %w",
+	"only show tweets newer than this ID": "この ID より新しいツイートだけを表示する",
+
+	// Tracking
+	"%s and %d more":               "%s ほか %d 人",
+	"1 new reply to %q":            "%q への新しい返信 1 件",
+	"1 new reply to %q from %s":    "%q への新しい返信 1 件(%s)",
+	"%d new replies to %q":         "新しい返信 %d 件(%q)",
+	"%d new replies to %q from %s": "新しい返信 %d 件(%q、%s)",
+	"Usage: clix track <id|url>...  |  track check [<id|url>...] [--notify] [--webhook url] [--watch]  |  track list":                                "使い方: clix track <id|url>...  |  track check [<id|url>...] [--notify] [--webhook url] [--watch]  |  track list",
+	"track <id|url>...  (watch replies to your tweets; track check shows the new ones)":                                                              "track <id|url>...  (ツイートへの返信を追跡します。track check で新しいものを表示します)",
+	"Warning: %s is not a tweet of yours; tracking it anyway\n":                                                                                      "警告: %s は自分のツイートではありませんが、追跡します\n",
+	"Tracking replies to %s (%d so far); 'clix track check' shows new ones.\n":                                                                       "%s への返信を追跡します(現在 %d 件)。'clix track check' で新しいものを表示します。\n",
+	"No tracked tweets; track one with 'clix track <id|url>'.":                                                                                       "追跡中のツイートはありません。'clix track <id|url>' で追跡してください。",
+	"%-20s %-10s %4d replies  checked %-10s %s\n":                                                                                                    "%-20s %-10s 返信 %4d  確認 %-10s %s\n",
+	"track check [<id|url>...] [--notify] [--webhook url] [--watch [--interval 5m]]  (new replies to the tracked tweets, all of them without an ID)": "track check [<id|url>...] [--notify] [--webhook url] [--watch [--interval 5m]]  (追跡中のツイートへの新しい返信。ID がなければすべて)",
+	"show a desktop notification when there are new replies":                                                                                         "新しい返信があればデスクトップ通知を表示する",
+	"also POST each new reply as a hook event to this URL (repeatable)":                                                                              "新しい返信ごとにフックのイベントとしてこの URL にも POST する(繰り返し可)",
+	"keep checking until interrupted":                                                                                                                "中断されるまで確認し続ける",
+	"with --watch, how often to check, at least 15s; a rate limit stretches it until the limit resets":                                               "--watch と組み合わせ、確認の間隔(15秒以上)。レート制限中はリセットまで延びる",
+	"--interval must be at least %s":                                                                                                                 "--interval は %s 以上にしてください",
+	"no tracked tweets; track one with 'clix track <id|url>'":                                                                                        "追跡中のツイートはありません。'clix track <id|url>' で追跡してください",
+	"tweet %s is not tracked": "ツイート %s は追跡されていません",
+	"%d tweets":               "%d 件のツイート",
+	"Watching replies to %s, checking every %s.\n": "%s への返信を監視しています。%s ごとに確認します。\n",
+	"Rate limited; next check at %s.\n":            "レート制限中です。次の確認は %s です。\n",
+	"replies to %s: %w":                            "%s への返信: %w",
+	"No new replies.":                              "新しい返信はありません。",
+	"stop tracking every tweet":                    "すべてのツイートの追跡をやめる",
+	"Stopped tracking %s.\n":                       "%s の追跡をやめました。\n",
+	"Stopped tracking %d tweets.\n":                "%d 件のツイートの追跡をやめました。\n",
+
+	// Transforms
+	"transforms: unknown type %q, use one of %s": "transforms: 不明な種類 %q です。%s のいずれかを使ってください",
+	"transforms: append_hashtags needs hashtags": "transforms: append_hashtags には hashtags が必要です",
+	"transforms: %q is not a hashtag":            "transforms: %q はハッシュタグではありません",
+
+	// Trends
+	"unknown place %q; use --woeid, or one of %s":                  "不明な場所 %q です。--woeid か %s のいずれかを使ってください",
+	"failed to fetch trends: %w":                                   "トレンドを取得できませんでした: %w",
+	"list the trends of this city or country":                      "この都市や国のトレンドを一覧表示する",
+	"list the trends of this where-on-earth ID":                    "この WOEID のトレンドを一覧表示する",
+	"number of trends to show, up to 50":                           "表示するトレンドの数(50まで)",
+	"open the search for the nth trend of the list in the browser": "一覧の n 番目のトレンドの検索をブラウザで開く",
+	"--place and --woeid cannot be combined":                       "--place と --woeid は同時に指定できません",
+	"--count must be between 1 and 50":                             "--count は1から50の間にしてください",
+	"--woeid must be positive":                                     "--woeid は正の数にしてください",
+	"there are only %d trends":                                     "トレンドは %d 件しかありません",
+	"No trends.":                                                   "トレンドはありません。",
+	"%3d. %-40s %d tweets\n":                                       "%3d. %-40s %d 件\n",
+
+	// Tweet IDs
+	"%q is not a tweet ID or URL": "%q はツイートの ID や URL ではありません",
+	"%q is not a tweet URL":       "%q はツイートの URL ではありません",
+
+	// Tweet settings
+	"unknown reply restriction %q, use one of %s":   "不明な返信の制限 %q です。%s のいずれかを使ってください",
+	"a place ID and coordinates cannot be combined": "場所の ID と座標は同時に指定できません",
+	"tweet: %w":            "tweet: %w",
+	"replies: %s":          "返信: %s",
+	"super followers only": "スーパーフォロワーのみ",
+	"place: nearest to %s": "場所: %s の最寄り",
+	"place: %s":            "場所: %s",
+	"who can reply: everyone, mentioned or following (default from config)":                           "返信できる人: everyone、mentioned、following(既定値は設定から)",
+	"show the tweet to your super followers alone; =false overrides the config":                       "スーパーフォロワーだけにツイートを表示する。=false で設定を上書き",
+	"tag the tweet with this place, from clix places search; none for no place (default from config)": "clix places search で得た場所をツイートにタグ付けする。none で場所なし(既定値は設定から)",
+	"tag the tweet with the place nearest to \"lat,lon\"":                                             "\"lat,lon\" の最寄りの場所をツイートにタグ付けする",
+
+	// Undo
+	"invalid undo delay %q, expected a duration like 10s":           "無効な取り消しの猶予 %q です。10s のような期間を指定してください",
+	"\r\u001b[KPosting in %s; press u to undo or enter to post now": "\r\u001b[K%s 後に投稿します。u で取り消し、Enter ですぐ投稿",
+
+	// Update
+	"failed to check for a new release: %w":                                         "新しいリリースを確認できませんでした: %w",
+	"%s is over %d MB":                                                              "%s は %d MB を超えています",
+	"release %s has no %s to verify the download with":                              "リリース %s にはダウンロードを検証する %s がありません",
+	"this build has an invalid release key":                                         "このビルドのリリース鍵は無効です",
+	"release %s is not signed":                                                      "リリース %s は署名されていません",
+	"the signature of %s does not match; not updating":                              "%s の署名が一致しないため、更新しません",
+	"the checksum of %s does not match; not updating":                               "%s のチェックサムが一致しないため、更新しません",
+	"%s has no checksum for %s":                                                     "%s に %s のチェックサムがありません",
+	"%s has no %s in it":                                                            "%s に %s が含まれていません",
+	"cannot write to %s; run the update with the permissions it was installed with": "%s に書き込めません。インストールしたときと同じ権限で更新してください",
+	"A new version of clix is available: %s (you have %s). Run 'clix update' to install it.\n":                             "clix の新しいバージョン %s があります(現在は %s)。'clix update' でインストールできます。\n",
+	"update [--check] [--force]  (replaces this binary with the latest release)":                                           "update [--check] [--force]  (このバイナリを最新のリリースに置き換えます)",
+	"only report whether a newer release is out":                                                                           "新しいリリースがあるかどうかだけを報告する",
+	"install the latest release even over a development build or a newer version":                                          "開発ビルドや新しいバージョンでも最新のリリースをインストールする",
+	"clix %s is out (you have %s): %s\n":                                                                                   "clix %s が公開されています(現在は %s): %s\n",
+	"clix %s is the latest release (you have %s).\n":                                                                       "clix %s が最新のリリースです(現在は %s)。\n",
+	"this is a development build; pass --force to replace it with %s":                                                      "これは開発ビルドです。%s に置き換えるには --force を指定してください",
+	"clix %s is up to date.\n":                                                                                             "clix %s は最新です。\n",
+	"release %s has no build for %s/%s":                                                                                    "リリース %s には %s/%s 向けのビルドがありません",
+	"this build has no release key to check the signature of %s with, so it cannot update itself; get the release from %s": "このビルドには %s の署名を確認するリリース鍵がないため、自動更新できません。%s からリリースを入手してください",
+	"Dry run, would install %s from %s.\n":                                                                                 "ドライラン: %s を %s からインストールします。\n",
+	"Downloading %s (%d KB)...\n":                                                                                          "%s をダウンロードしています(%d KB)...\n",
+	"failed to install the update: %w":                                                                                     "更新をインストールできませんでした: %w",
+	"Updated %s from %s to %s.\n":                                                                                          "%s を %s から %s に更新しました。\n",
+
+	// Upload
+	"chunk %d failed, retrying in %s":                    "チャンク %d が失敗しました。%s 後に再試行します",
+	"Uploading %s: %d%%\n":                               "%s をアップロード中: %d%%\n",
+	"\r\u001b[KUploading %s [%s%s] %3d%% %.1f/%.1f MB%s": "\r\u001b[K%s をアップロード中 [%s%s] %3d%% %.1f/%.1f MB%s",
+	"Upload:":            "アップロード:",
+	"Uploading %s: %s\n": "%s をアップロード中: %s\n",
+
+	// Users
+	"failed to fetch tweets of @%s: %w":           "@%s のツイートを取得できませんでした: %w",
+	"number of recent tweets to show, 0 for none": "表示する最近のツイートの数。0 で表示しない",
+	"%d following · %d followers · %d tweets\n":   "フォロー %d · フォロワー %d · ツイート %d\n",
+	"\nPinned:": "\n固定:",
+	"\nRecent:": "\n最近:",
+
+	// Watch
+	"notify-send is not installed":         "notify-send がインストールされていません",
+	"%s failed: %v %s":                     "%s に失敗しました: %v %s",
+	"Warning: could not save watch state:": "警告: watch の状態を保存できませんでした:",
+	"Usage: clix watch mentions [--interval 1m] [--no-notify] [--webhook url] [--once]": "使い方: clix watch mentions [--interval 1m] [--no-notify] [--webhook url] [--once]",
+	"unknown watch action %q": "不明な watch の操作 %q",
+	"watch mentions [--interval 1m] [--no-notify] [--webhook url] [--once]  (mentions and replies as they come in)": "watch mentions [--interval 1m] [--no-notify] [--webhook url] [--once]  (届いたメンションと返信)",
+	"how often to check, at least 15s; a rate limit stretches it until the limit resets":                            "確認の間隔(15秒以上)。レート制限中はリセットまで延びる",
+	"print new mentions without desktop notifications":                                                              "デスクトップ通知なしで新しいメンションを表示する",
+	"also POST each mention as a hook event to this URL (repeatable)":                                               "メンションごとにフックのイベントとしてこの URL にも POST する(繰り返し可)",
+	"check once and exit, for cron":                                                                                 "一度確認して終了する(cron 向け)",
+	"Watching mentions, checking every %s.\n":                                                                       "メンションを監視しています。%s ごとに確認します。\n",
+
+	// Posting window
+	"invalid time %q, expected HH:MM":                      "無効な時刻 %q です。HH:MM で指定してください",
+	"posting_window start: %w":                             "posting_window の start: %w",
+	"posting_window end: %w":                               "posting_window の end: %w",
+	"posting_window start and end must differ":             "posting_window の start と end は別の時刻にしてください",
+	"posting_window timezone: %w":                          "posting_window の timezone: %w",
+	"posting_window: unknown weekday %q":                   "posting_window: 不明な曜日 %q",
+	"posting_window never opens":                           "posting_window が一度も開きません",
+	"posting is restricted to %s; next allowed time is %s": "投稿できるのは %s のみです。次に投稿できるのは %s です",
+}
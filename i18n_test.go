@@ -0,0 +1,92 @@
+package clix
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// printfVerb matches the verbs of a format, which a translation has to
+// keep in the same order
+var printfVerb = regexp.MustCompile(`%[-+# 0-9.*]*[a-zA-Z%]`)
+
+func TestCatalogsHaveTheSameKeys(t *testing.T) {
+	for _, lang := range languages[1:] {
+		if catalogs[lang] == nil {
+			t.Errorf("language %q has no catalog", lang)
+		}
+	}
+	for lang, catalog := range catalogs {
+		for other, otherCatalog := range catalogs {
+			if lang == other {
+				continue
+			}
+			for key := range catalog {
+				if _, ok := otherCatalog[key]; !ok {
+					t.Errorf("%q is in the %s catalog but not the %s one", key, lang, other)
+				}
+			}
+		}
+	}
+}
+
+func TestTranslationsKeepTheVerbs(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for key, translated := range catalog {
+			want := printfVerb.FindAllString(key, -1)
+			if got := printfVerb.FindAllString(translated, -1); !slices.Equal(got, want) {
+				t.Errorf("%s: %q has the verbs %v, but %q has %v", lang, key, want, translated, got)
+			}
+		}
+	}
+}
+
+// TestMessagesAreTranslated checks every literal message given to tr and
+// trf is in the catalogs. Messages with no words, such as "%s: %v", need
+// no translation.
+func TestMessagesAreTranslated(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	words := regexp.MustCompile(`[A-Za-z]{2,}`)
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			if fn, ok := call.Fun.(*ast.Ident); !ok || (fn.Name != "tr" && fn.Name != "trf") {
+				return true
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			message, err := strconv.Unquote(lit.Value)
+			if err != nil || !words.MatchString(printfVerb.ReplaceAllString(message, "")) {
+				return true
+			}
+			for lang, catalog := range catalogs {
+				if _, ok := catalog[message]; !ok {
+					t.Errorf("%s: %q is not in the %s catalog", fset.Position(lit.Pos()), message, lang)
+				}
+			}
+			return true
+		})
+	}
+}
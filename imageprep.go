@@ -0,0 +1,417 @@
+package clix
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	// maxImageSide is the longest side images are scaled down to when they
+	// are over the size limit
+	maxImageSide = 4096
+	// jpegQuality is used whenever a JPEG has to be encoded again
+	jpegQuality = 90
+)
+
+// convertedFormats are image formats X does not take, or that may carry
+// metadata clix cannot strip itself, with the format they are converted to
+var convertedFormats = map[string]string{
+	".heic": "jpg",
+	".heif": "jpg",
+	".webp": "png",
+}
+
+// imageConverter is an external program that can convert images
+type imageConverter struct {
+	name    string
+	formats []string // input extensions, all when empty
+	args    func(in, out string) []string
+}
+
+var imageConverters = []imageConverter{
+	{"magick", nil, func(in, out string) []string { return []string{in, out} }},
+	{"convert", nil, func(in, out string) []string { return []string{in, out} }},
+	{"heif-convert", []string{".heic", ".heif"}, func(in, out string) []string { return []string{in, out} }},
+	{"dwebp", []string{".webp"}, func(in, out string) []string { return []string{in, "-o", out} }},
+	{"sips", nil, func(in, out string) []string {
+		format := strings.TrimPrefix(filepath.Ext(out), ".")
+		if format == "jpg" {
+			format = "jpeg"
+		}
+		return []string{"-s", "format", format, in, "--out", out}
+	}},
+}
+
+// preparedImage is an image ready for upload. data is nil when the file
+// needed no changes.
+type preparedImage struct {
+	data    []byte
+	ext     string
+	changes []string // what was done to it, for --dry-run
+}
+
+// prepareImage makes an image fit for upload: it converts formats X does
+// not take, strips EXIF and other metadata unless keepEXIF is set, and
+// scales and recompresses it until it is under the size limit
+func prepareImage(path string, keepEXIF bool) (*preparedImage, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p := &preparedImage{ext: ext}
+	changed := false
+
+	if to, ok := convertedFormats[ext]; ok {
+		if data, err = convertImage(path, ext, to); err != nil {
+			return nil, err
+		}
+		p.ext, changed = "."+to, true
+		p.changes = append(p.changes, trf("converted from %s to %s", strings.ToUpper(ext[1:]), strings.ToUpper(to)))
+	}
+
+	if !keepEXIF {
+		var stripped []byte
+		var note string
+		switch p.ext {
+		case ".jpg", ".jpeg":
+			stripped, note, err = stripJPEGMetadata(data)
+		case ".png":
+			stripped, note = stripPNGMetadata(data)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if note != "" {
+			data, changed = stripped, true
+			p.changes = append(p.changes, note)
+		}
+	}
+
+	if len(data) > maxImageBytes {
+		shrunk, note, err := shrinkImage(data)
+		if err != nil {
+			return nil, err
+		}
+		data, p.ext, changed = shrunk, ".jpg", true
+		p.changes = append(p.changes, note)
+	}
+
+	if changed {
+		p.data = data
+	}
+	return p, nil
+}
+
+// convertImage converts path to the format with extension to, using the
+// first converter installed that handles it
+func convertImage(path, ext, to string) ([]byte, error) {
+	out, err := os.CreateTemp("", "clix-convert-*."+to)
+	if err != nil {
+		return nil, err
+	}
+	out.Close()
+	defer os.Remove(out.Name())
+
+	var tried []string
+	for _, c := range imageConverters {
+		if c.formats != nil && !slices.Contains(c.formats, ext) {
+			continue
+		}
+		tried = append(tried, c.name)
+		bin, err := exec.LookPath(c.name)
+		if err != nil {
+			continue
+		}
+		if output, err := exec.Command(bin, c.args(path, out.Name())...).CombinedOutput(); err != nil {
+			return nil, fmt.Errorf(tr("%s could not convert %s: %s"), c.name, path, bytes.TrimSpace(output))
+		}
+		return os.ReadFile(out.Name())
+	}
+	return nil, fmt.Errorf(tr("%s: converting %s images needs one of %s installed"), path, strings.ToUpper(ext[1:]), strings.Join(tried, ", "))
+}
+
+// stripJPEGMetadata drops the APP1 (EXIF and XMP), APP13 (IPTC) and comment
+// segments, and returns a note of what was removed, or "" for nothing. A
+// photo the EXIF orientation says to rotate is rotated for real, since
+// the tag that said so is gone.
+func stripJPEGMetadata(data []byte) ([]byte, string, error) {
+	if len(data) < 4 || data[0] != 0xff || data[1] != 0xd8 {
+		return nil, "", errors.New(tr("not a JPEG file"))
+	}
+	out := []byte{0xff, 0xd8}
+	orientation, hasGPS, removed := 1, false, false
+	i := 2
+	for i+4 <= len(data) {
+		if data[i] != 0xff {
+			return nil, "", errors.New(tr("corrupt JPEG file"))
+		}
+		marker := data[i+1]
+		// Start of scan: the image data runs to the end
+		if marker == 0xda {
+			break
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:]))
+		if end > len(data) {
+			return nil, "", errors.New(tr("corrupt JPEG file"))
+		}
+		segment := data[i:end]
+		switch {
+		case marker == 0xe1 && bytes.HasPrefix(segment[4:], []byte("Exif\x00\x00")):
+			o, gps := parseEXIF(segment[10:])
+			orientation, hasGPS = o, hasGPS || gps
+			removed = true
+		case marker == 0xe1, marker == 0xed, marker == 0xfe:
+			removed = true
+		default:
+			out = append(out, segment...)
+		}
+		i = end
+	}
+	if !removed {
+		return nil, "", nil
+	}
+	out = append(out, data[i:]...)
+
+	note := tr("removed EXIF metadata")
+	if hasGPS {
+		note += tr(", including the GPS location")
+	}
+	if orientation > 1 && orientation <= 8 {
+		img, err := jpeg.Decode(bytes.NewReader(out))
+		if err != nil {
+			return nil, "", fmt.Errorf(tr("failed to decode JPEG: %w"), err)
+		}
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, orient(toNRGBA(img), orientation), &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, "", err
+		}
+		out = buf.Bytes()
+		note += tr("; applied its rotation to the pixels")
+	}
+	return out, note, nil
+}
+
+// parseEXIF reads the orientation and whether there is a GPS position from
+// the first IFD of TIFF-encoded EXIF data
+func parseEXIF(tiff []byte) (orientation int, hasGPS bool) {
+	orientation = 1
+	if len(tiff) < 8 {
+		return
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if string(tiff[:2]) == "MM" {
+		order = binary.BigEndian
+	}
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for e := 0; e < entries; e++ {
+		at := ifd + 2 + e*12
+		if at+12 > len(tiff) {
+			break
+		}
+		switch order.Uint16(tiff[at:]) {
+		case 0x0112:
+			orientation = int(order.Uint16(tiff[at+8:]))
+		case 0x8825:
+			hasGPS = true
+		}
+	}
+	return
+}
+
+// pngMetadataChunks are the ancillary chunks that can hold metadata
+var pngMetadataChunks = map[string]bool{"eXIf": true, "tEXt": true, "zTXt": true, "iTXt": true, "tIME": true}
+
+// stripPNGMetadata drops the metadata chunks, returning a note of what was
+// removed, or "" for nothing
+func stripPNGMetadata(data []byte) ([]byte, string) {
+	const signature = "\x89PNG\r\n\x1a\n"
+	if !bytes.HasPrefix(data, []byte(signature)) {
+		return nil, ""
+	}
+	out := []byte(signature)
+	removed := false
+	for i := len(signature); i+12 <= len(data); {
+		end := i + 12 + int(binary.BigEndian.Uint32(data[i:]))
+		if end > len(data) || end < i {
+			return nil, ""
+		}
+		if pngMetadataChunks[string(data[i+4:i+8])] {
+			removed = true
+		} else {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+	if !removed {
+		return nil, ""
+	}
+	return out, tr("removed EXIF and text metadata")
+}
+
+// shrinkImage scales the image down and encodes it as JPEG, at falling
+// quality and then smaller sizes, until it is under the size limit
+func shrinkImage(data []byte) ([]byte, string, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf(tr("failed to decode image to shrink it: %w"), err)
+	}
+	src := toNRGBA(img)
+	side := min(max(src.Rect.Dx(), src.Rect.Dy()), maxImageSide)
+	for {
+		scaled := scaleDown(src, side)
+		// JPEG has no transparency, so transparent areas become white
+		flat := image.NewRGBA(scaled.Rect)
+		draw.Draw(flat, flat.Rect, image.NewUniform(color.White), image.Point{}, draw.Src)
+		draw.Draw(flat, flat.Rect, scaled, image.Point{}, draw.Over)
+		for quality := jpegQuality; quality >= 60; quality -= 10 {
+			var buf bytes.Buffer
+			if err := jpeg.Encode(&buf, flat, &jpeg.Options{Quality: quality}); err != nil {
+				return nil, "", err
+			}
+			if buf.Len() <= maxImageBytes {
+				note := trf("saved as JPEG (%d KB) to fit the %d MB limit", buf.Len()>>10, maxImageBytes>>20)
+				if scaled != src {
+					note = trf("resized to %dx%d and %s", flat.Rect.Dx(), flat.Rect.Dy(), note)
+				}
+				return buf.Bytes(), note, nil
+			}
+		}
+		side = side * 3 / 4
+	}
+}
+
+// toNRGBA copies img into an NRGBA image starting at the origin
+func toNRGBA(img image.Image) *image.NRGBA {
+	b := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Rect, img, b.Min, draw.Src)
+	return dst
+}
+
+// scaleDown shrinks src so its longest side is side pixels, averaging the
+// source pixels each one covers. Smaller images are returned as they are.
+func scaleDown(src *image.NRGBA, side int) *image.NRGBA {
+	sw, sh := src.Rect.Dx(), src.Rect.Dy()
+	if max(sw, sh) <= side {
+		return src
+	}
+	dw, dh := side, max(sh*side/sw, 1)
+	if sh > sw {
+		dw, dh = max(sw*side/sh, 1), side
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := y*sh/dh, max((y+1)*sh/dh, y*sh/dh+1)
+		for x := 0; x < dw; x++ {
+			x0, x1 := x*sw/dw, max((x+1)*sw/dw, x*sw/dw+1)
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride+x0*4 : sy*src.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += int(row[i])
+					sum[1] += int(row[i+1])
+					sum[2] += int(row[i+2])
+					sum[3] += int(row[i+3])
+				}
+			}
+			n := (y1 - y0) * (x1 - x0)
+			at := y*dst.Stride + x*4
+			for c := range sum {
+				dst.Pix[at+c] = uint8(sum[c] / n)
+			}
+		}
+	}
+	return dst
+}
+
+// orient transforms src as EXIF orientation o (2 to 8) describes
+func orient(src *image.NRGBA, o int) *image.NRGBA {
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	dw, dh := w, h
+	if o >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch o {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			}
+			copy(dst.Pix[dy*dst.Stride+dx*4:dy*dst.Stride+dx*4+4], src.Pix[y*src.Stride+x*4:])
+		}
+	}
+	return dst
+}
+
+// tempMedia holds the processed copies of images made by this run, in a
+// directory of its own
+var tempMedia struct {
+	sync.Mutex
+	dir string
+}
+
+// writeTempMedia saves processed image data for upload. The name comes
+// from the content, so preparing the same image twice makes one file. The
+// directory is made afresh for the run, so no one else can have made it
+// or put anything in it.
+func writeTempMedia(data []byte, ext string) (string, error) {
+	tempMedia.Lock()
+	defer tempMedia.Unlock()
+	if tempMedia.dir == "" {
+		dir, err := os.MkdirTemp("", "clix-media-")
+		if err != nil {
+			return "", fmt.Errorf(tr("failed to save processed image: %w"), err)
+		}
+		tempMedia.dir = dir
+	}
+	sum := sha256.Sum256(data)
+	path := filepath.Join(tempMedia.dir, hex.EncodeToString(sum[:8])+ext)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", fmt.Errorf(tr("failed to save processed image: %w"), err)
+	}
+	return path, nil
+}
+
+// removeTempMedia deletes the processed images once clix is done with them
+func removeTempMedia() {
+	tempMedia.Lock()
+	defer tempMedia.Unlock()
+	if tempMedia.dir != "" {
+		os.RemoveAll(tempMedia.dir)
+		tempMedia.dir = ""
+	}
+}
@@ -0,0 +1,302 @@
+package clix
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// importRow is one tweet of a file given to clix import. A row with a time
+// is scheduled for it; one without is posted straight away.
+type importRow struct {
+	line int
+	err  error // why the row could not be read
+
+	Text    string   `json:"text"`
+	Media   []string `json:"media,omitempty"`
+	Alt     []string `json:"alt,omitempty"`
+	At      string   `json:"at,omitempty"`
+	ReplyTo string   `json:"reply_to,omitempty"`
+	Quote   string   `json:"quote,omitempty"`
+	Split   bool     `json:"split,omitempty"`
+}
+
+// importResult is what became of a row
+type importResult struct {
+	Line   int        `json:"line"`
+	Status string     `json:"status"` // posted, scheduled, valid or failed
+	At     *time.Time `json:"at,omitempty"`
+	// ID is the tweet's for a posted row and the schedule's for a
+	// scheduled one
+	ID    string `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// importColumns are the CSV columns when the file has no header row
+var importColumns = []string{"text", "media", "at"}
+
+// readImportCSV reads rows from CSV. A header row names the columns: text,
+// media, alt, at, reply_to, quote and split; without one they are text,
+// media and at. Several media files or alt texts are separated by |.
+func readImportCSV(r io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	columns := importColumns
+	var rows []importRow
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, importRow{line: parseErr.StartLine, err: parseErr.Err})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf(tr("failed to read CSV: %w"), err)
+		}
+		if first && strings.EqualFold(strings.TrimSpace(record[0]), "text") {
+			columns = record
+			continue
+		}
+
+		row := importRow{line: line}
+		for j, value := range record {
+			if j >= len(columns) {
+				break
+			}
+			value = strings.TrimSpace(value)
+			switch strings.ToLower(strings.TrimSpace(columns[j])) {
+			case "text":
+				row.Text = value
+			case "media":
+				row.Media = splitImportList(value)
+			case "alt":
+				row.Alt = splitImportList(value)
+			case "at", "datetime", "time":
+				row.At = value
+			case "reply_to":
+				row.ReplyTo = value
+			case "quote":
+				row.Quote = value
+			case "split":
+				row.Split = value == "true" || value == "yes" || value == "1"
+			default:
+				return nil, fmt.Errorf(tr("unknown CSV column %q"), columns[j])
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func splitImportList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	items := strings.Split(s, "|")
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+	return items
+}
+
+// readImportJSONL reads a JSON object per line, with the fields of
+// importRow. A line that does not parse fails on its own.
+func readImportJSONL(r io.Reader) ([]importRow, error) {
+	var rows []importRow
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		row := importRow{}
+		if err := json.Unmarshal(data, &row); err != nil {
+			row = importRow{err: fmt.Errorf(tr("invalid JSON: %w"), err)}
+		}
+		row.line = line
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf(tr("failed to read JSONL: %w"), err)
+	}
+	return rows, nil
+}
+
+// importPost takes a row as far as the mode goes: validating it with
+// --dry-run, otherwise scheduling or posting it
+func (a *app) importPost(row importRow, dir string, force bool, now time.Time) importResult {
+	result := importResult{Line: row.line}
+	fail := func(err error) importResult {
+		result.Status, result.Error = "failed", err.Error()
+		return result
+	}
+	if row.err != nil {
+		return fail(row.err)
+	}
+
+	media := make([]string, len(row.Media))
+	for i, path := range row.Media {
+		// Relative paths are relative to the file, not where clix runs
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		media[i] = path
+	}
+	req := &postRequest{text: row.Text, media: media, alt: row.Alt, replyTo: row.ReplyTo, quote: row.Quote, split: row.Split, force: force}
+	if err := req.transform(a.config); err != nil {
+		return fail(err)
+	}
+	prepared, err := req.prepare()
+	if err != nil {
+		return fail(err)
+	}
+	if err := a.config.SafeMode.check(prepared.parts...); err != nil {
+		return fail(err)
+	}
+
+	when := now
+	if row.At != "" {
+		if when, err = parseScheduleTime(row.At, now); err != nil {
+			return fail(err)
+		}
+		if when.Before(now) {
+			return fail(fmt.Errorf(tr("%s is in the past"), when.Local().Format("2006-01-02 15:04")))
+		}
+		result.At = &when
+	}
+	if !force {
+		if err := checkPostingWindow(a.config.PostingWindow, when); err != nil {
+			return fail(fmt.Errorf(tr("%w (use --force to import anyway)"), err))
+		}
+	}
+	if globalOptions.dryRun {
+		result.Status = "valid"
+		return result
+	}
+
+	if row.At != "" {
+		if _, err := checkToSave(rootCtx, a.config, a, req, prepared.parts, prepared.media, false); err != nil {
+			return fail(err)
+		}
+		saved, err := req.save()
+		if err != nil {
+			return fail(err)
+		}
+		if result.ID, err = enqueue(&scheduledPost{Account: a.config.Active, At: when, savedPost: saved}); err != nil {
+			return fail(err)
+		}
+		result.Status = "scheduled"
+		return result
+	}
+	posted, err := a.publish(rootCtx, prepared, func(postResult) {})
+	if err != nil {
+		return fail(err)
+	}
+	result.Status, result.ID = "posted", posted[0].ID
+	return result
+}
+
+func runImport(args []string) error {
+	fs := newFlagSet("import", "import [--force] <file.csv|file.jsonl>  (rows with a time are scheduled, the rest posted now; --dry-run only checks them)")
+	fileType := fs.String("type", "", "csv or jsonl (default from the file extension)")
+	force := fs.Bool("force", false, "post and schedule even outside the configured posting window or against the style rules")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+	path := args[0]
+	kind := *fileType
+	if kind == "" {
+		kind = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	var r io.Reader = os.Stdin
+	dir := "."
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf(tr("failed to open import file: %w"), err)
+		}
+		defer file.Close()
+		r, dir = file, filepath.Dir(path)
+	}
+	var rows []importRow
+	switch kind {
+	case "csv":
+		rows, err = readImportCSV(r)
+	case "jsonl", "ndjson":
+		rows, err = readImportJSONL(r)
+	default:
+		return fmt.Errorf(tr("cannot tell the format of %s; pass --type csv or --type jsonl"), path)
+	}
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf(tr("%s has no rows"), path)
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	results := []importResult{}
+	counts := map[string]int{}
+	for _, row := range rows {
+		if interrupted() {
+			break
+		}
+		result := a.importPost(row, dir, *force, time.Now())
+		results = append(results, result)
+		counts[result.Status]++
+		if machineReadable() {
+			continue
+		}
+		switch result.Status {
+		case "failed":
+			fmt.Fprintf(os.Stderr, tr("line %d: %s\n"), result.Line, result.Error)
+		case "scheduled":
+			fmt.Printf(tr("line %d: scheduled as %s for %s\n"), result.Line, result.ID, result.At.Local().Format("Mon Jan 2 15:04 MST"))
+		case "posted":
+			fmt.Printf(tr("line %d: posted %s\n"), result.Line, result.ID)
+		}
+	}
+
+	if machineReadable() {
+		printResult(results)
+	} else if globalOptions.dryRun {
+		fmt.Printf(tr("%d of %d rows are valid.\n"), counts["valid"], len(rows))
+	} else {
+		fmt.Printf(tr("%d posted, %d scheduled, %d failed.\n"), counts["posted"], counts["scheduled"], counts["failed"])
+	}
+	if counts["failed"] > 0 {
+		err := fmt.Errorf(tr("%d of %d rows failed"), counts["failed"], len(rows))
+		switch {
+		case globalOptions.dryRun:
+			return withExitCode(exitValidation, err)
+		case counts["failed"] < len(rows):
+			return withExitCode(exitPartial, err)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package clix
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/user/userlookup"
+	userlookuptypes "github.com/michimani/gotwi/user/userlookup/types"
+	clixconfig "github.com/voltycodes/clix/config"
+)
+
+// developerPortalURL is where the keys of an app are made
+const developerPortalURL = "https://developer.x.com/en/portal/dashboard"
+
+// Where the wizard can store credentials
+const (
+	storageFile     = "file"
+	storageKeychain = "keychain"
+)
+
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// initOptions are the answers given ahead with flags
+type initOptions struct {
+	profile string
+	storage string
+	verify  bool
+}
+
+func runInit(args []string) error {
+	fs := newFlagSet("init", "init [--profile name] [--storage file|keychain] [--no-verify]")
+	profile := fs.String("profile", "", "name of the account to set up (asked when not given)")
+	storage := fs.String("storage", "", "where to keep the credentials: file or keychain (asked when not given)")
+	noVerify := fs.Bool("no-verify", false, "save the credentials without checking them with the API")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *storage != "" && *storage != storageFile && *storage != storageKeychain {
+		return withExitCode(exitUsage, errors.New(tr("--storage must be file or keychain")))
+	}
+	if !stdinIsTerminal() {
+		return withExitCode(exitUsage, errors.New(tr("init needs a terminal to ask on; use 'clix config set' or the $CLIX_* variables in scripts")))
+	}
+	if envCredentialsSet() {
+		return withExitCode(exitRefused, errors.New(tr("credentials are set in the environment, which take the place of the config; unset them first")))
+	}
+	path := getConfigFilePath()
+	config, err := readConfig(path)
+	if err != nil {
+		return fmt.Errorf(tr("failed to load configuration: %w"), err)
+	}
+	return initWizard(config, path, initOptions{profile: *profile, storage: *storage, verify: !*noVerify})
+}
+
+// initWizard guides through setting up the credentials of an account:
+// where to get them, checking them with the API and where to keep them
+func initWizard(config *Config, path string, opts initOptions) error {
+	fmt.Println(tr("clix posts with the keys of an app you create in the X developer portal:"))
+	fmt.Println()
+	fmt.Println("  " + developerPortalURL)
+	fmt.Println()
+	fmt.Println(tr("Give the app Read and Write permissions under User authentication settings,"))
+	fmt.Println(tr("then copy the API Key and Secret and generate the Access Token and Secret"))
+	fmt.Println(tr("under Keys and tokens. Secrets are not shown as you type or paste them."))
+	fmt.Println()
+
+	profile := opts.profile
+	for profile == "" {
+		answer, err := promptLine(trf("Profile name [%s]: ", config.Active))
+		if err != nil {
+			return err
+		}
+		profile = cmp.Or(answer, config.Active)
+		if !profileNamePattern.MatchString(profile) {
+			fmt.Println(tr("Use letters, digits, dots, dashes and underscores."))
+			profile = ""
+		}
+	}
+	if !profileNamePattern.MatchString(profile) {
+		return withExitCode(exitUsage, fmt.Errorf(tr("profile name %q may only have letters, digits, dots, dashes and underscores"), profile))
+	}
+	existing, err := config.account(profile, false)
+	if err == nil && existing.Complete() {
+		if !confirm(trf("Profile %q is set up already. Replace its credentials?", profile)) {
+			return errors.New(tr("aborted"))
+		}
+	}
+
+	creds := &Credentials{}
+	for {
+		if err := promptCredentials(creds); err != nil {
+			return err
+		}
+		if !opts.verify {
+			break
+		}
+		fmt.Print(tr("Checking the credentials... "))
+		username, err := verifyCredentials(config, creds)
+		if err == nil {
+			fmt.Printf(tr("they work for @%s.\n"), username)
+			break
+		}
+		fmt.Println(tr("failed."))
+		fmt.Println(err)
+		if !confirm(tr("Enter them again?")) {
+			return withExitCode(exitAuth, errors.New(tr("the credentials were not saved")))
+		}
+		*creds = Credentials{}
+	}
+
+	storage := opts.storage
+	if storage == "" {
+		storage = storageFile
+		if clixconfig.KeychainAvailable() {
+			answer, err := promptLine(tr("Keep the credentials in the config file or the system keychain? [file/keychain] "))
+			if err != nil {
+				return err
+			}
+			if strings.HasPrefix(strings.ToLower(answer), "k") {
+				storage = storageKeychain
+			}
+		}
+	}
+	if storage == storageKeychain {
+		if err := clixconfig.CheckKeychain(); err != nil {
+			return err
+		}
+		creds.Keychain = true
+	}
+
+	account, _ := config.account(profile, true)
+	*account = *creds
+	if profile != defaultAccountName && config.DefaultAccount == "" && !config.Credentials.Complete() {
+		// The first account set up is the one used without --account
+		config.DefaultAccount = profile
+	}
+	if err := saveConfig(config, path); err != nil {
+		return err
+	}
+	where := secretsFile(path)
+	if creds.Keychain {
+		where = tr("the keychain")
+	}
+	fmt.Printf(tr("Saved profile %q in %s.\n"), profile, where)
+	if profile != defaultAccountName && config.DefaultAccount != profile {
+		fmt.Printf(tr("Use it with --account %s, or make it the default with 'clix accounts default %s'.\n"), profile, profile)
+	}
+	return nil
+}
+
+// promptCredentials asks for the OAuth 1.0a keys, hiding the secrets
+func promptCredentials(creds *Credentials) error {
+	for _, field := range []struct {
+		label  string
+		value  *string
+		secret bool
+	}{
+		{"API Key (consumer key)", &creds.ConsumerKey, false},
+		{"API Key Secret (consumer secret)", &creds.ConsumerSecret, true},
+		{"Access Token", &creds.AccessToken, false},
+		{"Access Token Secret", &creds.AccessSecret, true},
+	} {
+		for *field.value == "" {
+			var answer string
+			var err error
+			if field.secret {
+				answer, err = promptSecret(tr(field.label) + ": ")
+			} else {
+				answer, err = promptLine(tr(field.label) + ": ")
+			}
+			if err != nil {
+				return err
+			}
+			*field.value = credentialValue(answer)
+		}
+	}
+	return nil
+}
+
+// verifyCredentials makes a test call with creds and returns the username
+// they belong to
+func verifyCredentials(config *Config, creds *Credentials) (string, error) {
+	probe := &Config{Config: clixconfig.Config{Credentials: *creds, Active: defaultAccountName}, Network: config.Network}
+	a, err := newApp(probe)
+	if err != nil {
+		return "", err
+	}
+	res, err := userlookup.GetMe(rootCtx, a.client, &userlookuptypes.GetMeInput{})
+	if err != nil {
+		return "", describeAuthError(err)
+	}
+	return gotwi.StringValue(res.Data.Username), nil
+}
+
+// describeAuthError adds what usually causes a failed test call
+func describeAuthError(err error) error {
+	switch exitCode(err) {
+	case exitAuth:
+		return withExitCode(exitAuth, fmt.Errorf(tr("%w\nX did not accept them; check they were copied whole and that the access token was generated after the app's permissions were last changed"), err))
+	case exitNetwork, exitTimeout:
+		return fmt.Errorf(tr("%w\nX could not be reached; check the network, or run init with --no-verify"), err)
+	}
+	return err
+}
@@ -0,0 +1,250 @@
+package clix
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// minSlotTweets is how many tracked tweets a recommendation needs; fewer
+// say more about the tweets than about the time they went out
+const minSlotTweets = 10
+
+// slotSmoothing is how many tweets' worth of the overall average each
+// slot starts with, so one lucky tweet does not make its hour the best
+const slotSmoothing = 2
+
+// autoScheduleDays is how far ahead schedule --auto looks for a slot
+const autoScheduleDays = 7
+
+var slotMetrics = []string{"engagement", "impressions"}
+
+var heatBlocks = []rune(" ░▒▓█")
+
+// slotDayNames name the rows of the slots, Monday first
+var slotDayNames = [7]string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// postingSlots is how an account's tracked tweets did by the weekday and
+// hour they were posted, Monday first
+type postingSlots struct {
+	metric string
+	sum    [7][24]float64
+	count  [7][24]int
+	tweets int
+	mean   float64
+}
+
+// slotView is a slot as `clix insights times --json` prints it
+type slotView struct {
+	Day     string  `json:"day"`
+	Hour    int     `json:"hour"`
+	Tweets  int     `json:"tweets"`
+	Average float64 `json:"average"`
+	Score   float64 `json:"score"`
+}
+
+func weekdayIndex(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
+
+// loadPostingSlots sorts the account's tweets posted after since into
+// slots by the latest metrics stats track recorded for them
+func loadPostingSlots(account, metric string, since time.Time) (*postingSlots, error) {
+	snapshots, err := loadStatsSnapshots()
+	if err != nil {
+		return nil, err
+	}
+	latest := map[string]tweetStats{}
+	for _, snapshot := range snapshots {
+		if snapshot.Account != account {
+			continue
+		}
+		// Snapshots are in the order they were taken
+		for _, tweet := range snapshot.Tweets {
+			latest[tweet.ID] = tweet
+		}
+	}
+	slots := &postingSlots{metric: metric}
+	total := 0.0
+	for _, tweet := range latest {
+		if tweet.CreatedAt.Before(since) {
+			continue
+		}
+		value := float64(tweet.Impressions)
+		if metric == "engagement" {
+			value = float64(tweet.Likes + tweet.Retweets + tweet.Replies + tweet.Quotes + tweet.Bookmarks)
+		}
+		at := tweet.CreatedAt.In(calendarZone())
+		day, hour := weekdayIndex(at), at.Hour()
+		slots.sum[day][hour] += value
+		slots.count[day][hour]++
+		slots.tweets++
+		total += value
+	}
+	if slots.tweets > 0 {
+		slots.mean = total / float64(slots.tweets)
+	}
+	return slots, nil
+}
+
+// score is the slot's average, pulled towards the overall one the fewer
+// tweets it has
+func (s *postingSlots) score(day, hour int) float64 {
+	return (s.sum[day][hour] + slotSmoothing*s.mean) / float64(s.count[day][hour]+slotSmoothing)
+}
+
+func (s *postingSlots) view(day, hour int) slotView {
+	v := slotView{Day: slotDayNames[day], Hour: hour, Tweets: s.count[day][hour], Score: s.score(day, hour)}
+	if v.Tweets > 0 {
+		v.Average = s.sum[day][hour] / float64(v.Tweets)
+	}
+	return v
+}
+
+// best returns the slots with tweets in them, best first
+func (s *postingSlots) best() []slotView {
+	var views []slotView
+	for day := range 7 {
+		for hour := range 24 {
+			if s.count[day][hour] > 0 {
+				views = append(views, s.view(day, hour))
+			}
+		}
+	}
+	slices.SortStableFunc(views, func(x, y slotView) int { return cmp.Compare(y.Score, x.Score) })
+	return views
+}
+
+// nextBest returns the start of the best-scoring hour in the coming week
+// that allowed accepts and that is clear of the posts already scheduled
+func (s *postingSlots) nextBest(now time.Time, taken []time.Time, allowed func(time.Time) bool) (time.Time, bool) {
+	var best time.Time
+	bestScore := -1.0
+	start := now.In(calendarZone()).Truncate(time.Hour).Add(time.Hour)
+	for t := start; t.Before(start.AddDate(0, 0, autoScheduleDays)); t = t.Add(time.Hour) {
+		if !allowed(t) || slices.ContainsFunc(taken, func(at time.Time) bool { return (at.Sub(t)).Abs() < calendarCollision }) {
+			continue
+		}
+		// Sooner wins a tie
+		if score := s.score(weekdayIndex(t), t.Hour()); score > bestScore {
+			best, bestScore = t, score
+		}
+	}
+	return best, bestScore >= 0
+}
+
+// autoScheduleTime picks when schedule --auto posts for account
+func autoScheduleTime(config *Config, now time.Time) (time.Time, error) {
+	slots, err := loadPostingSlots(config.Active, "engagement", time.Time{})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if slots.tweets < minSlotTweets {
+		return time.Time{}, withExitCode(exitNotFound, fmt.Errorf(tr("--auto needs the metrics of at least %d tweets and has %d; record them with 'clix stats track'"), minSlotTweets, slots.tweets))
+	}
+	posts, err := loadSchedule()
+	if err != nil {
+		return time.Time{}, err
+	}
+	var taken []time.Time
+	for _, post := range posts {
+		if post.Status == schedulePending && post.Account == config.Active {
+			taken = append(taken, post.At)
+		}
+	}
+	when, ok := slots.nextBest(now, taken, func(t time.Time) bool {
+		return checkPostingWindow(config.PostingWindow, t) == nil
+	})
+	if !ok {
+		return time.Time{}, fmt.Errorf(tr("no free hour in the next %d days; pick one with --at"), autoScheduleDays)
+	}
+	return when, nil
+}
+
+func runInsights(args []string) error {
+	if len(args) == 0 || args[0] != "times" {
+		fmt.Fprintln(os.Stderr, tr("Usage: clix insights times [--since 90d] [--metric engagement|impressions]"))
+		if len(args) > 0 && isHelpArg(args[0]) {
+			return nil
+		}
+		return errUsage
+	}
+	fs := newFlagSet("insights times", "insights times [--since 90d] [--metric engagement|impressions]  (how your tweets did by the hour they went out, from stats track)")
+	since := fs.String("since", "90d", "only tweets posted after this date, time or duration ago")
+	metric := fs.String("metric", "engagement", "what counts: engagement (likes, retweets, replies, quotes and bookmarks) or impressions")
+	if _, err := parseFlags(fs, args[1:]); err != nil {
+		return err
+	}
+	if !slices.Contains(slotMetrics, *metric) {
+		return withExitCode(exitUsage, fmt.Errorf(tr("--metric must be one of %s"), strings.Join(slotMetrics, ", ")))
+	}
+	start, err := parseHistoryTime(*since, time.Now())
+	if err != nil {
+		return err
+	}
+	config, err := readConfig(getConfigFilePath())
+	if err != nil {
+		return fmt.Errorf(tr("failed to load configuration: %w"), err)
+	}
+	slots, err := loadPostingSlots(config.Active, *metric, start)
+	if err != nil {
+		return err
+	}
+	best := slots.best()
+
+	if machineReadable() {
+		return printResult(map[string]any{"metric": *metric, "tweets": slots.tweets, "average": slots.mean, "slots": append([]slotView{}, best...)})
+	}
+	if slots.tweets == 0 {
+		fmt.Println(tr("No tracked tweets in that time; record their metrics with 'clix stats track'."))
+		return nil
+	}
+	printHeatmap(slots)
+	fmt.Println()
+	fmt.Printf(tr("Average %s per tweet, over %d tweets: %.1f\n"), *metric, slots.tweets, slots.mean)
+	for i, v := range best[:min(len(best), 3)] {
+		fmt.Printf(tr("%d. %s %02d:00  %.1f over %d tweets\n"), i+1, v.Day, v.Hour, v.Average, v.Tweets)
+	}
+	if slots.tweets < minSlotTweets {
+		fmt.Fprintf(os.Stderr, tr("Warning: with fewer than %d tweets this says little; keep running 'clix stats track'\n"), minSlotTweets)
+	}
+	return nil
+}
+
+// printHeatmap draws the slots' averages as a weekday by hour grid
+func printHeatmap(s *postingSlots) {
+	top := 0.0
+	for day := range 7 {
+		for hour := range 24 {
+			if s.count[day][hour] > 0 {
+				top = max(top, s.sum[day][hour]/float64(s.count[day][hour]))
+			}
+		}
+	}
+	var header strings.Builder
+	header.WriteString("    ")
+	for hour := 0; hour < 24; hour += 3 {
+		fmt.Fprintf(&header, "%-6s", fmt.Sprintf("%02d", hour))
+	}
+	fmt.Println(strings.TrimRight(header.String(), " "))
+	for day := range 7 {
+		var row strings.Builder
+		row.WriteString(slotDayNames[day] + " ")
+		for hour := range 24 {
+			block := '·'
+			if n := s.count[day][hour]; n > 0 {
+				level := 1
+				if top > 0 {
+					level = 1 + int(s.sum[day][hour]/float64(n)/top*float64(len(heatBlocks)-2)+0.5)
+				}
+				block = heatBlocks[min(level, len(heatBlocks)-1)]
+			}
+			row.WriteString(strings.Repeat(string(block), 2))
+		}
+		fmt.Println(row.String())
+	}
+	fmt.Printf(tr("    · no tweets  %s low to high\n"), string(heatBlocks[1:]))
+}
@@ -0,0 +1,260 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/tweet/timeline"
+	"github.com/michimani/gotwi/tweet/timeline/types"
+)
+
+// The API allows 50 deletes per user every 15 minutes
+const (
+	deleteRateLimit  = 50
+	deleteRateWindow = 15 * time.Minute
+)
+
+// janitorCandidate is a tweet the janitor would delete
+type janitorCandidate struct {
+	ID        string    `json:"id"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// janitorFilter selects the tweets to delete
+type janitorFilter struct {
+	before  time.Time // zero for any age
+	pattern *regexp.Regexp
+}
+
+func (f janitorFilter) match(text string, createdAt time.Time) bool {
+	if !f.before.IsZero() && !createdAt.Before(f.before) {
+		return false
+	}
+	return f.pattern == nil || f.pattern.MatchString(text)
+}
+
+// ownTweets walks the user's timeline back to the oldest tweet the API
+// returns, which is around the 3200 most recent
+func (a *app) ownTweets(ctx context.Context, f janitorFilter) ([]janitorCandidate, error) {
+	if err := a.ensureToken(ctx); err != nil {
+		return nil, err
+	}
+	userID, err := a.me(ctx)
+	if err != nil {
+		return nil, err
+	}
+	input := &types.ListTweetsInput{
+		ID:          userID,
+		MaxResults:  100,
+		TweetFields: fields.TweetFieldList{fields.TweetFieldCreatedAt},
+		Exclude:     fields.ExcludeList{fields.ExcludeRetweets},
+	}
+	if !f.before.IsZero() {
+		input.EndTime = &f.before
+	}
+
+	found := []janitorCandidate{}
+	for {
+		res, err := timeline.ListTweets(ctx, a.client, input)
+		if err != nil {
+			return nil, fmt.Errorf(tr("failed to fetch your tweets: %w"), err)
+		}
+		for _, tweet := range res.Data {
+			text, createdAt := gotwi.StringValue(tweet.Text), gotwi.TimeValue(tweet.CreatedAt)
+			if f.match(text, createdAt) {
+				found = append(found, janitorCandidate{gotwi.StringValue(tweet.ID), text, createdAt})
+			}
+		}
+		next := gotwi.StringValue(res.Meta.NextToken)
+		if next == "" {
+			return found, nil
+		}
+		input.PaginationToken = next
+	}
+}
+
+// historyTweets finds the tweets to delete in the local history, which
+// has every tweet posted with clix however old it is
+func historyTweets(account string, f janitorFilter) ([]janitorCandidate, error) {
+	entries, err := loadHistory()
+	if err != nil {
+		return nil, err
+	}
+	found := []janitorCandidate{}
+	for _, entry := range entries {
+		if !(historyFilter{account: account}).match(entry) || !f.match(entry.Text, entry.PostedAt) {
+			continue
+		}
+		found = append(found, janitorCandidate{entry.ID, entry.Text, entry.PostedAt})
+	}
+	return found, nil
+}
+
+// deletePacer keeps deletes under the rate limit by waiting for the window
+// to move on once it is used up, rather than running into 429s
+type deletePacer struct {
+	recent []time.Time
+}
+
+func (p *deletePacer) wait(ctx context.Context) error {
+	cutoff := time.Now().Add(-deleteRateWindow)
+	for len(p.recent) > 0 && p.recent[0].Before(cutoff) {
+		p.recent = p.recent[1:]
+	}
+	if len(p.recent) >= deleteRateLimit {
+		until := p.recent[0].Add(deleteRateWindow)
+		fmt.Fprintf(os.Stderr, tr("Pausing until %s to stay under the delete rate limit.\n"), until.Local().Format("15:04:05"))
+		timer := time.NewTimer(time.Until(until))
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	p.recent = append(p.recent, time.Now())
+	return nil
+}
+
+func runJanitor(args []string) error {
+	fs := newFlagSet("janitor", "janitor --older-than t|--all [--pattern regexp] [--from timeline|history] [--yes]  (--dry-run lists the tweets instead)")
+	olderThan := fs.String("older-than", "", "only delete tweets older than this, e.g. 90d or 2024-01-01")
+	all := fs.Bool("all", false, "with --pattern, delete matching tweets however recent they are")
+	pattern := fs.String("pattern", "", "only delete tweets matching this regular expression")
+	from := fs.String("from", "timeline", "where to find tweets: timeline (your last ~3200 tweets) or history (those posted with clix)")
+	limit := fs.Int("limit", 0, "delete at most this many tweets, oldest first (0 for all)")
+	yes := fs.Bool("yes", false, "do not ask for confirmation")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	switch {
+	case *all && *olderThan != "":
+		return errors.New(tr("--all and --older-than cannot be combined"))
+	case *all && *pattern == "":
+		return errors.New(tr("--all needs --pattern; the janitor does not delete everything"))
+	case !*all && *olderThan == "":
+		// A pattern alone could match every tweet, so deleting regardless
+		// of age has to be asked for
+		return errors.New(tr("give --older-than, or --all with --pattern to delete matching tweets of any age"))
+	}
+	if *limit < 0 {
+		return errors.New(tr("--limit cannot be negative"))
+	}
+
+	var filter janitorFilter
+	var err error
+	if *olderThan != "" {
+		if filter.before, err = parseHistoryTime(*olderThan, time.Now()); err != nil {
+			return err
+		}
+	}
+	if *pattern != "" {
+		if filter.pattern, err = regexp.Compile(*pattern); err != nil {
+			return fmt.Errorf(tr("invalid --pattern: %w"), err)
+		}
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	ctx := rootCtx
+	var tweets []janitorCandidate
+	switch *from {
+	case "timeline":
+		tweets, err = a.ownTweets(ctx, filter)
+	case "history":
+		tweets, err = historyTweets(a.config.Active, filter)
+	default:
+		return fmt.Errorf(tr("unknown --from %q, expected timeline or history"), *from)
+	}
+	if err != nil {
+		return err
+	}
+	// The oldest go first
+	slices.SortFunc(tweets, func(x, y janitorCandidate) int { return x.CreatedAt.Compare(y.CreatedAt) })
+	if *limit > 0 && len(tweets) > *limit {
+		tweets = tweets[:*limit]
+	}
+
+	if globalOptions.dryRun {
+		if machineReadable() {
+			return printResult(tweets)
+		}
+		for _, tweet := range tweets {
+			line, _, _ := strings.Cut(tweet.Text, "\n")
+			fmt.Printf("  %s  %-16s  %s\n", tweet.ID, formatTime(tweet.CreatedAt), truncateRunes(line, 60))
+		}
+		fmt.Printf(tr("Dry run, would delete %d tweets.\n"), len(tweets))
+		return nil
+	}
+	if len(tweets) == 0 {
+		if machineReadable() {
+			return printResult([]string{})
+		}
+		fmt.Println(tr("No tweets to delete."))
+		return nil
+	}
+
+	if !machineReadable() {
+		fmt.Fprintf(os.Stderr, tr("%d tweets match.\n"), len(tweets))
+	}
+	if !*yes {
+		if !stdinIsTerminal() {
+			return withExitCode(exitRefused, errors.New(tr("refusing to delete without confirmation; pass --yes")))
+		}
+		if !confirm(trf("Delete %d tweets? Run with --dry-run to list them first.", len(tweets))) {
+			return errors.New(tr("aborted"))
+		}
+	}
+	if len(tweets) > deleteRateLimit {
+		fmt.Fprintf(os.Stderr, tr("The API allows %d deletes every 15 minutes, so this takes about %s.\n"),
+			deleteRateLimit, time.Duration(len(tweets)/deleteRateLimit)*deleteRateWindow)
+	}
+
+	pacer := &deletePacer{}
+	deleted := []string{}
+	failed := 0
+	for i, tweet := range tweets {
+		if err := pacer.wait(ctx); err != nil {
+			break
+		}
+		if err := a.deleteTweet(ctx, tweet.ID); err != nil {
+			if interrupted() {
+				break
+			}
+			failed++
+			fmt.Fprintf(os.Stderr, tr("[%d/%d] Failed to delete %s: %v\n"), i+1, len(tweets), tweet.ID, err)
+			continue
+		}
+		deleted = append(deleted, tweet.ID)
+		if !machineReadable() {
+			fmt.Printf(tr("[%d/%d] Deleted %s from %s\n"), i+1, len(tweets), tweet.ID, tweet.CreatedAt.Local().Format("2006-01-02"))
+		}
+	}
+
+	if machineReadable() {
+		printResult(deleted)
+	} else {
+		fmt.Printf(tr("Deleted %d of %d tweets.\n"), len(deleted), len(tweets))
+	}
+	if failed > 0 {
+		err := fmt.Errorf(tr("failed to delete %d of %d tweets"), failed, len(tweets))
+		if len(deleted) > 0 {
+			return withExitCode(exitPartial, err)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package clix
+
+import (
+	"errors"
+	"fmt"
+
+	clixconfig "github.com/voltycodes/clix/config"
+)
+
+// keychainError explains that the credentials of account, as the config
+// says, are kept in the keychain but not found there
+func keychainError(err error, account string) error {
+	if errors.Is(err, clixconfig.ErrNotInKeychain) {
+		return withExitCode(exitAuth, fmt.Errorf(tr("the credentials of account %q are not in the keychain; run 'clix init --profile %s'"), account, account))
+	}
+	return err
+}
@@ -0,0 +1,202 @@
+package clix
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// limitsStateFile keeps the last rate limit each endpoint answered with,
+// by account, so the next run knows what is left before calling
+const limitsStateFile = "limits.json"
+
+// limitRecord is what the x-rate-limit-* headers last said about an
+// endpoint
+type limitRecord struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+	SeenAt    time.Time `json:"seen_at"`
+}
+
+// limitBudget holds the rate limits of one account: those stored by
+// earlier runs and those seen in this one, saved when the app closes
+type limitBudget struct {
+	account string
+
+	mu      sync.Mutex
+	records map[string]limitRecord
+	changed bool
+}
+
+func loadLimitBudget(account string) *limitBudget {
+	all := map[string]map[string]limitRecord{}
+	// State that cannot be read only costs the head start
+	loadState(limitsStateFile, &all)
+	records := all[account]
+	if records == nil {
+		records = map[string]limitRecord{}
+	}
+	return &limitBudget{account: account, records: records}
+}
+
+// limitKey names the endpoint of req, with IDs and usernames in its path
+// replaced so that every user's timeline, say, shares one limit. The
+// first segment is the API version.
+func limitKey(req *http.Request) string {
+	segments := strings.Split(req.URL.Path, "/")
+	for i, s := range segments {
+		if _, err := strconv.ParseUint(s, 10, 64); err == nil && i > 1 {
+			segments[i] = ":id"
+		} else if i > 0 && segments[i-1] == "username" {
+			segments[i] = ":username"
+		}
+	}
+	return req.Method + " " + strings.Join(segments, "/")
+}
+
+// observe records the limit an endpoint answered with
+func (b *limitBudget) observe(req *http.Request, limit rateLimit) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.records[limitKey(req)] = limitRecord{Limit: limit.limit, Remaining: limit.remaining, Reset: limit.reset, SeenAt: time.Now()}
+	b.changed = true
+}
+
+// exhausted returns the limit of req's endpoint when an earlier response
+// said it is used up until a reset still to come
+func (b *limitBudget) exhausted(req *http.Request) (limitRecord, bool) {
+	if b == nil {
+		return limitRecord{}, false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	r, ok := b.records[limitKey(req)]
+	return r, ok && r.Remaining <= 0 && time.Now().Before(r.Reset)
+}
+
+// rateLimitedResponse stands in for the 429 the API would answer a
+// request to an endpoint whose limit is used up, so it is handled like
+// one without spending a call
+func rateLimitedResponse(req *http.Request, r limitRecord) *http.Response {
+	body := `{"title":"Too Many Requests","detail":"The rate limit of this endpoint is used up until it resets.","status":429}`
+	return &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Status:     "429 Too Many Requests",
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header: http.Header{
+			"Content-Type":           {"application/json"},
+			"X-Rate-Limit-Limit":     {strconv.Itoa(r.Limit)},
+			"X-Rate-Limit-Remaining": {"0"},
+			"X-Rate-Limit-Reset":     {strconv.FormatInt(r.Reset.Unix(), 10)},
+		},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// save stores the limits seen in this run, dropping those long past
+// their reset
+func (b *limitBudget) save() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.changed {
+		return nil
+	}
+	unlock, err := lockState(limitsStateFile)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	all := map[string]map[string]limitRecord{}
+	if err := loadState(limitsStateFile, &all); err != nil {
+		return err
+	}
+	records := all[b.account]
+	if records == nil {
+		records = map[string]limitRecord{}
+	}
+	for key, r := range b.records {
+		if stored, ok := records[key]; !ok || r.SeenAt.After(stored.SeenAt) {
+			records[key] = r
+		}
+	}
+	for key, r := range records {
+		if time.Since(r.Reset) > 24*time.Hour {
+			delete(records, key)
+		}
+	}
+	all[b.account] = records
+	b.changed = false
+	return saveState(limitsStateFile, all)
+}
+
+// limitView is a line of clix limits
+type limitView struct {
+	Endpoint  string    `json:"endpoint"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+	SeenAt    time.Time `json:"seen_at"`
+}
+
+func runLimits(args []string) error {
+	fs := newFlagSet("limits", "limits [--low]  (the rate limits the API last reported for the account)")
+	low := fs.Bool("low", false, "only endpoints with less than a tenth of their limit left")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	budget := loadLimitBudget(config.Active)
+
+	views := []limitView{}
+	for _, key := range slices.Sorted(maps.Keys(budget.records)) {
+		r := budget.records[key]
+		// Past the reset the whole limit is back
+		if time.Now().After(r.Reset) {
+			r.Remaining = r.Limit
+		}
+		if *low && r.Remaining*10 >= r.Limit {
+			continue
+		}
+		views = append(views, limitView{Endpoint: key, Limit: r.Limit, Remaining: r.Remaining, Reset: r.Reset, SeenAt: r.SeenAt})
+	}
+	if machineReadable() {
+		return printResult(views)
+	}
+	if len(views) == 0 {
+		fmt.Printf(tr("No rate limits recorded for %s yet; they are noted as commands call the API.\n"), config.Active)
+		return nil
+	}
+	width := len("ENDPOINT")
+	for _, v := range views {
+		width = max(width, len(v.Endpoint))
+	}
+	fmt.Printf("%-*s %11s  %-16s %s\n", width, "ENDPOINT", "REMAINING", "RESETS", "SEEN")
+	for _, v := range views {
+		resets := formatTime(v.Reset)
+		if time.Now().After(v.Reset) {
+			resets = "reset"
+		}
+		fmt.Printf("%-*s %11s  %-16s %s\n", width, v.Endpoint, fmt.Sprintf("%d/%d", v.Remaining, v.Limit), resets, formatTime(v.SeenAt))
+	}
+	return nil
+}
@@ -0,0 +1,701 @@
+package clix
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/term"
+)
+
+const (
+	replHistoryFile = "repl_history.json"
+	// maxReplHistory is how many entries of the repl history are kept
+	maxReplHistory = 500
+	// maxListedHandles is how many candidates a second Tab lists
+	maxListedHandles = 30
+	// maxListedShortcodes is the same for :shortcodes:, which take more room
+	maxListedShortcodes = 20
+)
+
+// Keys that are not a single rune, in the private use area so they cannot
+// collide with typed characters
+const (
+	keyUp rune = 0xe000 + iota
+	keyDown
+	keyLeft
+	keyRight
+	keyWordLeft
+	keyWordRight
+	keyHome
+	keyEnd
+	keyDelete
+	keyKillWordRight
+	keyKillWordLeft
+	keyPasteStart
+	keyPasteEnd
+	keyUnknown
+)
+
+// lineEditor reads input from a terminal in raw mode with Emacs-style
+// editing, a history that is kept across sessions and Ctrl-R search.
+// Input can span lines: Enter starts a new line and an empty line or
+// Ctrl-D submits it.
+type lineEditor struct {
+	prompt       string
+	continuation string
+
+	history []string
+	// historyIndex is the entry being shown, len(history) for the input
+	// being typed
+	historyIndex int
+	pending      []rune // the typed input while browsing the history
+
+	buf  []rune
+	pos  int
+	kill []rune
+
+	pasting bool
+	// searching is set during a Ctrl-R search; query is what was typed and
+	// match the history entry found
+	searching bool
+	query     []rune
+	match     int
+
+	// complete returns the handles for a partial @mention, for Tab
+	complete func(prefix string) []string
+	// shortcodes are the :shortcode:s Tab completes and a closing colon
+	// turns into their emoji; nil leaves colons alone
+	shortcodes map[string]string
+	// tabbed is set after a Tab that could not complete further, so a
+	// second one lists the candidates
+	tabbed bool
+
+	in        []byte
+	cursorRow int // rows between the start of the prompt and the cursor
+}
+
+func newLineEditor(prompt string) *lineEditor {
+	e := &lineEditor{
+		prompt:       prompt,
+		continuation: strings.Repeat(" ", max(len(prompt)-2, 0)) + "> ",
+	}
+	if err := loadState(replHistoryFile, &e.history); err != nil {
+		fmt.Fprintln(os.Stderr, tr("Warning:"), err)
+	}
+	return e
+}
+
+// addHistory records input in the history file, merging in entries other
+// sessions added in the meantime
+func (e *lineEditor) addHistory(input string) {
+	var history []string
+	if err := loadState(replHistoryFile, &history); err != nil {
+		history = e.history
+	}
+	if len(history) == 0 || history[len(history)-1] != input {
+		history = append(history, input)
+	}
+	if len(history) > maxReplHistory {
+		history = history[len(history)-maxReplHistory:]
+	}
+	e.history = history
+	if err := saveState(replHistoryFile, history); err != nil {
+		fmt.Fprintln(os.Stderr, tr("Warning: failed to save the repl history:"), err)
+	}
+}
+
+// errInterrupted is returned when Ctrl-C discards the input
+var errInterrupted = errors.New("interrupted")
+
+// readInput reads one input. It returns io.EOF for Ctrl-D or Ctrl-C on an
+// empty prompt and errInterrupted when Ctrl-C discards what was typed.
+// single reports whether Enter submits the first line straight away, for
+// commands like /media.
+func (e *lineEditor) readInput(single func(line string) bool) (string, error) {
+	fd := int(os.Stdin.Fd())
+	restore, err := makeRaw(fd)
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+	// Bracketed paste keeps pasted newlines from submitting the input
+	fmt.Print("\x1b[?2004h")
+	defer fmt.Print("\x1b[?2004l")
+
+	e.buf, e.pos, e.cursorRow = nil, 0, 0
+	e.historyIndex, e.pending = len(e.history), nil
+	e.searching, e.pasting = false, false
+	e.redraw()
+
+	read := make([]byte, 256)
+	for {
+		k, n := parseKey(e.in)
+		if n == 0 {
+			m, err := input.Read(read)
+			if err != nil {
+				e.finish()
+				return "", err
+			}
+			e.in = append(e.in, read[:m]...)
+			continue
+		}
+		e.in = e.in[n:]
+
+		if e.searching && e.handleSearchKey(k) {
+			e.redraw()
+			continue
+		}
+		done, err := e.handleKey(k, single)
+		if done || err != nil {
+			e.finish()
+			if err != nil {
+				return "", err
+			}
+			input := strings.TrimRight(string(e.buf), "\n")
+			if strings.TrimSpace(input) != "" {
+				e.addHistory(input)
+			}
+			return input, nil
+		}
+		e.redraw()
+	}
+}
+
+// handleKey applies a key to the input and reports whether it submits it
+func (e *lineEditor) handleKey(k rune, single func(string) bool) (bool, error) {
+	if e.pasting {
+		switch k {
+		case keyPasteEnd:
+			e.pasting = false
+		case '\r', '\n':
+			e.insert('\n')
+		default:
+			if unicode.IsPrint(k) {
+				e.insert(k)
+			}
+		}
+		return false, nil
+	}
+
+	tabbed := e.tabbed
+	e.tabbed = false
+	start, end := e.lineBounds()
+	switch k {
+	case '\t':
+		if !e.completeShortcode(tabbed) {
+			e.completeMention(tabbed)
+		}
+	case '\r', '\n':
+		switch {
+		case len(e.buf) == 0:
+			return true, nil
+		case !slices.Contains(e.buf, '\n') && single(string(e.buf)):
+			return true, nil
+		case e.pos == len(e.buf) && start == end:
+			// An empty last line ends the input
+			return true, nil
+		}
+		e.insert('\n')
+	case 4: // Ctrl-D
+		switch {
+		case len(e.buf) == 0:
+			return false, io.EOF
+		case e.pos < len(e.buf):
+			e.delete(e.pos, e.pos+1)
+		default:
+			return true, nil
+		}
+	case 3: // Ctrl-C
+		if len(e.buf) == 0 {
+			return false, io.EOF
+		}
+		return false, errInterrupted
+	case 1, keyHome: // Ctrl-A
+		e.pos = start
+	case 5, keyEnd: // Ctrl-E
+		e.pos = end
+	case 2, keyLeft: // Ctrl-B
+		e.pos = max(e.pos-1, 0)
+	case 6, keyRight: // Ctrl-F
+		e.pos = min(e.pos+1, len(e.buf))
+	case keyWordLeft:
+		e.pos = e.wordLeft()
+	case keyWordRight:
+		e.pos = e.wordRight()
+	case 8, 127: // Ctrl-H, backspace
+		if e.pos > 0 {
+			e.delete(e.pos-1, e.pos)
+		}
+	case keyDelete:
+		if e.pos < len(e.buf) {
+			e.delete(e.pos, e.pos+1)
+		}
+	case 11: // Ctrl-K kills to the end of the line, or the line break at its end
+		if e.pos == end && end < len(e.buf) {
+			end++
+		}
+		e.killRange(e.pos, end)
+	case 21: // Ctrl-U
+		e.killRange(start, e.pos)
+	case 23, keyKillWordLeft: // Ctrl-W
+		e.killRange(e.wordLeft(), e.pos)
+	case keyKillWordRight:
+		e.killRange(e.pos, e.wordRight())
+	case 25: // Ctrl-Y
+		for _, r := range e.kill {
+			e.insert(r)
+		}
+	case 12: // Ctrl-L
+		fmt.Print("\x1b[H\x1b[2J")
+		e.cursorRow = 0
+	case 16, keyUp: // Ctrl-P
+		if start == 0 {
+			e.showHistory(e.historyIndex - 1)
+		} else {
+			e.moveLine(-1)
+		}
+	case 14, keyDown: // Ctrl-N
+		if end == len(e.buf) {
+			e.showHistory(e.historyIndex + 1)
+		} else {
+			e.moveLine(1)
+		}
+	case 18: // Ctrl-R
+		e.searching, e.query, e.match = true, nil, len(e.history)
+		e.pending = slices.Clone(e.buf)
+	case keyPasteStart:
+		e.pasting = true
+	default:
+		if unicode.IsPrint(k) {
+			e.insert(k)
+		}
+		if k == ':' {
+			e.expandShortcode()
+		}
+	}
+	return false, nil
+}
+
+// handleSearchKey handles a key during a Ctrl-R search. Keys that do not
+// refine the search end it, keeping the match, and are then handled as
+// usual; it reports whether the key was used up.
+func (e *lineEditor) handleSearchKey(k rune) bool {
+	switch k {
+	case 18: // Ctrl-R finds the next older match
+		e.search(e.match - 1)
+	case 8, 127:
+		if len(e.query) > 0 {
+			e.query = e.query[:len(e.query)-1]
+			e.search(len(e.history) - 1)
+		}
+	case 7, 27: // Ctrl-G, Esc give up the search
+		e.searching = false
+		e.setBuffer(e.pending)
+	case '\r', '\n':
+		e.searching = false
+	default:
+		if unicode.IsPrint(k) {
+			e.query = append(e.query, k)
+			e.search(e.match)
+			return true
+		}
+		e.searching = false
+		return false
+	}
+	return true
+}
+
+// search looks for the query in the history from entry from backwards,
+// loading the match into the buffer
+func (e *lineEditor) search(from int) {
+	for i := min(from, len(e.history)-1); i >= 0; i-- {
+		if at := strings.Index(e.history[i], string(e.query)); at >= 0 {
+			e.match = i
+			e.setBuffer([]rune(e.history[i]))
+			e.pos = utf8.RuneCountInString(e.history[i][:at])
+			return
+		}
+	}
+}
+
+func (e *lineEditor) showHistory(i int) {
+	if i < 0 || i > len(e.history) {
+		return
+	}
+	if e.historyIndex == len(e.history) {
+		e.pending = slices.Clone(e.buf)
+	}
+	e.historyIndex = i
+	if i == len(e.history) {
+		e.setBuffer(e.pending)
+	} else {
+		e.setBuffer([]rune(e.history[i]))
+	}
+}
+
+func (e *lineEditor) setBuffer(r []rune) {
+	e.buf = slices.Clone(r)
+	e.pos = len(e.buf)
+}
+
+func (e *lineEditor) insert(r rune) {
+	e.buf = slices.Insert(e.buf, e.pos, r)
+	e.pos++
+}
+
+// completeMention completes the @mention before the cursor from the
+// cached handles: a single match is inserted with the case it has on X,
+// several are completed as far as they agree and a second Tab lists them
+func (e *lineEditor) completeMention(listing bool) {
+	if e.complete == nil {
+		return
+	}
+	at := mentionPrefix(e.buf[:e.pos])
+	if at < 0 {
+		return
+	}
+	typed := string(e.buf[at+1 : e.pos])
+	matches := e.complete(typed)
+	switch {
+	case len(matches) == 0:
+		return
+	case len(matches) == 1:
+		e.delete(at+1, e.pos)
+		for _, r := range matches[0] + " " {
+			e.insert(r)
+		}
+		return
+	}
+	if common := commonPrefix(typed, matches); len(common) > len(typed) {
+		e.delete(at+1, e.pos)
+		for _, r := range common {
+			e.insert(r)
+		}
+		return
+	}
+	if !listing {
+		e.tabbed = true
+		return
+	}
+	// Print the candidates below the input and start the prompt afresh
+	pos := e.pos
+	e.pos = len(e.buf)
+	e.redraw()
+	list := "@" + strings.Join(matches[:min(len(matches), maxListedHandles)], "  @")
+	if len(matches) > maxListedHandles {
+		list += trf("  (%d more)", len(matches)-maxListedHandles)
+	}
+	fmt.Print("\r\n" + list + "\r\n")
+	e.cursorRow, e.pos = 0, pos
+}
+
+// expandShortcode replaces the :shortcode: the colon just typed closes
+// with its emoji
+func (e *lineEditor) expandShortcode() {
+	at := shortcodePrefix(e.buf[:e.pos-1])
+	if at < 0 {
+		return
+	}
+	if emoji, ok := e.shortcodes[string(e.buf[at+1:e.pos-1])]; ok {
+		e.delete(at, e.pos)
+		for _, r := range emoji {
+			e.insert(r)
+		}
+	}
+}
+
+// completeShortcode completes the :shortcode: before the cursor as
+// completeMention does a handle, putting in the emoji for a single match.
+// It reports whether there was a shortcode to complete.
+func (e *lineEditor) completeShortcode(listing bool) bool {
+	at := shortcodePrefix(e.buf[:e.pos])
+	if at < 0 || e.shortcodes == nil {
+		return false
+	}
+	typed := string(e.buf[at+1 : e.pos])
+	var matches []string
+	for name := range e.shortcodes {
+		if strings.HasPrefix(name, typed) {
+			matches = append(matches, name)
+		}
+	}
+	slices.Sort(matches)
+	switch {
+	case len(matches) == 0:
+		return true
+	case len(matches) == 1:
+		e.delete(at, e.pos)
+		for _, r := range e.shortcodes[matches[0]] {
+			e.insert(r)
+		}
+		return true
+	}
+	if common := commonPrefix(typed, matches); len(common) > len(typed) {
+		e.delete(at+1, e.pos)
+		for _, r := range common {
+			e.insert(r)
+		}
+		return true
+	}
+	if !listing {
+		e.tabbed = true
+		return true
+	}
+	pos := e.pos
+	e.pos = len(e.buf)
+	e.redraw()
+	var list []string
+	for _, name := range matches[:min(len(matches), maxListedShortcodes)] {
+		list = append(list, e.shortcodes[name]+" :"+name+":")
+	}
+	if len(matches) > maxListedShortcodes {
+		list = append(list, trf("(%d more)", len(matches)-maxListedShortcodes))
+	}
+	fmt.Print("\r\n" + strings.Join(list, "  ") + "\r\n")
+	e.cursorRow, e.pos = 0, pos
+	return true
+}
+
+func (e *lineEditor) delete(from, to int) {
+	e.buf = slices.Delete(e.buf, from, to)
+	e.pos = from
+}
+
+func (e *lineEditor) killRange(from, to int) {
+	if from < to {
+		e.kill = slices.Clone(e.buf[from:to])
+		e.delete(from, to)
+	}
+}
+
+// lineBounds returns where the line the cursor is on starts and ends
+func (e *lineEditor) lineBounds() (int, int) {
+	start := e.pos
+	for start > 0 && e.buf[start-1] != '\n' {
+		start--
+	}
+	end := e.pos
+	for end < len(e.buf) && e.buf[end] != '\n' {
+		end++
+	}
+	return start, end
+}
+
+// moveLine moves the cursor to the same column of the line above or below
+func (e *lineEditor) moveLine(dir int) {
+	start, end := e.lineBounds()
+	column := e.pos - start
+	if dir < 0 {
+		end = start - 1
+		start = end
+		for start > 0 && e.buf[start-1] != '\n' {
+			start--
+		}
+	} else {
+		start = end + 1
+		end = start
+		for end < len(e.buf) && e.buf[end] != '\n' {
+			end++
+		}
+	}
+	e.pos = min(start+column, end)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '#' || r == '@'
+}
+
+func (e *lineEditor) wordLeft() int {
+	i := e.pos
+	for i > 0 && !isWordRune(e.buf[i-1]) {
+		i--
+	}
+	for i > 0 && isWordRune(e.buf[i-1]) {
+		i--
+	}
+	return i
+}
+
+func (e *lineEditor) wordRight() int {
+	i := e.pos
+	for i < len(e.buf) && !isWordRune(e.buf[i]) {
+		i++
+	}
+	for i < len(e.buf) && isWordRune(e.buf[i]) {
+		i++
+	}
+	return i
+}
+
+// redraw prints the prompt and the input again, which keeps wrapping and
+// multi-line input simple to get right
+func (e *lineEditor) redraw() {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 {
+		width = 80
+	}
+	prompt := e.prompt
+	if e.searching {
+		prompt = trf("(reverse-i-search)`%s': ", string(e.query))
+	}
+
+	var b strings.Builder
+	if e.cursorRow > 0 {
+		fmt.Fprintf(&b, "\x1b[%dA", e.cursorRow)
+	}
+	b.WriteString("\r\x1b[J" + prompt)
+	b.WriteString(strings.ReplaceAll(string(e.buf), "\n", "\r\n"+e.continuation))
+
+	row, col := e.layout(prompt, e.buf, width)
+	if col == 0 && row > 0 {
+		// The terminal holds the cursor on the last column until the next
+		// character, so move it to the new row ourselves
+		b.WriteString("\r\n")
+	}
+	cursorRow, cursorCol := e.layout(prompt, e.buf[:e.pos], width)
+	if up := row - cursorRow; up > 0 {
+		fmt.Fprintf(&b, "\x1b[%dA", up)
+	}
+	b.WriteString("\r")
+	if cursorCol > 0 {
+		fmt.Fprintf(&b, "\x1b[%dC", cursorCol)
+	}
+	e.cursorRow = cursorRow
+	fmt.Print(b.String())
+}
+
+// layout returns the row and column on screen reached by printing the
+// prompt and then text, wrapping at width
+func (e *lineEditor) layout(prompt string, text []rune, width int) (int, int) {
+	row, col := 0, 0
+	advance := func(s string) {
+		col += uniseg.StringWidth(s)
+		row += col / width
+		col %= width
+	}
+	advance(prompt)
+	for i, line := range strings.Split(string(text), "\n") {
+		if i > 0 {
+			// A line that filled the row exactly has already moved down
+			if col > 0 {
+				row++
+			}
+			col = 0
+			advance(e.continuation)
+		}
+		advance(line)
+	}
+	return row, col
+}
+
+// finish moves the cursor past the input so output continues below it
+func (e *lineEditor) finish() {
+	e.searching = false
+	e.pos = len(e.buf)
+	e.redraw()
+	fmt.Print("\r\n")
+}
+
+// parseKey decodes the first key in b and returns it with the number of
+// bytes it took, or 0 bytes when b does not hold a whole key yet
+func parseKey(b []byte) (rune, int) {
+	if len(b) == 0 {
+		return 0, 0
+	}
+	if b[0] != 27 {
+		if !utf8.FullRune(b) {
+			return 0, 0
+		}
+		return utf8.DecodeRune(b)
+	}
+	// A lone Esc is a key of its own
+	if len(b) == 1 {
+		return 27, 1
+	}
+	switch b[1] {
+	case 'b':
+		return keyWordLeft, 2
+	case 'f':
+		return keyWordRight, 2
+	case 'd':
+		return keyKillWordRight, 2
+	case 127, 8:
+		return keyKillWordLeft, 2
+	case 'O':
+		if len(b) < 3 {
+			return 0, 0
+		}
+		switch b[2] {
+		case 'A':
+			return keyUp, 3
+		case 'B':
+			return keyDown, 3
+		case 'C':
+			return keyRight, 3
+		case 'D':
+			return keyLeft, 3
+		case 'H':
+			return keyHome, 3
+		case 'F':
+			return keyEnd, 3
+		}
+		return keyUnknown, 3
+	case '[':
+	default:
+		return keyUnknown, 2
+	}
+
+	// A CSI sequence: parameters then a final byte
+	end := 2
+	for end < len(b) && (b[end] < 0x40 || b[end] > 0x7e) {
+		end++
+	}
+	if end == len(b) {
+		return 0, 0
+	}
+	params, final := string(b[2:end]), b[end]
+	n := end + 1
+	// Ctrl or Alt with an arrow moves by words
+	modified := strings.HasSuffix(params, ";5") || strings.HasSuffix(params, ";3")
+	switch final {
+	case 'A':
+		return keyUp, n
+	case 'B':
+		return keyDown, n
+	case 'C':
+		if modified {
+			return keyWordRight, n
+		}
+		return keyRight, n
+	case 'D':
+		if modified {
+			return keyWordLeft, n
+		}
+		return keyLeft, n
+	case 'H':
+		return keyHome, n
+	case 'F':
+		return keyEnd, n
+	case '~':
+		switch params {
+		case "1", "7":
+			return keyHome, n
+		case "4", "8":
+			return keyEnd, n
+		case "3":
+			return keyDelete, n
+		case "200":
+			return keyPasteStart, n
+		case "201":
+			return keyPasteEnd, n
+		}
+	}
+	return keyUnknown, n
+}
@@ -0,0 +1,203 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/voltycodes/clix/compose"
+)
+
+// maxCardPage is how much of a page is read for its card; the metadata is
+// in the head
+const maxCardPage = 512 << 10
+
+// cardUserAgent is the crawler X fetches cards as, which some sites serve
+// their tags to alone
+const cardUserAgent = "Twitterbot/1.0"
+
+var (
+	metaTagPattern  = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	metaAttrPattern = regexp.MustCompile(`(?is)([a-z:_-]+)\s*=\s*("[^"]*"|'[^']*'|[^\s"'>]+)`)
+	titlePattern    = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+)
+
+// linkCard is the preview X shows under a tweet for a link, from the
+// page's Twitter Card or OpenGraph tags
+type linkCard struct {
+	URL         string
+	Tagged      bool // the page has card or OpenGraph tags at all
+	Title       string
+	Description string
+	Image       string
+	// ImageProblem says why the image will not show, "" when it loads
+	ImageProblem string
+}
+
+// cardLink returns the link of text X makes a card for, the last one, or
+// "" when there is none
+func cardLink(text string) string {
+	link := ""
+	for _, span := range compose.SplitURLs(text) {
+		if span.URL {
+			link = span.Text
+		}
+	}
+	return link
+}
+
+// fetchLinkCard reads the card tags of the page at link. Twitter's tags
+// win over OpenGraph's, as they do on X.
+func fetchLinkCard(ctx context.Context, client *http.Client, link string) (*linkCard, error) {
+	if !strings.Contains(link, "://") {
+		link = "https://" + link
+	}
+	pageCtx, cancel := context.WithTimeout(ctx, lintLinkTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(pageCtx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, errors.New(tr("is not a valid URL"))
+	}
+	req.Header.Set("User-Agent", cardUserAgent)
+	res, err := client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf(tr("does not load: %v"), err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 400 {
+		return nil, fmt.Errorf(tr("returns %s"), res.Status)
+	}
+	page, err := io.ReadAll(io.LimitReader(res.Body, maxCardPage))
+	if err != nil {
+		return nil, fmt.Errorf(tr("does not load: %v"), err)
+	}
+
+	tags := map[string]string{}
+	for _, tag := range metaTagPattern.FindAllString(string(page), -1) {
+		attrs := map[string]string{}
+		for _, m := range metaAttrPattern.FindAllStringSubmatch(tag, -1) {
+			attrs[strings.ToLower(m[1])] = html.UnescapeString(strings.Trim(m[2], `"'`))
+		}
+		name := strings.ToLower(attrs["property"])
+		if name == "" {
+			name = strings.ToLower(attrs["name"])
+		}
+		if (strings.HasPrefix(name, "og:") || strings.HasPrefix(name, "twitter:")) && tags[name] == "" {
+			tags[name] = strings.TrimSpace(attrs["content"])
+		}
+	}
+	pick := func(names ...string) string {
+		for _, name := range names {
+			if tags[name] != "" {
+				return tags[name]
+			}
+		}
+		return ""
+	}
+	card := &linkCard{
+		URL:         res.Request.URL.String(),
+		Tagged:      len(tags) > 0,
+		Title:       pick("twitter:title", "og:title"),
+		Description: pick("twitter:description", "og:description"),
+		Image:       pick("twitter:image", "twitter:image:src", "og:image", "og:image:url", "og:image:secure_url"),
+	}
+	if card.Title == "" {
+		if m := titlePattern.FindStringSubmatch(string(page)); m != nil {
+			card.Title = strings.Join(strings.Fields(html.UnescapeString(m[1])), " ")
+		}
+	}
+	if card.Image != "" {
+		if u, err := res.Request.URL.Parse(card.Image); err == nil {
+			card.Image = u.String()
+		}
+		card.ImageProblem = checkLink(ctx, client, card.Image)
+	}
+	return card, nil
+}
+
+// problems lists what makes the card look broken or bare on X
+func (c *linkCard) problems() []string {
+	if !c.Tagged {
+		return []string{tr("has no Twitter Card or OpenGraph tags, so it shows as a plain link")}
+	}
+	var problems []string
+	if c.Title == "" {
+		problems = append(problems, tr("has no title in its card tags"))
+	}
+	switch {
+	case c.Image == "":
+		problems = append(problems, tr("has no image in its card tags"))
+	case c.ImageProblem != "":
+		problems = append(problems, trf("card image %s", c.ImageProblem))
+	}
+	return problems
+}
+
+// render draws the card the way X lays it out, marking what is missing
+func (c *linkCard) render(width int) string {
+	var lines []string
+	switch {
+	case c.Image == "":
+		lines = append(lines, previewOverStyle.Render(tr("▢ no image")))
+	case c.ImageProblem != "":
+		lines = append(lines, previewOverStyle.Render(trf("▢ image %s", c.ImageProblem)))
+	default:
+		lines = append(lines, previewMutedStyle.Render(tr("▣ image")))
+	}
+	if c.Title != "" {
+		lines = append(lines, previewTitleStyle.Render(truncateRunes(c.Title, width-4)))
+	} else {
+		lines = append(lines, previewOverStyle.Render(tr("no title")))
+	}
+	if c.Description != "" {
+		lines = append(lines, truncateRunes(c.Description, 2*(width-4)))
+	}
+	host := c.URL
+	if u, err := url.Parse(c.URL); err == nil && u.Host != "" {
+		host = strings.TrimPrefix(u.Host, "www.")
+	}
+	lines = append(lines, previewMutedStyle.Render(host))
+	return previewQuoteStyle.Width(width - 4).Render(strings.Join(lines, "\n"))
+}
+
+// lintLinkCards fetches the card of each part's card link at once and
+// warns about those that are missing or broken
+func lintLinkCards(ctx context.Context, parts []string) []lintWarning {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var warnings []lintWarning
+	client := newDirectHTTPClient()
+	for i, part := range parts {
+		link := cardLink(part)
+		if link == "" {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			card, err := fetchLinkCard(ctx, client, link)
+			if err != nil {
+				// lintLinks reports links that do not load
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, problem := range card.problems() {
+				warnings = append(warnings, lintWarning{i, trf("link card for %s %s", link, problem)})
+			}
+		}()
+	}
+	wg.Wait()
+	return warnings
+}
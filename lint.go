@@ -0,0 +1,419 @@
+package clix
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/user/userlookup"
+	userlookuptypes "github.com/michimani/gotwi/user/userlookup/types"
+	"github.com/voltycodes/clix/compose"
+)
+
+// LintConfig is the "lint" section of the config. While it is present,
+// tweets are checked for likely mistakes before posting and the warnings
+// have to be confirmed to post anyway.
+type LintConfig struct {
+	// Language is the dictionary the spell checker uses, e.g. "en_GB"
+	// (default en_US)
+	Language string `json:"language,omitempty"`
+	// Dictionary is a file of extra words to accept, one per line
+	Dictionary string `json:"dictionary,omitempty"`
+	// Words are accepted on top of the dictionary file
+	Words []string `json:"words,omitempty"`
+	// The checks can be turned off one by one
+	NoSpelling bool `json:"no_spelling,omitempty"`
+	NoLinks    bool `json:"no_links,omitempty"`
+	NoMentions bool `json:"no_mentions,omitempty"`
+	// NoLinkCards skips fetching the card X shows for a link
+	NoLinkCards bool `json:"no_link_cards,omitempty"`
+}
+
+const (
+	defaultLintLanguage = "en_US"
+	// lintLinkTimeout bounds the request checking each link
+	lintLinkTimeout = 5 * time.Second
+	// maxHandleLength is the longest handle X allows
+	maxHandleLength = 15
+)
+
+// spellCheckers are tried in order; each reads text on stdin and prints the
+// words it does not know, one per line
+var spellCheckers = []struct {
+	name string
+	args func(language string) []string
+}{
+	{"hunspell", func(language string) []string { return []string{"-l", "-d", language} }},
+	{"aspell", func(language string) []string { return []string{"list", "--lang=" + language} }},
+}
+
+var (
+	mentionPattern  = regexp.MustCompile(`(?:^|[^A-Za-z0-9_@])@([A-Za-z0-9_]+)`)
+	hashtagPattern  = regexp.MustCompile(`[#$][\p{L}\p{N}_]+`)
+	doubleSpace     = regexp.MustCompile(`\S {2,}\S`)
+	emoticonPattern = regexp.MustCompile(`[:;=][-^']?[()]|[()][-^']?[:;=]`)
+	listItemPattern = regexp.MustCompile(`(?m)^\s*[0-9A-Za-z]{1,2}\)`)
+)
+
+// lintWarning is one likely mistake; part is the index of the tweet in a
+// thread
+type lintWarning struct {
+	part    int
+	message string
+}
+
+// lintFlag turns --lint and --no-lint into an override of the config, nil
+// when neither was given
+func lintFlag(yes, no bool) (*bool, error) {
+	switch {
+	case yes && no:
+		return nil, errors.New(tr("--lint and --no-lint cannot be combined"))
+	case yes || no:
+		return &yes, nil
+	}
+	return nil, nil
+}
+
+// checkLint runs the lint pass when the config or --lint turns it on. The
+// warnings are printed and, unless this is a dry run, posting anyway has
+// to be confirmed; it reports whether to go on. a is nil when not posting
+// to X, which leaves out the check of the mentions. offset is the number of
+// thread parts before these, to number them right with --resume-at.
+func checkLint(ctx context.Context, config *Config, a *app, parts []string, offset int, override *bool) (bool, error) {
+	warnings := lintWarnings(ctx, config, a, parts, override)
+	if len(warnings) == 0 {
+		return true, nil
+	}
+	printLintWarnings(warnings, parts, offset)
+	if globalOptions.dryRun {
+		return true, nil
+	}
+	if !stdinIsTerminal() {
+		return false, withExitCode(exitRefused, errors.New(tr("refusing to post with lint warnings; fix them or pass --no-lint")))
+	}
+	return confirm(tr("Post anyway?")), nil
+}
+
+// refuseLint is checkLint for posts made without anyone to ask, which it
+// refuses when lint warns about them
+func refuseLint(ctx context.Context, config *Config, a *app, parts []string, offset int, override *bool) error {
+	warnings := lintWarnings(ctx, config, a, parts, override)
+	if len(warnings) == 0 {
+		return nil
+	}
+	printLintWarnings(warnings, parts, offset)
+	if globalOptions.dryRun {
+		return nil
+	}
+	return withExitCode(exitRefused, errors.New(tr("refusing to post with lint warnings; fix them or turn lint off in the config")))
+}
+
+// lintWarnings returns the lint warnings for parts, none when lint is off
+func lintWarnings(ctx context.Context, config *Config, a *app, parts []string, override *bool) []lintWarning {
+	lint := config.Lint
+	if override != nil && !*override || override == nil && lint == nil {
+		return nil
+	}
+	if lint == nil {
+		lint = &LintConfig{}
+	}
+
+	var warnings []lintWarning
+	for i, part := range parts {
+		for _, message := range lintText(part) {
+			warnings = append(warnings, lintWarning{i, message})
+		}
+	}
+	if !lint.NoSpelling {
+		warnings = append(warnings, lint.spelling(parts)...)
+	}
+	if !lint.NoLinks {
+		warnings = append(warnings, lintLinks(ctx, parts)...)
+	}
+	if !lint.NoLinkCards {
+		warnings = append(warnings, lintLinkCards(ctx, parts)...)
+	}
+	if !lint.NoMentions && a != nil {
+		warnings = append(warnings, a.lintMentions(ctx, parts)...)
+	}
+	slices.SortStableFunc(warnings, func(x, y lintWarning) int { return x.part - y.part })
+	return warnings
+}
+
+func printLintWarnings(warnings []lintWarning, parts []string, offset int) {
+	for _, w := range warnings {
+		if len(parts) > 1 || offset > 0 {
+			fmt.Fprintf(os.Stderr, tr("Lint: part %d: %s\n"), offset+w.part+1, w.message)
+		} else {
+			fmt.Fprintf(os.Stderr, tr("Lint: %s\n"), w.message)
+		}
+	}
+}
+
+// lintText finds double spaces and brackets left open, leaving out
+// emoticons and list items like "1)". Links count for the brackets, since
+// one like https://en.wikipedia.org/wiki/Go_(game) closes its own.
+func lintText(text string) []string {
+	var warnings []string
+	if n := len(doubleSpace.FindAllString(text, -1)); n == 1 {
+		warnings = append(warnings, tr("double space"))
+	} else if n > 1 {
+		warnings = append(warnings, trf("%d double spaces", n))
+	}
+
+	s := emoticonPattern.ReplaceAllString(text, "")
+	s = listItemPattern.ReplaceAllString(s, "")
+	pairs := map[rune]rune{')': '(', ']': '[', '}': '{'}
+	var open []rune
+	for _, r := range s {
+		switch r {
+		case '(', '[', '{':
+			open = append(open, r)
+		case ')', ']', '}':
+			if len(open) == 0 || open[len(open)-1] != pairs[r] {
+				return append(warnings, trf("unmatched %q", r))
+			}
+			open = open[:len(open)-1]
+		}
+	}
+	if len(open) > 0 {
+		warnings = append(warnings, trf("unclosed %q", open[len(open)-1]))
+	}
+	return warnings
+}
+
+// spelling runs the first spell checker installed on the parts, without
+// their links, mentions and hashtags
+func (c *LintConfig) spelling(parts []string) []lintWarning {
+	language := c.Language
+	if language == "" {
+		language = defaultLintLanguage
+	}
+	accepted, err := c.acceptedWords()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, tr("Warning:"), err)
+	}
+
+	name, bin, args, err := spellChecker(language)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, tr("Warning:"), err)
+		return nil
+	}
+	var warnings []lintWarning
+	for i, part := range parts {
+		words, err := spellCheck(bin, args, spellableText(part))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Warning: %s failed, skipping the spell check: %v\n"), name, err)
+			return warnings
+		}
+		var unknown []string
+		for _, word := range words {
+			if !accepted[strings.ToLower(word)] && !slices.Contains(unknown, word) {
+				unknown = append(unknown, word)
+			}
+		}
+		if len(unknown) > 0 {
+			warnings = append(warnings, lintWarning{i, tr("possible misspelling: ") + strings.Join(unknown, ", ")})
+		}
+	}
+	return warnings
+}
+
+// spellChecker finds the first spell checker installed and its arguments
+// for language
+func spellChecker(language string) (string, string, []string, error) {
+	for _, checker := range spellCheckers {
+		if bin, err := exec.LookPath(checker.name); err == nil {
+			return checker.name, bin, checker.args(language), nil
+		}
+	}
+	return "", "", nil, errors.New(tr("spell checking needs hunspell or aspell installed; set no_spelling in the lint config to skip it"))
+}
+
+func spellCheck(bin string, args []string, text string) ([]string, error) {
+	cmd := exec.Command(bin, args...)
+	cmd.Stdin = strings.NewReader(text)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s", msg)
+		}
+		return nil, err
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// spellableText is text without the links, mentions, hashtags and
+// cashtags, which are not words a dictionary knows
+func spellableText(text string) string {
+	var b strings.Builder
+	for _, span := range compose.SplitURLs(text) {
+		if !span.URL {
+			b.WriteString(span.Text)
+		}
+	}
+	s := mentionPattern.ReplaceAllString(b.String(), " ")
+	return hashtagPattern.ReplaceAllString(s, " ")
+}
+
+// acceptedWords is the lower-cased words of the dictionary file and the
+// words list
+func (c *LintConfig) acceptedWords() (map[string]bool, error) {
+	accepted := map[string]bool{}
+	for _, word := range c.Words {
+		accepted[strings.ToLower(word)] = true
+	}
+	if c.Dictionary == "" {
+		return accepted, nil
+	}
+	file, err := os.Open(c.Dictionary)
+	if err != nil {
+		return accepted, fmt.Errorf(tr("failed to read the lint dictionary: %w"), err)
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if word := strings.TrimSpace(scanner.Text()); word != "" && !strings.HasPrefix(word, "#") {
+			accepted[strings.ToLower(word)] = true
+		}
+	}
+	return accepted, scanner.Err()
+}
+
+// lintLinks requests every link in the parts at once and warns about those
+// that fail to load or return an error status
+func lintLinks(ctx context.Context, parts []string) []lintWarning {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var warnings []lintWarning
+	client := newDirectHTTPClient()
+	for i, part := range parts {
+		for _, span := range compose.SplitURLs(part) {
+			if !span.URL {
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if problem := checkLink(ctx, client, span.Text); problem != "" {
+					mu.Lock()
+					warnings = append(warnings, lintWarning{i, trf("link %s %s", span.Text, problem)})
+					mu.Unlock()
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	return warnings
+}
+
+// checkLink describes what is wrong with a link, or returns "" when it
+// loads. Servers that do not take HEAD are asked with GET.
+func checkLink(ctx context.Context, client *http.Client, link string) string {
+	if !strings.Contains(link, "://") {
+		link = "https://" + link
+	}
+	ctx, cancel := context.WithTimeout(ctx, lintLinkTimeout)
+	defer cancel()
+	var res *http.Response
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		req, err := http.NewRequestWithContext(ctx, method, link, nil)
+		if err != nil {
+			return tr("is not a valid URL")
+		}
+		req.Header.Set("User-Agent", "clix")
+		if res, err = client.Do(req); err != nil {
+			// The URL is in the message already
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) {
+				err = urlErr.Err
+			}
+			return trf("does not load: %v", err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusMethodNotAllowed && res.StatusCode != http.StatusNotImplemented && res.StatusCode != http.StatusForbidden {
+			break
+		}
+	}
+	if res.StatusCode >= 400 {
+		return trf("returns %s", res.Status)
+	}
+	return ""
+}
+
+// lintMentions warns about @mentions of handles that cannot exist or that
+// X does not know. Handles in the completion cache are taken as known.
+func (a *app) lintMentions(ctx context.Context, parts []string) []lintWarning {
+	var warnings []lintWarning
+	known := map[string]bool{}
+	if cache, err := loadHandles(a.config.Active); err == nil {
+		for _, h := range cache.Handles {
+			known[strings.ToLower(h.Username)] = true
+		}
+	}
+
+	mentioned := map[string][]int{} // lower-cased handle to the parts it is in
+	var handles []string
+	for i, part := range parts {
+		for _, m := range mentionPattern.FindAllStringSubmatch(part, -1) {
+			handle := m[1]
+			if len(handle) > maxHandleLength {
+				warnings = append(warnings, lintWarning{i, trf("@%s is longer than %d characters, so it is not a handle", handle, maxHandleLength)})
+				continue
+			}
+			key := strings.ToLower(handle)
+			if known[key] {
+				continue
+			}
+			if _, ok := mentioned[key]; !ok {
+				handles = append(handles, handle)
+			}
+			if !slices.Contains(mentioned[key], i) {
+				mentioned[key] = append(mentioned[key], i)
+			}
+		}
+	}
+	if len(handles) == 0 {
+		return warnings
+	}
+	if err := a.ensureToken(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, tr("Warning: could not check the mentions:"), err)
+		return warnings
+	}
+
+	// Up to 100 handles can be looked up at once
+	for batch := range slices.Chunk(handles, 100) {
+		res, err := userlookup.ListByUsernames(ctx, a.client, &userlookuptypes.ListByUsernamesInput{Usernames: batch})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, tr("Warning: could not check the mentions:"), err)
+			return warnings
+		}
+		found := map[string]bool{}
+		for _, user := range res.Data {
+			found[strings.ToLower(gotwi.StringValue(user.Username))] = true
+		}
+		for _, handle := range batch {
+			if found[strings.ToLower(handle)] {
+				continue
+			}
+			for _, i := range mentioned[strings.ToLower(handle)] {
+				warnings = append(warnings, lintWarning{i, trf("@%s does not exist or is suspended", handle)})
+			}
+		}
+	}
+	return warnings
+}
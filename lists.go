@@ -0,0 +1,441 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/fields"
+	listlookup "github.com/michimani/gotwi/list/listlookup"
+	lookuptypes "github.com/michimani/gotwi/list/listlookup/types"
+	"github.com/michimani/gotwi/list/listmember"
+	membertypes "github.com/michimani/gotwi/list/listmember/types"
+	"github.com/michimani/gotwi/list/listtweetlookup"
+	tweettypes "github.com/michimani/gotwi/list/listtweetlookup/types"
+	"github.com/michimani/gotwi/list/managelist"
+	managetypes "github.com/michimani/gotwi/list/managelist/types"
+	"github.com/michimani/gotwi/resources"
+)
+
+var listViewFields = fields.ListFieldList{
+	fields.ListFieldDescription, fields.ListFieldPrivate, fields.ListFieldMemberCount,
+	fields.ListFieldFollowerCount, fields.ListFieldOwnerID, fields.ListFieldCreatedAt,
+}
+
+// listURLPattern matches links to a list such as https://x.com/i/lists/123
+var listURLPattern = regexp.MustCompile(`^(?:https?://)?(?:www\.|mobile\.)?(?:x|twitter)\.com/i/lists/(\d+)`)
+
+// listView is the flattened form of a list that clix prints
+type listView struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Private     bool       `json:"private,omitempty"`
+	Members     int        `json:"members"`
+	Followers   int        `json:"followers"`
+	OwnerID     string     `json:"owner_id,omitempty"`
+	Users       []userView `json:"users,omitempty"`
+}
+
+func newListView(l resources.List) listView {
+	return listView{
+		ID:          gotwi.StringValue(l.ID),
+		Name:        gotwi.StringValue(l.Name),
+		Description: gotwi.StringValue(l.Description),
+		Private:     gotwi.BoolValue(l.Private),
+		Members:     gotwi.IntValue(l.MemberCount),
+		Followers:   gotwi.IntValue(l.FollowerCount),
+		OwnerID:     gotwi.StringValue(l.OwnerID),
+	}
+}
+
+// ownedLists returns the lists the authenticated user owns
+func (a *app) ownedLists(ctx context.Context) ([]listView, error) {
+	userID, err := a.me(ctx)
+	if err != nil {
+		return nil, err
+	}
+	input := &lookuptypes.ListOwnedInput{ID: userID, MaxResults: 100, ListFields: listViewFields}
+	views := []listView{}
+	for {
+		res, err := listlookup.ListOwned(ctx, a.client, input)
+		if err != nil {
+			return nil, fmt.Errorf(tr("failed to fetch your lists: %w"), err)
+		}
+		for _, l := range res.Data {
+			views = append(views, newListView(l))
+		}
+		if res.Meta.NextToken == nil || *res.Meta.NextToken == "" {
+			return views, nil
+		}
+		input.PaginationToken = *res.Meta.NextToken
+	}
+}
+
+// resolveList finds a list by ID, URL or the name of one of your lists
+func (a *app) resolveList(ctx context.Context, arg string) (listView, error) {
+	if err := a.ensureToken(ctx); err != nil {
+		return listView{}, err
+	}
+	id := arg
+	if m := listURLPattern.FindStringSubmatch(arg); m != nil {
+		id = m[1]
+	}
+	if strings.Trim(id, "0123456789") == "" {
+		res, err := listlookup.Get(ctx, a.client, &lookuptypes.GetInput{ID: id, ListFields: listViewFields})
+		if err != nil {
+			return listView{}, fmt.Errorf(tr("failed to look up list %s: %w"), id, err)
+		}
+		return newListView(res.Data), nil
+	}
+
+	owned, err := a.ownedLists(ctx)
+	if err != nil {
+		return listView{}, err
+	}
+	for _, l := range owned {
+		if strings.EqualFold(l.Name, arg) {
+			return l, nil
+		}
+	}
+	return listView{}, fmt.Errorf(tr("you have no list named %q"), arg)
+}
+
+func (a *app) createList(ctx context.Context, name, description string, private bool) (listView, error) {
+	if err := a.ensureToken(ctx); err != nil {
+		return listView{}, err
+	}
+	input := &managetypes.CreateInput{Name: name, Private: gotwi.Bool(private)}
+	if description != "" {
+		input.Description = gotwi.String(description)
+	}
+	start := time.Now()
+	res, err := managelist.Create(ctx, a.client, input)
+	a.stats.observe("lists", "list_create", start, err)
+	if err != nil {
+		return listView{}, fmt.Errorf(tr("failed to create list: %w"), err)
+	}
+	return listView{ID: res.Data.ID, Name: res.Data.Name, Description: description, Private: private}, nil
+}
+
+func (a *app) addListMember(ctx context.Context, listID, userID string) error {
+	start := time.Now()
+	_, err := listmember.Create(ctx, a.client, &membertypes.CreateInput{ID: listID, UserID: userID})
+	a.stats.observe("list_members", "list_add", start, err)
+	return err
+}
+
+func (a *app) removeListMember(ctx context.Context, listID, userID string) error {
+	start := time.Now()
+	_, err := listmember.Delete(ctx, a.client, &membertypes.DeleteInput{ID: listID, UserID: userID})
+	a.stats.observe("list_members", "list_remove", start, err)
+	return err
+}
+
+// listMembers returns up to count members of a list
+func (a *app) listMembers(ctx context.Context, listID string, count int) ([]userView, error) {
+	input := &membertypes.ListInput{
+		ID: listID, MaxResults: membertypes.ListMembersGetMaxResults(min(max(count, 1), 100)), UserFields: userViewFields,
+	}
+	views := []userView{}
+	for len(views) < count {
+		res, err := listmember.List(ctx, a.client, input)
+		if err != nil {
+			return nil, fmt.Errorf(tr("failed to fetch list members: %w"), err)
+		}
+		for _, u := range res.Data {
+			views = append(views, newUserView(u))
+		}
+		if res.Meta.NextToken == nil || *res.Meta.NextToken == "" {
+			break
+		}
+		input.PaginationToken = *res.Meta.NextToken
+	}
+	if len(views) > count {
+		views = views[:count]
+	}
+	return views, nil
+}
+
+// listTweets returns a page of a list's timeline and the token of the next
+func (a *app) listTweets(ctx context.Context, input *tweettypes.ListInput) ([]tweetView, string, error) {
+	if err := a.ensureToken(ctx); err != nil {
+		return nil, "", err
+	}
+	res, err := listtweetlookup.List(ctx, a.client, input)
+	if err != nil {
+		return nil, "", fmt.Errorf(tr("failed to fetch the list timeline: %w"), err)
+	}
+	return newTweetViews(res.Data, res.Includes.Users), gotwi.StringValue(res.Meta.NextToken), nil
+}
+
+func runList(args []string) error {
+	if len(args) > 0 && isHelpArg(args[0]) {
+		fmt.Fprintln(os.Stderr, tr("Usage: clix list [list|create <name>|add <list> @user...|remove <list> @user...|show <list>|timeline <list>]"))
+		fmt.Fprintln(os.Stderr, tr("A <list> is its ID, its URL or the name of one of your lists."))
+		return nil
+	}
+	action := "list"
+	if len(args) > 0 && !isFlagArg(args[0]) {
+		action, args = args[0], args[1:]
+	}
+
+	switch action {
+	case "list":
+		return runListList(args)
+	case "create":
+		return runListCreate(args)
+	case "add":
+		return runListMembers("add", args)
+	case "remove":
+		return runListMembers("remove", args)
+	case "show":
+		return runListShow(args)
+	case "timeline":
+		return runListTimeline(args)
+	default:
+		return withExitCode(exitUsage, fmt.Errorf(tr("unknown list action %q"), action))
+	}
+}
+
+func runListList(args []string) error {
+	fs := newFlagSet("list list", "list list  (the lists you own)")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	owned, err := a.ownedLists(rootCtx)
+	if err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(owned)
+	}
+	if len(owned) == 0 {
+		fmt.Println(tr("No lists."))
+		return nil
+	}
+	for _, l := range owned {
+		private := ""
+		if l.Private {
+			private = tr(" (private)")
+		}
+		fmt.Printf(tr("%-20s %-25s %4d members%s\n"), l.ID, l.Name, l.Members, private)
+	}
+	return nil
+}
+
+func runListCreate(args []string) error {
+	fs := newFlagSet("list create", "list create [--description text] [--private] <name>")
+	description := fs.String("description", "", "what the list is about")
+	private := fs.Bool("private", false, "only you can see the list")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+	name := strings.Join(args, " ")
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	l, err := a.createList(rootCtx, name, *description, *private)
+	if err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(l)
+	}
+	fmt.Printf(tr("Created list %s. [ID: %s]\n"), l.Name, l.ID)
+	return nil
+}
+
+// listMembership is the result of list add or remove for one user
+type listMembership struct {
+	List     string `json:"list"`
+	Username string `json:"username"`
+	ID       string `json:"id"`
+	Action   string `json:"action"`
+}
+
+func runListMembers(action string, args []string) error {
+	fs := newFlagSet("list "+action, "list "+action+" <list> @user...")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) < 2 {
+		fs.Usage()
+		return errUsage
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	ctx := rootCtx
+	l, err := a.resolveList(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	results := []listMembership{}
+	for _, username := range args[1:] {
+		userID, err := a.lookupUser(ctx, username)
+		if err == nil {
+			if action == "add" {
+				err = a.addListMember(ctx, l.ID, userID)
+			} else {
+				err = a.removeListMember(ctx, l.ID, userID)
+			}
+		}
+		if err != nil {
+			if machineReadable() {
+				printResult(results)
+			}
+			failed := "failed to remove @%s: %w"
+			if action == "add" {
+				failed = "failed to add @%s: %w"
+			}
+			return fmt.Errorf(tr(failed), trimHandle(username), err)
+		}
+		results = append(results, listMembership{List: l.ID, Username: trimHandle(username), ID: userID, Action: action})
+		if !machineReadable() {
+			if action == "add" {
+				fmt.Printf(tr("Added @%s to %s.\n"), trimHandle(username), l.Name)
+			} else {
+				fmt.Printf(tr("Removed @%s from %s.\n"), trimHandle(username), l.Name)
+			}
+		}
+	}
+	if machineReadable() {
+		return printResult(results)
+	}
+	return nil
+}
+
+func runListShow(args []string) error {
+	fs := newFlagSet("list show", "list show [--count n] <list>")
+	count := fs.Int("count", 100, "number of members to show")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+	if *count < 1 {
+		return errors.New(tr("--count must be at least 1"))
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	ctx := rootCtx
+	l, err := a.resolveList(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	if l.Users, err = a.listMembers(ctx, l.ID, *count); err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(l)
+	}
+
+	private := ""
+	if l.Private {
+		private = tr(", private")
+	}
+	fmt.Printf(tr("%s (%d members, %d followers%s)\n"), l.Name, l.Members, l.Followers, private)
+	if l.Description != "" {
+		fmt.Println("  " + l.Description)
+	}
+	fmt.Println()
+	for _, u := range l.Users {
+		fmt.Printf("@%-16s %s\n", u.Username, u.Name)
+	}
+	return nil
+}
+
+func runListTimeline(args []string) error {
+	fs := newFlagSet("list timeline", "list timeline [--count n] <list>")
+	count := fs.Int("count", 20, "number of tweets to show per page")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+	if *count < 1 {
+		return errors.New(tr("--count must be at least 1"))
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	ctx := rootCtx
+	l, err := a.resolveList(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	input := &tweettypes.ListInput{
+		ID:          l.ID,
+		MaxResults:  tweettypes.ListMaxResults(pageSize(*count)),
+		TweetFields: tweetViewFields,
+		Expansions:  tweetViewExpand,
+		UserFields:  tweetViewUserFld,
+	}
+
+	all := []tweetView{}
+	for {
+		views, next, err := a.listTweets(ctx, input)
+		if err != nil {
+			return err
+		}
+		if len(views) > *count {
+			views = views[:*count]
+		}
+		if machineReadable() {
+			all = append(all, views...)
+		} else {
+			printTweets(os.Stdout, views)
+		}
+
+		if next == "" || machineReadable() || !stdinIsTerminal() || !confirm(tr("Load more?")) {
+			break
+		}
+		input.PaginationToken = next
+	}
+
+	if machineReadable() {
+		return printResult(all)
+	}
+	return nil
+}
@@ -0,0 +1,255 @@
+package clix
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	logFileName        = "clix.log"
+	defaultLogMaxSize  = 5 // MB
+	defaultLogMaxFiles = 3
+)
+
+// LogConfig is the "log" section of the config. When enabled every run is
+// logged as JSON to clix.log next to the other state, which is rotated
+// once it grows past MaxSizeMB.
+type LogConfig struct {
+	Enabled   bool   `json:"enabled"`
+	Level     string `json:"level,omitempty"` // debug, info (the default), warn or error
+	MaxSizeMB int    `json:"max_size_mb,omitempty"`
+	MaxFiles  int    `json:"max_files,omitempty"` // rotated files kept besides the current one
+}
+
+var (
+	// consoleLevel is set from --verbose and --debug; nothing below warn
+	// is shown without them
+	consoleLevel = new(slog.LevelVar)
+	logger       = slog.New(&consoleHandler{w: os.Stderr, level: consoleLevel})
+	logFileOnce  sync.Once
+)
+
+// setLogLevel applies --verbose and --debug, which may be given after the
+// command name and so are applied again once its flags are parsed
+func setLogLevel() {
+	switch {
+	case globalOptions.debug:
+		consoleLevel.Set(slog.LevelDebug)
+	case globalOptions.verbose:
+		consoleLevel.Set(slog.LevelInfo)
+	default:
+		consoleLevel.Set(slog.LevelWarn)
+	}
+}
+
+// openLogFile adds the log file from config to the logger. Only the first
+// config read counts; a failure to open the file is only a warning.
+func openLogFile(config *LogConfig) {
+	if config == nil || !config.Enabled {
+		return
+	}
+	logFileOnce.Do(func() {
+		var level slog.Level
+		if config.Level != "" {
+			if err := level.UnmarshalText([]byte(config.Level)); err != nil {
+				fmt.Fprintf(os.Stderr, tr("Warning: invalid log level %q, logging at info\n"), config.Level)
+			}
+		}
+		dir, err := getDataDir()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, tr("Warning: not logging to a file:"), err)
+			return
+		}
+		path := filepath.Join(dir, logFileName)
+		maxSize := int64(cmp.Or(config.MaxSizeMB, defaultLogMaxSize)) << 20
+		if err := rotateLog(path, maxSize, cmp.Or(config.MaxFiles, defaultLogMaxFiles)); err != nil {
+			fmt.Fprintln(os.Stderr, tr("Warning: failed to rotate the log file:"), err)
+		}
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, tr("Warning: not logging to a file:"), err)
+			return
+		}
+		file := slog.NewJSONHandler(f, &slog.HandlerOptions{Level: level}).WithAttrs([]slog.Attr{
+			slog.Int("pid", os.Getpid()),
+		})
+		logger = slog.New(multiHandler{logger.Handler(), file})
+		logger.Debug("started", "args", redactArgs(os.Args[1:]))
+	})
+}
+
+// rotateLog renames path to path.1, path.1 to path.2 and so on once path
+// is over maxSize, keeping keep rotated files
+func rotateLog(path string, maxSize int64, keep int) error {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() < maxSize {
+		return nil
+	}
+	os.Remove(fmt.Sprintf("%s.%d", path, keep))
+	for i := keep - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", path, i), fmt.Sprintf("%s.%d", path, i+1))
+	}
+	return os.Rename(path, path+".1")
+}
+
+// consoleHandler writes records to stderr for people rather than
+// machines: the message followed by its attributes as key=value
+type consoleHandler struct {
+	w     io.Writer
+	level slog.Leveler
+	attrs []slog.Attr
+}
+
+func (h *consoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *consoleHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+	if r.Level >= slog.LevelWarn {
+		b.WriteString(r.Level.String() + ": ")
+	}
+	b.WriteString(r.Message)
+	write := func(a slog.Attr) bool {
+		if !a.Equal(slog.Attr{}) {
+			fmt.Fprintf(&b, " %s=%s", a.Key, quoteLogValue(a.Value.String()))
+		}
+		return true
+	}
+	for _, a := range h.attrs {
+		write(a)
+	}
+	r.Attrs(write)
+	b.WriteByte('\n')
+	_, err := io.WriteString(h.w, b.String())
+	return err
+}
+
+func quoteLogValue(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
+
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append(slices.Clip(h.attrs), attrs...)
+	return &clone
+}
+
+func (h *consoleHandler) WithGroup(string) slog.Handler {
+	return h
+}
+
+// multiHandler sends every record to each of its handlers
+type multiHandler []slog.Handler
+
+func (m multiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return slices.ContainsFunc(m, func(h slog.Handler) bool { return h.Enabled(ctx, level) })
+}
+
+func (m multiHandler) Handle(ctx context.Context, r slog.Record) error {
+	for _, h := range m {
+		if h.Enabled(ctx, r.Level) {
+			h.Handle(ctx, r.Clone())
+		}
+	}
+	return nil
+}
+
+func (m multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(multiHandler, len(m))
+	for i, h := range m {
+		handlers[i] = h.WithAttrs(attrs)
+	}
+	return handlers
+}
+
+func (m multiHandler) WithGroup(name string) slog.Handler {
+	handlers := make(multiHandler, len(m))
+	for i, h := range m {
+		handlers[i] = h.WithGroup(name)
+	}
+	return handlers
+}
+
+// secretNames are header and parameter names whose values are never logged
+var secretNames = []string{"authorization", "cookie", "set-cookie", "token", "secret", "password", "passphrase", "key", "code", "verifier"}
+
+func isSecretName(name string) bool {
+	name = strings.ToLower(name)
+	return slices.ContainsFunc(secretNames, func(secret string) bool { return strings.Contains(name, secret) })
+}
+
+const redacted = "[redacted]"
+
+// redactURL returns u with the values of secret query parameters hidden
+func redactURL(u *url.URL) string {
+	query := u.Query()
+	for name := range query {
+		if isSecretName(name) {
+			query.Set(name, redacted)
+		}
+	}
+	clean := *u
+	clean.User = nil
+	clean.RawQuery = query.Encode()
+	return clean.String()
+}
+
+// redactHeaders returns h as log attributes with secret values hidden
+func redactHeaders(h http.Header) []any {
+	attrs := make([]any, 0, len(h))
+	for _, name := range sortedKeys(h) {
+		value := strings.Join(h[name], ", ")
+		if isSecretName(name) {
+			value = redacted
+		}
+		attrs = append(attrs, slog.String(strings.ToLower(name), value))
+	}
+	return attrs
+}
+
+// redactArgs hides the values of flags that look like secrets
+func redactArgs(args []string) []string {
+	clean := slices.Clone(args)
+	for i, arg := range clean {
+		if !isFlagArg(arg) || !isSecretName(strings.TrimLeft(arg, "-")) {
+			continue
+		}
+		if name, _, ok := strings.Cut(arg, "="); ok {
+			clean[i] = name + "=" + redacted
+		} else if i+1 < len(clean) {
+			clean[i+1] = redacted
+		}
+	}
+	return clean
+}
+
+// logRequest logs an API request and its outcome: the method, path,
+// status and timing at info, and the redacted headers at debug
+func logRequest(req *http.Request, res *http.Response, err error, start time.Time) {
+	ctx := req.Context()
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		logger.Info("request failed", "method", req.Method, "url", redactURL(req.URL), "error", err, "duration", elapsed)
+		return
+	}
+	logger.Info("request", "method", req.Method, "url", redactURL(req.URL), "status", res.StatusCode, "duration", elapsed)
+	if logger.Enabled(ctx, slog.LevelDebug) {
+		logger.Debug("request headers", redactHeaders(req.Header)...)
+		logger.Debug("response headers", redactHeaders(res.Header)...)
+	}
+}
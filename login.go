@@ -0,0 +1,253 @@
+package clix
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	oauth2AuthorizeURL = "https://x.com/i/oauth2/authorize"
+	oauth2TokenURL     = "https://api.x.com/2/oauth2/token"
+
+	defaultOAuth2Scopes = "tweet.read tweet.write users.read follows.read follows.write like.read like.write " +
+		"bookmark.read bookmark.write list.read list.write mute.read mute.write block.read block.write " +
+		"dm.read dm.write offline.access"
+
+	// loginTimeout bounds how long clix waits for the browser to call back
+	loginTimeout = 5 * time.Minute
+)
+
+type oauth2TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Scope        string `json:"scope"`
+}
+
+func randomURLString(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// requestToken calls the token endpoint with form and returns the new token
+func requestToken(ctx context.Context, creds *Credentials, form url.Values) (*OAuth2Token, error) {
+	form.Set("client_id", creds.ClientID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, oauth2TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if creds.ClientSecret != "" {
+		// Confidential clients must authenticate; public clients only send client_id
+		req.SetBasicAuth(url.QueryEscape(creds.ClientID), url.QueryEscape(creds.ClientSecret))
+	}
+
+	res, err := newDirectHTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		json.NewDecoder(res.Body).Decode(&apiErr)
+		return nil, fmt.Errorf(tr("token request failed: %s %s %s"), res.Status, apiErr.Error, apiErr.Description)
+	}
+
+	var tokenRes oauth2TokenResponse
+	if err := json.NewDecoder(res.Body).Decode(&tokenRes); err != nil {
+		return nil, fmt.Errorf(tr("failed to parse token response: %w"), err)
+	}
+	token := &OAuth2Token{
+		AccessToken:  tokenRes.AccessToken,
+		RefreshToken: tokenRes.RefreshToken,
+		Scope:        tokenRes.Scope,
+	}
+	if tokenRes.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(tokenRes.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// refreshOAuth2Token replaces an expired token using its refresh token and
+// saves the config. Tokens that are still valid are left alone.
+func refreshOAuth2Token(ctx context.Context, config *Config, creds *Credentials) error {
+	if !creds.OAuth2.Expired() {
+		return nil
+	}
+	if creds.OAuth2.RefreshToken == "" {
+		return withExitCode(exitAuth, fmt.Errorf(tr("OAuth 2.0 token for account %q has expired; run 'clix login' again"), config.Active))
+	}
+
+	token, err := requestToken(ctx, creds, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {creds.OAuth2.RefreshToken},
+	})
+	if err != nil {
+		err = fmt.Errorf(tr("failed to refresh OAuth 2.0 token (run 'clix login' again): %w"), err)
+		// Only a refusal means logging in again; the network may just be down
+		if isNetworkError(err) || isTimeout(err) {
+			return err
+		}
+		return withExitCode(exitAuth, err)
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = creds.OAuth2.RefreshToken
+	}
+	creds.OAuth2 = token
+	return saveConfig(config, getConfigFilePath())
+}
+
+// waitForCallback serves the redirect URI until the authorization code
+// arrives, the user denies access or ctx ends
+func waitForCallback(ctx context.Context, listener net.Listener, state string) (string, error) {
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		var res result
+		switch {
+		case query.Get("state") != state:
+			res.err = errors.New(tr("authorization response has an unexpected state"))
+		case query.Get("error") != "":
+			res.err = fmt.Errorf(tr("authorization denied: %s"), query.Get("error"))
+		case query.Get("code") == "":
+			res.err = errors.New(tr("authorization response has no code"))
+		default:
+			res.code = query.Get("code")
+		}
+		if res.err != nil {
+			http.Error(w, res.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, tr("clix is logged in. You can close this window."))
+		}
+		select {
+		case results <- res:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	select {
+	case res := <-results:
+		return res.code, res.err
+	case <-ctx.Done():
+		return "", errors.New(tr("timed out waiting for authorization"))
+	}
+}
+
+func runLogin(args []string) error {
+	fs := newFlagSet("login", "login [--client-id id] [--client-secret secret] [--port n]")
+	clientID := fs.String("client-id", "", "OAuth 2.0 client ID from the developer portal")
+	clientSecret := fs.String("client-secret", "", "OAuth 2.0 client secret, for confidential clients")
+	port := fs.Int("port", 8976, "local port for the redirect URI http://127.0.0.1:<port>/callback")
+	scopes := fs.String("scopes", defaultOAuth2Scopes, "space separated scopes to request")
+	noBrowser := fs.Bool("no-browser", false, "print the authorization URL instead of opening a browser")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	configFilePath := getConfigFilePath()
+	config, err := readConfig(configFilePath)
+	if err != nil {
+		return fmt.Errorf(tr("failed to load configuration: %w"), err)
+	}
+	creds, err := config.account(config.Active, true)
+	if err != nil {
+		return err
+	}
+
+	if *clientID != "" {
+		creds.ClientID = *clientID
+	}
+	if *clientSecret != "" {
+		creds.ClientSecret = *clientSecret
+	}
+	if creds.ClientID == "" {
+		if creds.ClientID, err = promptLine(tr("Enter OAuth 2.0 Client ID: ")); err != nil || creds.ClientID == "" {
+			return errors.New(tr("a client ID is required"))
+		}
+	}
+
+	verifier, err := randomURLString(32)
+	if err != nil {
+		return err
+	}
+	state, err := randomURLString(16)
+	if err != nil {
+		return err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", *port))
+	if err != nil {
+		return fmt.Errorf(tr("failed to start callback server: %w"), err)
+	}
+	redirectURI := fmt.Sprintf("http://127.0.0.1:%d/callback", *port)
+
+	authURL := oauth2AuthorizeURL + "?" + url.Values{
+		"response_type":         {"code"},
+		"client_id":             {creds.ClientID},
+		"redirect_uri":          {redirectURI},
+		"scope":                 {*scopes},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}.Encode()
+
+	fmt.Println(tr("Authorize clix in your browser:"))
+	fmt.Println(authURL)
+	if !*noBrowser {
+		if err := openBrowser(authURL); err != nil {
+			fmt.Println(tr("Could not open a browser, open the URL above manually."))
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(rootCtx, loginTimeout)
+	defer cancel()
+	code, err := waitForCallback(ctx, listener, state)
+	if err != nil {
+		return err
+	}
+
+	token, err := requestToken(ctx, creds, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {verifier},
+	})
+	if err != nil {
+		return err
+	}
+	creds.OAuth2 = token
+	if err := saveConfig(config, configFilePath); err != nil {
+		return err
+	}
+
+	fmt.Printf(tr("Logged in account %q.\n"), config.Active)
+	return nil
+}
@@ -1,169 +1,289 @@
-package main
+// Package clix is the clix command line: its commands, flags and output.
+// The command is built from cmd/clix; the packages under it, compose,
+// config, client and store, can be used on their own.
+package clix
 
 import (
-	"bufio"
-	"context"
-	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
-
-	"github.com/michimani/gotwi"
-	"github.com/michimani/gotwi/tweet/managetweet"
-	"github.com/michimani/gotwi/tweet/managetweet/types"
 )
 
-// Config represents the structure of the configuration file
-type Config struct {
-	ConsumerKey    string `json:"consumer_key"`
-	ConsumerSecret string `json:"consumer_secret"`
-	AccessToken    string `json:"access_token"`
-	AccessSecret   string `json:"access_secret"`
+// command is a clix subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
 }
 
-const configFileName = "clix.json"
+var commands []*command
 
-func getConfigFilePath() string {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		fmt.Println("Error getting home directory:", err)
-		os.Exit(1)
+func init() {
+	commands = []*command{
+		{"post", "Post a tweet", runPost},
+		{"quote", "Quote a tweet with a comment", runQuote},
+		{"thread", "Post a thread of tweets", runThread},
+		{"delete", "Delete a tweet", runDelete},
+		{"janitor", "Delete your tweets by age or pattern", runJanitor},
+		{"history", "List or undo tweets posted with clix", runHistory},
+		{"open", "Open a tweet or profile in the browser", runOpen},
+		{"stats", "Show impressions, likes and retweets of your tweets", runStats},
+		{"draft", "Save, edit and post drafts", runDraft},
+		{"propose", "Propose a tweet for someone else to approve", runPropose},
+		{"approve", "Review and post proposed tweets", runApprove},
+		{"template", "Save tweet templates with variables", runTemplate},
+		{"schedule", "Schedule a tweet to post later", runSchedule},
+		{"import", "Post or schedule tweets from a CSV or JSONL file", runImport},
+		{"scheduler", "Post scheduled tweets when they are due", runScheduler},
+		{"calendar", "Show scheduled posts and planned drafts by day", runCalendar},
+		{"insights", "Show when your tweets do best", runInsights},
+		{"queue", "List or post tweets queued while offline", runQueue},
+		{"like", "Like tweets", runLike},
+		{"unlike", "Remove your like from tweets", runUnlike},
+		{"rt", "Retweet tweets", runRetweet},
+		{"unrt", "Undo retweets", runUnretweet},
+		{"show", "Show a tweet, or with --thread its whole conversation", runShow},
+		{"user", "Show a user's profile and recent tweets", runUser},
+		{"follow", "Follow users", runFollow},
+		{"unfollow", "Unfollow users", runUnfollow},
+		{"followers", "List followers", runFollowers},
+		{"following", "List followed accounts", runFollowing},
+		{"handles", "Cache the handles Tab completes after @", runHandles},
+		{"mute", "Mute users", runMute},
+		{"unmute", "Unmute users", runUnmute},
+		{"mutes", "List muted accounts", runMutes},
+		{"block", "Block users", runBlock},
+		{"unblock", "Unblock users", runUnblock},
+		{"blocks", "List blocked accounts", runBlocks},
+		{"list", "Create, fill and read X Lists", runList},
+		{"bookmark", "List, add and remove bookmarks", runBookmark},
+		{"export", "Write all bookmarks or likes to a file", runExport},
+		{"archive", "Download your profile, tweets and media", runArchive},
+		{"dm", "Send and read direct messages", runDM},
+		{"count", "Count characters the way X does", runCount},
+		{"timeline", "Show your home timeline", runTimeline},
+		{"mentions", "Show recent mentions of you", runMentions},
+		{"watch", "Notify about new mentions as they come in", runWatch},
+		{"track", "Follow the replies to your tweets", runTrack},
+		{"untrack", "Stop following the replies to a tweet", runUntrack},
+		{"search", "Search recent tweets", runSearch},
+		{"suggest", "Suggest hashtags and cashtags for a draft", runSuggest},
+		{"trends", "List trending topics", runTrends},
+		{"places", "Search for places to tag tweets with", runPlaces},
+		{"emoji", "Look up emoji shortcodes", runEmoji},
+		{"limits", "Show the rate limits left and when they reset", runLimits},
+		{"stream", "Stream tweets matching filter rules live", runStream},
+		{"init", "Set up an account step by step", runInit},
+		{"config", "Show or change the configuration", runConfig},
+		{"doctor", "Check the config, credentials and rate limit", runDoctor},
+		{"accounts", "Manage account profiles", runAccounts},
+		{"login", "Log in with OAuth 2.0 in the browser", runLogin},
+		{"tui", "Full-screen interface for reading and posting", runTui},
+		{"repl", "Post tweets from an interactive prompt (default)", runRepl},
+		{"serve", "Serve an HTTP API for local tools to post through", runServe},
+		{"rpc", "Answer JSON requests on stdin, for editor plugins", runRPC},
+		{"completion", "Print a shell completion script", runCompletion},
+		{"update", "Update clix to the latest release", runUpdate},
+		{"version", "Print the version of clix", runVersion},
+		{"help", "Show help for clix or a command", runHelp},
 	}
-	return filepath.Join(homeDir, ".config", configFileName)
 }
 
-func loadOrCreateConfig() (*Config, error) {
-	configFilePath := getConfigFilePath()
-	configDir := filepath.Dir(configFilePath)
-	if _, err := os.Stat(configDir); os.IsNotExist(err) {
-		if err := os.MkdirAll(configDir, 0755); err != nil {
-			return nil, fmt.Errorf("failed to create config directory: %w", err)
+// errUsage is returned once usage has already been printed, so main only
+// needs to set the exit code
+var errUsage = errors.New("invalid usage")
+
+func findCommand(name string) *command {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd
 		}
 	}
+	return nil
+}
 
-	config := &Config{}
-	if _, err := os.Stat(configFilePath); os.IsNotExist(err) {
-		fmt.Println("Configuration file not found. Creating a new one...")
-		if err := promptForConfigValues(config); err != nil {
-			return nil, err
-		}
-		if err := saveConfig(config, configFilePath); err != nil {
-			return nil, err
-		}
-	} else {
-		file, err := os.Open(configFilePath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to open config file: %w", err)
-		}
-		defer file.Close()
+// globalOptions hold flags accepted by every command, either before or
+// after the command name
+var globalOptions struct {
+	account string
+	json    bool
+	format  string
+	verbose bool
+	debug   bool
+	dryRun  bool
+	mock    bool
+	noColor bool
 
-		decoder := json.NewDecoder(file)
-		if err := decoder.Decode(config); err != nil {
-			return nil, fmt.Errorf("failed to parse config file: %w", err)
-		}
+	waitOnLimit bool
 
-		if config.ConsumerKey == "" || config.ConsumerSecret == "" || config.AccessToken == "" || config.AccessSecret == "" {
-			fmt.Println("Configuration file is incomplete. Prompting for missing values...")
-			if err := promptForConfigValues(config); err != nil {
-				return nil, err
-			}
-			if err := saveConfig(config, configFilePath); err != nil {
-				return nil, err
-			}
-		}
-	}
-	return config, nil
+	// How read commands show times, see formatTime
+	utc        bool
+	timeFormat string
+
+	// Network settings, see NetworkConfig
+	proxy          string
+	caBundle       string
+	requestTimeout string
+	timeout        string
+}
+
+func addGlobalFlags(fs *flag.FlagSet) {
+	fs.StringVar(&globalOptions.account, "account", globalOptions.account, "use this account profile (or set $"+accountEnvVar+")")
+	fs.BoolVar(&globalOptions.json, "json", globalOptions.json, "print results as JSON")
+	fs.StringVar(&globalOptions.format, "format", globalOptions.format, "print results with a Go template, e.g. '{{.ID}}'")
+	fs.BoolVar(&globalOptions.verbose, "verbose", globalOptions.verbose, "report API requests and remaining rate limits on stderr")
+	fs.BoolVar(&globalOptions.debug, "debug", globalOptions.debug, "like --verbose, adding request and response headers with secrets redacted")
+	fs.BoolVar(&globalOptions.dryRun, "dry-run", globalOptions.dryRun, "validate and show what would be posted without sending it")
+	fs.BoolVar(&globalOptions.mock, "mock", globalOptions.mock, "answer API calls with canned responses and record them instead of calling X (or set $"+mockEnvVar+"=1)")
+	fs.BoolVar(&globalOptions.waitOnLimit, "wait-on-limit", globalOptions.waitOnLimit, "wait for the rate limit to reset instead of failing")
+	fs.BoolVar(&globalOptions.noColor, "no-color", globalOptions.noColor, "print without colors (or set $NO_COLOR)")
+	fs.BoolVar(&globalOptions.utc, "utc", globalOptions.utc, "show times in UTC rather than the local timezone")
+	fs.StringVar(&globalOptions.timeFormat, "time-format", globalOptions.timeFormat, "show times as relative (\"3h ago\", the default), absolute, iso or a Go layout")
+	fs.StringVar(&globalOptions.proxy, "proxy", globalOptions.proxy, "send requests through this http:// or socks5:// proxy")
+	fs.StringVar(&globalOptions.caBundle, "ca-bundle", globalOptions.caBundle, "also trust the CA certificates in this PEM file")
+	fs.StringVar(&globalOptions.requestTimeout, "request-timeout", globalOptions.requestTimeout, "give up on a request attempt after this long (default 30s)")
+	fs.StringVar(&globalOptions.timeout, "timeout", globalOptions.timeout, "give up on an API call, retries and rate limit waits included, after this long and exit with 124")
 }
 
-func promptForConfigValues(config *Config) error {
-	reader := bufio.NewReader(os.Stdin)
-	if config.ConsumerKey == "" {
-		fmt.Print("Enter Consumer Key: ")
-		key, _ := reader.ReadString('\n')
-		config.ConsumerKey = strings.TrimSpace(key)
+// newFlagSet creates the flag set for a subcommand with a usage line
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	addGlobalFlags(fs)
+	fs.Usage = func() {
+		// The usage line and the flags' help are looked up in the catalog
+		// as they are printed
+		fmt.Fprintf(fs.Output(), tr("Usage: clix %s\n"), tr(usage))
+		fs.VisitAll(func(f *flag.Flag) { f.Usage = tr(f.Usage) })
+		fs.PrintDefaults()
 	}
-	if config.ConsumerSecret == "" {
-		fmt.Print("Enter Consumer Secret: ")
-		secret, _ := reader.ReadString('\n')
-		config.ConsumerSecret = strings.TrimSpace(secret)
+	if capturingFlags {
+		capturedFlags = fs
 	}
-	if config.AccessToken == "" {
-		fmt.Print("Enter Access Token: ")
-		token, _ := reader.ReadString('\n')
-		config.AccessToken = strings.TrimSpace(token)
+	return fs
+}
+
+// parseFlags parses args allowing flags to appear after positional
+// arguments, and returns the positional arguments
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	if capturingFlags {
+		return nil, errCapturedFlags
 	}
-	if config.AccessSecret == "" {
-		fmt.Print("Enter Access Secret: ")
-		secret, _ := reader.ReadString('\n')
-		config.AccessSecret = strings.TrimSpace(secret)
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, errUsage
+		}
+		setLogLevel()
+		rest := fs.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), parseOutputFormat()
+		}
+		if len(rest) == 0 {
+			return positional, parseOutputFormat()
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
 	}
-	return nil
 }
 
-func saveConfig(config *Config, configFilePath string) error {
-	file, err := os.Create(configFilePath)
-	if err != nil {
-		return fmt.Errorf("failed to create config file: %w", err)
-	}
-	defer file.Close()
+// isFlagArg reports whether arg is a flag rather than a subcommand action
+func isFlagArg(arg string) bool {
+	return strings.HasPrefix(arg, "-")
+}
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(config); err != nil {
-		return fmt.Errorf("failed to write config file: %w", err)
-	}
-	return nil
+func isHelpArg(arg string) bool {
+	return arg == "-h" || arg == "-help" || arg == "--help"
 }
 
-func main() {
-	config, err := loadOrCreateConfig()
-	if err != nil {
-		fmt.Println("Error loading configuration:", err)
-		return
-	}
+// stringList is a flag that can be repeated, collecting every value
+type stringList []string
 
-	os.Setenv("GOTWI_API_KEY", config.ConsumerKey)
-	os.Setenv("GOTWI_API_KEY_SECRET", config.ConsumerSecret)
+func (l *stringList) String() string {
+	return strings.Join(*l, ", ")
+}
 
-	clientInput := &gotwi.NewClientInput{
-		AuthenticationMethod: gotwi.AuthenMethodOAuth1UserContext,
-		OAuthToken:           config.AccessToken,
-		OAuthTokenSecret:     config.AccessSecret,
-	}
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
 
-	client, err := gotwi.NewClient(clientInput)
-	if err != nil {
-		fmt.Println("Error creating client:", err)
-		return
+func usage() {
+	fmt.Fprintln(os.Stderr, tr("clix - cli for x"))
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, tr("Usage: clix [--account name] [--json|--format tmpl] [command] [arguments]"))
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, tr("Commands:"))
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, tr(cmd.summary))
 	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, tr("Run 'clix help <command>' for details about a command."))
+}
 
-	reader := bufio.NewReader(os.Stdin)
-	for {
-		fmt.Print("tweet: ")
-		tweetText, err := reader.ReadString('\n')
-		if err != nil {
-			fmt.Println("Error reading input:", err)
-			continue
-		}
+func runHelp(args []string) error {
+	if len(args) == 0 {
+		usage()
+		return nil
+	}
+	cmd := findCommand(args[0])
+	if cmd == nil || cmd.name == "help" {
+		usage()
+		return nil
+	}
+	return cmd.run([]string{"-h"})
+}
 
-		tweetText = strings.TrimSpace(tweetText)
-		if tweetText == "exit" || tweetText == "quit" {
-			fmt.Println("Goodbye!")
-			break
+func run(args []string) error {
+	setLanguage(peekConfigLanguage())
+	global := flag.NewFlagSet("clix", flag.ContinueOnError)
+	global.Usage = usage
+	addGlobalFlags(global)
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
 		}
+		return errUsage
+	}
+	if err := parseOutputFormat(); err != nil {
+		return err
+	}
+	setLogLevel()
 
-		tweetInput := &types.CreateInput{
-			Text: gotwi.String(tweetText),
-		}
+	args = global.Args()
+	if len(args) == 0 {
+		return runRepl(nil)
+	}
+	if args[0] == completeCommand {
+		return runComplete(args[1:])
+	}
 
-		res, err := managetweet.Create(context.Background(), client, tweetInput)
-		if err != nil {
-			fmt.Println("Error posting tweet:", err)
-			continue
-		}
+	cmd := findCommand(args[0])
+	if cmd == nil {
+		usage()
+		return withExitCode(exitUsage, fmt.Errorf(tr("unknown command %q"), args[0]))
+	}
+	return cmd.run(args[1:])
+}
 
-		fmt.Printf("Tweet posted successfully! [ID: %s]\n\n",
-			gotwi.StringValue(res.Data.ID))
+// Main runs clix with the command line arguments and exits with its status
+func Main() {
+	handleSignals()
+	err := run(os.Args[1:])
+	removeTempMedia()
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		os.Exit(exitUsage)
+	case interrupted():
+		fmt.Fprintln(os.Stderr, tr("Interrupted."))
+		os.Exit(int(exitInterrupted.Load()))
+	default:
+		printError(err)
+		os.Exit(exitCode(err))
 	}
 }
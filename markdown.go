@@ -0,0 +1,232 @@
+package clix
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/voltycodes/clix/compose"
+)
+
+// markdownPart is a tweet of a thread compiled from markdown, with the
+// images it references
+type markdownPart struct {
+	text   string
+	images []markdownImage
+}
+
+type markdownImage struct {
+	path, alt string
+}
+
+var (
+	markdownImagePattern    = regexp.MustCompile(`!\[([^\]]*)\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	markdownLinkPattern     = regexp.MustCompile(`\[([^\]]+)\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	markdownAutolinkPattern = regexp.MustCompile(`<((?:https?|mailto):[^>\s]+)>`)
+	markdownHeadingPattern  = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*\s*$`)
+	markdownListPattern     = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+`)
+	markdownRulePattern     = regexp.MustCompile(`^\s*(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	// A sentence ends at . ! or ? followed by optional closing quotes or
+	// brackets and whitespace
+	sentenceEndPattern = regexp.MustCompile(`[.!?]+["'”’)\]]*\s+`)
+)
+
+// markdownBlock is a heading, paragraph, list, code block or image line
+type markdownBlock struct {
+	text    string
+	heading bool
+	// breakBefore starts a new tweet, as a horizontal rule does
+	breakBefore bool
+	images      []markdownImage
+}
+
+// compileMarkdownThread turns a markdown document into thread parts.
+// Headings start a new tweet, so the title becomes the lead tweet; the
+// blocks under them are packed into tweets, with paragraphs that do not
+// fit split at sentence and then word boundaries. Links keep their URL and
+// local images are attached to the tweet they appear in, resolved
+// relative to dir.
+func compileMarkdownThread(text, dir string) []markdownPart {
+	var parts []markdownPart
+	var current markdownPart
+	flush := func() {
+		current.text = strings.TrimSpace(current.text)
+		if current.text != "" || len(current.images) > 0 {
+			parts = append(parts, current)
+		}
+		current = markdownPart{}
+	}
+	add := func(text string) {
+		if text == "" {
+			return
+		}
+		candidate := text
+		if current.text != "" {
+			candidate = current.text + "\n\n" + text
+		}
+		if compose.Length(candidate) > compose.MaxLength && current.text != "" {
+			flush()
+			candidate = text
+		}
+		current.text = candidate
+	}
+
+	for _, block := range markdownBlocks(text) {
+		if block.heading || block.breakBefore {
+			flush()
+		}
+		if compose.Length(block.text) <= compose.MaxLength {
+			add(block.text)
+		} else {
+			for _, piece := range splitSentences(block.text) {
+				add(piece)
+			}
+		}
+		for _, image := range block.images {
+			if len(current.images) == maxImages {
+				flush()
+			}
+			if !filepath.IsAbs(image.path) {
+				image.path = filepath.Join(dir, image.path)
+			}
+			current.images = append(current.images, image)
+		}
+	}
+	flush()
+	return parts
+}
+
+// markdownBlocks splits a document into blocks and converts their inline
+// markup to plain tweet text
+func markdownBlocks(text string) []markdownBlock {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	// Skip YAML front matter
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" {
+		for i := 1; i < len(lines); i++ {
+			if strings.TrimSpace(lines[i]) == "---" {
+				lines = lines[i+1:]
+				break
+			}
+		}
+	}
+
+	var blocks []markdownBlock
+	var paragraph []string
+	breakNext := false
+	flush := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+		block := markdownBlock{breakBefore: breakNext}
+		block.text, block.images = markdownInline(strings.Join(paragraph, "\n"))
+		blocks = append(blocks, block)
+		paragraph, breakNext = nil, false
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			// Code is kept verbatim
+			flush()
+			fence := trimmed[:3]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			blocks = append(blocks, markdownBlock{text: strings.Join(code, "\n"), breakBefore: breakNext})
+			breakNext = false
+		case trimmed == "":
+			flush()
+		case markdownRulePattern.MatchString(trimmed):
+			flush()
+			breakNext = true
+		case markdownHeadingPattern.MatchString(trimmed):
+			flush()
+			heading := markdownHeadingPattern.FindStringSubmatch(trimmed)[1]
+			block := markdownBlock{heading: true}
+			block.text, block.images = markdownInline(heading)
+			blocks = append(blocks, block)
+			breakNext = false
+		case markdownListPattern.MatchString(line):
+			paragraph = append(paragraph, markdownListPattern.ReplaceAllString(line, "• "))
+		default:
+			paragraph = append(paragraph, strings.TrimSpace(strings.TrimLeft(trimmed, ">")))
+		}
+	}
+	flush()
+	return blocks
+}
+
+// markdownInline converts links to "text url", keeps bare URLs, drops
+// emphasis markers and pulls out local images. Remote images become links.
+func markdownInline(text string) (string, []markdownImage) {
+	var images []markdownImage
+	text = markdownImagePattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := markdownImagePattern.FindStringSubmatch(match)
+		if strings.Contains(m[2], "://") {
+			return m[2]
+		}
+		images = append(images, markdownImage{path: m[2], alt: m[1]})
+		return ""
+	})
+	text = markdownLinkPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := markdownLinkPattern.FindStringSubmatch(match)
+		if m[1] == m[2] || strings.TrimPrefix(strings.TrimPrefix(m[2], "https://"), "http://") == m[1] {
+			return m[2]
+		}
+		return m[1] + " " + m[2]
+	})
+	text = markdownAutolinkPattern.ReplaceAllString(text, "$1")
+	text = strings.NewReplacer("**", "", "__", "", "`", "").Replace(text)
+
+	// Images removed from a line can leave it empty
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n"), images
+}
+
+// splitSentences cuts over-length text into pieces that each fit in a
+// tweet, at sentence ends where possible and at words otherwise
+func splitSentences(text string) []string {
+	var sentences []string
+	last := 0
+	for _, loc := range sentenceEndPattern.FindAllStringIndex(text, -1) {
+		sentences = append(sentences, strings.TrimSpace(text[last:loc[1]]))
+		last = loc[1]
+	}
+	if rest := strings.TrimSpace(text[last:]); rest != "" {
+		sentences = append(sentences, rest)
+	}
+
+	var pieces []string
+	current := ""
+	for _, sentence := range sentences {
+		if compose.Length(sentence) > compose.MaxLength {
+			if current != "" {
+				pieces = append(pieces, current)
+				current = ""
+			}
+			pieces = append(pieces, compose.Split(sentence)...)
+			continue
+		}
+		candidate := sentence
+		if current != "" {
+			candidate = current + " " + sentence
+		}
+		if compose.Length(candidate) > compose.MaxLength {
+			pieces = append(pieces, current)
+			candidate = sentence
+		}
+		current = candidate
+	}
+	if current != "" {
+		pieces = append(pieces, current)
+	}
+	return pieces
+}
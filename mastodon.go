@@ -0,0 +1,161 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	clixclient "github.com/voltycodes/clix/client"
+	"github.com/voltycodes/clix/compose"
+)
+
+// MastodonConfig is the "mastodon" section of the config: the instance and
+// an access token with the write:statuses and write:media scopes
+type MastodonConfig struct {
+	Server      string `json:"server"`
+	AccessToken string `json:"access_token"`
+}
+
+const (
+	mastodonMaxLength = 500
+	// Mastodon counts every link as this many characters
+	mastodonURLLength = 23
+	mastodonMaxMedia  = 4
+)
+
+type mastodon struct {
+	server, token string
+	client        *http.Client
+}
+
+func newMastodon(config *MastodonConfig) (*mastodon, error) {
+	if config == nil || config.Server == "" || config.AccessToken == "" {
+		return nil, fmt.Errorf(tr(`mastodon is not configured; add "mastodon": {"server": "https://mastodon.social", "access_token": "..."} to %s`), getConfigFilePath())
+	}
+	server := strings.TrimSuffix(config.Server, "/")
+	if !strings.Contains(server, "://") {
+		server = "https://" + server
+	}
+	return &mastodon{server: server, token: config.AccessToken, client: newHTTPClient()}, nil
+}
+
+func (m *mastodon) name() string {
+	return "mastodon"
+}
+
+// mastodonLength counts text the way Mastodon does for its length limit
+func mastodonLength(text string) int {
+	n := 0
+	for _, span := range compose.SplitURLs(text) {
+		if span.URL {
+			n += mastodonURLLength
+		} else {
+			n += utf8.RuneCountInString(span.Text)
+		}
+	}
+	return n
+}
+
+func (m *mastodon) check(text string, media []*mediaFile) error {
+	if n := mastodonLength(text); n > mastodonMaxLength {
+		return fmt.Errorf(tr("post is %d characters, the limit is %d"), n, mastodonMaxLength)
+	}
+	if len(media) > mastodonMaxMedia {
+		return fmt.Errorf(tr("at most %d media files can be attached"), mastodonMaxMedia)
+	}
+	return nil
+}
+
+func (m *mastodon) post(ctx context.Context, text string, media []*mediaFile) (crossPost, error) {
+	form := url.Values{"status": {text}}
+	for _, file := range media {
+		id, err := m.uploadMedia(ctx, file)
+		if err != nil {
+			return crossPost{}, err
+		}
+		form.Add("media_ids[]", id)
+	}
+
+	req, err := m.newRequest(ctx, http.MethodPost, "/api/v1/statuses", strings.NewReader(form.Encode()))
+	if err != nil {
+		return crossPost{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// Makes a retried request create the status only once
+	req.Header.Set("Idempotency-Key", newQueueKey())
+	var status struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+	if err := clixclient.DoJSON(m.client, req, &status); err != nil {
+		return crossPost{}, fmt.Errorf(tr("failed to post: %w"), err)
+	}
+	return crossPost{Destination: m.name(), ID: status.ID, URL: status.URL}, nil
+}
+
+// uploadMedia uploads a file with its alt text and waits for the server to
+// finish processing it
+func (m *mastodon) uploadMedia(ctx context.Context, file *mediaFile) (string, error) {
+	data, err := os.ReadFile(file.path)
+	if err != nil {
+		return "", err
+	}
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", filepath.Base(file.path))
+	if err != nil {
+		return "", err
+	}
+	part.Write(data)
+	if file.alt != "" {
+		form.WriteField("description", file.alt)
+	}
+	form.Close()
+
+	req, err := m.newRequest(ctx, http.MethodPost, "/api/v2/media", bytes.NewReader(body.Bytes()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	var attachment struct {
+		ID  string  `json:"id"`
+		URL *string `json:"url"`
+	}
+	if err := clixclient.DoJSON(m.client, req, &attachment); err != nil {
+		return "", fmt.Errorf(tr("failed to upload %s: %w"), file.path, err)
+	}
+
+	// Videos and GIFs are processed asynchronously; url is null until done
+	for deadline := time.Now().Add(2 * time.Minute); attachment.URL == nil; {
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf(tr("%s is still processing after 2 minutes"), file.path)
+		}
+		time.Sleep(time.Second)
+		req, err := m.newRequest(ctx, http.MethodGet, "/api/v1/media/"+url.PathEscape(attachment.ID), nil)
+		if err != nil {
+			return "", err
+		}
+		if err := clixclient.DoJSON(m.client, req, &attachment); err != nil {
+			return "", fmt.Errorf(tr("failed to check processing of %s: %w"), file.path, err)
+		}
+	}
+	return attachment.ID, nil
+}
+
+func (m *mastodon) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, m.server+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+m.token)
+	return req, nil
+}
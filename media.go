@@ -0,0 +1,367 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	mediaUploadEndpoint   = "https://upload.twitter.com/1.1/media/upload.json"
+	mediaMetadataEndpoint = "https://upload.twitter.com/1.1/media/metadata/create.json"
+)
+
+// maxAltTextLength is the API's limit for media descriptions
+const maxAltTextLength = 1000
+
+const (
+	maxImages      = 4
+	maxImageBytes  = 5 << 20
+	maxGIFBytes    = 15 << 20
+	maxVideoBytes  = 512 << 20
+	mediaChunkSize = 4 << 20
+
+	// progressThreshold is the file size above which upload progress is
+	// shown and saved to resume from
+	progressThreshold = 2 * mediaChunkSize
+)
+
+// mediaFile is a local file to attach to a tweet
+type mediaFile struct {
+	path      string
+	mediaType string // MIME type sent to the upload endpoint
+	category  string // tweet_image, tweet_gif or tweet_video
+	size      int64
+	alt       string // description for screen readers
+
+	// source is the file given when path is a processed copy of it, and
+	// changes say what was done to it
+	source  string
+	changes []string
+}
+
+type mediaUploadResponse struct {
+	MediaIDString    string `json:"media_id_string"`
+	ExpiresAfterSecs int    `json:"expires_after_secs"`
+	ProcessingInfo   *struct {
+		State           string `json:"state"`
+		CheckAfterSecs  int    `json:"check_after_secs"`
+		ProgressPercent int    `json:"progress_percent"`
+		Error           *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"processing_info"`
+}
+
+var mediaCategories = map[string]string{
+	".jpg":  "tweet_image",
+	".jpeg": "tweet_image",
+	".png":  "tweet_image",
+	".webp": "tweet_image",
+	".heic": "tweet_image",
+	".heif": "tweet_image",
+	".gif":  "tweet_gif",
+	".mp4":  "tweet_video",
+	".mov":  "tweet_video",
+}
+
+// openMedia checks that path is a supported media file within X's size
+// limit for its kind. Images are prepared for upload first, see
+// prepareImage.
+func openMedia(path string, keepEXIF bool) (*mediaFile, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	category, ok := mediaCategories[ext]
+	if !ok {
+		return nil, fmt.Errorf(tr("%s: unsupported media type %q"), path, ext)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf(tr("%s is a directory"), path)
+	}
+
+	var source string
+	var changes []string
+	if category == "tweet_image" {
+		prepared, err := prepareImage(path, keepEXIF)
+		if err != nil {
+			return nil, err
+		}
+		if prepared.data != nil {
+			processed, err := writeTempMedia(prepared.data, prepared.ext)
+			if err != nil {
+				return nil, err
+			}
+			source, changes, path, ext = path, prepared.changes, processed, prepared.ext
+			if info, err = os.Stat(path); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	limit := int64(maxImageBytes)
+	switch category {
+	case "tweet_gif":
+		limit = maxGIFBytes
+	case "tweet_video":
+		limit = maxVideoBytes
+	}
+	if info.Size() > limit {
+		return nil, fmt.Errorf(tr("%s is %d MB, the limit for this media type is %d MB"), path, info.Size()>>20, limit>>20)
+	}
+
+	mediaType := mime.TypeByExtension(ext)
+	if ext == ".mov" {
+		mediaType = "video/quicktime"
+	}
+	return &mediaFile{path: path, mediaType: mediaType, category: category, size: info.Size(), source: source, changes: changes}, nil
+}
+
+// openMediaFiles validates a set of attachments: up to four images, or a
+// single GIF or video. alts[i], when present, describes paths[i].
+// keepEXIF leaves the metadata in images.
+func openMediaFiles(paths, alts []string, keepEXIF bool) ([]*mediaFile, error) {
+	if len(alts) > len(paths) {
+		return nil, fmt.Errorf(tr("got %d --alt descriptions for %d media files"), len(alts), len(paths))
+	}
+	files := make([]*mediaFile, 0, len(paths))
+	for i, path := range paths {
+		file, err := openMedia(path, keepEXIF)
+		if err != nil {
+			return nil, err
+		}
+		if i < len(alts) {
+			if n := utf8.RuneCountInString(alts[i]); n > maxAltTextLength {
+				return nil, fmt.Errorf(tr("alt text for %s is %d characters, the limit is %d"), path, n, maxAltTextLength)
+			}
+			file.alt = alts[i]
+		}
+		files = append(files, file)
+	}
+
+	for _, file := range files {
+		if file.category != "tweet_image" && len(files) > 1 {
+			return nil, errors.New(tr("a GIF or video must be the only attachment"))
+		}
+	}
+	if len(files) > maxImages {
+		return nil, fmt.Errorf(tr("at most %d images can be attached, got %d"), maxImages, len(files))
+	}
+	return files, nil
+}
+
+// uploadMedia uploads each file and returns the media IDs in order
+func (a *app) uploadMedia(ctx context.Context, files []*mediaFile) ([]string, error) {
+	ids := make([]string, 0, len(files))
+	for _, file := range files {
+		start := time.Now()
+		id, err := a.uploadMediaFile(ctx, file)
+		a.stats.observe("uploads", "upload", start, err)
+		if err != nil {
+			return nil, fmt.Errorf(tr("failed to upload %s: %w"), file.path, err)
+		}
+		if file.alt != "" {
+			if err := a.setAltText(ctx, id, file.alt); err != nil {
+				return nil, fmt.Errorf(tr("failed to set alt text for %s: %w"), file.path, err)
+			}
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// uploadMediaFile runs the chunked INIT/APPEND/FINALIZE flow for one file
+// and waits for any server-side processing to finish. The progress of a
+// large file is saved after every chunk, so an upload that is interrupted
+// resumes on the next try rather than starting over.
+func (a *app) uploadMediaFile(ctx context.Context, file *mediaFile) (string, error) {
+	f, err := os.Open(file.path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	resumable := file.size > progressThreshold
+	var key string
+	var pending pendingUpload
+	if resumable {
+		if key, err = a.uploadKey(file); err != nil {
+			return "", err
+		}
+		pending, _ = pendingUploadFor(key)
+	}
+	if pending.MediaID == "" {
+		var initRes mediaUploadResponse
+		err = a.mediaCommand(ctx, url.Values{
+			"command":        {"INIT"},
+			"total_bytes":    {strconv.FormatInt(file.size, 10)},
+			"media_type":     {file.mediaType},
+			"media_category": {file.category},
+		}, nil, &initRes)
+		if err != nil {
+			return "", err
+		}
+		expiry := defaultUploadExpiry
+		if initRes.ExpiresAfterSecs > 0 {
+			expiry = time.Duration(initRes.ExpiresAfterSecs) * time.Second
+		}
+		pending = pendingUpload{MediaID: initRes.MediaIDString, ExpiresAt: time.Now().Add(expiry)}
+	} else {
+		if _, err := f.Seek(int64(pending.Segments)*mediaChunkSize, io.SeekStart); err != nil {
+			return "", err
+		}
+		if !machineReadable() {
+			fmt.Fprintf(os.Stderr, tr("Resuming the upload of %s after %d MB.\n"), filepath.Base(file.path), int64(pending.Segments)*mediaChunkSize>>20)
+		}
+	}
+	mediaID := pending.MediaID
+
+	// A failure the API gave, rather than the network or the API being
+	// down, means the saved upload cannot be continued, so the next try
+	// starts afresh
+	fail := func(err error) (string, error) {
+		if resumable && !chunkRetryable(err) && ctx.Err() == nil {
+			savePendingUpload(key, nil)
+		}
+		return "", err
+	}
+
+	progress := newUploadProgress(file, int64(pending.Segments)*mediaChunkSize)
+	chunk := make([]byte, mediaChunkSize)
+	for segment := pending.Segments; ; segment++ {
+		n, err := io.ReadFull(f, chunk)
+		if err == io.EOF {
+			break
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return "", err
+		}
+		if err := a.appendChunk(ctx, mediaID, segment, chunk[:n], progress); err != nil {
+			progress.done()
+			return fail(err)
+		}
+		progress.add(n)
+		if resumable {
+			pending.Segments = segment + 1
+			if err := savePendingUpload(key, &pending); err != nil {
+				progress.note(trf("could not save the upload progress: %v", err))
+			}
+		}
+	}
+	progress.done()
+
+	var finalRes mediaUploadResponse
+	err = a.mediaCommand(ctx, url.Values{
+		"command":  {"FINALIZE"},
+		"media_id": {mediaID},
+	}, nil, &finalRes)
+	if err != nil {
+		return fail(err)
+	}
+	if resumable {
+		savePendingUpload(key, nil)
+	}
+
+	return mediaID, a.waitForProcessing(ctx, mediaID, &finalRes)
+}
+
+// waitForProcessing polls STATUS until videos and GIFs are ready to attach
+func (a *app) waitForProcessing(ctx context.Context, mediaID string, res *mediaUploadResponse) error {
+	for res.ProcessingInfo != nil {
+		info := res.ProcessingInfo
+		switch info.State {
+		case "succeeded":
+			return nil
+		case "failed":
+			if info.Error != nil {
+				return fmt.Errorf(tr("media processing failed: %s"), info.Error.Message)
+			}
+			return errors.New(tr("media processing failed"))
+		}
+
+		wait := time.Duration(max(info.CheckAfterSecs, 1)) * time.Second
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+
+		*res = mediaUploadResponse{}
+		err := a.mediaCommand(ctx, url.Values{
+			"command":  {"STATUS"},
+			"media_id": {mediaID},
+		}, nil, res)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setAltText attaches a description to uploaded media
+func (a *app) setAltText(ctx context.Context, mediaID, text string) error {
+	body, err := json.Marshal(map[string]any{
+		"media_id": mediaID,
+		"alt_text": map[string]string{"text": text},
+	})
+	if err != nil {
+		return err
+	}
+	req, err := a.newSignedRequest(ctx, http.MethodPost, mediaMetadataEndpoint, nil, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return a.doJSON(req, nil)
+}
+
+// mediaCommand calls the upload endpoint; data, when present, is sent as
+// the multipart "media" field of an APPEND
+func (a *app) mediaCommand(ctx context.Context, query url.Values, data []byte, out any) error {
+	method := http.MethodPost
+	if query.Get("command") == "STATUS" {
+		method = http.MethodGet
+	}
+
+	var body io.Reader
+	contentType := ""
+	if data != nil {
+		buf := &bytes.Buffer{}
+		writer := multipart.NewWriter(buf)
+		part, err := writer.CreateFormFile("media", "blob")
+		if err != nil {
+			return err
+		}
+		part.Write(data)
+		if err := writer.Close(); err != nil {
+			return err
+		}
+		body = buf
+		contentType = writer.FormDataContentType()
+	}
+
+	req, err := a.newSignedRequest(ctx, method, mediaUploadEndpoint, query, body)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return a.doJSON(req, out)
+}
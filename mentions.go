@@ -0,0 +1,97 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/tweet/timeline"
+	"github.com/michimani/gotwi/tweet/timeline/types"
+)
+
+// mentionsStateFile maps account names to the newest mention already shown
+const mentionsStateFile = "mentions.json"
+
+// listMentions fetches mentions of the authenticated user and returns them
+// with the newest ID in the response
+func (a *app) listMentions(ctx context.Context, input *types.ListMentionsInput) ([]tweetView, string, error) {
+	if err := a.ensureToken(ctx); err != nil {
+		return nil, "", err
+	}
+	res, err := timeline.ListMentions(ctx, a.client, input)
+	if err != nil {
+		return nil, "", fmt.Errorf(tr("failed to fetch mentions: %w"), err)
+	}
+	return newTweetViews(res.Data, res.Includes.Users), gotwi.StringValue(res.Meta.NewestID), nil
+}
+
+func runMentions(args []string) error {
+	fs := newFlagSet("mentions", "mentions [--new] [--count n]")
+	onlyNew := fs.Bool("new", false, "only show mentions since the last check")
+	count := fs.Int("count", 20, "maximum number of mentions to show")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *count < 1 {
+		return errors.New(tr("--count must be at least 1"))
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	lastSeen := map[string]string{}
+	if err := loadState(mentionsStateFile, &lastSeen); err != nil {
+		return err
+	}
+
+	ctx := rootCtx
+	userID, err := a.me(ctx)
+	if err != nil {
+		return err
+	}
+
+	input := &types.ListMentionsInput{
+		ID:          userID,
+		MaxResults:  types.ListMaxResults(pageSize(*count)),
+		TweetFields: tweetViewFields,
+		Expansions:  tweetViewExpand,
+		UserFields:  tweetViewUserFld,
+	}
+	if *onlyNew {
+		input.SinceID = lastSeen[a.config.Active]
+	}
+	views, newest, err := a.listMentions(ctx, input)
+	if err != nil {
+		return err
+	}
+	if len(views) > *count {
+		views = views[:*count]
+	}
+
+	// Mentions arrive newest first, so the newest ID marks them all as seen
+	if newest != "" {
+		lastSeen[a.config.Active] = newest
+		if err := saveState(mentionsStateFile, lastSeen); err != nil {
+			fmt.Fprintln(os.Stderr, tr("Warning: could not save mention state:"), err)
+		}
+	}
+
+	if machineReadable() {
+		return printResult(views)
+	}
+	if len(views) == 0 {
+		if *onlyNew {
+			fmt.Println(tr("No new mentions."))
+		} else {
+			fmt.Println(tr("No mentions."))
+		}
+		return nil
+	}
+	printTweets(os.Stdout, views)
+	return nil
+}
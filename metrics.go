@@ -0,0 +1,87 @@
+package clix
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// MetricsConfig enables emitting statsd metrics about API calls
+type MetricsConfig struct {
+	Address string `json:"address"`          // host:port of a statsd agent, sent over UDP
+	Prefix  string `json:"prefix,omitempty"` // metric name prefix, defaults to "clix"
+}
+
+// metrics sends statsd packets on a best-effort basis. A nil *metrics is
+// valid and does nothing, so callers never need to check whether metrics
+// are configured.
+type metrics struct {
+	conn   net.Conn
+	prefix string
+	tags   string
+}
+
+// newMetrics returns nil when metrics are not configured or the agent
+// address cannot be resolved; posting must never depend on metrics.
+func newMetrics(cfg *MetricsConfig, profile string) *metrics {
+	if cfg == nil || cfg.Address == "" {
+		return nil
+	}
+	conn, err := net.Dial("udp", cfg.Address)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, tr("Warning: metrics disabled:"), err)
+		return nil
+	}
+	prefix := cfg.Prefix
+	if prefix == "" {
+		prefix = "clix"
+	}
+	return &metrics{
+		conn:   conn,
+		prefix: strings.TrimSuffix(prefix, "."),
+		tags:   "profile:" + profile,
+	}
+}
+
+func (m *metrics) send(name, value, kind string, tags ...string) {
+	if m == nil {
+		return
+	}
+	all := append([]string{m.tags}, tags...)
+	packet := fmt.Sprintf("%s.%s:%s|%s|#%s", m.prefix, name, value, kind, strings.Join(all, ","))
+	// Write errors are deliberately ignored: UDP is fire-and-forget and a
+	// missing agent must not affect the command being measured
+	m.conn.Write([]byte(packet))
+}
+
+func (m *metrics) incr(name string, tags ...string) {
+	m.send(name, "1", "c", tags...)
+}
+
+func (m *metrics) timing(name string, d time.Duration, tags ...string) {
+	m.send(name, fmt.Sprintf("%d", d.Milliseconds()), "ms", tags...)
+}
+
+// observe records the latency of an API call and counts it as a success
+// under name or as a failure
+func (m *metrics) observe(name, action string, start time.Time, err error) {
+	if m == nil {
+		return
+	}
+	tag := "action:" + action
+	m.timing("latency", time.Since(start), tag)
+	if err != nil {
+		m.incr("failures", tag)
+		return
+	}
+	m.incr(name)
+}
+
+func (m *metrics) close() {
+	if m == nil {
+		return
+	}
+	m.conn.Close()
+}
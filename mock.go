@@ -0,0 +1,286 @@
+package clix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Mock mode answers X's API with canned responses instead of calling it
+const (
+	mockEnvVar = "CLIX_MOCK"
+	// mockResponsesEnvVar names a JSON file of responses to give instead
+	// of the built-in ones, see mockResponse
+	mockResponsesEnvVar = "CLIX_MOCK_RESPONSES"
+	// mockLogEnvVar names the file requests are recorded to instead of
+	// mockLogFile in the data directory
+	mockLogEnvVar = "CLIX_MOCK_LOG"
+	mockLogFile   = "mock-requests.jsonl"
+)
+
+// mockHosts are the hosts of X's API, which mock mode answers itself
+var mockHosts = []string{"api.twitter.com", "api.x.com", "upload.twitter.com", "upload.x.com"}
+
+// mockUser is who the mock credentials belong to
+var mockUser = map[string]any{"id": "1000000000", "name": "clix demo", "username": "clixdemo"}
+
+// mocking reports whether --mock or $CLIX_MOCK is set
+func mocking() bool {
+	if globalOptions.mock {
+		return true
+	}
+	on, _ := strconv.ParseBool(os.Getenv(mockEnvVar))
+	return on
+}
+
+// mockCredentials stand in for the account's in mock mode, so nothing
+// real is signed with and a missing login does not matter
+func mockCredentials() *Credentials {
+	return &Credentials{ConsumerKey: "mock", ConsumerSecret: "mock", AccessToken: "mock", AccessSecret: "mock"}
+}
+
+// mockResponse is an entry of the $CLIX_MOCK_RESPONSES file, which maps
+// "METHOD /path" to the response, e.g.
+//
+//	{"GET /2/users/*/followers": {"body": {"data": [{"id": "2", "username": "a"}]}}}
+//
+// Paths match with path.Match, so * stands for one segment; an exact
+// match wins over a pattern.
+type mockResponse struct {
+	// Status defaults to 200
+	Status int `json:"status,omitempty"`
+	// Headers are added to the response, e.g. x-rate-limit-remaining
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// mockRequest is a line of the request log
+type mockRequest struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	URL    string    `json:"url"`
+	Body   any       `json:"body,omitempty"`
+	Status int       `json:"status"`
+}
+
+// mockTransport takes the place of the network under the API's HTTP
+// clients: the retries, dry-run guard and logging above it work as they
+// do against X. Other hosts are reached for reads alone.
+type mockTransport struct {
+	base      http.RoundTripper
+	responses map[string]mockResponse
+	logPath   string
+	mu        sync.Mutex
+}
+
+// mockSequence tells apart the IDs made in the same millisecond
+var mockSequence atomic.Int64
+
+// mockID returns a new ID shaped like X's, which sort by creation time
+func mockID() string {
+	const twitterEpoch = 1288834974657
+	ms := time.Now().UnixMilli() - twitterEpoch
+	return strconv.FormatInt(ms<<22|mockSequence.Add(1)&0xfff, 10)
+}
+
+// newMockTransport reads the responses file, if any. An unreadable one is
+// reported by every request, like a bad network setting.
+func newMockTransport(base http.RoundTripper) http.RoundTripper {
+	t := &mockTransport{base: base, responses: map[string]mockResponse{}}
+	if file := os.Getenv(mockResponsesEnvVar); file != "" {
+		data, err := os.ReadFile(file)
+		if err == nil {
+			err = json.Unmarshal(data, &t.responses)
+		}
+		if err != nil {
+			return failingTransport{fmt.Errorf(tr("failed to read $%s: %w"), mockResponsesEnvVar, err)}
+		}
+	}
+	t.logPath = os.Getenv(mockLogEnvVar)
+	if t.logPath == "" {
+		dir, err := getDataDir()
+		if err != nil {
+			return failingTransport{err}
+		}
+		t.logPath = filepath.Join(dir, mockLogFile)
+	}
+	return t
+}
+
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !slices.Contains(mockHosts, req.URL.Hostname()) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			return nil, fmt.Errorf(tr("mock mode: not sending %s %s"), req.Method, redactURL(req.URL))
+		}
+		return t.base.RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+	status, header, out := t.respond(req, body)
+	t.record(req, body, status)
+
+	res := &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader(out)),
+		Request:    req,
+	}
+	res.ContentLength = int64(len(out))
+	return res, nil
+}
+
+// respond returns the file's response for req if it has one, otherwise
+// the built-in one
+func (t *mockTransport) respond(req *http.Request, body []byte) (int, http.Header, []byte) {
+	header := http.Header{"Content-Type": {"application/json"}}
+	key := req.Method + " " + req.URL.Path
+	r, ok := t.responses[key]
+	if !ok {
+		for _, pattern := range slices.Sorted(maps.Keys(t.responses)) {
+			if matched, _ := path.Match(pattern, key); matched {
+				r, ok = t.responses[pattern], true
+				break
+			}
+		}
+	}
+	if ok {
+		status := r.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		for name, value := range r.Headers {
+			header.Set(name, value)
+		}
+		return status, header, r.Body
+	}
+
+	status, out := cannedResponse(req, body)
+	if out == nil {
+		return status, header, nil
+	}
+	data, _ := json.Marshal(out)
+	return status, header, data
+}
+
+// mockRelations are the flags the API answers with when a user likes,
+// follows and so on, by the last segment of the path
+var mockRelations = map[string]string{
+	"likes":     "liked",
+	"retweets":  "retweeted",
+	"bookmarks": "bookmarked",
+	"following": "following",
+	"blocking":  "blocking",
+	"muting":    "muting",
+}
+
+// cannedResponse is the built-in answer to a request: what X would say to
+// it succeeding, with new IDs for what is created and empty lists for
+// what is read
+func cannedResponse(req *http.Request, body []byte) (int, any) {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	last := segments[len(segments)-1]
+	query := req.URL.Query()
+
+	if strings.HasPrefix(req.URL.Host, "upload.") {
+		switch {
+		case last != "upload.json":
+			return http.StatusOK, nil
+		case query.Get("command") == "INIT":
+			return http.StatusAccepted, map[string]any{"media_id_string": mockID(), "expires_after_secs": 86400}
+		case query.Get("command") == "APPEND":
+			return http.StatusNoContent, nil
+		}
+		return http.StatusOK, map[string]any{"media_id_string": query.Get("media_id")}
+	}
+
+	switch req.Method {
+	case http.MethodPost:
+		if req.URL.Path == "/2/tweets" {
+			var input struct {
+				Text string `json:"text"`
+			}
+			json.Unmarshal(body, &input)
+			return http.StatusCreated, map[string]any{"data": map[string]any{"id": mockID(), "text": input.Text}}
+		}
+		if flag, ok := mockRelations[last]; ok {
+			return http.StatusOK, map[string]any{"data": map[string]any{flag: true}}
+		}
+		return http.StatusCreated, map[string]any{"data": map[string]any{"id": mockID()}}
+	case http.MethodDelete:
+		if len(segments) > 3 {
+			if flag, ok := mockRelations[segments[3]]; ok {
+				return http.StatusOK, map[string]any{"data": map[string]any{flag: false}}
+			}
+		}
+		return http.StatusOK, map[string]any{"data": map[string]any{"deleted": true}}
+	}
+
+	// Reads of one user or tweet get one; anything else is an empty page
+	switch {
+	case req.URL.Path == "/2/users/me", len(segments) == 3 && segments[1] == "users",
+		len(segments) == 4 && segments[1] == "users" && segments[2] == "by":
+		return http.StatusOK, map[string]any{"data": mockUser}
+	case len(segments) == 3 && segments[1] == "tweets" && segments[2] != "search":
+		return http.StatusOK, map[string]any{"data": map[string]any{
+			"id": segments[2], "text": "A mock tweet.", "author_id": mockUser["id"],
+			"created_at": time.Now().UTC().Format(time.RFC3339),
+		}}
+	}
+	return http.StatusOK, map[string]any{"data": []any{}, "meta": map[string]any{"result_count": 0}}
+}
+
+// record appends req to the log. Media are noted by size, and a failure
+// to write is only a warning.
+func (t *mockTransport) record(req *http.Request, body []byte, status int) {
+	entry := mockRequest{Time: time.Now(), Method: req.Method, URL: redactURL(req.URL), Status: status}
+	switch contentType := req.Header.Get("Content-Type"); {
+	case len(body) == 0:
+	case json.Valid(body):
+		entry.Body = json.RawMessage(body)
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		entry.Body, _ = url.ParseQuery(string(body))
+	default:
+		entry.Body = fmt.Sprintf("%d bytes", len(body))
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	f, err := os.OpenFile(t.logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err == nil {
+		_, err = f.Write(append(line, '\n'))
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, tr("Warning: could not record the mock request:"), err)
+	}
+}
@@ -0,0 +1,242 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/user/block"
+	blocktypes "github.com/michimani/gotwi/user/block/types"
+	"github.com/michimani/gotwi/user/mute"
+	mutetypes "github.com/michimani/gotwi/user/mute/types"
+)
+
+// moderationActions are the commands that mute or block accounts, with
+// what they report once done or on failing, to translate
+var moderationActions = map[string]struct {
+	done, failed string
+	run          func(a *app, ctx context.Context, userID, targetID string) error
+}{
+	"mute":    {"Muted @%s.\n", "Failed to mute @%s: %v\n", (*app).mute},
+	"unmute":  {"Unmuted @%s.\n", "Failed to unmute @%s: %v\n", (*app).unmute},
+	"block":   {"Blocked @%s.\n", "Failed to block @%s: %v\n", (*app).block},
+	"unblock": {"Unblocked @%s.\n", "Failed to unblock @%s: %v\n", (*app).unblock},
+}
+
+func (a *app) mute(ctx context.Context, userID, targetID string) error {
+	start := time.Now()
+	_, err := mute.Create(ctx, a.client, &mutetypes.CreateInput{ID: userID, TargetID: targetID})
+	a.stats.observe("mutes", "mute", start, err)
+	return err
+}
+
+func (a *app) unmute(ctx context.Context, userID, targetID string) error {
+	start := time.Now()
+	_, err := mute.Delete(ctx, a.client, &mutetypes.DeleteInput{SourceUserID: userID, TargetID: targetID})
+	a.stats.observe("unmutes", "unmute", start, err)
+	return err
+}
+
+func (a *app) block(ctx context.Context, userID, targetID string) error {
+	start := time.Now()
+	_, err := block.Create(ctx, a.client, &blocktypes.CreateInput{ID: userID, TargetID: targetID})
+	a.stats.observe("blocks", "block", start, err)
+	return err
+}
+
+func (a *app) unblock(ctx context.Context, userID, targetID string) error {
+	start := time.Now()
+	_, err := block.Delete(ctx, a.client, &blocktypes.DeleteInput{SourceUserID: userID, TargetID: targetID})
+	a.stats.observe("unblocks", "unblock", start, err)
+	return err
+}
+
+// moderationList returns up to count accounts you have blocked, or muted
+func (a *app) moderationList(ctx context.Context, blocked bool, count int) ([]userView, error) {
+	userID, err := a.me(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token := ""
+	views := []userView{}
+	for len(views) < count {
+		var users []resources.User
+		var meta resources.PaginationMeta
+		if blocked {
+			res, err := block.List(ctx, a.client, &blocktypes.ListInput{
+				ID: userID, MaxResults: blocktypes.ListMaxResults(min(max(count, 1), 1000)), PaginationToken: token, UserFields: userViewFields,
+			})
+			if err != nil {
+				return nil, fmt.Errorf(tr("failed to fetch blocked accounts: %w"), err)
+			}
+			users, meta = res.Data, res.Meta
+		} else {
+			res, err := mute.Lists(ctx, a.client, &mutetypes.ListsInput{
+				ID: userID, MaxResults: mutetypes.ListMaxResults(min(max(count, 1), 1000)), PaginationToken: token, UserFields: userViewFields,
+			})
+			if err != nil {
+				return nil, fmt.Errorf(tr("failed to fetch muted accounts: %w"), err)
+			}
+			users, meta = res.Data, res.Meta
+		}
+		for _, u := range users {
+			views = append(views, newUserView(u))
+		}
+		if meta.NextToken == nil || *meta.NextToken == "" {
+			break
+		}
+		token = *meta.NextToken
+	}
+	if len(views) > count {
+		views = views[:count]
+	}
+	return views, nil
+}
+
+// readHandles reads one handle per line from a file, or stdin for "-",
+// skipping blank lines and # comments
+func readHandles(path string) ([]string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(tr("failed to read handles: %w"), err)
+	}
+	var handles []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		if line = strings.TrimSpace(line); line != "" {
+			handles = append(handles, line)
+		}
+	}
+	return handles, nil
+}
+
+func runMute(args []string) error    { return runModeration("mute", args) }
+func runUnmute(args []string) error  { return runModeration("unmute", args) }
+func runBlock(args []string) error   { return runModeration("block", args) }
+func runUnblock(args []string) error { return runModeration("unblock", args) }
+
+// runModeration mutes, unmutes, blocks or unblocks every account given.
+// One account failing does not stop the others, so a long list of handles
+// can be worked through in one go.
+func runModeration(name string, args []string) error {
+	fs := newFlagSet(name, name+" @user... | "+name+" --file handles.txt")
+	file := fs.String("file", "", `read handles from this file, one per line ("-" for stdin)`)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if *file != "" {
+		handles, err := readHandles(*file)
+		if err != nil {
+			return err
+		}
+		args = append(args, handles...)
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	ctx := rootCtx
+	userID, err := a.me(ctx)
+	if err != nil {
+		return err
+	}
+	action := moderationActions[name]
+	results := []relationship{}
+	failed := 0
+	for _, username := range args {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		targetID, err := a.lookupUser(ctx, username)
+		if err == nil {
+			err = action.run(a, ctx, userID, targetID)
+		}
+		if err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, tr(action.failed), trimHandle(username), err)
+			continue
+		}
+		results = append(results, relationship{Username: trimHandle(username), ID: targetID, Action: name})
+		if !machineReadable() {
+			fmt.Printf(tr(action.done), trimHandle(username))
+		}
+	}
+	if machineReadable() {
+		printResult(results)
+	}
+	if failed > 0 {
+		err := fmt.Errorf(tr("failed to %s %d of %d accounts"), name, failed, len(args))
+		if len(results) > 0 {
+			return withExitCode(exitPartial, err)
+		}
+		return err
+	}
+	return nil
+}
+
+func runBlocks(args []string) error { return runModerationList("blocks", true, args) }
+func runMutes(args []string) error  { return runModerationList("mutes", false, args) }
+
+func runModerationList(name string, blocked bool, args []string) error {
+	if len(args) > 0 && isHelpArg(args[0]) {
+		fmt.Fprintf(os.Stderr, tr("Usage: clix %s [list]\n"), name)
+		return nil
+	}
+	action := "list"
+	if len(args) > 0 && !isFlagArg(args[0]) {
+		action, args = args[0], args[1:]
+	}
+	if action != "list" {
+		return fmt.Errorf(tr("unknown %s action %q"), name, action)
+	}
+
+	fs := newFlagSet(name+" list", name+" list [--count n]")
+	count := fs.Int("count", 1000, "number of accounts to show")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *count < 1 {
+		return errors.New(tr("--count must be at least 1"))
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	views, err := a.moderationList(rootCtx, blocked, *count)
+	if err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(views)
+	}
+	if len(views) == 0 {
+		fmt.Println(tr("No accounts."))
+		return nil
+	}
+	for _, view := range views {
+		fmt.Printf("@%-16s %s\n", view.Username, view.Name)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	clixclient "github.com/voltycodes/clix/client"
+)
+
+// NetworkConfig is the "network" section of the config
+type NetworkConfig = clixclient.Network
+
+// networkConfig is the section of the config file last read
+var networkConfig *NetworkConfig
+
+// networkSettings merges the flags over the config file
+func networkSettings() NetworkConfig {
+	var settings NetworkConfig
+	if networkConfig != nil {
+		settings = *networkConfig
+	}
+	if globalOptions.proxy != "" {
+		settings.Proxy = globalOptions.proxy
+	}
+	if globalOptions.caBundle != "" {
+		settings.CABundle = globalOptions.caBundle
+	}
+	if globalOptions.requestTimeout != "" {
+		settings.RequestTimeout = globalOptions.requestTimeout
+	}
+	if globalOptions.timeout != "" {
+		settings.Timeout = globalOptions.timeout
+	}
+	return settings
+}
+
+// callTimeout returns the --timeout in effect, 0 for none
+func callTimeout() (time.Duration, error) {
+	settings := networkSettings()
+	if settings.Timeout == "" || settings.Timeout == "0" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(settings.Timeout)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf(tr("invalid timeout %q, expected a duration like 1m"), settings.Timeout)
+	}
+	return d, nil
+}
+
+// isTimeout reports whether err is a call or connection that ran out of
+// time, which exits with exitTimeout
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// newTransport returns the transport every HTTP client is built on, set
+// up with the proxy, CA bundle and timeout in effect
+func newTransport() (*http.Transport, error) {
+	return clixclient.NewTransport(networkSettings())
+}
+
+// failingTransport fails every request with the error that kept the
+// transport from being set up, so a bad network setting is reported by
+// the first request rather than ignored
+type failingTransport struct {
+	err error
+}
+
+func (t failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, t.err
+}
+
+// newDirectHTTPClient is an HTTP client without the retries and dry-run
+// guard of newHTTPClient, for calls that are not API requests such as
+// OAuth token exchanges and webhooks
+func newDirectHTTPClient() *http.Client {
+	transport, err := newTransport()
+	if err != nil {
+		return &http.Client{Transport: failingTransport{err}}
+	}
+	if mocking() {
+		return &http.Client{Transport: newMockTransport(transport)}
+	}
+	return &http.Client{Transport: transport}
+}
@@ -0,0 +1,92 @@
+package clix
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/user/userlookup"
+	userlookuptypes "github.com/michimani/gotwi/user/userlookup/types"
+)
+
+// usernamePattern is what X allows in a username
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_]{1,15}$`)
+
+func profileURL(username string) string {
+	return "https://x.com/" + username
+}
+
+// openLink opens url in the browser, printing it instead when there is
+// none to open it in
+func openLink(url string) {
+	if err := openBrowser(url); err != nil {
+		fmt.Fprintln(os.Stderr, tr("Could not open a browser:"), url)
+	}
+}
+
+func runOpen(args []string) error {
+	fs := newFlagSet("open", "open <id|url|@user> | --last | --profile")
+	last := fs.Bool("last", false, "open the most recent tweet posted with clix")
+	profile := fs.Bool("profile", false, "open the profile of the account")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	modes := 0
+	for _, set := range []bool{len(args) > 0, *last, *profile} {
+		if set {
+			modes++
+		}
+	}
+	if modes != 1 || len(args) > 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	var url string
+	switch {
+	case *last:
+		entry, err := lastPosted()
+		if err != nil {
+			return err
+		}
+		url = tweetURL("", entry.ID)
+	case *profile:
+		// Only the account's username needs the API
+		a, err := setup(false)
+		if err != nil {
+			return err
+		}
+		defer a.close()
+		if err := a.ensureToken(rootCtx); err != nil {
+			return err
+		}
+		res, err := userlookup.GetMe(rootCtx, a.client, &userlookuptypes.GetMeInput{})
+		if err != nil {
+			return fmt.Errorf(tr("failed to look up the authenticated user: %w"), err)
+		}
+		url = profileURL(gotwi.StringValue(res.Data.Username))
+	case strings.HasPrefix(args[0], "@"):
+		username := trimHandle(args[0])
+		if !usernamePattern.MatchString(username) {
+			return withExitCode(exitValidation, fmt.Errorf(tr("%q is not a username"), args[0]))
+		}
+		url = profileURL(username)
+	default:
+		id, err := parseTweetID(args[0])
+		if err != nil {
+			return err
+		}
+		url = tweetURL("", id)
+	}
+
+	if machineReadable() {
+		if err := printResult(map[string]string{"url": url}); err != nil {
+			return err
+		}
+	}
+	openLink(url)
+	return nil
+}
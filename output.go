@@ -0,0 +1,88 @@
+package clix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"text/template"
+)
+
+// outputTemplate is the parsed --format template, if one was given
+var outputTemplate *template.Template
+
+// machineReadable reports whether --json or --format was given, in which
+// case commands print results with printResult instead of prose
+func machineReadable() bool {
+	return globalOptions.json || globalOptions.format != ""
+}
+
+// parseOutputFormat checks the --format template up front, so a typo is
+// reported before anything is posted
+func parseOutputFormat() error {
+	if globalOptions.format == "" {
+		return nil
+	}
+	tmpl, err := template.New("format").Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}).Parse(globalOptions.format)
+	if err != nil {
+		return fmt.Errorf(tr("invalid --format template: %w"), err)
+	}
+	outputTemplate = tmpl
+	return nil
+}
+
+func printJSON(v any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// printResult prints v as JSON, or through the --format template. A
+// template is applied to each element of a slice, one per line.
+func printResult(v any) error {
+	if globalOptions.format == "" {
+		return printJSON(v)
+	}
+	items := []any{v}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+		items = make([]any, rv.Len())
+		for i := range items {
+			items[i] = rv.Index(i).Interface()
+		}
+	}
+	for _, item := range items {
+		if err := outputTemplate.Execute(os.Stdout, item); err != nil {
+			return fmt.Errorf(tr("failed to format output: %w"), err)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// verbosef logs a diagnostic line, shown on stderr when --verbose is given
+func verbosef(format string, args ...any) {
+	if logger.Enabled(context.Background(), slog.LevelInfo) {
+		logger.Info(fmt.Sprintf(format, args...))
+	}
+}
+
+// printError reports a failed command on stderr, as a JSON object when
+// machine-readable output was requested
+func printError(err error) {
+	if machineReadable() {
+		data, _ := json.Marshal(struct {
+			Error    string `json:"error"`
+			ExitCode int    `json:"exit_code"`
+		}{err.Error(), exitCode(err)})
+		fmt.Fprintln(os.Stderr, string(data))
+		return
+	}
+	fmt.Fprintln(os.Stderr, paint(colorsFor(os.Stderr).err, tr("Error:")), err)
+}
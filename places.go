@@ -0,0 +1,185 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	clixclient "github.com/voltycodes/clix/client"
+)
+
+const (
+	placesSearchEndpoint   = "https://api.twitter.com/1.1/geo/search.json"
+	reverseGeocodeEndpoint = "https://api.twitter.com/1.1/geo/reverse_geocode.json"
+)
+
+// noPlace is the --place-id that tags a tweet with no place even when the
+// config gives one
+const noPlace = "none"
+
+// place is a location a tweet can be tagged with
+type place struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+	Country  string `json:"country,omitempty"`
+	Type     string `json:"type"`
+}
+
+// parseCoordinates reads "lat,lon" as --geo and --near take it
+func parseCoordinates(s string) (lat, lon float64, err error) {
+	latText, lonText, ok := strings.Cut(s, ",")
+	if ok {
+		lat, err = strconv.ParseFloat(strings.TrimSpace(latText), 64)
+	}
+	if ok && err == nil {
+		lon, err = strconv.ParseFloat(strings.TrimSpace(lonText), 64)
+	}
+	if !ok || err != nil {
+		return 0, 0, fmt.Errorf(tr("coordinates %q are not \"lat,lon\", e.g. \"51.5072,-0.1276\""), s)
+	}
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return 0, 0, fmt.Errorf(tr("coordinates %q are out of range: the latitude is within ±90 and the longitude within ±180"), s)
+	}
+	return lat, lon, nil
+}
+
+// geoError explains the 403 that tiers without the v1.1 geo endpoints get
+func geoError(err error) error {
+	var statusErr *clixclient.StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusForbidden {
+		return fmt.Errorf(tr("%w (looking up places needs access to the v1.1 geo API, which not every tier has; tag tweets with a known --place-id instead)"), err)
+	}
+	return err
+}
+
+// places calls a v1.1 geo endpoint, which both answer with a list of places
+func (a *app) places(ctx context.Context, endpoint string, query url.Values) ([]place, error) {
+	req, err := a.newSignedRequest(ctx, http.MethodGet, endpoint, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Result struct {
+			Places []struct {
+				ID        string `json:"id"`
+				Name      string `json:"name"`
+				FullName  string `json:"full_name"`
+				Country   string `json:"country"`
+				PlaceType string `json:"place_type"`
+			} `json:"places"`
+		} `json:"result"`
+	}
+	if err := a.doJSON(req, &res); err != nil {
+		return nil, geoError(err)
+	}
+	places := make([]place, 0, len(res.Result.Places))
+	for _, p := range res.Result.Places {
+		places = append(places, place{ID: p.ID, Name: p.Name, FullName: p.FullName, Country: p.Country, Type: p.PlaceType})
+	}
+	return places, nil
+}
+
+// searchPlaces returns up to count places matching name, near near when
+// it is given as "lat,lon"
+func (a *app) searchPlaces(ctx context.Context, name, near string, count int) ([]place, error) {
+	query := url.Values{"max_results": {strconv.Itoa(count)}}
+	if name != "" {
+		query.Set("query", name)
+	}
+	if near != "" {
+		lat, lon, err := parseCoordinates(near)
+		if err != nil {
+			return nil, err
+		}
+		query.Set("lat", strconv.FormatFloat(lat, 'f', -1, 64))
+		query.Set("long", strconv.FormatFloat(lon, 'f', -1, 64))
+	}
+	places, err := a.places(ctx, placesSearchEndpoint, query)
+	if err != nil {
+		return nil, fmt.Errorf(tr("failed to search places: %w"), err)
+	}
+	return places, nil
+}
+
+// resolveGeo turns the coordinates of --geo into the place X knows nearest
+// to them, since tweets are tagged with places and not with coordinates
+func (a *app) resolveGeo(ctx context.Context, s TweetSettings) (TweetSettings, error) {
+	if s.Geo == "" {
+		return s, nil
+	}
+	lat, lon, err := parseCoordinates(s.Geo)
+	if err != nil {
+		return s, err
+	}
+	query := url.Values{
+		"lat":         {strconv.FormatFloat(lat, 'f', -1, 64)},
+		"long":        {strconv.FormatFloat(lon, 'f', -1, 64)},
+		"max_results": {"1"},
+	}
+	places, err := a.places(ctx, reverseGeocodeEndpoint, query)
+	if err != nil {
+		return s, fmt.Errorf(tr("failed to find the place for --geo: %w"), err)
+	}
+	if len(places) == 0 {
+		return s, withExitCode(exitNotFound, fmt.Errorf(tr("X knows no place near %s"), s.Geo))
+	}
+	s.PlaceID, s.Geo = places[0].ID, ""
+	return s, nil
+}
+
+func runPlaces(args []string) error {
+	if len(args) == 0 || args[0] != "search" {
+		fmt.Fprintln(os.Stderr, tr(`Usage: clix places search ["city"] [--near "lat,lon"] [--count 10]`))
+		if len(args) > 0 && isHelpArg(args[0]) {
+			return nil
+		}
+		return errUsage
+	}
+	fs := newFlagSet("places search", `places search ["city"] [--near "lat,lon"] [--count 10]  (place IDs to tag tweets with, with post --place-id)`)
+	near := fs.String("near", "", `look around "lat,lon", without a name the places there`)
+	count := fs.Int("count", 10, "the most places to list, up to 20")
+	args, err := parseFlags(fs, args[1:])
+	if err != nil {
+		return err
+	}
+	name := strings.Join(args, " ")
+	if name == "" && *near == "" {
+		fs.Usage()
+		return errUsage
+	}
+	if *count < 1 || *count > 20 {
+		return withExitCode(exitUsage, errors.New(tr("--count must be between 1 and 20")))
+	}
+	if *near != "" {
+		if _, _, err := parseCoordinates(*near); err != nil {
+			return withExitCode(exitUsage, err)
+		}
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+	places, err := a.searchPlaces(rootCtx, name, *near, *count)
+	if err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(places)
+	}
+	if len(places) == 0 {
+		fmt.Println(tr("No places found."))
+		return nil
+	}
+	for _, p := range places {
+		fmt.Printf("%-18s %-14s %s\n", p.ID, p.Type, p.FullName)
+	}
+	return nil
+}
@@ -0,0 +1,813 @@
+package clix
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/tweet/managetweet/types"
+	clixclient "github.com/voltycodes/clix/client"
+	"github.com/voltycodes/clix/compose"
+	"golang.org/x/term"
+)
+
+// postResult is the --json output of a successful post
+type postResult struct {
+	ID       string   `json:"id,omitempty"`
+	Text     string   `json:"text"`
+	MediaIDs []string `json:"media_ids,omitempty"`
+	DryRun   bool     `json:"dry_run,omitempty"`
+}
+
+// postTweet creates a tweet and returns its ID
+func (a *app) postTweet(ctx context.Context, input *types.CreateInput) (string, error) {
+	if err := a.ensureToken(ctx); err != nil {
+		return "", err
+	}
+	var links []shortLink
+	if shortener := a.config.Shortener; shortener != nil && input.Text != nil {
+		text, shortened, err := shortener.shortenLinks(ctx, *input.Text)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, tr("Warning: posting the links unshortened:"), err)
+		} else {
+			input.Text, links = gotwi.String(text), shortened
+		}
+	}
+	start := time.Now()
+	id, err := clixclient.Post(ctx, a.client, input)
+	a.stats.observe("posts", "post", start, err)
+	text := gotwi.StringValue(input.Text)
+	if err != nil {
+		a.runHooks(hookPostFailed, "", text, err)
+		return "", err
+	}
+
+	entry := historyEntry{ID: id, Account: a.config.Active, Text: text, PostedAt: time.Now(), Links: links}
+	if err := appendHistory(entry); err != nil {
+		fmt.Fprintln(os.Stderr, tr("Warning: tweet was posted but not recorded in history:"), err)
+	}
+	a.runHooks(hookPost, id, text, nil)
+	return id, nil
+}
+
+// stdinIsTerminal reports whether stdin is attached to a terminal rather
+// than a pipe or file
+func stdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// readText returns the tweet text from the arguments, or from stdin when
+// there are none and stdin is not a terminal
+func readText(args []string) (string, error) {
+	if len(args) > 0 {
+		return strings.TrimSpace(strings.Join(args, " ")), nil
+	}
+	if stdinIsTerminal() {
+		return "", nil
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf(tr("failed to read stdin: %w"), err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// postRequest is a tweet as the user describes it, before validation
+type postRequest struct {
+	text    string
+	media   []string // local file paths
+	alt     []string // alt text for media, in the same order
+	replyTo string   // tweet ID or URL
+	quote   string   // tweet ID or URL
+	split   bool     // post over-length text as a thread instead of failing
+	// keepEXIF leaves the metadata, such as the GPS location, in images
+	keepEXIF bool
+
+	poll         []string // poll options
+	pollDuration int      // minutes the poll stays open
+
+	// notX is set when the post only goes to other networks with --to, so
+	// X's length limit does not apply
+	notX bool
+	// transformed lists the transforms from the config that rewrote text
+	transformed []string
+	// settings are those given with flags; the config fills in the rest
+	// when the tweet is posted
+	settings TweetSettings
+	// force posts it against the style rules
+	force bool
+	// lint is --lint or --no-lint, nil for the config to decide
+	lint *bool
+	// strictA11y refuses to post when the accessibility checks warn
+	strictA11y bool
+	// checked is set once lint and the other checks ran on the request,
+	// asking where they could, before it was saved for later
+	checked bool
+}
+
+// savedPost is a postRequest stored to be posted later, by the scheduler or
+// the offline queue
+type savedPost struct {
+	Text         string   `json:"text"`
+	Media        []string `json:"media,omitempty"`
+	Alt          []string `json:"alt,omitempty"`
+	ReplyTo      string   `json:"reply_to,omitempty"`
+	Quote        string   `json:"quote,omitempty"`
+	Split        bool     `json:"split,omitempty"`
+	Poll         []string `json:"poll,omitempty"`
+	PollDuration int      `json:"poll_duration,omitempty"`
+	KeepEXIF     bool     `json:"keep_exif,omitempty"`
+	// Force posts it against the style rules, as --force did when it was
+	// saved
+	Force bool `json:"force,omitempty"`
+	// Lint and StrictA11y are --lint or --no-lint and --strict-a11y as
+	// given when it was saved
+	Lint       *bool `json:"lint,omitempty"`
+	StrictA11y bool  `json:"strict_a11y,omitempty"`
+	// Checked is set when lint and the other checks ran as it was saved,
+	// so when it is posted lint only warns: a link gone down or a word the
+	// spell checker there does not know should not hold up a post the user
+	// already looked over
+	Checked bool `json:"checked,omitempty"`
+	TweetSettings
+}
+
+// save makes the request storable, with absolute media paths so it can
+// be posted from any directory
+func (r *postRequest) save() (savedPost, error) {
+	media, err := absPaths(r.media)
+	if err != nil {
+		return savedPost{}, err
+	}
+	return savedPost{
+		Text: r.text, Media: media, Alt: r.alt, ReplyTo: r.replyTo, Quote: r.quote,
+		Split: r.split, Poll: r.poll, PollDuration: r.pollDuration, KeepEXIF: r.keepEXIF,
+		Force: r.force, Lint: r.lint, StrictA11y: r.strictA11y, Checked: r.checked, TweetSettings: r.settings,
+	}, nil
+}
+
+func (p *savedPost) request() *postRequest {
+	return &postRequest{
+		text: p.Text, media: p.Media, alt: p.Alt, replyTo: p.ReplyTo, quote: p.Quote,
+		split: p.Split, poll: p.Poll, pollDuration: p.PollDuration, keepEXIF: p.KeepEXIF,
+		force: p.Force, lint: p.Lint, strictA11y: p.StrictA11y, checked: p.Checked, settings: p.TweetSettings,
+	}
+}
+
+// Poll limits enforced by the API
+const (
+	minPollOptions      = 2
+	maxPollOptions      = 4
+	maxPollOptionLength = 25
+	minPollDuration     = 5
+	maxPollDuration     = 7 * 24 * 60
+	defaultPollDuration = 24 * 60
+)
+
+// preparedPost is a validated postRequest, ready to publish
+type preparedPost struct {
+	parts   []string
+	media   []*mediaFile
+	replyID string
+	quoteID string
+	poll    *types.CreateInputPoll
+	// settings are the request's until publish fills in the config's
+	settings TweetSettings
+	// transformed is carried over from the request for --dry-run to show
+	transformed []string
+	// allowDuplicate skips the check for the same post sent just before
+	allowDuplicate bool
+	// strictA11y refuses to post when the accessibility checks warn
+	strictA11y bool
+	// template is the --template the text came from, for the hashtags the
+	// style rules require of it
+	template string
+	// lint is --lint or --no-lint, nil for the config to decide
+	lint *bool
+	// force posts against the style rules
+	force bool
+	// checked is set once the command ran lint, the accessibility checks
+	// and the style rules, asking where it could, so publish does not
+	checked bool
+	// lintWarnOnly prints the lint warnings without refusing the post, for
+	// one that was checked when it was saved
+	lintWarnOnly bool
+}
+
+// prepare validates the request without touching the API
+func (r *postRequest) prepare() (*preparedPost, error) {
+	p, err := r.validate()
+	return p, withExitCode(exitValidation, err)
+}
+
+func (r *postRequest) validate() (*preparedPost, error) {
+	p := &preparedPost{
+		parts: []string{r.text}, transformed: r.transformed, settings: r.settings, force: r.force,
+		lint: r.lint, strictA11y: r.strictA11y, lintWarnOnly: r.checked,
+	}
+	if err := r.settings.check(); err != nil {
+		return nil, err
+	}
+	var err error
+	if r.replyTo != "" {
+		if p.replyID, err = parseTweetID(r.replyTo); err != nil {
+			return nil, err
+		}
+	}
+	if r.quote != "" {
+		if p.quoteID, err = parseTweetID(r.quote); err != nil {
+			return nil, err
+		}
+	}
+	if r.text == "" && len(r.media) == 0 && p.quoteID == "" {
+		return nil, errors.New(tr("nothing to post"))
+	}
+	if p.media, err = openMediaFiles(r.media, r.alt, r.keepEXIF); err != nil {
+		return nil, err
+	}
+	if len(r.poll) > 0 {
+		if p.poll, err = r.preparePoll(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := checkLength(r.text); err != nil && !r.notX {
+		if !r.split {
+			return nil, fmt.Errorf(tr("%w (use --split to post it as a thread)"), err)
+		}
+		p.parts = compose.Split(r.text)
+	}
+	return p, nil
+}
+
+func (r *postRequest) preparePoll() (*types.CreateInputPoll, error) {
+	switch {
+	case len(r.media) > 0 || r.quote != "":
+		return nil, errors.New(tr("a poll cannot be combined with media or a quote"))
+	case len(r.poll) < minPollOptions || len(r.poll) > maxPollOptions:
+		return nil, fmt.Errorf(tr("a poll needs %d to %d options, got %d"), minPollOptions, maxPollOptions, len(r.poll))
+	case r.pollDuration < minPollDuration || r.pollDuration > maxPollDuration:
+		return nil, fmt.Errorf(tr("poll duration must be between %d and %d minutes"), minPollDuration, maxPollDuration)
+	}
+	for _, option := range r.poll {
+		if option == "" {
+			return nil, errors.New(tr("poll options cannot be empty"))
+		}
+		if n := utf8.RuneCountInString(option); n > maxPollOptionLength {
+			return nil, fmt.Errorf(tr("poll option %q is %d characters, the limit is %d"), option, n, maxPollOptionLength)
+		}
+	}
+	return &types.CreateInputPoll{Options: r.poll, DurationMinutes: gotwi.Int(r.pollDuration)}, nil
+}
+
+// publish uploads the media and posts the tweet, continuing a split tweet
+// as a thread under the first part. onPosted is called for every part.
+func (a *app) publish(ctx context.Context, p *preparedPost, onPosted func(postResult)) ([]postResult, error) {
+	// Checked here too so drafts, the queue and scheduled tweets cannot get
+	// around safe mode, nor the other checks
+	if err := a.config.SafeMode.check(p.parts...); err != nil {
+		return nil, err
+	}
+	if err := a.checkPrepared(ctx, p); err != nil {
+		return nil, err
+	}
+	settings, err := a.config.tweetSettings(p.settings)
+	if err != nil {
+		return nil, err
+	}
+	p.settings = settings
+	key, err := a.claimPost(p)
+	if err != nil {
+		return nil, err
+	}
+	if globalOptions.dryRun {
+		return dryRunResults(p), nil
+	}
+	if settings, err = a.resolveGeo(ctx, settings); err != nil {
+		forgetPost(key)
+		return nil, err
+	}
+	delay, err := a.config.threadDelay()
+	if err != nil {
+		forgetPost(key)
+		return nil, err
+	}
+	// The first part's media are uploaded before anything is posted, so a
+	// failed upload leaves the post to try again
+	var mediaIDs []string
+	if len(p.media) > 0 {
+		if mediaIDs, err = a.uploadMedia(ctx, p.media); err != nil {
+			forgetPost(key)
+			return nil, err
+		}
+	}
+	thread := &clixclient.Thread{ReplyTo: p.replyID, Quote: p.quoteID, Poll: p.poll, Settings: settings.apply, Delay: delay}
+	for _, part := range p.parts {
+		thread.Tweets = append(thread.Tweets, clixclient.Tweet{Text: part})
+	}
+	thread.Tweets[0].MediaIDs = mediaIDs
+
+	var results []postResult
+	_, err = clixclient.Publish(ctx, thread, a.postTweet, func(i int, id string) {
+		result := postResult{ID: id, Text: p.parts[i]}
+		if i == 0 {
+			settlePost(key, id, nil)
+			result.MediaIDs = mediaIDs
+		}
+		results = append(results, result)
+		onPosted(result)
+	})
+	var failed *clixclient.ThreadError
+	if !errors.As(err, &failed) {
+		return results, nil
+	}
+	if len(failed.Posted) == 0 {
+		settlePost(key, "", failed.Err)
+		return nil, fmt.Errorf(tr("failed to post tweet: %w"), failed.Err)
+	}
+	return results, withExitCode(exitPartial, fmt.Errorf(tr("split tweet stopped after %d of %d parts: %w"), len(results), len(p.parts), failed.Err))
+}
+
+// checkPrepared runs lint, the accessibility checks and the style rules on
+// a post its command did not check, refusing it where they would ask
+func (a *app) checkPrepared(ctx context.Context, p *preparedPost) error {
+	if p.checked {
+		return nil
+	}
+	if p.lintWarnOnly {
+		if warnings := lintWarnings(ctx, a.config, a, p.parts, p.lint); len(warnings) > 0 {
+			printLintWarnings(warnings, p.parts, 0)
+		}
+	} else if err := refuseLint(ctx, a.config, a, p.parts, 0, p.lint); err != nil {
+		return err
+	}
+	if err := checkA11y(a.config, p.parts, [][]*mediaFile{p.media}, 0, p.strictA11y); err != nil {
+		return err
+	}
+	if err := checkStyle(a.config, p.parts, p.template, 0, p.force); err != nil {
+		return err
+	}
+	p.checked = true
+	return nil
+}
+
+// checkToSave runs safe mode, lint, the accessibility checks and the style
+// rules on a post about to be saved for later, and marks req checked. With
+// ask set lint asks to go on as the post command does, otherwise it refuses
+// the post; it reports whether to go on.
+func checkToSave(ctx context.Context, config *Config, a *app, req *postRequest, parts []string, media []*mediaFile, ask bool) (bool, error) {
+	if err := config.SafeMode.check(parts...); err != nil {
+		return false, err
+	}
+	if ask {
+		if ok, err := checkLint(ctx, config, a, parts, 0, req.lint); !ok || err != nil {
+			return false, err
+		}
+	} else if err := refuseLint(ctx, config, a, parts, 0, req.lint); err != nil {
+		return false, err
+	}
+	if err := checkA11y(config, parts, [][]*mediaFile{media}, 0, req.strictA11y); err != nil {
+		return false, err
+	}
+	if err := checkStyle(config, parts, "", 0, req.force); err != nil {
+		return false, err
+	}
+	req.checked = true
+	return true, nil
+}
+
+// postChecks is what a post goes through between being written and being
+// sent: the posting window, the undo delay, safe mode, lint, the
+// accessibility checks, the style rules, the confirmation and the hold for
+// undo, in that order
+type postChecks struct {
+	config *Config
+	// a is nil when not posting to X, which leaves out the check of the
+	// mentions
+	a      *app
+	parts  []string
+	media  [][]*mediaFile
+	offset int
+	// template, force, lint and strictA11y are the post's, as for
+	// checkStyle, checkLint and checkA11y
+	template   string
+	force      bool
+	lint       *bool
+	strictA11y bool
+	// ask is set when someone is there to answer: lint asks whether to
+	// post anyway instead of refusing, and the post is held for undo for
+	// undo, the --undo-delay flag, or the config's undo_delay
+	ask  bool
+	undo string
+	// confirm, when set, asks before posting outside a dry run
+	confirm func() (bool, error)
+	// closed, when set, is offered a post the posting window blocks, with
+	// the reason, before it is refused with a hint at --force; it reports
+	// whether it took care of the post
+	closed func(error) (bool, error)
+}
+
+// checks are the checks for p, which the command fills in with what it
+// can ask and how; a is nil as for postChecks
+func (p *preparedPost) checks(config *Config, a *app) *postChecks {
+	return &postChecks{
+		config: config, a: a, parts: p.parts, media: [][]*mediaFile{p.media},
+		template: p.template, force: p.force, lint: p.lint, strictA11y: p.strictA11y,
+	}
+}
+
+// run puts the post through the checks, reporting whether to send it
+func (c *postChecks) run(ctx context.Context) (bool, error) {
+	if !c.force {
+		if err := checkPostingWindow(c.config.PostingWindow, time.Now()); err != nil {
+			if c.closed != nil {
+				if handled, err := c.closed(err); handled || err != nil {
+					return false, err
+				}
+			}
+			return false, fmt.Errorf(tr("%w (use --force to post anyway)"), err)
+		}
+	}
+	var delay time.Duration
+	if c.ask {
+		var err error
+		if delay, err = undoDelay(c.config, c.undo); err != nil {
+			return false, err
+		}
+	}
+	if len(c.parts) == 1 && c.offset == 0 {
+		if err := c.config.SafeMode.check(c.parts[0]); err != nil {
+			return false, err
+		}
+	} else {
+		for i, part := range c.parts {
+			if err := c.config.SafeMode.check(part); err != nil {
+				return false, fmt.Errorf(tr("part %d: %w"), c.offset+i+1, err)
+			}
+		}
+	}
+	if c.ask {
+		if ok, err := checkLint(ctx, c.config, c.a, c.parts, c.offset, c.lint); !ok || err != nil {
+			if err == nil {
+				fmt.Println(tr("Not posted."))
+			}
+			return false, err
+		}
+	} else if err := refuseLint(ctx, c.config, c.a, c.parts, c.offset, c.lint); err != nil {
+		return false, err
+	}
+	if err := checkA11y(c.config, c.parts, c.media, c.offset, c.strictA11y); err != nil {
+		return false, err
+	}
+	if err := checkStyle(c.config, c.parts, c.template, c.offset, c.force); err != nil {
+		return false, err
+	}
+	if c.confirm != nil && !globalOptions.dryRun {
+		if ok, err := c.confirm(); !ok || err != nil {
+			if err == nil {
+				fmt.Println(tr("Not posted."))
+			}
+			return false, err
+		}
+	}
+	if c.ask {
+		if ok, err := holdForUndo(delay); !ok || err != nil {
+			if err == nil {
+				fmt.Println(tr("Not posted."))
+			}
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// quotedTweet takes the tweet to quote from the clipboard or the first
+// argument, returning the arguments left for the comment
+func quotedTweet(args []string, fromClipboard bool) (string, []string, error) {
+	if !fromClipboard {
+		return args[0], args[1:], nil
+	}
+	text, err := readClipboard()
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := parseTweetID(text); err != nil {
+		return "", nil, withExitCode(exitValidation, fmt.Errorf(tr("the clipboard holds no tweet URL: %q"), truncateRunes(text, 60)))
+	}
+	return text, args, nil
+}
+
+const postEditHelp = `Write the tweet above. Saving an empty tweet aborts.
+Tweets are limited to 280 characters; use --split to post longer text as a thread.`
+
+func runPost(args []string) error {
+	return postCommand("post", args)
+}
+
+// runQuote is post with the quoted tweet as the first argument, or taken
+// from the clipboard, and the comment after it
+func runQuote(args []string) error {
+	return postCommand("quote", args)
+}
+
+func postCommand(name string, args []string) error {
+	usage := "post [flags] [text]  (reads stdin when no text is given)"
+	if name == "quote" {
+		usage = "quote [flags] <tweet> [text]  (the tweet is an ID or URL; reads the comment from stdin when no text is given)"
+	}
+	fs := newFlagSet(name, usage)
+	file := fs.String("file", "", "read the tweet from a file")
+	edit := fs.Bool("edit", false, "compose the tweet in $EDITOR, starting from any text given")
+	templateName := fs.String("template", "", "fill in a saved template instead of giving the text")
+	var vars stringList
+	fs.Var(&vars, "var", "a template variable as key=value (repeatable)")
+	force := fs.Bool("force", false, "post even outside the configured posting window or against the style rules")
+	var mediaPaths stringList
+	fs.Var(&mediaPaths, "media", "attach an image, GIF or video (repeat for up to 4 images)")
+	var alts stringList
+	fs.Var(&alts, "alt", "alt text for the media, paired with each --media in order")
+	keepEXIF := fs.Bool("keep-exif", false, "leave the EXIF metadata, such as the GPS location, in attached images")
+	gif := fs.String("gif", "", "search the configured GIF provider and attach the GIF chosen from the results")
+	gifPick := fs.Int("gif-pick", 0, "with --gif, attach the nth result without asking")
+	replyTo := fs.String("reply-to", "", "reply to this tweet (ID or URL)")
+	quote := new(string)
+	var fromClipboard *bool
+	if name == "quote" {
+		fromClipboard = fs.Bool("from-clipboard", false, "quote the tweet whose URL is on the clipboard, taking all arguments as the comment")
+	} else {
+		quote = fs.String("quote", "", "quote this tweet (ID or URL)")
+		fromClipboard = fs.Bool("from-clipboard", false, "post the text on the clipboard; with --edit, start from it")
+	}
+	copyLinkFlag := fs.Bool("copy-link", false, "copy the URL of the posted tweet to the clipboard")
+	openFlag := fs.Bool("open", false, "open the posted tweet in the browser")
+	allowDuplicate := fs.Bool("allow-duplicate", false, "post even if the same tweet was sent within the duplicates window")
+	settings := tweetSettingsFlags(fs)
+	split := fs.Bool("split", false, "split an over-length tweet into a thread at word boundaries")
+	var poll stringList
+	fs.Var(&poll, "poll", "add a poll option (repeat for 2 to 4 options)")
+	pollDuration := fs.Int("poll-duration", defaultPollDuration, "minutes the poll stays open, up to 7 days")
+	to := fs.String("to", xDestination, "comma-separated networks to post to: x, mastodon, bsky")
+	confirmPost := fs.Bool("confirm", false, "show a preview of the tweet and ask before posting it (default from config)")
+	noConfirm := fs.Bool("no-confirm", false, "post without asking, even with confirm_before_post in the config")
+	rotation := fs.String("from-rotation", "", "post the next line of this file, keeping track of the lines posted")
+	random := fs.Bool("random", false, "with --from-rotation, post a random line instead of the next one")
+	noRepeat := fs.Bool("no-repeat", false, "with --from-rotation, never post a line twice")
+	undo := fs.String("undo-delay", "", "hold the tweet this long so it can be undone, e.g. 10s (default from config, 0 for none)")
+	lint := fs.Bool("lint", false, "check spelling, spacing, brackets, links and mentions before posting (default from config)")
+	noLint := fs.Bool("no-lint", false, "skip the lint checks, even with lint in the config")
+	noTransform := fs.Bool("no-transform", false, "post the text as given, without the transforms from the config")
+	strictA11y := strictA11yFlag(fs)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if name == "quote" {
+		if len(args) == 0 && !*fromClipboard {
+			fs.Usage()
+			return errUsage
+		}
+		if *quote, args, err = quotedTweet(args, *fromClipboard); err != nil {
+			return err
+		}
+	}
+
+	confirmOverride, err := confirmFlag(*confirmPost, *noConfirm)
+	if err != nil {
+		return err
+	}
+	lintOverride, err := lintFlag(*lint, *noLint)
+	if err != nil {
+		return err
+	}
+
+	var text string
+	var pick *rotationPick
+	switch {
+	case (*random || *noRepeat) && *rotation == "":
+		return errors.New(tr("--random and --no-repeat need --from-rotation"))
+	case *rotation != "" && (*templateName != "" || *file != "" || *edit || len(args) > 0):
+		return errors.New(tr("--from-rotation cannot be combined with --template, --file, --edit or text arguments"))
+	case name == "post" && *fromClipboard && (*rotation != "" || *templateName != "" || *file != "" || len(args) > 0):
+		return errors.New(tr("--from-clipboard cannot be combined with --from-rotation, --template, --file or text arguments"))
+	case name == "post" && *fromClipboard:
+		if text, err = readClipboard(); err != nil {
+			return err
+		}
+		if *edit {
+			if text, err = editText(text, tr(postEditHelp)); err != nil {
+				return err
+			}
+		}
+		if text == "" {
+			return errors.New(tr("aborting post due to empty tweet"))
+		}
+	case *rotation != "":
+		if pick, err = pickFromRotation(*rotation, *random, *noRepeat); err != nil {
+			return err
+		}
+		text = pick.text
+	case len(vars) > 0 && *templateName == "":
+		return errors.New(tr("--var needs --template"))
+	case *templateName != "" && (*file != "" || len(args) > 0):
+		return errors.New(tr("--template cannot be combined with --file or text arguments"))
+	case *templateName != "":
+		values, err := parseVars(vars)
+		if err != nil {
+			return err
+		}
+		if text, err = renderTemplate(*templateName, values); err != nil {
+			return err
+		}
+		if *edit {
+			if text, err = editText(text, tr(postEditHelp)); err != nil {
+				return err
+			}
+			if text == "" {
+				return errors.New(tr("aborting post due to empty tweet"))
+			}
+		}
+	case *file != "" && (*edit || len(args) > 0):
+		return errors.New(tr("--file cannot be combined with --edit or text arguments"))
+	case *file != "":
+		data, err := os.ReadFile(*file)
+		if err != nil {
+			return fmt.Errorf(tr("failed to read tweet file: %w"), err)
+		}
+		text = strings.TrimSpace(string(data))
+	case *edit:
+		if text, err = editText(strings.Join(args, " "), tr(postEditHelp)); err != nil {
+			return err
+		}
+		if text == "" {
+			return errors.New(tr("aborting post due to empty tweet"))
+		}
+	default:
+		if text, err = readText(args); err != nil {
+			return err
+		}
+	}
+	if *gifPick != 0 && *gif == "" {
+		return errors.New(tr("--gif-pick needs --gif"))
+	}
+	config, err := readConfig(getConfigFilePath())
+	if err != nil {
+		return fmt.Errorf(tr("failed to load configuration: %w"), err)
+	}
+	if *gif != "" {
+		path, title, err := pickGIF(rootCtx, config.GIF, *gif, *gifPick)
+		if err != nil {
+			return err
+		}
+		// The GIF's title is its alt text unless --alt gave one
+		if len(alts) == len(mediaPaths) && title != "" {
+			alts = append(alts, title)
+		}
+		mediaPaths = append(mediaPaths, path)
+	}
+	req := &postRequest{
+		text: text, media: mediaPaths, alt: alts, replyTo: *replyTo, quote: *quote, split: *split,
+		poll: poll, pollDuration: *pollDuration, keepEXIF: *keepEXIF, settings: settings(), force: *force,
+		lint: lintOverride, strictA11y: *strictA11y,
+	}
+	destinations, err := parseDestinations(*to)
+	if err != nil {
+		return err
+	}
+	req.notX = !slices.Contains(destinations, xDestination)
+	if !*noTransform {
+		if err := req.transform(config); err != nil {
+			return err
+		}
+	}
+	prepared, err := req.prepare()
+	if err != nil {
+		return err
+	}
+	prepared.allowDuplicate = *allowDuplicate
+	prepared.template = *templateName
+	if len(destinations) > 1 || req.notX {
+		return runPostTo(destinations, req, prepared, confirmOverride, *undo, pick, *copyLinkFlag, *openFlag)
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	checks := prepared.checks(a.config, a)
+	checks.ask, checks.undo = true, *undo
+	checks.closed = func(error) (bool, error) {
+		if !stdinIsTerminal() || globalOptions.dryRun {
+			return false, nil
+		}
+		return scheduleInstead(a, req, prepared)
+	}
+	if a.config.confirmBeforePost(confirmOverride, false) {
+		checks.confirm = func() (bool, error) { return a.confirmPreview(rootCtx, prepared) }
+	}
+	if ok, err := checks.run(rootCtx); !ok || err != nil {
+		return err
+	}
+	// publish need not check it again, nor the request queueInstead saves
+	prepared.checked, req.checked = true, true
+	results, err := a.publishAndReport(rootCtx, prepared)
+	if len(results) > 0 {
+		pick.markPosted()
+		if *copyLinkFlag && !results[0].DryRun {
+			copyLink(tweetURL("", results[0].ID))
+		}
+		if *openFlag && !results[0].DryRun {
+			openLink(tweetURL("", results[0].ID))
+		}
+	}
+	if len(results) == 0 && isNetworkError(err) {
+		queued, queueErr := queueInstead(a.config.Active, req)
+		if queued {
+			pick.markPosted()
+		}
+		if queued || queueErr != nil {
+			return queueErr
+		}
+	}
+	return err
+}
+
+// runPostTo posts to the networks named with --to, setting up the X client
+// only when x is one of them
+func runPostTo(names []string, req *postRequest, prepared *preparedPost, confirmOverride *bool, undo string, pick *rotationPick, copyLinkFlag, openFlag bool) error {
+	if err := crossPostRequest(req); err != nil {
+		return err
+	}
+	var a *app
+	var config *Config
+	var err error
+	if !req.notX {
+		if a, err = setup(false); err != nil {
+			return err
+		}
+		defer a.close()
+		config = a.config
+	} else if config, err = readConfig(getConfigFilePath()); err != nil {
+		return fmt.Errorf(tr("failed to load configuration: %w"), err)
+	}
+
+	var others []destination
+	for _, name := range names {
+		if name == xDestination {
+			continue
+		}
+		dest, err := newDestination(name, config)
+		if err != nil {
+			return err
+		}
+		others = append(others, dest)
+	}
+	checks := prepared.checks(config, a)
+	checks.ask, checks.undo = true, undo
+	if config.confirmBeforePost(confirmOverride, false) {
+		checks.confirm = func() (bool, error) { return a.confirmPreview(rootCtx, prepared) }
+	}
+	if ok, err := checks.run(rootCtx); !ok || err != nil {
+		return err
+	}
+	prepared.checked = true
+	results, err := runCrossPost(rootCtx, a, prepared, others)
+	if len(results) > 0 && copyLinkFlag {
+		copyLink(cmp.Or(results[0].URL, results[0].ID))
+	}
+	if len(results) > 0 && openFlag && results[0].URL != "" {
+		openLink(results[0].URL)
+	}
+	if err != nil {
+		return err
+	}
+	pick.markPosted()
+	return nil
+}
+
+// publishAndReport publishes p and prints the IDs as they are posted, or
+// all results at the end in machine-readable mode
+func (a *app) publishAndReport(ctx context.Context, p *preparedPost) ([]postResult, error) {
+	results, err := a.publish(ctx, p, func(result postResult) {
+		if !machineReadable() {
+			fmt.Println(result.ID)
+		}
+	})
+	if machineReadable() && len(results) > 0 {
+		if len(p.parts) == 1 {
+			printResult(results[0])
+		} else {
+			printResult(results)
+		}
+	}
+	return results, err
+}
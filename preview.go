@@ -0,0 +1,229 @@
+package clix
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/tweet/tweetlookup"
+	"github.com/michimani/gotwi/tweet/tweetlookup/types"
+	"github.com/voltycodes/clix/compose"
+	"golang.org/x/term"
+)
+
+const previewWidth = 60
+
+var (
+	previewStyle      = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+	previewQuoteStyle = lipgloss.NewStyle().Border(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("8")).Padding(0, 1)
+	previewMutedStyle = lipgloss.NewStyle().Faint(true)
+	previewTitleStyle = lipgloss.NewStyle().Bold(true)
+	previewOverStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+)
+
+// lookupTweet fetches a tweet with its author
+func (a *app) lookupTweet(ctx context.Context, id string) (*tweetView, error) {
+	if err := a.ensureToken(ctx); err != nil {
+		return nil, err
+	}
+	res, err := tweetlookup.Get(ctx, a.client, &types.GetInput{
+		ID:          id,
+		Expansions:  tweetViewExpand,
+		TweetFields: tweetViewFields,
+		UserFields:  tweetViewUserFld,
+	})
+	if err != nil {
+		return nil, err
+	}
+	views := newTweetViews([]resources.Tweet{res.Data}, res.Includes.Users)
+	return &views[0], nil
+}
+
+// renderPreview draws each tweet of p in a box the way it will appear,
+// with the tweet it replies to or quotes looked up for context. Looking
+// them up is best-effort, so a preview works offline too, and a may be nil
+// when not posting to X.
+func (a *app) renderPreview(ctx context.Context, w io.Writer, p *preparedPost) {
+	width := previewWidth
+	if cols, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && cols-4 < width {
+		width = max(cols-4, 20)
+	}
+	describe := func(label, id string) string {
+		if a == nil {
+			return label + " " + id
+		}
+		if tweet, err := a.lookupTweet(ctx, id); err == nil {
+			return fmt.Sprintf("%s @%s: %s", label, tweet.AuthorUsername, tweet.Text)
+		}
+		return label + " " + id
+	}
+
+	for i, part := range p.parts {
+		var lines []string
+		if i > 0 {
+			lines = append(lines, previewMutedStyle.Render(tr("↩ Replying to the previous part")))
+		} else if p.replyID != "" {
+			lines = append(lines, previewMutedStyle.Render(truncateRunes(describe(tr("↩ Replying to"), p.replyID), 2*width)))
+		}
+		if part != "" {
+			lines = append(lines, part)
+		}
+
+		if i == 0 {
+			for _, file := range p.media {
+				name := filepath.Base(file.path)
+				if file.source != "" {
+					name = filepath.Base(file.source)
+				}
+				line := trf("%s %s (%d KB)", mediaIcon(file), name, max(file.size>>10, 1))
+				if file.alt != "" {
+					line += tr(" · alt: ") + file.alt
+				} else {
+					line += tr(" · no alt text")
+				}
+				lines = append(lines, previewMutedStyle.Render(line))
+			}
+			if p.poll != nil {
+				var options []string
+				for _, option := range p.poll.Options {
+					options = append(options, "○ "+option)
+				}
+				options = append(options, previewMutedStyle.Render(trf("Poll closes after %s", pollDurationText(*p.poll.DurationMinutes))))
+				lines = append(lines, strings.Join(options, "\n"))
+			}
+			if p.quoteID != "" {
+				quoted := truncateRunes(strings.TrimPrefix(describe("", p.quoteID), " "), 3*width)
+				lines = append(lines, previewQuoteStyle.Width(width-4).Render(quoted))
+			}
+		}
+		// Media, a poll or a quoted tweet take the place of the card
+		if link := cardLink(part); link != "" && (i > 0 || len(p.media) == 0 && p.poll == nil && p.quoteID == "") {
+			if card, err := fetchLinkCard(ctx, newDirectHTTPClient(), link); err == nil {
+				lines = append(lines, card.render(width))
+			} else {
+				lines = append(lines, previewOverStyle.Render(trf("No card: %s %v", link, err)))
+			}
+		}
+
+		count := trf("%d/%d characters", compose.Length(part), compose.MaxLength)
+		if compose.Length(part) > compose.MaxLength {
+			count = previewOverStyle.Render(count)
+		} else {
+			count = previewMutedStyle.Render(count)
+		}
+		if len(p.parts) > 1 {
+			count = previewMutedStyle.Render(trf("Tweet %d/%d · ", i+1, len(p.parts))) + count
+		}
+		lines = append(lines, count)
+
+		fmt.Fprintln(w, previewStyle.Width(width).Render(strings.Join(lines, "\n\n")))
+		if i == 0 {
+			printThumbnails(w, p.media)
+		}
+	}
+}
+
+func mediaIcon(file *mediaFile) string {
+	switch file.category {
+	case "tweet_gif":
+		return "GIF"
+	case "tweet_video":
+		return "▶"
+	}
+	return "🖼"
+}
+
+func pollDurationText(minutes int) string {
+	switch {
+	case minutes%(24*60) == 0:
+		return trf("%d day(s)", minutes/(24*60))
+	case minutes%60 == 0:
+		return trf("%d hour(s)", minutes/60)
+	}
+	return trf("%d minute(s)", minutes)
+}
+
+func truncateRunes(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if runes := []rune(s); len(runes) > n {
+		return string(runes[:n-1]) + "…"
+	}
+	return s
+}
+
+// imageProtocol returns the inline image protocol of the terminal on
+// stdout: "kitty", "iterm" or "" when images can only be named
+func imageProtocol() string {
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		return ""
+	}
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "" || os.Getenv("TERM") == "xterm-kitty" || os.Getenv("TERM_PROGRAM") == "ghostty":
+		return "kitty"
+	case os.Getenv("TERM_PROGRAM") == "iTerm.app" || os.Getenv("TERM_PROGRAM") == "WezTerm":
+		return "iterm"
+	}
+	return ""
+}
+
+// printThumbnails shows the attached images in the terminal, when it
+// supports an inline image protocol
+func printThumbnails(w io.Writer, files []*mediaFile) {
+	protocol := imageProtocol()
+	if protocol == "" {
+		return
+	}
+	for _, file := range files {
+		// kitty is sent PNG data as is; decoding other formats is not worth it
+		if file.category == "tweet_video" || protocol == "kitty" && file.mediaType != "image/png" {
+			continue
+		}
+		data, err := os.ReadFile(file.path)
+		if err != nil {
+			continue
+		}
+		printInlineImage(w, protocol, data)
+	}
+}
+
+// printInlineImage draws an image with the terminal's inline image
+// protocol. kitty only gets PNG data this way.
+func printInlineImage(w io.Writer, protocol string, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	switch protocol {
+	case "iterm":
+		fmt.Fprintf(w, "\x1b]1337;File=inline=1;height=8;preserveAspectRatio=1:%s\a\n", encoded)
+	case "kitty":
+		// The payload is sent in chunks of at most 4096 bytes
+		for first := true; encoded != ""; first = false {
+			chunk := encoded[:min(len(encoded), 4096)]
+			encoded = encoded[len(chunk):]
+			more := 0
+			if encoded != "" {
+				more = 1
+			}
+			if first {
+				fmt.Fprintf(w, "\x1b_Ga=T,f=100,r=8,m=%d;%s\x1b\\", more, chunk)
+			} else {
+				fmt.Fprintf(w, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// confirmPreview shows the preview of p and asks whether to post it
+func (a *app) confirmPreview(ctx context.Context, p *preparedPost) (bool, error) {
+	if !stdinIsTerminal() {
+		return false, errors.New(tr("--confirm needs a terminal to ask on"))
+	}
+	a.renderPreview(ctx, os.Stdout, p)
+	return confirm(tr("Post it?")), nil
+}
@@ -0,0 +1,261 @@
+package clix
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	clixconfig "github.com/voltycodes/clix/config"
+)
+
+// trustedConfigsStateFile records the project configs the user trusted,
+// by path, with the SHA-256 of the file as it was then
+const trustedConfigsStateFile = "trusted_configs.json"
+
+// warnedUntrusted keeps the notice about an untrusted project config to
+// once per invocation
+var warnedUntrusted bool
+
+// isProjectConfig reports whether path is a .clix.json found in the
+// current directory rather than the user's own config
+func isProjectConfig(path string) bool {
+	return filepath.Base(path) == projectConfigFileName
+}
+
+// secretsFile returns where the credentials of the config at path are
+// saved: the user's config, also when a project config is in use, so keys
+// never end up in a checkout
+func secretsFile(path string) string {
+	if isProjectConfig(path) {
+		path, _ = userConfigFileSource()
+	}
+	return path
+}
+
+func configDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func loadTrustedConfigs() (map[string]string, error) {
+	trusted := map[string]string{}
+	if err := loadState(trustedConfigsStateFile, &trusted); err != nil {
+		return nil, err
+	}
+	return trusted, nil
+}
+
+// projectConfigTrusted reports whether the user trusted the project config
+// at path as it is now; any change to the file needs trusting again
+func projectConfigTrusted(path string, data []byte) bool {
+	trusted, err := loadTrustedConfigs()
+	return err == nil && trusted[path] == configDigest(data)
+}
+
+// decodeConfig reads a config file without applying any of it, returning
+// an empty config and no data when it does not exist
+func decodeConfig(path string) (*Config, []byte, error) {
+	config := &Config{}
+	var asked string
+	raw, err := clixconfig.Read(path, config, func() (string, error) {
+		if configPassphrase != "" {
+			return configPassphrase, nil
+		}
+		var err error
+		asked, err = readPassphrase(trf("Passphrase for %s: ", filepath.Base(path)), false)
+		return asked, err
+	})
+	var fileErr *clixconfig.FileError
+	if errors.As(err, &fileErr) {
+		switch fileErr.Op {
+		case "open":
+			return nil, nil, fmt.Errorf(tr("failed to open config file: %w"), fileErr.Err)
+		case "decrypt":
+			return nil, nil, fmt.Errorf(tr("failed to decrypt config file: %w"), fileErr.Err)
+		default:
+			return nil, nil, fmt.Errorf(tr("failed to parse config file %s: %w"), path, fileErr.Err)
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if asked != "" {
+		configPassphrase = asked
+	}
+	return config, raw, nil
+}
+
+// withUserSecrets takes the credentials of project config c, read from
+// data at path, from the user's config. A project config someone else
+// wrote may only set how posts are formatted and checked, see
+// takeProjectSettings, until the user trusted it with `clix config
+// trust`: its hooks run any command, and its network, review, shortener
+// and other settings decide where clix connects and whom it trusts.
+func (c *Config) withUserSecrets(path string, data []byte) error {
+	userPath, _ := userConfigFileSource()
+	user, _, err := decodeConfig(userPath)
+	if err != nil {
+		return err
+	}
+	project := *c
+	if !projectConfigTrusted(path, data) {
+		var allowed Config
+		allowed.takeProjectSettings(&project)
+		if !sameConfig(&allowed, &project) && !warnedUntrusted {
+			warnedUntrusted = true
+			fmt.Fprintf(os.Stderr, tr("Warning: %s is not trusted, so only its formatting and check settings apply; run 'clix config trust' if you wrote it.\n"), path)
+		}
+		*c = *user
+		c.takeProjectSettings(&project)
+		return nil
+	}
+	c.Credentials, c.Accounts = user.Credentials, user.Accounts
+	// The user's own accounts win over those of the same name
+	if !c.Credentials.Complete() && project.Credentials != (Credentials{}) {
+		c.Credentials = project.Credentials
+	}
+	for name, creds := range project.Accounts {
+		if mine, ok := c.Accounts[name]; !ok || !mine.Complete() {
+			if c.Accounts == nil {
+				c.Accounts = map[string]*Credentials{}
+			}
+			c.Accounts[name] = creds
+		}
+	}
+	return nil
+}
+
+// takeProjectSettings sets the settings an untrusted project config may
+// set from project, where project sets them: the default account among
+// the user's own, and how posts are formatted and checked. Nothing that
+// decides where clix connects, what it runs or whom it trusts is taken.
+func (c *Config) takeProjectSettings(project *Config) {
+	c.DefaultAccount = cmp.Or(project.DefaultAccount, c.DefaultAccount)
+	c.Thread = cmp.Or(project.Thread, c.Thread)
+	c.Tweet = cmp.Or(project.Tweet, c.Tweet)
+	c.Lint = cmp.Or(project.Lint, c.Lint)
+	c.A11y = cmp.Or(project.A11y, c.A11y)
+	c.Style = cmp.Or(project.Style, c.Style)
+	if len(project.Transforms) > 0 {
+		c.Transforms = project.Transforms
+	}
+	c.ConfirmBeforePost = cmp.Or(project.ConfirmBeforePost, c.ConfirmBeforePost)
+	c.UndoDelay = cmp.Or(project.UndoDelay, c.UndoDelay)
+	c.Theme = cmp.Or(project.Theme, c.Theme)
+	c.Language = cmp.Or(project.Language, c.Language)
+}
+
+// sameConfig reports whether a and b would be saved alike
+func sameConfig(a, b *Config) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
+}
+
+// saveProjectConfig saves config, read from the project config at path,
+// with its credentials going to the user's config and the rest to the
+// project's. The credentials already in the project file are left as they
+// are, and an untrusted one only has the settings it may set changed.
+func saveProjectConfig(config *Config, path string) error {
+	userPath, _ := userConfigFileSource()
+	user, _, err := decodeConfig(userPath)
+	if err != nil {
+		return err
+	}
+	user.Credentials, user.Accounts = config.Credentials, config.Accounts
+	if err := writeConfigFile(user, userPath, configPassphrase); err != nil {
+		return err
+	}
+
+	onDisk, raw, err := decodeConfig(path)
+	if err != nil {
+		return err
+	}
+	trusted := projectConfigTrusted(path, raw)
+	file := *config
+	file.Credentials, file.Accounts = onDisk.Credentials, onDisk.Accounts
+	if !trusted {
+		// config holds the user's own settings then, which stay out of the
+		// project's file
+		file = *onDisk
+		file.takeProjectSettings(config)
+	}
+	// A project config is only encrypted if it was already
+	passphrase := ""
+	if clixconfig.IsEncrypted(raw) {
+		passphrase = configPassphrase
+	}
+	if err := writeConfigFile(&file, path, passphrase); err != nil {
+		return err
+	}
+	if trusted {
+		return trustProjectConfig(path)
+	}
+	return nil
+}
+
+// trustProjectConfig records the project config at path, as it is now, as
+// trusted
+func trustProjectConfig(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf(tr("failed to read %s: %w"), path, err)
+	}
+	trusted, err := loadTrustedConfigs()
+	if err != nil {
+		return err
+	}
+	trusted[path] = configDigest(data)
+	return saveState(trustedConfigsStateFile, trusted)
+}
+
+// runConfigTrust is `clix config trust` and `clix config untrust`, for the
+// project config in the current directory
+func runConfigTrust(trust bool) error {
+	path, source := configFileSource()
+	if source != "project" {
+		return withExitCode(exitNotFound, fmt.Errorf(tr("there is no %s in the current directory"), projectConfigFileName))
+	}
+	if !trust {
+		trusted, err := loadTrustedConfigs()
+		if err != nil {
+			return err
+		}
+		delete(trusted, path)
+		if err := saveState(trustedConfigsStateFile, trusted); err != nil {
+			return err
+		}
+		fmt.Printf(tr("No longer trusting %s; only its formatting and check settings apply.\n"), path)
+		return nil
+	}
+
+	project, _, err := decodeConfig(path)
+	if err != nil {
+		return err
+	}
+	if len(project.Hooks) > 0 {
+		fmt.Println(tr("Its hooks will run:"))
+		for _, hook := range project.Hooks {
+			if hook.Command != "" {
+				fmt.Printf("  %s\n", hook.Command)
+			}
+			if hook.URL != "" {
+				fmt.Printf("  POST %s\n", hook.URL)
+			}
+		}
+	}
+	if stdinIsTerminal() && !confirm(trf("Trust %s?", path)) {
+		return errors.New(tr("aborted"))
+	}
+	if err := trustProjectConfig(path); err != nil {
+		return err
+	}
+	fmt.Printf(tr("Trusted %s until it changes.\n"), path)
+	return nil
+}
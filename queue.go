@@ -0,0 +1,336 @@
+package clix
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+const queueStateFile = "queue.json"
+
+// A flush claims an item while posting it, so a second clix running at the
+// same time skips it; a claim older than this is from a flush that died
+const queueClaimTimeout = 10 * time.Minute
+
+// postedKeyRetention is how long the keys of posted items are remembered
+const postedKeyRetention = 30 * 24 * time.Hour
+
+// queuedPost is a tweet that could not be posted because the network was
+// down. Key is generated when it is queued and identifies it across flushes.
+type queuedPost struct {
+	Key     string `json:"key"`
+	Account string `json:"account"`
+	savedPost
+	QueuedAt time.Time `json:"queued_at"`
+	Error    string    `json:"error,omitempty"`
+
+	ClaimedAt time.Time `json:"claimed_at,omitempty"`
+}
+
+// postQueue is the offline queue. Posted remembers the keys that were
+// already posted so an item is never posted twice.
+type postQueue struct {
+	Pending []*queuedPost        `json:"pending"`
+	Posted  map[string]time.Time `json:"posted,omitempty"`
+}
+
+func loadQueue() (*postQueue, error) {
+	queue := &postQueue{}
+	if err := loadState(queueStateFile, queue); err != nil {
+		return nil, err
+	}
+	return queue, nil
+}
+
+// updateQueue applies fn to the queue under the state lock and saves it
+func updateQueue(fn func(queue *postQueue) error) error {
+	unlock, err := lockState(queueStateFile)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	queue, err := loadQueue()
+	if err != nil {
+		return err
+	}
+	if err := fn(queue); err != nil {
+		return err
+	}
+	return saveState(queueStateFile, queue)
+}
+
+func newQueueKey() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// isNetworkError reports whether err means the API could not be reached,
+// as opposed to the API rejecting the request
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	return err != nil && errors.As(err, &netErr) && !errors.Is(err, context.Canceled)
+}
+
+// queueInstead offers to queue a post that failed because the network is
+// down, and reports whether it was queued
+func queueInstead(account string, req *postRequest) (bool, error) {
+	if !stdinIsTerminal() || !confirm(tr("Could not reach X. Queue the tweet to post when back online?")) {
+		return false, nil
+	}
+	saved, err := req.save()
+	if err != nil {
+		return false, err
+	}
+	post := &queuedPost{Key: newQueueKey(), Account: account, savedPost: saved, QueuedAt: time.Now()}
+	err = updateQueue(func(queue *postQueue) error {
+		queue.Pending = append(queue.Pending, post)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	fmt.Println(tr("Queued; it will be posted the next time clix reaches X, or run 'clix queue flush'."))
+	return true, nil
+}
+
+// claimNext marks the oldest unclaimed item of account as being posted and
+// returns it, dropping items whose key was already posted
+func claimNext(account string) (*queuedPost, error) {
+	var claimed *queuedPost
+	err := updateQueue(func(queue *postQueue) error {
+		pending := queue.Pending[:0]
+		for _, post := range queue.Pending {
+			if _, done := queue.Posted[post.Key]; done {
+				continue
+			}
+			pending = append(pending, post)
+			if claimed == nil && post.Account == account && time.Since(post.ClaimedAt) > queueClaimTimeout {
+				post.ClaimedAt = time.Now()
+				claimed = post
+			}
+		}
+		queue.Pending = pending
+		return nil
+	})
+	return claimed, err
+}
+
+// finishQueued records the outcome of posting a claimed item: a posted item
+// leaves the queue and its key is remembered, a failed one is released
+func finishQueued(key string, postErr error) error {
+	return updateQueue(func(queue *postQueue) error {
+		pending := queue.Pending[:0]
+		for _, post := range queue.Pending {
+			if post.Key == key {
+				if postErr == nil {
+					continue
+				}
+				post.ClaimedAt, post.Error = time.Time{}, postErr.Error()
+			}
+			pending = append(pending, post)
+		}
+		queue.Pending = pending
+
+		if postErr == nil {
+			if queue.Posted == nil {
+				queue.Posted = map[string]time.Time{}
+			}
+			queue.Posted[key] = time.Now()
+		}
+		for k, at := range queue.Posted {
+			if time.Since(at) > postedKeyRetention {
+				delete(queue.Posted, k)
+			}
+		}
+		return nil
+	})
+}
+
+// flushQueue posts the queued items of a's account in the order they were
+// queued, stopping at the first failure so the order is kept. It returns
+// how many were posted.
+func flushQueue(ctx context.Context, a *app) (int, error) {
+	queue, err := loadQueue()
+	if err != nil {
+		return 0, err
+	}
+	if !slices.ContainsFunc(queue.Pending, func(p *queuedPost) bool { return p.Account == a.config.Active }) {
+		return 0, nil
+	}
+
+	if globalOptions.dryRun {
+		for _, post := range queue.Pending {
+			if post.Account != a.config.Active {
+				continue
+			}
+			if prepared, err := post.request().prepare(); err == nil {
+				a.publish(ctx, prepared, func(postResult) {})
+			}
+		}
+		return 0, nil
+	}
+
+	posted := 0
+	for {
+		post, err := claimNext(a.config.Active)
+		if err != nil || post == nil {
+			return posted, err
+		}
+
+		prepared, err := post.request().prepare()
+		var results []postResult
+		if err == nil {
+			results, err = a.publish(ctx, prepared, func(postResult) {})
+		}
+		if len(results) > 0 && err != nil {
+			// Part of a split tweet went out; posting it again would repeat it
+			fmt.Fprintf(os.Stderr, tr("Warning: queued tweet %s was only partly posted: %v\n"), post.Key, err)
+			err = nil
+		}
+		if finishErr := finishQueued(post.Key, err); finishErr != nil {
+			return posted, finishErr
+		}
+		if err != nil {
+			return posted, fmt.Errorf(tr("queued tweet %s: %w"), post.Key, err)
+		}
+
+		posted++
+		ids := make([]string, 0, len(results))
+		for _, result := range results {
+			ids = append(ids, result.ID)
+		}
+		fmt.Fprintf(os.Stderr, tr("Posted queued tweet %s as %s\n"), post.Key, strings.Join(ids, ", "))
+	}
+}
+
+func runQueue(args []string) error {
+	if len(args) > 0 && isHelpArg(args[0]) {
+		fmt.Fprintln(os.Stderr, tr("Usage: clix queue [list|flush|drop <key>...]"))
+		return nil
+	}
+	action := "list"
+	if len(args) > 0 && !isFlagArg(args[0]) {
+		action, args = args[0], args[1:]
+	}
+	switch action {
+	case "list":
+		return runQueueList(args)
+	case "flush":
+		return runQueueFlush(args)
+	case "drop":
+		return runQueueDrop(args)
+	default:
+		return withExitCode(exitUsage, fmt.Errorf(tr("unknown queue action %q"), action))
+	}
+}
+
+func runQueueList(args []string) error {
+	fs := newFlagSet("queue list", "queue list")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	queue, err := loadQueue()
+	if err != nil {
+		return err
+	}
+	pending := queue.Pending
+	if pending == nil {
+		pending = []*queuedPost{}
+	}
+	if machineReadable() {
+		return printResult(pending)
+	}
+	if len(pending) == 0 {
+		fmt.Println(tr("The queue is empty."))
+		return nil
+	}
+	for _, post := range pending {
+		line, _, _ := strings.Cut(post.Text, "\n")
+		fmt.Printf("%s %-16s  %-10s %s\n", post.Key, formatTime(post.QueuedAt), post.Account, line)
+		if post.Error != "" {
+			fmt.Printf(tr("  last error: %s\n"), post.Error)
+		}
+	}
+	return nil
+}
+
+func runQueueFlush(args []string) error {
+	fs := newFlagSet("queue flush", "queue flush  (posts queued tweets of every account)")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	queue, err := loadQueue()
+	if err != nil {
+		return err
+	}
+	var accounts []string
+	seen := map[string]bool{}
+	for _, post := range queue.Pending {
+		if !seen[post.Account] {
+			seen[post.Account] = true
+			accounts = append(accounts, post.Account)
+		}
+	}
+	if len(accounts) == 0 {
+		fmt.Fprintln(os.Stderr, tr("The queue is empty."))
+		return nil
+	}
+
+	apps, err := newAppPool()
+	if err != nil {
+		return err
+	}
+	defer apps.close()
+
+	var errs []error
+	for _, account := range accounts {
+		a, err := apps.get(account)
+		if err == nil {
+			_, err = flushQueue(rootCtx, a)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf(tr("account %q: %w"), account, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func runQueueDrop(args []string) error {
+	fs := newFlagSet("queue drop", "queue drop <key>...")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	return updateQueue(func(queue *postQueue) error {
+		for _, key := range args {
+			found := false
+			for i, post := range queue.Pending {
+				if post.Key == key {
+					queue.Pending = append(queue.Pending[:i], queue.Pending[i+1:]...)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf(tr("no queued tweet %s"), key)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,126 @@
+package clix
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClaimNext(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		queue   postQueue
+		wantKey string
+		wantLen int // pending items left
+	}{
+		{"empty", postQueue{}, "", 0},
+		{"oldest first", postQueue{Pending: []*queuedPost{{Key: "a", Account: "me"}, {Key: "b", Account: "me"}}}, "a", 2},
+		{"other account", postQueue{Pending: []*queuedPost{{Key: "a", Account: "you"}}}, "", 1},
+		{"claimed", postQueue{Pending: []*queuedPost{{Key: "a", Account: "me", ClaimedAt: now}}}, "", 1},
+		{"next after claimed", postQueue{Pending: []*queuedPost{{Key: "a", Account: "me", ClaimedAt: now}, {Key: "b", Account: "me"}}}, "b", 2},
+		{"claim of a flush that died", postQueue{Pending: []*queuedPost{{Key: "a", Account: "me", ClaimedAt: now.Add(-queueClaimTimeout - time.Minute)}}}, "a", 1},
+		{"already posted", postQueue{Pending: []*queuedPost{{Key: "a", Account: "me"}, {Key: "b", Account: "me"}}, Posted: map[string]time.Time{"a": now}}, "b", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inStateDir(t)
+			if err := saveState(queueStateFile, tt.queue); err != nil {
+				t.Fatal(err)
+			}
+			post, err := claimNext("me")
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotKey := ""
+			if post != nil {
+				gotKey = post.Key
+			}
+			if gotKey != tt.wantKey {
+				t.Fatalf("claimNext() = %q, want %q", gotKey, tt.wantKey)
+			}
+			queue, err := loadQueue()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(queue.Pending) != tt.wantLen {
+				t.Errorf("%d items pending, want %d", len(queue.Pending), tt.wantLen)
+			}
+			if post == nil {
+				return
+			}
+			if again, err := claimNext("me"); err != nil || again != nil && again.Key == gotKey {
+				t.Errorf("item %s was claimed twice", gotKey)
+			}
+		})
+	}
+}
+
+func TestFinishQueued(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantPending bool
+		wantPosted  bool
+	}{
+		{"posted", nil, false, true},
+		{"failed", errors.New("no network"), true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inStateDir(t)
+			if err := saveState(queueStateFile, postQueue{Pending: []*queuedPost{{Key: "a", Account: "me"}}}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := claimNext("me"); err != nil {
+				t.Fatal(err)
+			}
+			if err := finishQueued("a", tt.err); err != nil {
+				t.Fatal(err)
+			}
+			queue, err := loadQueue()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pending := len(queue.Pending) == 1; pending != tt.wantPending {
+				t.Fatalf("pending = %v, want %v", pending, tt.wantPending)
+			}
+			if _, posted := queue.Posted["a"]; posted != tt.wantPosted {
+				t.Errorf("posted = %v, want %v", posted, tt.wantPosted)
+			}
+			// A failed item is released for the next flush
+			post, err := claimNext("me")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (post != nil) != tt.wantPending {
+				t.Errorf("claimNext() after finishing = %v, want an item: %v", post, tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestClaimNextConcurrently(t *testing.T) {
+	inStateDir(t)
+	if err := saveState(queueStateFile, postQueue{Pending: []*queuedPost{{Key: "a", Account: "me"}}}); err != nil {
+		t.Fatal(err)
+	}
+	const flushes = 8
+	var wg sync.WaitGroup
+	claims := make(chan *queuedPost, flushes)
+	for range flushes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if post, err := claimNext("me"); err == nil && post != nil {
+				claims <- post
+			}
+		}()
+	}
+	wg.Wait()
+	close(claims)
+	if n := len(claims); n != 1 {
+		t.Fatalf("%d flushes claimed the item, want 1", n)
+	}
+}
@@ -0,0 +1,244 @@
+package clix
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// Retries back off exponentially from retryBaseDelay up to retryMaxDelay
+const (
+	maxRetries     = 4
+	retryBaseDelay = time.Second
+	retryMaxDelay  = 30 * time.Second
+)
+
+// maxLimitWaits is how many times --wait-on-limit waits for a rate limit
+// to reset before giving up on a request that keeps hitting it
+const maxLimitWaits = 3
+
+// rateLimit is what the x-rate-limit-* headers say about an endpoint
+type rateLimit struct {
+	limit, remaining int
+	reset            time.Time
+}
+
+func parseRateLimit(h http.Header) (rateLimit, bool) {
+	limit, err1 := strconv.Atoi(h.Get("x-rate-limit-limit"))
+	remaining, err2 := strconv.Atoi(h.Get("x-rate-limit-remaining"))
+	reset, err3 := strconv.ParseInt(h.Get("x-rate-limit-reset"), 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return rateLimit{}, false
+	}
+	return rateLimit{limit, remaining, time.Unix(reset, 0)}, true
+}
+
+// retryTransport retries requests that hit the rate limit or a server
+// error, with exponential backoff and jitter. Every API call goes through
+// it, whether made by gotwi or by doJSON.
+type retryTransport struct {
+	base http.RoundTripper
+	// waitForReset waits until the rate limit resets instead of failing
+	// when it is too far away to retry
+	waitForReset bool
+	// timeout bounds each call as a whole, retries, waits and reading the
+	// response included; 0 for none
+	timeout time.Duration
+
+	// budget keeps the rate limits of the account across runs, so an
+	// endpoint known to be used up is not called; nil for none
+	budget *limitBudget
+
+	// responded is set once the API has answered at all, which tells
+	// the offline queue that the network is back
+	responded atomic.Bool
+}
+
+func newHTTPClient() *http.Client {
+	timeout, err := callTimeout()
+	if err != nil {
+		return &http.Client{Transport: failingTransport{err}}
+	}
+	return newRetryingClient(timeout)
+}
+
+// newStreamHTTPClient is newHTTPClient without --timeout, for the filtered
+// stream, which stays connected for as long as it runs
+func newStreamHTTPClient() *http.Client {
+	return newRetryingClient(0)
+}
+
+func newRetryingClient(timeout time.Duration) *http.Client {
+	transport, err := newTransport()
+	if err != nil {
+		return &http.Client{Transport: failingTransport{err}}
+	}
+	var base http.RoundTripper = transport
+	if mocking() {
+		base = newMockTransport(transport)
+	}
+	return &http.Client{Transport: &retryTransport{base: base, waitForReset: globalOptions.waitOnLimit, timeout: timeout}}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.timeout == 0 {
+		return t.roundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	res, err := t.roundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		if ctx.Err() == context.DeadlineExceeded && req.Context().Err() == nil {
+			return nil, fmt.Errorf(tr("no answer within the timeout of %s: %w"), t.timeout, context.DeadlineExceeded)
+		}
+		return nil, err
+	}
+	// The deadline goes on to cover reading the body
+	res.Body = cancelOnClose{res.Body, cancel}
+	return res, nil
+}
+
+// cancelOnClose releases the context of a request when its response body
+// is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+func (t *retryTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	if dryRunBlocks(req) {
+		return nil, &errDryRun{req.Method, req.URL.Path}
+	}
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			// The previous attempt consumed the body
+			if req.GetBody == nil {
+				return nil, fmt.Errorf(tr("cannot retry %s %s: request body is not replayable"), req.Method, req.URL.Path)
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		var res *http.Response
+		var err error
+		if used, ok := t.budget.exhausted(req); ok {
+			logger.Info("rate limit used up, not calling", "path", req.URL.Path, "reset", used.Reset.Local().Format("15:04:05"))
+			res = rateLimitedResponse(req, used)
+		} else {
+			start := time.Now()
+			res, err = t.base.RoundTrip(req)
+			logRequest(req, res, err, start)
+			if err == nil {
+				t.responded.Store(true)
+			}
+		}
+		var limit rateLimit
+		var hasLimit bool
+		if err == nil {
+			if limit, hasLimit = parseRateLimit(res.Header); hasLimit {
+				logger.Info("rate limit", "path", req.URL.Path, "remaining", limit.remaining, "limit", limit.limit,
+					"reset", limit.reset.Local().Format("15:04:05"))
+				t.budget.observe(req, limit)
+			}
+		}
+
+		delay, retry := t.retryDelay(req, res, err, attempt, limit, hasLimit)
+		if !retry {
+			if err == nil && res.StatusCode == http.StatusTooManyRequests && hasLimit {
+				fmt.Fprintf(os.Stderr, tr("Warning: rate limit reached for %s, it resets at %s (use --wait-on-limit to wait for it)\n"),
+					req.URL.Path, limit.reset.Local().Format("15:04:05"))
+			}
+			return res, err
+		}
+		if err == nil {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+		logger.Info("retrying", "method", req.Method, "path", req.URL.Path, "attempt", attempt+2, "delay", delay.Round(time.Millisecond))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryDelay decides whether an attempt should be retried and after how long
+func (t *retryTransport) retryDelay(req *http.Request, res *http.Response, err error, attempt int, limit rateLimit, hasLimit bool) (time.Duration, bool) {
+	if err != nil {
+		// The request may have reached the API, so only retry reads
+		return backoff(attempt), isRead(req) && req.Context().Err() == nil && attempt < maxRetries
+	}
+
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests:
+		wait := retryAfter(res.Header)
+		if wait == 0 && hasLimit {
+			wait = max(time.Until(limit.reset)+time.Second, retryBaseDelay)
+		}
+		if wait == 0 {
+			wait = backoff(attempt)
+		}
+		// Retrying is pointless when the limit resets much later
+		if wait <= retryMaxDelay && attempt < maxRetries {
+			return wait, true
+		}
+		// Nor is waiting past the deadline of the call, or for a limit that
+		// is still used up after waiting for it a few times
+		deadline, hasDeadline := req.Context().Deadline()
+		if t.waitForReset && attempt < maxRetries+maxLimitWaits && (!hasDeadline || time.Now().Add(wait).Before(deadline)) {
+			fmt.Fprintf(os.Stderr, tr("Rate limited; waiting until %s.\n"), time.Now().Add(wait).Format("15:04:05"))
+			return wait, true
+		}
+		return 0, false
+	case res.StatusCode >= 500:
+		// X can fail a write with a 5xx after making the tweet or sending
+		// the message, so retrying one could post it twice
+		return backoff(attempt), isRead(req) && attempt < maxRetries
+	default:
+		return 0, false
+	}
+}
+
+// isRead reports whether req only reads, so sending it again cannot do
+// anything twice
+func isRead(req *http.Request) bool {
+	return req.Method == http.MethodGet || req.Method == http.MethodHead
+}
+
+// backoff returns the delay before retry number attempt+1: exponential,
+// capped, with jitter spreading it between half and all of that
+func backoff(attempt int) time.Duration {
+	d := retryMaxDelay
+	if attempt < 5 {
+		d = min(retryBaseDelay<<attempt, retryMaxDelay)
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// retryAfter reads a Retry-After header given in seconds
+func retryAfter(h http.Header) time.Duration {
+	seconds, err := strconv.Atoi(h.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,144 @@
+package clix
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/resources"
+)
+
+// Fields requested by every command that displays tweets
+var (
+	tweetViewFields  = fields.TweetFieldList{fields.TweetFieldAuthorID, fields.TweetFieldCreatedAt, fields.TweetFieldPublicMetrics, fields.TweetFieldConversationID}
+	tweetViewExpand  = fields.ExpansionList{fields.ExpansionAuthorID}
+	tweetViewUserFld = fields.UserFieldList{fields.UserFieldName, fields.UserFieldUsername}
+)
+
+// tweetView is a tweet with its author resolved, as shown by read commands
+type tweetView struct {
+	ID             string    `json:"id"`
+	Text           string    `json:"text"`
+	AuthorID       string    `json:"author_id,omitempty"`
+	AuthorName     string    `json:"author_name,omitempty"`
+	AuthorUsername string    `json:"author_username,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	Replies        int       `json:"replies"`
+	Retweets       int       `json:"retweets"`
+	Likes          int       `json:"likes"`
+	Quotes         int       `json:"quotes"`
+	URL            string    `json:"url"`
+	// Media is only filled in by commands that ask for it
+	Media []tweetMedia `json:"media,omitempty"`
+}
+
+// tweetMedia is a photo, video or GIF attached to a tweet
+type tweetMedia struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+	Alt  string `json:"alt_text,omitempty"`
+}
+
+// newTweetViews joins tweets with the users included in the same response
+func newTweetViews(tweets []resources.Tweet, users []resources.User) []tweetView {
+	byID := make(map[string]resources.User, len(users))
+	for _, user := range users {
+		byID[gotwi.StringValue(user.ID)] = user
+	}
+
+	views := make([]tweetView, 0, len(tweets))
+	for _, tweet := range tweets {
+		view := tweetView{
+			ID:       gotwi.StringValue(tweet.ID),
+			Text:     gotwi.StringValue(tweet.Text),
+			AuthorID: gotwi.StringValue(tweet.AuthorID),
+		}
+		if tweet.CreatedAt != nil {
+			view.CreatedAt = *tweet.CreatedAt
+		}
+		if author, ok := byID[view.AuthorID]; ok {
+			view.AuthorName = gotwi.StringValue(author.Name)
+			view.AuthorUsername = gotwi.StringValue(author.Username)
+		}
+		if m := tweet.PublicMetrics; m != nil {
+			view.Replies = gotwi.IntValue(m.ReplyCount)
+			view.Retweets = gotwi.IntValue(m.RetweetCount)
+			view.Likes = gotwi.IntValue(m.LikeCount)
+			view.Quotes = gotwi.IntValue(m.QuoteCount)
+		}
+		view.URL = tweetURL(view.AuthorUsername, view.ID)
+		views = append(views, view)
+	}
+	return views
+}
+
+// withMedia fills in the media of views from the media included in the
+// response they came from. Videos and GIFs link to their best MP4.
+func withMedia(views []tweetView, tweets []resources.Tweet, media []resources.Media) []tweetView {
+	byKey := make(map[string]resources.Media, len(media))
+	for _, m := range media {
+		byKey[gotwi.StringValue(m.MediaKey)] = m
+	}
+	for i, tweet := range tweets {
+		if i >= len(views) || tweet.Attachments == nil {
+			continue
+		}
+		for _, key := range tweet.Attachments.MediaKeys {
+			m, ok := byKey[key]
+			if !ok {
+				continue
+			}
+			item := tweetMedia{Type: gotwi.StringValue(m.Type), URL: gotwi.StringValue(m.URL), Alt: gotwi.StringValue(m.AltText)}
+			bitRate := -1
+			for _, v := range m.Variants {
+				if v.ContentType == "video/mp4" && v.BitRate > bitRate {
+					item.URL, bitRate = v.URL, v.BitRate
+				}
+			}
+			if item.URL == "" {
+				item.URL = gotwi.StringValue(m.PreviewImageUrl)
+			}
+			views[i].Media = append(views[i].Media, item)
+		}
+	}
+	return views
+}
+
+// printTweet renders one tweet as a short block of text
+func printTweet(w io.Writer, view tweetView) {
+	author := view.AuthorName
+	if view.AuthorUsername != "" {
+		author += " @" + view.AuthorUsername
+	}
+	if author == "" {
+		author = view.AuthorID
+	}
+	colors := colorsFor(w)
+	when := ""
+	if !view.CreatedAt.IsZero() {
+		when = paint(colors.time, " · "+formatTime(view.CreatedAt))
+	}
+
+	fmt.Fprintf(w, "%s%s\n", paint(colors.author, strings.TrimSpace(author)), when)
+	for _, line := range strings.Split(view.Text, "\n") {
+		fmt.Fprintf(w, "  %s\n", line)
+	}
+	for _, m := range view.Media {
+		line := fmt.Sprintf("  [%s] %s", m.Type, m.URL)
+		if m.Alt != "" {
+			line += " · alt: " + m.Alt
+		}
+		fmt.Fprintln(w, line)
+	}
+	metrics := fmt.Sprintf("↩ %d  ⟲ %d  ♥ %d  ❝ %d  · %s", view.Replies, view.Retweets, view.Likes, view.Quotes, view.ID)
+	fmt.Fprintf(w, "  %s\n\n", paint(colors.metrics, metrics))
+}
+
+func printTweets(w io.Writer, views []tweetView) {
+	for _, view := range views {
+		printTweet(w, view)
+	}
+}
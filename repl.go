@@ -0,0 +1,202 @@
+package clix
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// attachMedia adds a file to the next tweet, asking for its alt text
+func attachMedia(req *postRequest, path string) error {
+	if _, err := openMediaFiles(append(req.media, path), nil, req.keepEXIF); err != nil {
+		return err
+	}
+	alt, err := promptLine(trf("Alt text for %s (enter to skip): ", filepath.Base(path)))
+	if err != nil {
+		return err
+	}
+	// Alt texts pair with media by position, so skipped ones stay empty
+	req.alt = append(req.alt, alt)
+	req.media = append(req.media, path)
+	fmt.Printf(tr("Attached %s to the next tweet.\n"), filepath.Base(path))
+	return nil
+}
+
+// isReplCommand reports whether line is a repl command, which Enter runs
+// straight away instead of starting a second line of the tweet
+func isReplCommand(line string) bool {
+	line = strings.TrimSpace(line)
+	return strings.HasPrefix(line, "/") || line == "exit" || line == "quit"
+}
+
+func runRepl(args []string) error {
+	fs := newFlagSet("repl", "repl")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	a, err := setup(true)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+	delay, err := undoDelay(a.config, "")
+	if err != nil {
+		return err
+	}
+
+	// Typed input gets the line editor; piped input is one tweet per line
+	var editor *lineEditor
+	if stdinIsTerminal() {
+		editor = newLineEditor("tweet: ")
+		editor.complete = handleCompleter(a.config.Active)
+		editor.shortcodes = a.config.shortcodes()
+		fmt.Println(tr("Type a tweet and end it with an empty line or Ctrl-D; /media <path> attaches a file to the next one."))
+		fmt.Println(tr("Up and down recall earlier input, Ctrl-R searches it and Tab completes @mentions and :emoji: shortcodes."))
+	} else {
+		fmt.Println(tr("Type a tweet and press enter to post it; /media <path> attaches a file to the next one."))
+	}
+	req := &postRequest{}
+	for {
+		var tweetText string
+		if editor != nil {
+			tweetText, err = editor.readInput(isReplCommand)
+		} else {
+			fmt.Print(tr("tweet: "))
+			tweetText, err = stdin.ReadString('\n')
+			if err == io.EOF && tweetText != "" {
+				err = nil
+			}
+		}
+		if errors.Is(err, errInterrupted) {
+			continue
+		}
+		if err == io.EOF {
+			fmt.Println(tr("Goodbye!"))
+			break
+		}
+		if err != nil {
+			return fmt.Errorf(tr("failed to read input: %w"), err)
+		}
+
+		tweetText = strings.TrimSpace(tweetText)
+		if tweetText == "" {
+			continue
+		}
+		if tweetText == "exit" || tweetText == "quit" {
+			fmt.Println(tr("Goodbye!"))
+			break
+		}
+
+		if path, ok := strings.CutPrefix(tweetText, "/media "); ok {
+			if err := attachMedia(req, strings.TrimSpace(path)); err != nil {
+				fmt.Println(tr("Error attaching media:"), err)
+			}
+			continue
+		}
+
+		if draft, ok := strings.CutPrefix(tweetText, "/suggest"); ok && (draft == "" || draft[0] == ' ') {
+			if draft = strings.TrimSpace(draft); draft == "" {
+				fmt.Println(tr("Usage: /suggest <draft text>"))
+				continue
+			}
+			suggestions, err := a.suggestTags(rootCtx, draft, "", 5)
+			if err != nil {
+				fmt.Println(tr("Error:"), err)
+				continue
+			}
+			printSuggestions(suggestions)
+			fmt.Println()
+			continue
+		}
+
+		req.text = tweetText
+		if err := req.transform(a.config); err != nil {
+			fmt.Println(tr("Not posting:"), err)
+			continue
+		}
+		prepared, err := req.prepare()
+		if err != nil {
+			fmt.Println(tr("Not posting:"), err)
+			continue
+		}
+
+		if err := checkPostingWindow(a.config.PostingWindow, time.Now()); err != nil {
+			scheduled, scheduleErr := scheduleInstead(a, req, prepared)
+			if scheduleErr != nil {
+				fmt.Println(tr("Error scheduling tweet:"), scheduleErr)
+			} else if !scheduled {
+				fmt.Println(tr("Not posting:"), err)
+			}
+			req = &postRequest{}
+			continue
+		}
+
+		if err := a.config.SafeMode.check(prepared.parts...); err != nil {
+			fmt.Println(tr("Not posting:"), err)
+			req = &postRequest{}
+			continue
+		}
+		if ok, err := checkLint(rootCtx, a.config, a, prepared.parts, 0, nil); !ok || err != nil {
+			if err != nil {
+				fmt.Println(tr("Not posting:"), err)
+			} else {
+				fmt.Println(tr("Not posted."))
+			}
+			fmt.Println()
+			continue
+		}
+		if err := checkA11y(a.config, prepared.parts, [][]*mediaFile{prepared.media}, 0, false); err != nil {
+			fmt.Println(tr("Not posting:"), err)
+			fmt.Println()
+			continue
+		}
+		if err := checkStyle(a.config, prepared.parts, "", 0, false); err != nil {
+			fmt.Println(tr("Not posting:"), err)
+			fmt.Println()
+			continue
+		}
+		prepared.checked = true
+		// Piped input is posted as is; typed tweets are previewed first
+		// unless confirm_before_post is false
+		if !globalOptions.dryRun && stdinIsTerminal() && a.config.confirmBeforePost(nil, true) {
+			if ok, _ := a.confirmPreview(rootCtx, prepared); !ok {
+				fmt.Println(tr("Not posted."))
+				fmt.Println()
+				continue
+			}
+		}
+		if ok, err := holdForUndo(delay); !ok || err != nil {
+			fmt.Println(tr("Not posted."))
+			fmt.Println()
+			continue
+		}
+
+		results, err := a.publish(rootCtx, prepared, func(postResult) {})
+		if err != nil && interrupted() {
+			return err
+		}
+		if err != nil {
+			fmt.Println(tr("Error posting tweet:"), err)
+			if len(results) == 0 && isNetworkError(err) {
+				if _, err := queueInstead(a.config.Active, req); err != nil {
+					fmt.Println(tr("Error queueing tweet:"), err)
+				}
+			}
+			req = &postRequest{}
+			continue
+		}
+		req = &postRequest{}
+		if results[0].DryRun {
+			fmt.Println()
+			continue
+		}
+		id := results[0].ID
+
+		fmt.Printf(tr("Tweet posted successfully! [ID: %s]\n\n"), id)
+	}
+	return nil
+}
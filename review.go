@@ -0,0 +1,613 @@
+package clix
+
+import (
+	"cmp"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// reviewKeyFile keeps the key proposals are signed with
+const reviewKeyFile = "review_key.json"
+
+// approvedProposalsFile records, by signature, the proposals approved
+// here, so one cannot be posted twice by copying it without its approval
+const approvedProposalsFile = "approved_proposals.json"
+
+// proposalClaimTimeout is how long a proposal being approved stays claimed
+// by an approve that never finished
+const proposalClaimTimeout = time.Hour
+
+// proposalMediaDir is where a proposal's media is copied to, within the
+// proposals directory, so the approver has it too
+const proposalMediaDir = "media"
+
+// ReviewConfig is the "review" section of the config, for teams where one
+// person proposes posts and another approves them from the shared account
+type ReviewConfig struct {
+	// Dir is the shared directory proposals are saved to and listed from,
+	// such as a synced folder or a git checkout
+	Dir string `json:"dir,omitempty"`
+	// Author is the name proposals and approvals are made under; the
+	// default is the login name
+	Author string `json:"author,omitempty"`
+	// Trusted maps the proposers whose posts may be approved to their
+	// public keys, as printed by clix propose --key
+	Trusted map[string]string `json:"trusted,omitempty"`
+}
+
+func (c *ReviewConfig) dir(flag string) (string, error) {
+	if flag != "" {
+		return flag, nil
+	}
+	if c != nil && c.Dir != "" {
+		return c.Dir, nil
+	}
+	return "", withExitCode(exitUsage, errors.New(tr("no proposals directory; pass --dir or set dir in the review section of the config")))
+}
+
+func (c *ReviewConfig) author() string {
+	if c != nil && c.Author != "" {
+		return c.Author
+	}
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return cmp.Or(os.Getenv("USER"), os.Getenv("USERNAME"), "anonymous")
+}
+
+// check reports a trusted key that is not one
+func (c *ReviewConfig) check() error {
+	if c == nil {
+		return nil
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Trusted)) {
+		if key, err := base64.StdEncoding.DecodeString(c.Trusted[name]); err != nil || len(key) != ed25519.PublicKeySize {
+			return fmt.Errorf(tr("trusted key of %q is not a public key printed by clix propose --key"), name)
+		}
+	}
+	return nil
+}
+
+// proposal is a post saved for someone else to approve. The signature
+// covers everything but itself and the approval, so what is posted is
+// what the author proposed.
+type proposal struct {
+	// Nonce makes every proposal's signature unique, even for the same
+	// text, so approvals can be recorded by it
+	Nonce  string `json:"nonce,omitempty"`
+	Author string `json:"author"`
+	// Key is the author's public key
+	Key string `json:"key"`
+	// Account is the account it is meant for, if the author named one
+	Account   string    `json:"account,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Post      savedPost `json:"post"`
+	// MediaSHA256 are the checksums of the media files, in order; their
+	// paths are relative to the proposals directory
+	MediaSHA256 []string `json:"media_sha256,omitempty"`
+	Signature   string   `json:"signature"`
+
+	Approved *proposalApproval `json:"approved,omitempty"`
+}
+
+// proposalApproval records who approved a proposal and what was posted
+type proposalApproval struct {
+	By  string    `json:"by"`
+	At  time.Time `json:"at"`
+	IDs []string  `json:"ids"`
+	// Claimed is set while an approve shows and posts the proposal, so a
+	// second approve meanwhile is refused
+	Claimed bool `json:"claimed,omitempty"`
+}
+
+// signed is what the signature covers
+func (p *proposal) signed() []byte {
+	unsigned := *p
+	unsigned.Signature, unsigned.Approved = "", nil
+	data, _ := json.Marshal(unsigned)
+	return data
+}
+
+// verify checks the proposal was signed by a proposer trusted in config,
+// and that neither it nor its media changed since
+func (p *proposal) verify(config *ReviewConfig, dir string) error {
+	var trusted map[string]string
+	if config != nil {
+		trusted = config.Trusted
+	}
+	if key, ok := trusted[p.Author]; !ok || key != p.Key {
+		return withExitCode(exitRefused, fmt.Errorf(tr("%s is not a trusted proposer; to trust them, add %q: %q to trusted in the review section of the config"), p.Author, p.Author, p.Key))
+	}
+	key, _ := base64.StdEncoding.DecodeString(p.Key)
+	sig, err := base64.StdEncoding.DecodeString(p.Signature)
+	if err != nil || len(key) != ed25519.PublicKeySize || !ed25519.Verify(key, p.signed(), sig) {
+		return withExitCode(exitRefused, fmt.Errorf(tr("the signature does not match; the proposal was changed after %s signed it"), p.Author))
+	}
+	if len(p.MediaSHA256) != len(p.Post.Media) {
+		return withExitCode(exitRefused, errors.New(tr("the proposal's media checksums do not match its media")))
+	}
+	for i, name := range p.Post.Media {
+		sum, err := fileSHA256(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if sum != p.MediaSHA256[i] {
+			return withExitCode(exitRefused, fmt.Errorf(tr("%s was changed after %s signed the proposal"), name, p.Author))
+		}
+	}
+	return nil
+}
+
+// loadApprovedProposals returns the approvals made here, by the signature
+// of the proposal
+func loadApprovedProposals() (map[string]*proposalApproval, error) {
+	approved := map[string]*proposalApproval{}
+	if err := loadState(approvedProposalsFile, &approved); err != nil {
+		return nil, err
+	}
+	return approved, nil
+}
+
+// claimProposal records the proposal with signature as being approved by
+// by, refusing one approved or claimed already. The state is locked only
+// while the claim is written, so the approver may take as long as they
+// like to look at the proposal; a claim older than proposalClaimTimeout
+// is left from an approve that crashed and is taken over.
+func claimProposal(signature, by string) (*proposalApproval, error) {
+	unlock, err := lockState(approvedProposalsFile)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	approved, err := loadApprovedProposals()
+	if err != nil {
+		return nil, err
+	}
+	if earlier := approved[signature]; earlier != nil {
+		if !earlier.Claimed {
+			return nil, withExitCode(exitRefused, fmt.Errorf(tr("this proposal was already approved by %s %s, as %s"), earlier.By, formatTime(earlier.At), strings.Join(earlier.IDs, ", ")))
+		}
+		if time.Since(earlier.At) < proposalClaimTimeout {
+			return nil, withExitCode(exitRefused, fmt.Errorf(tr("%s is approving this proposal since %s"), earlier.By, formatTime(earlier.At)))
+		}
+	}
+	claim := &proposalApproval{By: by, At: time.Now().UTC().Truncate(time.Second), Claimed: true}
+	approved[signature] = claim
+	if err := saveState(approvedProposalsFile, approved); err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+// renewClaim checks that claim on the proposal with signature is still
+// held, not taken over after it timed out, and starts its timeout again
+func renewClaim(signature string, claim *proposalApproval) error {
+	unlock, err := lockState(approvedProposalsFile)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	approved, err := loadApprovedProposals()
+	if err != nil {
+		return err
+	}
+	current := approved[signature]
+	if current == nil || !current.Claimed || current.By != claim.By || !current.At.Equal(claim.At) {
+		return withExitCode(exitRefused, errors.New(tr("the proposal was claimed by another approve while this one waited; not posted")))
+	}
+	current.At = time.Now().UTC().Truncate(time.Second)
+	if err := saveState(approvedProposalsFile, approved); err != nil {
+		return err
+	}
+	claim.At = current.At
+	return nil
+}
+
+// settleProposal records the approval of the proposal with signature, or
+// with approval nil drops claim on it so it can be approved again. A claim
+// another approve took over is left alone.
+func settleProposal(signature string, claim, approval *proposalApproval) error {
+	unlock, err := lockState(approvedProposalsFile)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	approved, err := loadApprovedProposals()
+	if err != nil {
+		return err
+	}
+	current := approved[signature]
+	switch {
+	case approval != nil:
+		approved[signature] = approval
+	case current != nil && current.Claimed && current.By == claim.By && current.At.Equal(claim.At):
+		delete(approved, signature)
+	default:
+		return nil
+	}
+	return saveState(approvedProposalsFile, approved)
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf(tr("failed to read media: %w"), err)
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf(tr("failed to read media: %w"), err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// reviewKey returns this user's signing key, creating it on first use
+func reviewKey() (ed25519.PrivateKey, error) {
+	unlock, err := lockState(reviewKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	var stored struct {
+		Seed string `json:"seed"`
+	}
+	if err := loadState(reviewKeyFile, &stored); err != nil {
+		return nil, err
+	}
+	if stored.Seed != "" {
+		seed, err := base64.StdEncoding.DecodeString(stored.Seed)
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf(tr("%s does not hold a signing key"), reviewKeyFile)
+		}
+		return ed25519.NewKeyFromSeed(seed), nil
+	}
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	stored.Seed = base64.StdEncoding.EncodeToString(key.Seed())
+	return key, saveState(reviewKeyFile, stored)
+}
+
+func publicKey(key ed25519.PrivateKey) string {
+	return base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey))
+}
+
+// copyProposalMedia copies a media file into the proposals directory,
+// named by its checksum, and returns its path there and the checksum
+func copyProposalMedia(dir, path string) (string, string, error) {
+	sum, err := fileSHA256(path)
+	if err != nil {
+		return "", "", err
+	}
+	name := filepath.ToSlash(filepath.Join(proposalMediaDir, sum[:16]+strings.ToLower(filepath.Ext(path))))
+	dest := filepath.Join(dir, name)
+	if _, err := os.Stat(dest); err == nil {
+		return name, sum, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return "", "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", fmt.Errorf(tr("failed to read media: %w"), err)
+	}
+	if err := os.WriteFile(dest, data, 0644); err != nil {
+		return "", "", fmt.Errorf(tr("failed to copy media: %w"), err)
+	}
+	return name, sum, nil
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// writeProposal saves p in dir under a new name and returns its path
+func writeProposal(dir string, p *proposal) (string, error) {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	base := p.CreatedAt.UTC().Format("20060102-150405") + "-" + cmp.Or(unsafeFileChars.ReplaceAllString(p.Author, "_"), "proposal")
+	for n := 1; ; n++ {
+		name := base + ".json"
+		if n > 1 {
+			name = fmt.Sprintf("%s-%d.json", base, n)
+		}
+		path := filepath.Join(dir, name)
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf(tr("failed to save the proposal: %w"), err)
+		}
+		_, err = f.Write(append(data, '\n'))
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return "", fmt.Errorf(tr("failed to save the proposal: %w"), err)
+		}
+		return path, nil
+	}
+}
+
+func readProposal(path string) (*proposal, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf(tr("failed to read the proposal: %w"), err)
+	}
+	var p proposal
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, withExitCode(exitValidation, fmt.Errorf(tr("%s is not a proposal: %w"), path, err))
+	}
+	return &p, nil
+}
+
+func runPropose(args []string) error {
+	fs := newFlagSet("propose", "propose [flags] [text]  |  propose --key  (reads stdin when no text is given)")
+	dir := fs.String("dir", "", "the shared proposals directory (default from the review section of the config)")
+	var media stringList
+	fs.Var(&media, "media", "attach a media file (repeatable)")
+	var alts stringList
+	fs.Var(&alts, "alt", "alt text for the media, paired with each --media in order")
+	replyTo := fs.String("reply-to", "", "reply to this tweet (ID or URL)")
+	quote := fs.String("quote", "", "quote this tweet (ID or URL)")
+	split := fs.Bool("split", false, "split an over-length tweet into a thread")
+	note := fs.String("note", "", "a note for the reviewer, which is not posted")
+	showKey := fs.Bool("key", false, "print the public key reviewers trust your proposals by")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	config, err := readConfig(getConfigFilePath())
+	if err != nil {
+		return fmt.Errorf(tr("failed to load configuration: %w"), err)
+	}
+	key, err := reviewKey()
+	if err != nil {
+		return err
+	}
+	if *showKey {
+		if machineReadable() {
+			return printResult(map[string]string{"author": config.Review.author(), "key": publicKey(key)})
+		}
+		fmt.Printf("%q: %q\n", config.Review.author(), publicKey(key))
+		return nil
+	}
+	proposals, err := config.Review.dir(*dir)
+	if err != nil {
+		return err
+	}
+
+	text, err := readText(args)
+	if err != nil {
+		return err
+	}
+	req := &postRequest{text: text, media: media, alt: alts, replyTo: *replyTo, quote: *quote, split: *split}
+	if _, err := req.prepare(); err != nil {
+		return err
+	}
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	p := &proposal{
+		Nonce:     hex.EncodeToString(nonce),
+		Author:    config.Review.author(),
+		Key:       publicKey(key),
+		Note:      *note,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Post:      savedPost{Text: req.text, Alt: req.alt, ReplyTo: req.replyTo, Quote: req.quote, Split: req.split},
+	}
+	if globalOptions.account != "" || os.Getenv(accountEnvVar) != "" {
+		p.Account = config.Active
+	}
+	if globalOptions.dryRun {
+		fmt.Println(tr("Dry run, nothing was proposed."))
+		return nil
+	}
+	if err := os.MkdirAll(proposals, 0755); err != nil {
+		return fmt.Errorf(tr("failed to create the proposals directory: %w"), err)
+	}
+	for _, path := range req.media {
+		name, sum, err := copyProposalMedia(proposals, path)
+		if err != nil {
+			return err
+		}
+		p.Post.Media = append(p.Post.Media, name)
+		p.MediaSHA256 = append(p.MediaSHA256, sum)
+	}
+	p.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, p.signed()))
+	path, err := writeProposal(proposals, p)
+	if err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(map[string]string{"file": path})
+	}
+	fmt.Println(tr("Proposed in"), path)
+	return nil
+}
+
+// pendingProposal is a line of clix approve without a file
+type pendingProposal struct {
+	File      string    `json:"file"`
+	Author    string    `json:"author"`
+	Account   string    `json:"account,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Text      string    `json:"text"`
+	Note      string    `json:"note,omitempty"`
+}
+
+// listProposals lists the proposals in dir not yet approved, oldest first
+func listProposals(dir string) ([]pendingProposal, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	approved, err := loadApprovedProposals()
+	if err != nil {
+		return nil, err
+	}
+	pending := []pendingProposal{}
+	for _, path := range paths {
+		p, err := readProposal(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, tr("Warning:"), err)
+			continue
+		}
+		if p.Approved == nil && approved[p.Signature] == nil {
+			pending = append(pending, pendingProposal{File: path, Author: p.Author, Account: p.Account, CreatedAt: p.CreatedAt, Text: p.Post.Text, Note: p.Note})
+		}
+	}
+	slices.SortFunc(pending, func(a, b pendingProposal) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return pending, nil
+}
+
+func runApprove(args []string) error {
+	fs := newFlagSet("approve", "approve [--yes] [--force] <file>  |  approve [--dir d]  (lists the proposals waiting)")
+	dir := fs.String("dir", "", "with no file, the proposals directory to list (default from the review section of the config)")
+	yes := fs.Bool("yes", false, "post without showing the proposal and asking")
+	force := fs.Bool("force", false, "post even outside the configured posting window or against the style rules")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 1 {
+		fs.Usage()
+		return errUsage
+	}
+	if len(args) == 0 {
+		config, err := readConfig(getConfigFilePath())
+		if err != nil {
+			return fmt.Errorf(tr("failed to load configuration: %w"), err)
+		}
+		proposals, err := config.Review.dir(*dir)
+		if err != nil {
+			return err
+		}
+		pending, err := listProposals(proposals)
+		if err != nil {
+			return err
+		}
+		if machineReadable() {
+			return printResult(pending)
+		}
+		if len(pending) == 0 {
+			fmt.Println(tr("No proposals waiting."))
+			return nil
+		}
+		for _, p := range pending {
+			line, _, _ := strings.Cut(p.Text, "\n")
+			fmt.Printf("%s  %-12s %-16s %s\n", filepath.Base(p.File), p.Author, formatTime(p.CreatedAt), line)
+		}
+		return nil
+	}
+
+	path := args[0]
+	p, err := readProposal(path)
+	if err != nil {
+		return err
+	}
+	if p.Approved != nil {
+		return withExitCode(exitRefused, fmt.Errorf(tr("already approved by %s %s"), p.Approved.By, formatTime(p.Approved.At)))
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	proposals := filepath.Dir(path)
+	if err := p.verify(a.config.Review, proposals); err != nil {
+		return err
+	}
+	if p.Account != "" && p.Account != a.config.Active {
+		return withExitCode(exitRefused, fmt.Errorf(tr("proposed for account %q, not %q; use --account %s"), p.Account, a.config.Active, p.Account))
+	}
+	// Claimed until the approval is recorded, so two approves of the same
+	// proposal cannot both post it
+	claim, err := claimProposal(p.Signature, a.config.Review.author())
+	if err != nil {
+		return err
+	}
+	posted := false
+	defer func() {
+		if !posted {
+			if err := settleProposal(p.Signature, claim, nil); err != nil {
+				fmt.Fprintln(os.Stderr, tr("Warning: could not release the claim on the proposal:"), err)
+			}
+		}
+	}()
+	req := p.Post.request()
+	for i, name := range req.media {
+		req.media[i] = filepath.Join(proposals, filepath.FromSlash(name))
+	}
+	if err := req.transform(a.config); err != nil {
+		return err
+	}
+	prepared, err := req.prepare()
+	if err != nil {
+		return err
+	}
+	prepared.force = prepared.force || *force
+	checks := prepared.checks(a.config, a)
+	if !*yes {
+		checks.confirm = func() (bool, error) {
+			if !stdinIsTerminal() {
+				return false, withExitCode(exitRefused, errors.New(tr("refusing to approve without showing the proposal; pass --yes")))
+			}
+			fmt.Printf(tr("Proposed by %s %s\n"), p.Author, formatTime(p.CreatedAt))
+			if p.Note != "" {
+				fmt.Println(tr("Note:"), p.Note)
+			}
+			return a.confirmPreview(rootCtx, prepared)
+		}
+	}
+	if ok, err := checks.run(rootCtx); !ok || err != nil {
+		return err
+	}
+	prepared.checked = true
+
+	if err := renewClaim(p.Signature, claim); err != nil {
+		return err
+	}
+	results, err := a.publishAndReport(rootCtx, prepared)
+	if len(results) == 0 || globalOptions.dryRun {
+		return err
+	}
+	// Even a thread posted in part is approved, so it is not posted again
+	posted = true
+	p.Approved = &proposalApproval{By: a.config.Review.author(), At: time.Now().UTC().Truncate(time.Second)}
+	for _, result := range results {
+		p.Approved.IDs = append(p.Approved.IDs, result.ID)
+	}
+	if saveErr := settleProposal(p.Signature, claim, p.Approved); saveErr != nil {
+		fmt.Fprintln(os.Stderr, tr("Warning: posted, but could not record the approval:"), saveErr)
+	}
+	data, saveErr := json.MarshalIndent(p, "", "  ")
+	if saveErr == nil {
+		saveErr = os.WriteFile(path, append(data, '\n'), 0644)
+	}
+	if saveErr != nil {
+		fmt.Fprintln(os.Stderr, tr("Warning: posted, but could not mark the proposal approved:"), saveErr)
+	}
+	return err
+}
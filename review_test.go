@@ -0,0 +1,112 @@
+package clix
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// inStateDir keeps clix's state in a fresh directory for the test
+func inStateDir(t *testing.T) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv(stateDirEnvVar, home)
+}
+
+func TestClaimProposalConcurrently(t *testing.T) {
+	inStateDir(t)
+	const approvers = 8
+	var wg sync.WaitGroup
+	claims := make(chan *proposalApproval, approvers)
+	for range approvers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if claim, err := claimProposal("sig", "sam"); err == nil {
+				claims <- claim
+			}
+		}()
+	}
+	wg.Wait()
+	close(claims)
+	if n := len(claims); n != 1 {
+		t.Fatalf("%d approves claimed the proposal, want 1", n)
+	}
+}
+
+func TestClaimProposal(t *testing.T) {
+	tests := []struct {
+		name    string
+		earlier *proposalApproval
+		wantErr bool
+	}{
+		{"new", nil, false},
+		{"approved", &proposalApproval{By: "alex", At: time.Now(), IDs: []string{"1"}}, true},
+		{"approved long ago", &proposalApproval{By: "alex", At: time.Now().Add(-48 * time.Hour), IDs: []string{"1"}}, true},
+		{"claimed", &proposalApproval{By: "alex", At: time.Now(), Claimed: true}, true},
+		// Held past the lock's staleLockAge but not the claim's timeout
+		{"claimed minutes ago", &proposalApproval{By: "alex", At: time.Now().Add(-10 * time.Minute), Claimed: true}, true},
+		{"claim timed out", &proposalApproval{By: "alex", At: time.Now().Add(-proposalClaimTimeout - time.Minute), Claimed: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inStateDir(t)
+			if tt.earlier != nil {
+				if err := saveState(approvedProposalsFile, map[string]*proposalApproval{"sig": tt.earlier}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			_, err := claimProposal("sig", "sam")
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Errorf("claimProposal() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSettleProposal(t *testing.T) {
+	inStateDir(t)
+	claim, err := claimProposal("sig", "sam")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Released when nothing was posted, so it can be approved again
+	if err := settleProposal("sig", claim, nil); err != nil {
+		t.Fatal(err)
+	}
+	if claim, err = claimProposal("sig", "sam"); err != nil {
+		t.Fatalf("a released proposal could not be claimed again: %v", err)
+	}
+	if err := renewClaim("sig", claim); err != nil {
+		t.Fatalf("renewClaim() = %v for the claim held", err)
+	}
+	if err := settleProposal("sig", claim, &proposalApproval{By: "sam", At: time.Now(), IDs: []string{"1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := claimProposal("sig", "alex"); err == nil {
+		t.Error("an approved proposal was claimed again")
+	}
+}
+
+func TestClaimTakenOver(t *testing.T) {
+	inStateDir(t)
+	stale := &proposalApproval{By: "sam", At: time.Now().Add(-2 * proposalClaimTimeout).UTC().Truncate(time.Second), Claimed: true}
+	if err := saveState(approvedProposalsFile, map[string]*proposalApproval{"sig": stale}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := claimProposal("sig", "alex"); err != nil {
+		t.Fatal(err)
+	}
+	// The approve that waited too long must not post, nor drop the new claim
+	if err := renewClaim("sig", stale); err == nil {
+		t.Error("renewClaim() succeeded for a claim taken over")
+	}
+	if err := settleProposal("sig", stale, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := claimProposal("sig", "sam"); err == nil {
+		t.Error("releasing a claim taken over dropped the new one")
+	}
+}
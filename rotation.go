@@ -0,0 +1,139 @@
+package clix
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const rotationStateFile = "rotation.json"
+
+// rotationState is the progress through one rotation file. Lines are
+// remembered by hash, so editing the file does not lose track of them.
+type rotationState struct {
+	Last   string   `json:"last,omitempty"`   // the line posted last
+	Posted []string `json:"posted,omitempty"` // every line posted so far
+}
+
+// rotationPick is the line post --from-rotation picked from a file
+type rotationPick struct {
+	path string // absolute, the key into the state
+	text string
+	hash string
+}
+
+// readRotation returns the lines of a rotation file: blank lines and lines
+// starting with # are skipped, and \n in a line is a line break
+func readRotation(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf(tr("failed to read rotation file: %w"), err)
+	}
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, strings.ReplaceAll(line, `\n`, "\n"))
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf(tr("no lines to post in %s"), path)
+	}
+	return lines, nil
+}
+
+func lineHash(line string) string {
+	sum := sha256.Sum256([]byte(line))
+	return hex.EncodeToString(sum[:8])
+}
+
+// pickFromRotation picks the line to post from a rotation file: the one
+// after the line posted last, or a random one. noRepeat skips lines that
+// were ever posted.
+func pickFromRotation(path string, random, noRepeat bool) (*rotationPick, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := readRotation(abs)
+	if err != nil {
+		return nil, err
+	}
+	states := map[string]*rotationState{}
+	if err := loadState(rotationStateFile, &states); err != nil {
+		return nil, err
+	}
+	state := states[abs]
+	if state == nil {
+		state = &rotationState{}
+	}
+
+	hashes := make([]string, len(lines))
+	last := -1
+	for i, line := range lines {
+		hashes[i] = lineHash(line)
+		if hashes[i] == state.Last {
+			last = i
+		}
+	}
+	var candidates []int
+	for offset := 1; offset <= len(lines); offset++ {
+		i := (last + offset) % len(lines)
+		if noRepeat && slices.Contains(state.Posted, hashes[i]) {
+			continue
+		}
+		candidates = append(candidates, i)
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf(tr("every line of %s has been posted; drop --no-repeat to start over"), path)
+	}
+
+	pick := candidates[0]
+	if random {
+		// Avoid posting the same line twice in a row when there is a choice
+		if len(candidates) > 1 && candidates[len(candidates)-1] == last {
+			candidates = candidates[:len(candidates)-1]
+		}
+		pick = candidates[rand.IntN(len(candidates))]
+	}
+	return &rotationPick{path: abs, text: lines[pick], hash: hashes[pick]}, nil
+}
+
+// markPosted records the picked line as posted, so the next run moves on.
+// It does nothing in dry-run mode or without a pick.
+func (p *rotationPick) markPosted() {
+	if p == nil || globalOptions.dryRun {
+		return
+	}
+	err := func() error {
+		unlock, err := lockState(rotationStateFile)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		states := map[string]*rotationState{}
+		if err := loadState(rotationStateFile, &states); err != nil {
+			return err
+		}
+		state := states[p.path]
+		if state == nil {
+			state = &rotationState{}
+			states[p.path] = state
+		}
+		state.Last = p.hash
+		if !slices.Contains(state.Posted, p.hash) {
+			state.Posted = append(state.Posted, p.hash)
+		}
+		return saveState(rotationStateFile, states)
+	}()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, tr("Warning: failed to save the rotation progress:"), err)
+	}
+}
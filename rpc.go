@@ -0,0 +1,227 @@
+package clix
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/michimani/gotwi/tweet/searchtweet/types"
+	"github.com/voltycodes/clix/compose"
+)
+
+// maxRPCLine bounds one request line of clix rpc
+const maxRPCLine = 1 << 20
+
+// rpcRequest is a line of clix rpc's input. ID is anything the client
+// picks and comes back unchanged in the response.
+type rpcRequest struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcResponse is a line of clix rpc's output: a result, or an error with
+// anything done before it in Result
+type rpcResponse struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Result any             `json:"result,omitempty"`
+	Error  *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Message  string `json:"message"`
+	ExitCode int    `json:"exit_code"`
+}
+
+// rpcCount is the params of count-chars
+type rpcCount struct {
+	Text string `json:"text"`
+}
+
+// rpcCountResult is what count-chars and each part of validate return
+type rpcCountResult struct {
+	Text      string `json:"text,omitempty"`
+	Length    int    `json:"length"`
+	Limit     int    `json:"limit"`
+	Remaining int    `json:"remaining"`
+}
+
+func countChars(text string) rpcCountResult {
+	n := compose.Length(text)
+	return rpcCountResult{Length: n, Limit: compose.MaxLength, Remaining: compose.MaxLength - n}
+}
+
+// rpcSearch is the params of search
+type rpcSearch struct {
+	Query string `json:"query"`
+	Count int    `json:"count,omitempty"`
+	Lang  string `json:"lang,omitempty"`
+	From  string `json:"from,omitempty"`
+}
+
+// rpcSession answers the requests of one clix rpc run. The app is only set
+// up for the methods that call X, so counting works without credentials.
+type rpcSession struct {
+	config *Config
+	server *apiServer
+}
+
+// rpcMethod is a method of clix rpc. Those that call X need the app.
+type rpcMethod struct {
+	call     apiMethod
+	needsApp bool
+}
+
+func (s *rpcSession) methods() map[string]rpcMethod {
+	return map[string]rpcMethod{
+		"count-chars": {s.countChars, false},
+		"validate":    {s.validate, false},
+		"post":        {s.server.post, true},
+		"thread":      {s.server.thread, true},
+		"schedule":    {s.server.schedule, true},
+		"queue":       {s.server.queue, false},
+		"search":      {s.search, true},
+	}
+}
+
+func (s *rpcSession) countChars(decode func(any) error) (any, error) {
+	var params rpcCount
+	if err := decode(&params); err != nil {
+		return nil, err
+	}
+	return countChars(params.Text), nil
+}
+
+// validate runs the checks post would, without posting: the transforms,
+// the length, media, poll and safe mode. It returns each tweet the post
+// would become.
+func (s *rpcSession) validate(decode func(any) error) (any, error) {
+	var params savedPost
+	if err := decode(&params); err != nil {
+		return nil, err
+	}
+	req := params.request()
+	if len(req.poll) > 0 && req.pollDuration == 0 {
+		req.pollDuration = defaultPollDuration
+	}
+	if err := req.transform(s.config); err != nil {
+		return nil, err
+	}
+	prepared, err := req.prepare()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.config.SafeMode.check(prepared.parts...); err != nil {
+		return nil, err
+	}
+	parts := make([]rpcCountResult, len(prepared.parts))
+	for i, part := range prepared.parts {
+		parts[i] = countChars(part)
+		parts[i].Text = part
+	}
+	return struct {
+		Parts       []rpcCountResult `json:"parts"`
+		Transformed []string         `json:"transformed,omitempty"`
+	}{parts, prepared.transformed}, nil
+}
+
+func (s *rpcSession) search(decode func(any) error) (any, error) {
+	var params rpcSearch
+	if err := decode(&params); err != nil {
+		return nil, err
+	}
+	query := buildQuery(params.Query, params.Lang, params.From)
+	if query == "" {
+		return nil, withExitCode(exitValidation, errors.New(tr("query is required")))
+	}
+	count := params.Count
+	if count == 0 {
+		count = 20
+	}
+	if count < 1 {
+		return nil, withExitCode(exitValidation, errors.New(tr("count must be at least 1")))
+	}
+	return s.server.a.searchRecent(rootCtx, &types.ListRecentInput{Query: query}, count)
+}
+
+// call answers one request line
+func (s *rpcSession) call(line []byte) rpcResponse {
+	var req rpcRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		return rpcResponse{Error: &rpcError{Message: trf("invalid request: %v", err), ExitCode: exitUsage}}
+	}
+	fail := func(err error) rpcResponse {
+		return rpcResponse{ID: req.ID, Error: &rpcError{Message: err.Error(), ExitCode: exitCode(err)}}
+	}
+	method, ok := s.methods()[req.Method]
+	if !ok {
+		return fail(withExitCode(exitUsage, fmt.Errorf(tr("unknown method %q"), req.Method)))
+	}
+	if method.needsApp && s.server.a == nil {
+		if err := s.config.requireCredentials(); err != nil {
+			return fail(err)
+		}
+		a, err := newApp(s.config)
+		if err != nil {
+			return fail(err)
+		}
+		s.server.a = a
+	}
+
+	params := req.Params
+	if len(params) == 0 || string(params) == "null" {
+		params = []byte("{}")
+	}
+	out, err := method.call(func(v any) error { return decodeParams(bytes.NewReader(params), v) })
+	if err != nil {
+		res := fail(err)
+		if results, ok := out.([]postResult); ok && len(results) > 0 {
+			res.Result = results
+		}
+		return res
+	}
+	return rpcResponse{ID: req.ID, Result: out}
+}
+
+func runRPC(args []string) error {
+	fs := newFlagSet("rpc", "rpc  (answers JSON requests on stdin, one per line, with JSON responses on stdout)")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	// Credentials are checked by the methods that call X
+	config, err := readActiveConfig()
+	if err != nil {
+		return fmt.Errorf(tr("failed to load configuration: %w"), err)
+	}
+	// Stdout is for responses alone, so nothing is printed for people
+	globalOptions.json, globalOptions.format, outputTemplate = true, "", nil
+
+	s := &rpcSession{config: config, server: &apiServer{}}
+	defer func() {
+		if s.server.a != nil {
+			s.server.a.close()
+		}
+	}()
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 64*1024), maxRPCLine)
+	out := json.NewEncoder(os.Stdout)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := out.Encode(s.call(line)); err != nil {
+			return err
+		}
+		if rootCtx.Err() != nil {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf(tr("failed to read a request: %w"), err)
+	}
+	return nil
+}
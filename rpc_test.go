@@ -0,0 +1,42 @@
+package clix
+
+import (
+	"encoding/json"
+	"testing"
+
+	clixconfig "github.com/voltycodes/clix/config"
+)
+
+func TestRPCWithoutCredentials(t *testing.T) {
+	inStateDir(t)
+	t.Setenv(mockEnvVar, "")
+	for _, field := range clixconfig.CredentialFields(&Credentials{}) {
+		t.Setenv(clixconfig.CredentialEnvVar(field.Key), "")
+	}
+	config, err := readActiveConfig()
+	if err != nil {
+		t.Fatalf("readActiveConfig() = %v, want a config without credentials", err)
+	}
+	s := &rpcSession{config: config, server: &apiServer{}}
+	tests := []struct {
+		line     string
+		wantCode int
+	}{
+		{`{"id":1,"method":"count-chars","params":{"text":"hi"}}`, 0},
+		{`{"id":2,"method":"validate","params":{"text":"hi"}}`, 0},
+		{`{"id":3,"method":"post","params":{"text":"hi"}}`, exitAuth},
+		{`{"id":4,"method":"search","params":{"query":"clix"}}`, exitAuth},
+		{`{"id":5,"method":"nope"}`, exitUsage},
+	}
+	for _, tt := range tests {
+		res := s.call([]byte(tt.line))
+		code := 0
+		if res.Error != nil {
+			code = res.Error.ExitCode
+		}
+		if code != tt.wantCode {
+			out, _ := json.Marshal(res)
+			t.Errorf("%s: got %s, want exit code %d", tt.line, out, tt.wantCode)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package clix
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// SafeModeConfig is the "safe_mode" section of the config. While it is
+// present, tweets matching any of the patterns are refused, so a pasted
+// secret or internal hostname does not get posted by mistake.
+type SafeModeConfig struct {
+	// Patterns are regular expressions checked on top of the built-in ones,
+	// e.g. `\.corp\.example\.com`
+	Patterns []string `json:"patterns,omitempty"`
+	// NoDefaults leaves out the built-in patterns
+	NoDefaults bool `json:"no_defaults,omitempty"`
+}
+
+// safeModeDefaults catch the most common kinds of credentials and hosts
+// that are only meant to be reached from inside a network
+var safeModeDefaults = []struct{ why, pattern string }{
+	{"looks like an AWS access key", `\b(AKIA|ASIA)[0-9A-Z]{16}\b`},
+	{"looks like a GitHub token", `\bgh[pousr]_[A-Za-z0-9]{36,}\b|\bgithub_pat_[A-Za-z0-9_]{22,}\b`},
+	{"looks like a Slack token", `\bxox[abposr]-[A-Za-z0-9-]{10,}\b`},
+	{"looks like a Google API key", `\bAIza[0-9A-Za-z_-]{35}\b`},
+	{"looks like a secret API key", `\b(sk|rk)[-_](live[-_])?[A-Za-z0-9_-]{20,}\b`},
+	{"looks like a private key", `-----BEGIN [A-Z ]*PRIVATE KEY-----`},
+	{"looks like an internal hostname", `\b[A-Za-z0-9-]+(\.[A-Za-z0-9-]+)*\.(internal|corp|intranet|lan)\b`},
+}
+
+type safeModeRule struct {
+	why string
+	re  *regexp.Regexp
+}
+
+// rules compiles the patterns in effect
+func (c *SafeModeConfig) rules() ([]safeModeRule, error) {
+	var rules []safeModeRule
+	if !c.NoDefaults {
+		for _, d := range safeModeDefaults {
+			rules = append(rules, safeModeRule{tr(d.why), regexp.MustCompile(d.pattern)})
+		}
+	}
+	for _, pattern := range c.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf(tr("invalid safe_mode pattern %q: %w"), pattern, err)
+		}
+		rules = append(rules, safeModeRule{trf("matches `%s`", pattern), re})
+	}
+	return rules, nil
+}
+
+// check refuses texts that match a pattern. A nil config means safe mode
+// is off.
+func (c *SafeModeConfig) check(texts ...string) error {
+	if c == nil {
+		return nil
+	}
+	rules, err := c.rules()
+	if err != nil {
+		return err
+	}
+	for _, text := range texts {
+		for _, rule := range rules {
+			if match := rule.re.FindString(text); match != "" {
+				return withExitCode(exitRefused, fmt.Errorf(tr("safe mode refused to post %q, which %s; edit the tweet or change safe_mode in the config"), maskSecret(match), rule.why))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package clix
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/michimani/gotwi/tweet/searchtweet/types"
+)
+
+const savedSearchesFile = "searches.json"
+
+var searchNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// savedSearch is a query `clix search run` repeats, reporting only the
+// tweets newer than the newest it saw last time
+type savedSearch struct {
+	Query   string    `json:"query"`
+	Created time.Time `json:"created"`
+	SinceID string    `json:"since_id,omitempty"`
+	LastRun time.Time `json:"last_run,omitempty"`
+}
+
+// savedSearchView is an entry of `clix search list`
+type savedSearchView struct {
+	Name string `json:"name"`
+	savedSearch
+}
+
+// savedSearchResult is what `clix search run --json` prints for a search
+type savedSearchResult struct {
+	Name   string      `json:"name"`
+	Query  string      `json:"query"`
+	Tweets []tweetView `json:"tweets"`
+}
+
+func loadSavedSearches() (map[string]*savedSearch, error) {
+	searches := map[string]*savedSearch{}
+	if err := loadState(savedSearchesFile, &searches); err != nil {
+		return nil, err
+	}
+	return searches, nil
+}
+
+// updateSavedSearches applies fn to the saved searches under the state
+// lock and saves them
+func updateSavedSearches(fn func(searches map[string]*savedSearch) error) error {
+	unlock, err := lockState(savedSearchesFile)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	searches, err := loadSavedSearches()
+	if err != nil {
+		return err
+	}
+	if err := fn(searches); err != nil {
+		return err
+	}
+	return saveState(savedSearchesFile, searches)
+}
+
+func runSearchSave(args []string) error {
+	fs := newFlagSet("search save", `search save "<query>" --name name [--lang en] [--from user]`)
+	name := fs.String("name", "", "what to call the search, for search run")
+	lang := fs.String("lang", "", "only tweets in this language (e.g. en)")
+	from := fs.String("from", "", "only tweets from this user")
+	force := fs.Bool("force", false, "replace a saved search of the same name")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	query := buildQuery(strings.Join(args, " "), *lang, *from)
+	if query == "" || *name == "" {
+		fs.Usage()
+		return errUsage
+	}
+	if !searchNamePattern.MatchString(*name) {
+		return withExitCode(exitUsage, fmt.Errorf(tr("search name %q may only have letters, digits, dots, dashes and underscores"), *name))
+	}
+	err = updateSavedSearches(func(searches map[string]*savedSearch) error {
+		if _, ok := searches[*name]; ok && !*force {
+			return withExitCode(exitRefused, fmt.Errorf(tr("there is a saved search %q already; use --force to replace it"), *name))
+		}
+		searches[*name] = &savedSearch{Query: query, Created: time.Now().UTC()}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(map[string]string{"name": *name, "query": query})
+	}
+	fmt.Printf(tr("Saved search %q for %s; run it with 'clix search run %s'.\n"), *name, query, *name)
+	return nil
+}
+
+func runSearchList(args []string) error {
+	fs := newFlagSet("search list", "search list")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	searches, err := loadSavedSearches()
+	if err != nil {
+		return err
+	}
+	views := []savedSearchView{}
+	for _, name := range sortedKeys(searches) {
+		views = append(views, savedSearchView{name, *searches[name]})
+	}
+	if machineReadable() {
+		return printResult(views)
+	}
+	if len(views) == 0 {
+		fmt.Println(tr(`No saved searches; save one with 'clix search save "<query>" --name name'.`))
+		return nil
+	}
+	for _, view := range views {
+		last := "never run"
+		if !view.LastRun.IsZero() {
+			last = "last run " + formatTime(view.LastRun)
+		}
+		fmt.Printf("%-16s %-40s %s\n", view.Name, view.Query, last)
+	}
+	return nil
+}
+
+func runSearchRemove(args []string) error {
+	fs := newFlagSet("search remove", "search remove <name>")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+	err = updateSavedSearches(func(searches map[string]*savedSearch) error {
+		if _, ok := searches[args[0]]; !ok {
+			return withExitCode(exitNotFound, fmt.Errorf(tr("no saved search %q"), args[0]))
+		}
+		delete(searches, args[0])
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !machineReadable() {
+		fmt.Printf(tr("Removed saved search %q.\n"), args[0])
+	}
+	return nil
+}
+
+func runSearchRun(args []string) error {
+	fs := newFlagSet("search run", "search run [name...] [--notify] [--webhook url] [--count 50]  (new tweets for saved searches, all of them without a name; for cron)")
+	notify := fs.Bool("notify", false, "show a desktop notification when there are new tweets")
+	var webhooks stringList
+	fs.Var(&webhooks, "webhook", "also POST each new tweet as a hook event to this URL (repeatable)")
+	count := fs.Int("count", 50, "the most new tweets to report per search")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if *count < 1 {
+		return errors.New(tr("--count must be at least 1"))
+	}
+	searches, err := loadSavedSearches()
+	if err != nil {
+		return err
+	}
+	names := args
+	if len(names) == 0 {
+		names = sortedKeys(searches)
+		if len(names) == 0 {
+			return withExitCode(exitNotFound, errors.New(tr(`no saved searches; save one with 'clix search save "<query>" --name name'`)))
+		}
+	}
+	for _, name := range names {
+		if searches[name] == nil {
+			return withExitCode(exitNotFound, fmt.Errorf(tr("no saved search %q"), name))
+		}
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+	var hooks []HookConfig
+	for _, u := range webhooks {
+		hooks = append(hooks, HookConfig{URL: u})
+	}
+
+	results := []savedSearchResult{}
+	for _, name := range names {
+		search := searches[name]
+		views, err := a.searchRecent(rootCtx, &types.ListRecentInput{Query: search.Query, SinceID: search.SinceID}, *count)
+		if err != nil {
+			return fmt.Errorf(tr("saved search %q: %w"), name, err)
+		}
+		run := time.Now().UTC()
+		// The first run shows what there is, but only later ones alert,
+		// so saving a search and adding it to cron does not go off at once
+		first := search.SinceID == ""
+		newest := search.SinceID
+		for _, view := range views {
+			if compareTweetIDs(view.ID, newest) > 0 {
+				newest = view.ID
+			}
+		}
+		err = updateSavedSearches(func(searches map[string]*savedSearch) error {
+			if s := searches[name]; s != nil {
+				s.SinceID, s.LastRun = newest, run
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		results = append(results, savedSearchResult{name, search.Query, views})
+
+		if first || len(views) == 0 {
+			continue
+		}
+		if *notify {
+			title := trf("%d new tweets for %s", len(views), name)
+			if len(views) == 1 {
+				title = trf("A new tweet for %s", name)
+			}
+			if err := desktopNotify(title, "@"+views[0].AuthorUsername+": "+views[0].Text); err != nil {
+				fmt.Fprintln(os.Stderr, tr("Warning: could not show a notification:"), err)
+				*notify = false
+			}
+		}
+		for _, view := range slices.Backward(views) {
+			a.fireHooks(hookEvent{
+				Event:   hookSearch,
+				Account: a.config.Active,
+				Tweet:   &hookTweet{ID: view.ID, Text: view.Text, URL: view.URL, Author: view.AuthorUsername},
+				Search:  name,
+				Time:    run,
+				Text:    fmt.Sprintf("New tweet for %s from @%s: %s", name, view.AuthorUsername, view.URL),
+			}, hooks)
+		}
+	}
+
+	if machineReadable() {
+		return printResult(results)
+	}
+	for _, result := range results {
+		if len(results) > 1 {
+			fmt.Printf("== %s: %s\n", result.Name, result.Query)
+		}
+		if len(result.Tweets) == 0 {
+			fmt.Println(tr("No new tweets."))
+			continue
+		}
+		printTweets(os.Stdout, result.Tweets)
+	}
+	return nil
+}
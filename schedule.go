@@ -0,0 +1,690 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/voltycodes/clix/compose"
+)
+
+const scheduleStateFile = "schedule.json"
+
+// maxScheduleAttempts is how often the scheduler tries a post before
+// marking it failed
+const maxScheduleAttempts = 3
+
+// A scheduler run claims a due post while posting it, so the daemon and a
+// `scheduler run --once` at the same time do not both post it; a claim
+// older than this is from a run that died. It leaves time for a long
+// thread with a delay between its parts.
+const scheduleClaimTimeout = 30 * time.Minute
+
+// Scheduled post states
+const (
+	schedulePending   = "pending"
+	schedulePosted    = "posted"
+	scheduleFailed    = "failed"
+	scheduleCancelled = "cancelled"
+)
+
+// scheduledPost is a tweet queued to be posted by `clix scheduler run`
+type scheduledPost struct {
+	ID      string    `json:"id"`
+	Account string    `json:"account"`
+	At      time.Time `json:"at"`
+	savedPost
+	// Thread holds the parts of a scheduled thread, numbered and signed as
+	// they are posted; Text is then its first part, for listings
+	Thread   []string `json:"thread,omitempty"`
+	Status   string   `json:"status"`
+	Attempts int      `json:"attempts,omitempty"`
+	Error    string   `json:"error,omitempty"`
+	// TweetIDs are those of the parts posted so far, in order, so a thread
+	// that failed part way goes on after the last of them
+	TweetIDs []string  `json:"tweet_ids,omitempty"`
+	PostedAt time.Time `json:"posted_at,omitempty"`
+	// ClaimedAt is when a scheduler run started posting it
+	ClaimedAt time.Time `json:"claimed_at,omitempty"`
+}
+
+func loadSchedule() ([]*scheduledPost, error) {
+	var posts []*scheduledPost
+	if err := loadState(scheduleStateFile, &posts); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// updateSchedule applies fn to the schedule under the state lock and saves
+// the result, so the daemon and CLI never lose each other's changes
+func updateSchedule(fn func(posts []*scheduledPost) ([]*scheduledPost, error)) error {
+	unlock, err := lockState(scheduleStateFile)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	posts, err := loadSchedule()
+	if err != nil {
+		return err
+	}
+	if posts, err = fn(posts); err != nil {
+		return err
+	}
+	return saveState(scheduleStateFile, posts)
+}
+
+// parseScheduleTime accepts "2006-01-02 15:04" in local time, RFC 3339, or
+// a duration from now such as "+90m"
+func parseScheduleTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "+") {
+		d, err := time.ParseDuration(s[1:])
+		if err != nil {
+			return time.Time{}, fmt.Errorf(tr("invalid duration %q"), s)
+		}
+		return now.Add(d), nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02 15:04:05"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(tr(`invalid time %q, expected "YYYY-MM-DD HH:MM", RFC 3339 or +duration`), s)
+	}
+	return t, nil
+}
+
+// enqueue adds a pending post to the schedule and returns its ID
+func enqueue(post *scheduledPost) (string, error) {
+	err := updateSchedule(func(posts []*scheduledPost) ([]*scheduledPost, error) {
+		next := 1
+		for _, p := range posts {
+			if n, err := strconv.Atoi(p.ID); err == nil && n >= next {
+				next = n + 1
+			}
+		}
+		post.ID = strconv.Itoa(next)
+		post.Status = schedulePending
+		return append(posts, post), nil
+	})
+	return post.ID, err
+}
+
+// scheduleInstead offers to queue a post the posting window blocks for the
+// next time the window opens, checking it first as it would be checked if
+// posted now. It reports whether the user took it up, queued or not.
+func scheduleInstead(a *app, req *postRequest, prepared *preparedPost) (bool, error) {
+	config := a.config
+	next, err := config.PostingWindow.NextAllowed(time.Now())
+	if err != nil {
+		return false, err
+	}
+	if !confirm(trf("Outside the posting window. Schedule it for %s instead?", next.Format("Mon Jan 2 15:04 MST"))) {
+		return false, nil
+	}
+	if ok, err := checkToSave(rootCtx, config, a, req, prepared.parts, prepared.media, true); !ok || err != nil {
+		if err == nil {
+			fmt.Println(tr("Not scheduled."))
+		}
+		return true, err
+	}
+
+	saved, err := req.save()
+	if err != nil {
+		return false, err
+	}
+	id, err := enqueue(&scheduledPost{Account: config.Active, At: next, savedPost: saved})
+	if err != nil {
+		return false, err
+	}
+	fmt.Printf(tr("Scheduled as %s; 'clix scheduler run' will post it.\n"), id)
+	return true, nil
+}
+
+func runSchedule(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "list":
+			return runScheduleList(args[1:])
+		case "cancel":
+			return runScheduleCancel(args[1:])
+		case "thread":
+			return runScheduleThread(args[1:])
+		}
+	}
+
+	fs := newFlagSet("schedule", `schedule --at "YYYY-MM-DD HH:MM" | --auto [flags] [text]  |  schedule thread  |  schedule list  |  schedule cancel <id>`)
+	at := fs.String("at", "", `when to post: "YYYY-MM-DD HH:MM" local time, RFC 3339 or +duration`)
+	auto := fs.Bool("auto", false, "post in the coming week's hour your tracked tweets did best in (see insights times)")
+	var media stringList
+	fs.Var(&media, "media", "attach a media file (repeatable)")
+	var alts stringList
+	fs.Var(&alts, "alt", "alt text for the media, paired with each --media in order")
+	replyTo := fs.String("reply-to", "", "reply to this tweet (ID or URL)")
+	quote := fs.String("quote", "", "quote this tweet (ID or URL)")
+	split := fs.Bool("split", false, "split an over-length tweet into a thread")
+	force := fs.Bool("force", false, "schedule even outside the configured posting window or against the style rules")
+	lint := fs.Bool("lint", false, "check spelling, spacing, brackets, links and mentions now, before scheduling (default from config)")
+	noLint := fs.Bool("no-lint", false, "skip the lint checks, now and when it is posted, even with lint in the config")
+	strictA11y := strictA11yFlag(fs)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if (*at == "") == !*auto {
+		fs.Usage()
+		return errUsage
+	}
+	lintOverride, err := lintFlag(*lint, *noLint)
+	if err != nil {
+		return err
+	}
+
+	text, err := readText(args)
+	if err != nil {
+		return err
+	}
+	req := &postRequest{
+		text: text, media: media, alt: alts, replyTo: *replyTo, quote: *quote, split: *split, force: *force,
+		lint: lintOverride, strictA11y: *strictA11y,
+	}
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+	config := a.config
+	when, err := scheduleTime(config, *at, *auto)
+	if err != nil {
+		return err
+	}
+	// Transformed now, so the scheduled text is what gets posted
+	if err := req.transform(config); err != nil {
+		return err
+	}
+	prepared, err := req.prepare()
+	if err != nil {
+		return err
+	}
+
+	if !*force {
+		if err := checkPostingWindow(config.PostingWindow, when); err != nil {
+			return fmt.Errorf(tr("%w (use --force to schedule anyway)"), err)
+		}
+	}
+	if ok, err := checkToSave(rootCtx, config, a, req, prepared.parts, prepared.media, true); !ok || err != nil {
+		if err == nil {
+			fmt.Println(tr("Not scheduled."))
+		}
+		return err
+	}
+	saved, err := req.save()
+	if err != nil {
+		return err
+	}
+	post := &scheduledPost{At: when, savedPost: saved}
+	post.Account = config.Active
+	if globalOptions.dryRun {
+		if machineReadable() {
+			return printResult(post)
+		}
+		describePost(os.Stdout, prepared)
+		fmt.Printf(tr("\nWould be scheduled for %s.\n"), when.Local().Format("Mon Jan 2 15:04 MST"))
+		return nil
+	}
+
+	id, err := enqueue(post)
+	if err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(post)
+	}
+	fmt.Printf(tr("Scheduled %s for %s.\n"), id, when.Local().Format("Mon Jan 2 15:04 MST"))
+	return nil
+}
+
+// scheduleTime is when --at or, with auto set, --auto says to post
+func scheduleTime(config *Config, at string, auto bool) (time.Time, error) {
+	if auto {
+		return autoScheduleTime(config, time.Now())
+	}
+	when, err := parseScheduleTime(at, time.Now())
+	if err != nil {
+		return time.Time{}, err
+	}
+	if when.Before(time.Now()) {
+		return time.Time{}, fmt.Errorf(tr("%s is in the past"), when.Format("2006-01-02 15:04"))
+	}
+	return when, nil
+}
+
+// runScheduleThread queues a thread, numbered and signed now so what is
+// checked is what gets posted
+func runScheduleThread(args []string) error {
+	fs := newFlagSet("schedule thread", `schedule thread --at "YYYY-MM-DD HH:MM" | --auto [flags]  (reads parts from --tweet, --file or stdin)`)
+	at := fs.String("at", "", `when to post: "YYYY-MM-DD HH:MM" local time, RFC 3339 or +duration`)
+	auto := fs.Bool("auto", false, "post in the coming week's hour your tracked tweets did best in (see insights times)")
+	var tweets stringList
+	fs.Var(&tweets, "tweet", "a part of the thread (repeat for each part)")
+	file := fs.String("file", "", "read parts from a file, separated by lines containing ---")
+	replyTo := fs.String("reply-to", "", "post the first part as a reply to this tweet (ID or URL)")
+	numbering := fs.String("numbering", "", "number the parts as 1/n: prefix, suffix or none (default from config)")
+	noSignature := fs.Bool("no-signature", false, "leave out the account's signature from the config")
+	noTransform := fs.Bool("no-transform", false, "post the parts as given, without the transforms from the config")
+	force := fs.Bool("force", false, "schedule even outside the configured posting window or against the style rules")
+	lint := fs.Bool("lint", false, "check spelling, spacing, brackets, links and mentions now, before scheduling (default from config)")
+	noLint := fs.Bool("no-lint", false, "skip the lint checks, now and when it is posted, even with lint in the config")
+	strictA11y := strictA11yFlag(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if (*at == "") == !*auto {
+		fs.Usage()
+		return errUsage
+	}
+	lintOverride, err := lintFlag(*lint, *noLint)
+	if err != nil {
+		return err
+	}
+
+	var replyID string
+	if *replyTo != "" {
+		var err error
+		if replyID, err = parseTweetID(*replyTo); err != nil {
+			return err
+		}
+	}
+	var parts []string
+	switch {
+	case len(tweets) > 0 && *file != "":
+		return errors.New(tr("use only one of --tweet and --file"))
+	case len(tweets) > 0:
+		parts = tweets
+	case *file != "":
+		data, err := os.ReadFile(*file)
+		if err != nil {
+			return fmt.Errorf(tr("failed to read thread file: %w"), err)
+		}
+		parts = compose.SplitThread(string(data))
+	default:
+		text, err := readText(nil)
+		if err != nil {
+			return err
+		}
+		parts = compose.SplitThread(text)
+	}
+	if len(parts) == 0 {
+		return withExitCode(exitValidation, errors.New(tr("nothing to post")))
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+	config := a.config
+	when, err := scheduleTime(config, *at, *auto)
+	if err != nil {
+		return err
+	}
+	if !*noTransform {
+		if parts, _, err = config.transformThread(parts); err != nil {
+			return err
+		}
+	}
+	decoration, err := config.threadDecoration(*numbering, !*noSignature)
+	if err != nil {
+		return err
+	}
+	if len(parts) == 1 && checkLength(decoration.apply(parts)[0]) != nil {
+		parts = decoration.split(parts[0])
+	}
+	decorated := decoration.apply(parts)
+	for i, part := range decorated {
+		if err := checkLength(part); err != nil {
+			if part != parts[i] {
+				return withExitCode(exitValidation, fmt.Errorf(tr("part %d: %w, including its numbering and signature"), i+1, err))
+			}
+			return withExitCode(exitValidation, fmt.Errorf(tr("part %d: %w"), i+1, err))
+		}
+	}
+	parts = decorated
+
+	if !*force {
+		if err := checkPostingWindow(config.PostingWindow, when); err != nil {
+			return fmt.Errorf(tr("%w (use --force to schedule anyway)"), err)
+		}
+	}
+	req := &postRequest{text: parts[0], replyTo: *replyTo, force: *force, lint: lintOverride, strictA11y: *strictA11y}
+	if ok, err := checkToSave(rootCtx, config, a, req, parts, nil, true); !ok || err != nil {
+		if err == nil {
+			fmt.Println(tr("Not scheduled."))
+		}
+		return err
+	}
+	saved, err := req.save()
+	if err != nil {
+		return err
+	}
+	post := &scheduledPost{Account: config.Active, At: when, savedPost: saved, Thread: parts}
+	if globalOptions.dryRun {
+		if machineReadable() {
+			return printResult(post)
+		}
+		describeThread(os.Stdout, parts, nil, replyID)
+		fmt.Printf(tr("\nWould be scheduled for %s.\n"), when.Local().Format("Mon Jan 2 15:04 MST"))
+		return nil
+	}
+
+	id, err := enqueue(post)
+	if err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(post)
+	}
+	fmt.Printf(tr("Scheduled %s, a thread of %d tweets, for %s.\n"), id, len(parts), when.Local().Format("Mon Jan 2 15:04 MST"))
+	return nil
+}
+
+func runScheduleList(args []string) error {
+	fs := newFlagSet("schedule list", "schedule list [--all]")
+	all := fs.Bool("all", false, "include posted, failed and cancelled posts")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	posts, err := loadSchedule()
+	if err != nil {
+		return err
+	}
+	shown := []*scheduledPost{}
+	for _, post := range posts {
+		if *all || post.Status == schedulePending {
+			shown = append(shown, post)
+		}
+	}
+	sort.SliceStable(shown, func(i, j int) bool { return shown[i].At.Before(shown[j].At) })
+
+	if machineReadable() {
+		return printResult(shown)
+	}
+	if len(shown) == 0 {
+		fmt.Println(tr("Nothing scheduled."))
+		return nil
+	}
+	for _, post := range shown {
+		line, _, _ := strings.Cut(post.Text, "\n")
+		if n := len(post.Thread); n > 0 {
+			line = fmt.Sprintf(tr("[thread of %d] %s"), n, line)
+		}
+		status := post.Status
+		if post.Error != "" {
+			status += ": " + post.Error
+		}
+		fmt.Printf("%-4s %-16s  %-10s %-9s %s\n", post.ID, formatTime(post.At), post.Account, status, line)
+	}
+	return nil
+}
+
+func runScheduleCancel(args []string) error {
+	fs := newFlagSet("schedule cancel", "schedule cancel <id>...")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	return updateSchedule(func(posts []*scheduledPost) ([]*scheduledPost, error) {
+		for _, id := range args {
+			found := false
+			for _, post := range posts {
+				if post.ID == id {
+					if post.Status != schedulePending {
+						return nil, fmt.Errorf(tr("scheduled post %s is already %s"), id, post.Status)
+					}
+					post.Status = scheduleCancelled
+					found = true
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf(tr("no scheduled post %s"), id)
+			}
+		}
+		return posts, nil
+	})
+}
+
+// runDue posts every pending item whose time has come and records the
+// outcome. Each item is posted with the account it was scheduled for.
+func runDue(ctx context.Context, now time.Time) error {
+	posts, err := loadSchedule()
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(posts, func(post *scheduledPost) bool { return post.due(now) }) {
+		return nil
+	}
+
+	apps, err := newAppPool()
+	if err != nil {
+		return err
+	}
+	defer apps.close()
+
+	// Each due post is tried once per run
+	tried := map[string]bool{}
+	failed := 0
+	for {
+		post, err := claimDue(now, tried)
+		if err != nil {
+			return err
+		}
+		if post == nil {
+			// Failing so cron and the service manager see it, not only the log
+			if failed > 0 {
+				return fmt.Errorf(tr("%d of the due scheduled posts failed; see 'clix schedule list'"), failed)
+			}
+			return nil
+		}
+		tried[post.ID] = true
+		ids, postErr := func() ([]string, error) {
+			a, err := apps.get(post.Account)
+			if err != nil {
+				return nil, err
+			}
+			return a.postScheduled(ctx, post)
+		}()
+		if globalOptions.dryRun {
+			continue
+		}
+
+		err = updateSchedule(func(posts []*scheduledPost) ([]*scheduledPost, error) {
+			for _, p := range posts {
+				if p.ID != post.ID {
+					continue
+				}
+				p.Attempts++
+				p.ClaimedAt = time.Time{}
+				p.TweetIDs = append(p.TweetIDs, ids...)
+				if postErr == nil {
+					p.Status, p.Error, p.PostedAt = schedulePosted, "", time.Now()
+				} else {
+					// A partly posted thread goes on after the parts in TweetIDs
+					p.Error = postErr.Error()
+					if p.Attempts >= maxScheduleAttempts {
+						p.Status = scheduleFailed
+					}
+				}
+			}
+			return posts, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case postErr != nil:
+			failed++
+			fmt.Fprintf(os.Stderr, tr("%s scheduled post %s failed: %v\n"), time.Now().Format(time.DateTime), post.ID, postErr)
+		case machineReadable():
+			post.Status, post.TweetIDs = schedulePosted, ids
+			printResult(post)
+		default:
+			fmt.Printf(tr("%s posted scheduled post %s as %s\n"), time.Now().Format(time.DateTime), post.ID, strings.Join(ids, ", "))
+		}
+	}
+}
+
+// due reports whether the post is waiting to be posted by now
+func (p *scheduledPost) due(now time.Time) bool {
+	return p.Status == schedulePending && !p.At.After(now)
+}
+
+// claimDue marks the first due post not in tried as being posted and
+// returns it, nil when there is none. A post claimed by another run is
+// skipped unless its claim is older than scheduleClaimTimeout. A dry run
+// claims nothing.
+func claimDue(now time.Time, tried map[string]bool) (*scheduledPost, error) {
+	next := func(posts []*scheduledPost) *scheduledPost {
+		for _, post := range posts {
+			if post.due(now) && !tried[post.ID] && time.Since(post.ClaimedAt) > scheduleClaimTimeout {
+				return post
+			}
+		}
+		return nil
+	}
+	if globalOptions.dryRun {
+		posts, err := loadSchedule()
+		if err != nil {
+			return nil, err
+		}
+		return next(posts), nil
+	}
+	var claimed *scheduledPost
+	err := updateSchedule(func(posts []*scheduledPost) ([]*scheduledPost, error) {
+		if post := next(posts); post != nil {
+			post.ClaimedAt = time.Now()
+			copied := *post
+			claimed = &copied
+		}
+		return posts, nil
+	})
+	return claimed, err
+}
+
+// postScheduled posts a due scheduled post and returns the IDs of the
+// parts it posted. A thread or split tweet that failed part way before goes
+// on from the part after the last one posted, as a reply to it.
+func (a *app) postScheduled(ctx context.Context, post *scheduledPost) ([]string, error) {
+	prepared, err := post.request().prepare()
+	if err != nil {
+		return nil, err
+	}
+	done := len(post.TweetIDs)
+	if len(post.Thread) == 0 && done == 0 {
+		results, err := a.publish(ctx, prepared, func(postResult) {})
+		ids := make([]string, 0, len(results))
+		for _, result := range results {
+			ids = append(ids, result.ID)
+		}
+		return ids, err
+	}
+
+	parts := prepared.parts
+	if len(post.Thread) > 0 {
+		parts = post.Thread
+	}
+	if done >= len(parts) {
+		return nil, nil
+	}
+	replyTo := prepared.replyID
+	if done > 0 {
+		replyTo = post.TweetIDs[done-1]
+	}
+	// The first part carries the media, quote and poll, so what is left is
+	// plain replies
+	prepared.parts, prepared.media = parts[done:], nil
+	if err := a.config.SafeMode.check(prepared.parts...); err != nil {
+		return nil, err
+	}
+	if err := a.checkPrepared(ctx, prepared); err != nil {
+		return nil, err
+	}
+	settings, err := a.config.tweetSettings(prepared.settings)
+	if err != nil {
+		return nil, err
+	}
+	if globalOptions.dryRun {
+		return nil, nil
+	}
+	if settings, err = a.resolveGeo(ctx, settings); err != nil {
+		return nil, err
+	}
+	ids, err := a.postThread(ctx, prepared.parts, nil, replyTo, settings, nil)
+	if err != nil {
+		return ids, withExitCode(exitPartial, fmt.Errorf(tr("thread stopped after %d of %d parts: %w"), done+len(ids), len(parts), err))
+	}
+	return ids, nil
+}
+
+func runScheduler(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "install":
+			return runSchedulerInstall(args[1:])
+		case "uninstall":
+			return runSchedulerUninstall(args[1:])
+		case "status":
+			return runSchedulerStatus(args[1:])
+		case "logs":
+			return runSchedulerLogs(args[1:])
+		}
+	}
+	if len(args) == 0 || args[0] != "run" {
+		fmt.Fprintf(os.Stderr, tr("Usage: clix %s\n"), "scheduler run [--once] [--interval 30s]")
+		fmt.Fprintln(os.Stderr, tr("       clix scheduler install [--interval 30s] [--force]"))
+		fmt.Fprintln(os.Stderr, tr("       clix scheduler status | logs [-n 50] [-f] | uninstall"))
+		return errUsage
+	}
+	fs := newFlagSet("scheduler run", "scheduler run [--once] [--interval 30s]")
+	once := fs.Bool("once", false, "post whatever is due and exit, for cron")
+	interval := fs.Duration("interval", 30*time.Second, "how often to check for due posts")
+	if _, err := parseFlags(fs, args[1:]); err != nil {
+		return err
+	}
+
+	ctx := rootCtx
+	if *once {
+		return runDue(ctx, time.Now())
+	}
+
+	fmt.Printf(tr("Scheduler running, checking every %s.\n"), *interval)
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		if err := runDue(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			fmt.Fprintln(os.Stderr, tr("Error:"), err)
+		}
+		select {
+		case <-ctx.Done():
+			fmt.Println(tr("Scheduler stopped."))
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
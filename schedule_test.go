@@ -0,0 +1,133 @@
+package clix
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClaimDue(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		posts  []*scheduledPost
+		tried  map[string]bool
+		wantID string
+	}{
+		{"none", nil, nil, ""},
+		{"due", []*scheduledPost{{ID: "1", At: now.Add(-time.Minute), Status: schedulePending}}, nil, "1"},
+		{"not yet", []*scheduledPost{{ID: "1", At: now.Add(time.Minute), Status: schedulePending}}, nil, ""},
+		{"posted", []*scheduledPost{{ID: "1", At: now.Add(-time.Minute), Status: schedulePosted}}, nil, ""},
+		{"cancelled", []*scheduledPost{{ID: "1", At: now.Add(-time.Minute), Status: scheduleCancelled}}, nil, ""},
+		{"claimed", []*scheduledPost{{ID: "1", At: now.Add(-time.Minute), Status: schedulePending, ClaimedAt: now.Add(-time.Minute)}}, nil, ""},
+		{"claim of a run that died", []*scheduledPost{{ID: "1", At: now.Add(-time.Hour), Status: schedulePending, ClaimedAt: now.Add(-scheduleClaimTimeout - time.Minute)}}, nil, "1"},
+		{"tried this run", []*scheduledPost{{ID: "1", At: now.Add(-time.Minute), Status: schedulePending}}, map[string]bool{"1": true}, ""},
+		{"next after claimed", []*scheduledPost{
+			{ID: "1", At: now.Add(-2 * time.Minute), Status: schedulePending, ClaimedAt: now},
+			{ID: "2", At: now.Add(-time.Minute), Status: schedulePending},
+		}, nil, "2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inStateDir(t)
+			if err := saveState(scheduleStateFile, tt.posts); err != nil {
+				t.Fatal(err)
+			}
+			post, err := claimDue(now, tt.tried)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotID := ""
+			if post != nil {
+				gotID = post.ID
+			}
+			if gotID != tt.wantID {
+				t.Fatalf("claimDue() = %q, want %q", gotID, tt.wantID)
+			}
+			if post == nil {
+				return
+			}
+			// Claimed on disk, so another run skips it
+			if again, err := claimDue(now, nil); err != nil || again != nil && again.ID == gotID {
+				t.Errorf("post %s was claimed twice", gotID)
+			}
+		})
+	}
+}
+
+func TestClaimDueConcurrently(t *testing.T) {
+	inStateDir(t)
+	due := []*scheduledPost{{ID: "1", At: time.Now().Add(-time.Minute), Status: schedulePending}}
+	if err := saveState(scheduleStateFile, due); err != nil {
+		t.Fatal(err)
+	}
+	const runs = 8
+	var wg sync.WaitGroup
+	claims := make(chan *scheduledPost, runs)
+	for range runs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if post, err := claimDue(time.Now(), map[string]bool{}); err == nil && post != nil {
+				claims <- post
+			}
+		}()
+	}
+	wg.Wait()
+	close(claims)
+	if n := len(claims); n != 1 {
+		t.Fatalf("%d scheduler runs claimed the post, want 1", n)
+	}
+}
+
+func TestCheckedWhenSaved(t *testing.T) {
+	config := &Config{Lint: &LintConfig{NoSpelling: true, NoLinks: true, NoLinkCards: true, NoMentions: true}}
+	a := &app{config: config}
+	off := false
+	tests := []struct {
+		name    string
+		text    string
+		lint    *bool
+		wantErr bool
+	}{
+		{"clean", "hello world", nil, false},
+		{"lint warns", "hello  world (oops", nil, true},
+		{"lint turned off", "hello  world (oops", &off, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &postRequest{text: tt.text, lint: tt.lint}
+			prepared, err := req.prepare()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := checkToSave(context.Background(), config, a, req, prepared.parts, nil, false); (err != nil) != tt.wantErr {
+				t.Fatalf("checkToSave() error = %v, want error %v", err, tt.wantErr)
+			}
+			if req.checked == tt.wantErr {
+				t.Errorf("checked = %v, want %v", req.checked, !tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			saved, err := req.save()
+			if err != nil {
+				t.Fatal(err)
+			}
+			// What is posted later is not refused for lint again
+			saved.Text = "hello  world (oops"
+			later, err := saved.request().prepare()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !later.lintWarnOnly {
+				t.Error("a post checked when saved is refused for lint when posted")
+			}
+			if err := a.checkPrepared(context.Background(), later); err != nil {
+				t.Errorf("checkPrepared() = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/tweet/searchtweet"
+	"github.com/michimani/gotwi/tweet/searchtweet/types"
+)
+
+// parseDate accepts a date ("2006-01-02", local midnight) or an RFC 3339
+// timestamp
+func parseDate(s string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(tr("invalid date %q, expected YYYY-MM-DD or RFC 3339"), s)
+	}
+	return t, nil
+}
+
+// buildQuery adds search operators for the convenience flags to query
+func buildQuery(query, lang, from string) string {
+	terms := []string{strings.TrimSpace(query)}
+	if lang != "" {
+		terms = append(terms, "lang:"+lang)
+	}
+	if from != "" {
+		terms = append(terms, "from:"+strings.TrimPrefix(from, "@"))
+	}
+	return strings.TrimSpace(strings.Join(terms, " "))
+}
+
+// searchRecent returns up to count tweets matching input, following
+// pagination as needed
+func (a *app) searchRecent(ctx context.Context, input *types.ListRecentInput, count int) ([]tweetView, error) {
+	input.MaxResults = types.ListMaxResults(min(max(count, 10), 100))
+	if input.TweetFields == nil {
+		input.TweetFields = tweetViewFields
+		input.Expansions = tweetViewExpand
+		input.UserFields = tweetViewUserFld
+	}
+
+	views := []tweetView{}
+	for len(views) < count {
+		if err := a.ensureToken(ctx); err != nil {
+			return nil, err
+		}
+		res, err := searchtweet.ListRecent(ctx, a.client, input)
+		if err != nil {
+			return nil, fmt.Errorf(tr("search failed: %w"), err)
+		}
+		views = append(views, withMedia(newTweetViews(res.Data, res.Includes.Users), res.Data, res.Includes.Media)...)
+
+		next := gotwi.StringValue(res.Meta.NextToken)
+		if next == "" {
+			break
+		}
+		input.NextToken = next
+	}
+	if len(views) > count {
+		views = views[:count]
+	}
+	return views, nil
+}
+
+func runSearch(args []string) error {
+	// A query that starts with one of these words goes after --
+	if len(args) > 0 {
+		switch args[0] {
+		case "save":
+			return runSearchSave(args[1:])
+		case "run":
+			return runSearchRun(args[1:])
+		case "list":
+			return runSearchList(args[1:])
+		case "remove":
+			return runSearchRemove(args[1:])
+		}
+	}
+	fs := newFlagSet("search", `search [flags] "<query>" | save | run | list | remove`)
+	count := fs.Int("count", 20, "maximum number of tweets to return")
+	lang := fs.String("lang", "", "only tweets in this language (e.g. en)")
+	from := fs.String("from", "", "only tweets from this user")
+	since := fs.String("since", "", "only tweets after this date (YYYY-MM-DD or RFC 3339)")
+	until := fs.String("until", "", "only tweets before this date (YYYY-MM-DD or RFC 3339)")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	query := buildQuery(strings.Join(args, " "), *lang, *from)
+	if query == "" {
+		fs.Usage()
+		return errUsage
+	}
+	if *count < 1 {
+		return errors.New(tr("--count must be at least 1"))
+	}
+
+	input := &types.ListRecentInput{Query: query}
+	if *since != "" {
+		t, err := parseDate(*since)
+		if err != nil {
+			return err
+		}
+		input.StartTime = &t
+	}
+	if *until != "" {
+		t, err := parseDate(*until)
+		if err != nil {
+			return err
+		}
+		input.EndTime = &t
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	views, err := a.searchRecent(rootCtx, input, *count)
+	if err != nil {
+		return err
+	}
+
+	if machineReadable() {
+		return printResult(views)
+	}
+	if len(views) == 0 {
+		fmt.Println(tr("No tweets found."))
+		return nil
+	}
+	printTweets(os.Stdout, views)
+	return nil
+}
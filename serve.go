@@ -0,0 +1,402 @@
+package clix
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// serveStateFile holds the token clix serve's clients authenticate with
+const serveStateFile = "serve.json"
+
+// serveTokenEnvVar sets the token instead of the stored one
+const serveTokenEnvVar = "CLIX_SERVE_TOKEN"
+
+// maxServeBody bounds a request body; media go by path, not in the body
+const maxServeBody = 1 << 20
+
+type serveState struct {
+	Token string `json:"token"`
+}
+
+// serveToken returns the token from the environment, or the stored one,
+// creating it on first use
+func serveToken() (string, error) {
+	if token := os.Getenv(serveTokenEnvVar); token != "" {
+		return token, nil
+	}
+	unlock, err := lockState(serveStateFile)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+	var state serveState
+	if err := loadState(serveStateFile, &state); err != nil {
+		return "", err
+	}
+	if state.Token != "" {
+		return state.Token, nil
+	}
+	if state.Token, err = randomURLString(32); err != nil {
+		return "", err
+	}
+	return state.Token, saveState(serveStateFile, state)
+}
+
+// servePost is the body of POST /v1/post: the fields of a saved post, plus
+// force to post outside the posting window or against the style rules and
+// allow_duplicate to post one sent just before again
+type servePost struct {
+	savedPost
+	Force          bool `json:"force,omitempty"`
+	AllowDuplicate bool `json:"allow_duplicate,omitempty"`
+}
+
+// serveThread is the body of POST /v1/thread
+type serveThread struct {
+	Parts   []string `json:"parts"`
+	ReplyTo string   `json:"reply_to,omitempty"`
+	Force   bool     `json:"force,omitempty"`
+	TweetSettings
+}
+
+// serveSchedule is the body of POST /v1/schedule; at takes what
+// schedule --at does
+type serveSchedule struct {
+	savedPost
+	At    string `json:"at"`
+	Force bool   `json:"force,omitempty"`
+}
+
+// serveError is the body of a failed request. Results are what was posted
+// before a thread or split tweet failed.
+type serveError struct {
+	Error    string       `json:"error"`
+	ExitCode int          `json:"exit_code"`
+	Results  []postResult `json:"results,omitempty"`
+}
+
+// apiServer serves the HTTP API of clix serve
+type apiServer struct {
+	a     *app
+	token string
+	// mu lets one request at a time use the app, which is not safe for
+	// concurrent use, and keeps posts in the order they came in
+	mu sync.Mutex
+}
+
+// apiMethod is a call of the API. decode reads its parameters, the body
+// of an HTTP request or the params of an rpc one.
+type apiMethod func(decode func(v any) error) (any, error)
+
+func (s *apiServer) routes() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/status", s.handle(s.status))
+	mux.HandleFunc("POST /v1/post", s.handle(s.post))
+	mux.HandleFunc("POST /v1/thread", s.handle(s.thread))
+	mux.HandleFunc("POST /v1/schedule", s.handle(s.schedule))
+	mux.HandleFunc("GET /v1/queue", s.handle(s.queue))
+	return mux
+}
+
+// handle checks the token, runs fn and writes what it returns as JSON
+func (s *apiServer) handle(fn apiMethod) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		status := http.StatusOK
+		defer func() {
+			fmt.Fprintf(os.Stderr, "%s %s %s %d %s\n", start.Format("15:04:05"), r.Method, r.URL.Path, status, time.Since(start).Round(time.Millisecond))
+		}()
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			status = http.StatusUnauthorized
+			writeServeJSON(w, status, serveError{Error: "missing or wrong bearer token", ExitCode: exitAuth})
+			return
+		}
+		body := http.MaxBytesReader(w, r.Body, maxServeBody)
+
+		s.mu.Lock()
+		out, err := fn(func(v any) error { return decodeParams(body, v) })
+		s.mu.Unlock()
+		if err != nil {
+			status = serveStatus(err)
+			failed := serveError{Error: err.Error(), ExitCode: exitCode(err)}
+			failed.Results, _ = out.([]postResult)
+			writeServeJSON(w, status, failed)
+			return
+		}
+		writeServeJSON(w, status, out)
+	}
+}
+
+func writeServeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// serveStatus is the HTTP status for an error, after its exit status
+func serveStatus(err error) int {
+	switch exitCode(err) {
+	case exitUsage, exitValidation:
+		return http.StatusUnprocessableEntity
+	case exitRefused:
+		return http.StatusConflict
+	case exitNotFound:
+		return http.StatusNotFound
+	case exitRateLimited:
+		return http.StatusTooManyRequests
+	case exitNetwork, exitTimeout:
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusBadGateway
+}
+
+// decodeParams reads JSON parameters into v, rejecting unknown fields so a
+// misspelt one does not go unnoticed
+func decodeParams(r io.Reader, v any) error {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return withExitCode(exitValidation, fmt.Errorf(tr("invalid parameters: %w"), err))
+	}
+	return nil
+}
+
+func (s *apiServer) status(decode func(any) error) (any, error) {
+	return map[string]any{"account": s.a.config.Active, "version": currentVersion(), "dry_run": globalOptions.dryRun}, nil
+}
+
+// request turns the body into a post request, transformed as the post
+// command would
+func (s *apiServer) request(saved savedPost) (*postRequest, *preparedPost, error) {
+	// Only clix itself marks a post as checked
+	saved.Checked = false
+	req := saved.request()
+	if len(req.poll) > 0 && req.pollDuration == 0 {
+		req.pollDuration = defaultPollDuration
+	}
+	if err := req.transform(s.a.config); err != nil {
+		return nil, nil, err
+	}
+	prepared, err := req.prepare()
+	if err != nil {
+		return nil, nil, err
+	}
+	return req, prepared, nil
+}
+
+func (s *apiServer) post(decode func(any) error) (any, error) {
+	var body servePost
+	if err := decode(&body); err != nil {
+		return nil, err
+	}
+	_, prepared, err := s.request(body.savedPost)
+	if err != nil {
+		return nil, err
+	}
+	prepared.allowDuplicate = body.AllowDuplicate
+	prepared.force = prepared.force || body.Force
+	if !body.Force {
+		if err := checkPostingWindow(s.a.config.PostingWindow, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	// Not the request's context: a client going away halfway through a
+	// split tweet should not leave it cut short
+	results, err := s.a.publish(rootCtx, prepared, func(postResult) {})
+	if results == nil {
+		results = []postResult{}
+	}
+	return results, err
+}
+
+func (s *apiServer) thread(decode func(any) error) (any, error) {
+	var body serveThread
+	if err := decode(&body); err != nil {
+		return nil, err
+	}
+	if len(body.Parts) == 0 {
+		return nil, withExitCode(exitValidation, errors.New(tr("nothing to post")))
+	}
+	if err := body.TweetSettings.check(); err != nil {
+		return nil, withExitCode(exitValidation, err)
+	}
+	var replyID string
+	if body.ReplyTo != "" {
+		var err error
+		if replyID, err = parseTweetID(body.ReplyTo); err != nil {
+			return nil, err
+		}
+	}
+	config := s.a.config
+	parts, _, err := config.transformThread(body.Parts)
+	if err != nil {
+		return nil, err
+	}
+	decoration, err := config.threadDecoration("", true)
+	if err != nil {
+		return nil, err
+	}
+	parts = decoration.apply(parts)
+	for i, part := range parts {
+		if err := checkLength(part); err != nil {
+			return nil, fmt.Errorf(tr("part %d: %w"), i+1, err)
+		}
+	}
+	// publish checks single posts; a thread has to be checked here, and
+	// refused outside the window without the hint at --force
+	checks := &postChecks{config: config, a: s.a, parts: parts, force: body.Force, closed: func(err error) (bool, error) { return false, err }}
+	if ok, err := checks.run(rootCtx); !ok || err != nil {
+		return nil, err
+	}
+	settings, err := config.tweetSettings(body.TweetSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []postResult{}
+	if globalOptions.dryRun {
+		for _, part := range parts {
+			results = append(results, postResult{Text: part, DryRun: true})
+		}
+		return results, nil
+	}
+	if settings, err = s.a.resolveGeo(rootCtx, settings); err != nil {
+		return nil, err
+	}
+	_, err = s.a.postThread(rootCtx, parts, nil, replyID, settings, func(i int, id string) {
+		results = append(results, postResult{ID: id, Text: parts[i]})
+	})
+	return results, err
+}
+
+func (s *apiServer) schedule(decode func(any) error) (any, error) {
+	var body serveSchedule
+	if err := decode(&body); err != nil {
+		return nil, err
+	}
+	if body.At == "" {
+		return nil, withExitCode(exitValidation, errors.New(tr("at is required")))
+	}
+	when, err := parseScheduleTime(body.At, time.Now())
+	if err != nil {
+		return nil, withExitCode(exitValidation, err)
+	}
+	if when.Before(time.Now()) {
+		return nil, withExitCode(exitValidation, fmt.Errorf(tr("%s is in the past"), when.Format("2006-01-02 15:04")))
+	}
+	req, prepared, err := s.request(body.savedPost)
+	if err != nil {
+		return nil, err
+	}
+	if !body.Force {
+		if err := checkPostingWindow(s.a.config.PostingWindow, when); err != nil {
+			return nil, err
+		}
+	}
+	req.force = req.force || body.Force
+	if _, err := checkToSave(rootCtx, s.a.config, s.a, req, prepared.parts, prepared.media, false); err != nil {
+		return nil, err
+	}
+	saved, err := req.save()
+	if err != nil {
+		return nil, err
+	}
+	post := &scheduledPost{Account: s.a.config.Active, At: when, savedPost: saved}
+	if globalOptions.dryRun {
+		return post, nil
+	}
+	if _, err := enqueue(post); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// queue lists the posts waiting in the offline queue and the schedule
+func (s *apiServer) queue(decode func(any) error) (any, error) {
+	queue, err := loadQueue()
+	if err != nil {
+		return nil, err
+	}
+	scheduled, err := loadSchedule()
+	if err != nil {
+		return nil, err
+	}
+	pending := []*scheduledPost{}
+	for _, post := range scheduled {
+		if post.Status == schedulePending {
+			pending = append(pending, post)
+		}
+	}
+	return map[string]any{"queue": append([]*queuedPost{}, queue.Pending...), "schedule": pending}, nil
+}
+
+// isLoopback reports whether a --listen address only takes connections
+// from this machine
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func runServe(args []string) error {
+	fs := newFlagSet("serve", "serve [--listen 127.0.0.1:8787] [--print-token]  (an HTTP API for local tools to post through)")
+	listen := fs.String("listen", "127.0.0.1:8787", "address to listen on")
+	public := fs.Bool("public", false, "allow a --listen address other machines can reach")
+	printToken := fs.Bool("print-token", false, "print the bearer token clients send and exit")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if !isLoopback(*listen) && !*public {
+		return withExitCode(exitUsage, fmt.Errorf(tr("%s can be reached from other machines; listen on 127.0.0.1 or pass --public"), *listen))
+	}
+
+	token, err := serveToken()
+	if err != nil {
+		return err
+	}
+	if *printToken {
+		fmt.Println(token)
+		return nil
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	listener, err := net.Listen("tcp", *listen)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{
+		Handler:           (&apiServer{a: a, token: token}).routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-rootCtx.Done()
+		server.Close()
+	}()
+	fmt.Fprintf(os.Stderr, tr("Serving the clix API for %s on http://%s (token: clix serve --print-token).\n"), a.config.Active, listener.Addr())
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
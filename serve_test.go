@@ -0,0 +1,83 @@
+package clix
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	clixconfig "github.com/voltycodes/clix/config"
+)
+
+func TestIsLoopback(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"127.0.0.1:8787", true},
+		{"127.1.2.3:8787", true},
+		{"localhost:8787", true},
+		{"[::1]:8787", true},
+		{"0.0.0.0:8787", false},
+		{":8787", false},
+		{"[::]:8787", false},
+		{"192.168.1.5:8787", false},
+		{"example.com:8787", false},
+		{"127.0.0.1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := isLoopback(tt.addr); got != tt.want {
+				t.Errorf("isLoopback(%q) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServeToken(t *testing.T) {
+	inStateDir(t)
+	t.Setenv(serveTokenEnvVar, "")
+	first, err := serveToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) < 32 {
+		t.Errorf("token %q is too short", first)
+	}
+	if again, err := serveToken(); err != nil || again != first {
+		t.Errorf("serveToken() = %q, %v, want the stored %q", again, err, first)
+	}
+	t.Setenv(serveTokenEnvVar, "from-env")
+	if got, err := serveToken(); err != nil || got != "from-env" {
+		t.Errorf("serveToken() = %q, %v, want the one from $%s", got, err, serveTokenEnvVar)
+	}
+}
+
+func TestServeChecksTheToken(t *testing.T) {
+	s := &apiServer{a: &app{config: &Config{Config: clixconfig.Config{Active: "me"}}}, token: "secret"}
+	tests := []struct {
+		name          string
+		authorization string
+		want          int
+	}{
+		{"right token", "Bearer secret", http.StatusOK},
+		{"no token", "", http.StatusUnauthorized},
+		{"wrong token", "Bearer guess", http.StatusUnauthorized},
+		{"token prefix", "Bearer secre", http.StatusUnauthorized},
+		{"not bearer", "Basic secret", http.StatusUnauthorized},
+		{"bare token", "secret", http.StatusUnauthorized},
+	}
+	routes := s.routes()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/status", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			res := httptest.NewRecorder()
+			routes.ServeHTTP(res, req)
+			if res.Code != tt.want {
+				t.Errorf("GET /v1/status = %d, want %d", res.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,478 @@
+package clix
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	clixconfig "github.com/voltycodes/clix/config"
+)
+
+// The scheduler service, as systemd and launchd know it
+const (
+	systemdUnitName = "clix-scheduler.service"
+	launchdLabel    = "com.voltycodes.clix.scheduler"
+)
+
+// schedulerLogFile is where launchd writes the scheduler's output; systemd
+// keeps it in the journal
+const schedulerLogFile = "scheduler.log"
+
+// schedulerEnvFile is read by the systemd service for the secrets that are
+// not written into the unit: $CLIX_PASSPHRASE and credentials from the
+// environment, as NAME=value lines
+const schedulerEnvFile = "scheduler.env"
+
+// schedulerService is the unit or agent that keeps `clix scheduler run`
+// going in the background
+type schedulerService struct {
+	manager string // "systemd" or "launchd"
+	path    string // the unit or plist file
+}
+
+func currentSchedulerService() (*schedulerService, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		return &schedulerService{"launchd", filepath.Join(home, "Library", "LaunchAgents", launchdLabel+".plist")}, nil
+	case "linux", "freebsd", "openbsd", "netbsd":
+		if _, err := exec.LookPath("systemctl"); err != nil {
+			return nil, errors.New(tr("no systemd here; run 'clix scheduler run --once' from cron instead"))
+		}
+		dir := os.Getenv("XDG_CONFIG_HOME")
+		if dir == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, err
+			}
+			dir = filepath.Join(home, ".config")
+		}
+		return &schedulerService{"systemd", filepath.Join(dir, "systemd", "user", systemdUnitName)}, nil
+	}
+	return nil, fmt.Errorf(tr("installing the scheduler is not supported on %s; run 'clix scheduler run --once' from the system's task scheduler instead"), runtime.GOOS)
+}
+
+func (s *schedulerService) installed() bool {
+	_, err := os.Stat(s.path)
+	return err == nil
+}
+
+// running asks the service manager whether the scheduler is up
+func (s *schedulerService) running() bool {
+	if s.manager == "systemd" {
+		return exec.Command("systemctl", "--user", "is-active", "--quiet", systemdUnitName).Run() == nil
+	}
+	out, err := exec.Command("launchctl", "print", launchdTarget()).Output()
+	return err == nil && bytes.Contains(out, []byte("state = running"))
+}
+
+func launchdDomain() string {
+	return fmt.Sprintf("gui/%d", os.Getuid())
+}
+
+func launchdTarget() string {
+	return launchdDomain() + "/" + launchdLabel
+}
+
+// schedulerCommand is the command line the service runs: this clix, by the
+// name it is found under on $PATH when that is the same file, so a package
+// manager's upgrade does not leave the service pointing at an old version
+func schedulerCommand(interval time.Duration) ([]string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	if onPath, err := exec.LookPath("clix"); err == nil {
+		if abs, err := filepath.Abs(onPath); err == nil && sameFile(abs, exe) {
+			exe = abs
+		}
+	}
+	return []string{exe, "scheduler", "run", "--interval", interval.String()}, nil
+}
+
+func sameFile(a, b string) bool {
+	ai, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	bi, err := os.Stat(b)
+	return err == nil && os.SameFile(ai, bi)
+}
+
+// schedulerEnvironment is what the service needs of the environment to
+// find the same config and state as this run
+func schedulerEnvironment() map[string]string {
+	env := map[string]string{}
+	for _, name := range []string{"XDG_CONFIG_HOME", stateDirEnvVar, storageEnvVar} {
+		if value := os.Getenv(name); value != "" {
+			env[name] = value
+		}
+	}
+	return env
+}
+
+// schedulerWorkDir is where the service runs: here when a project config
+// is in use, so it finds that config too, else the home directory
+func schedulerWorkDir() (string, error) {
+	if _, source := configFileSource(); source == "project" {
+		return os.Getwd()
+	}
+	return os.UserHomeDir()
+}
+
+// systemdQuote quotes an ExecStart or Environment word for systemd, which
+// also expands % specifiers in them
+func systemdQuote(s string) string {
+	s = strings.ReplaceAll(s, "%", "%%")
+	if strings.ContainsAny(s, " \t\"'\\") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// systemdPath escapes a path setting such as WorkingDirectory for systemd,
+// which takes it as it is, without quotes, expanding only % specifiers. A
+// line break cannot be written at all.
+func systemdPath(path string) (string, error) {
+	if strings.ContainsAny(path, "\n\r") {
+		return "", fmt.Errorf(tr("%q cannot be written into a systemd unit"), path)
+	}
+	return strings.ReplaceAll(path, "%", "%%"), nil
+}
+
+// systemdUnit is the unit running command in workDir with env. The
+// secrets the service also needs are read from envFile, which is not
+// required to exist.
+func systemdUnit(command []string, workDir, envFile string, env map[string]string) (string, error) {
+	workDir, err := systemdPath(workDir)
+	if err != nil {
+		return "", err
+	}
+	if envFile, err = systemdPath(envFile); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString("[Unit]\nDescription=clix scheduler, posting scheduled tweets when they are due\n\n")
+	b.WriteString("[Service]\n")
+	words := make([]string, len(command))
+	for i, word := range command {
+		// ExecStart expands $ variables too
+		words[i] = systemdQuote(strings.ReplaceAll(word, "$", "$$"))
+	}
+	fmt.Fprintf(&b, "ExecStart=%s\n", strings.Join(words, " "))
+	fmt.Fprintf(&b, "WorkingDirectory=%s\n", workDir)
+	for _, name := range sortedKeys(env) {
+		fmt.Fprintf(&b, "Environment=%s\n", systemdQuote(name+"="+env[name]))
+	}
+	// The leading - lets the file be missing
+	fmt.Fprintf(&b, "EnvironmentFile=-%s\n", envFile)
+	b.WriteString("Restart=on-failure\nRestartSec=30\n\n")
+	b.WriteString("[Install]\nWantedBy=default.target\n")
+	return b.String(), nil
+}
+
+func launchdPlist(command []string, workDir string, env map[string]string, logPath string) string {
+	esc := func(s string) string {
+		var b strings.Builder
+		xml.EscapeText(&b, []byte(s))
+		return b.String()
+	}
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+`)
+	fmt.Fprintf(&b, "\t<key>Label</key>\n\t<string>%s</string>\n", launchdLabel)
+	b.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, word := range command {
+		fmt.Fprintf(&b, "\t\t<string>%s</string>\n", esc(word))
+	}
+	b.WriteString("\t</array>\n")
+	fmt.Fprintf(&b, "\t<key>WorkingDirectory</key>\n\t<string>%s</string>\n", esc(workDir))
+	if len(env) > 0 {
+		b.WriteString("\t<key>EnvironmentVariables</key>\n\t<dict>\n")
+		for _, name := range sortedKeys(env) {
+			fmt.Fprintf(&b, "\t\t<key>%s</key>\n\t\t<string>%s</string>\n", esc(name), esc(env[name]))
+		}
+		b.WriteString("\t</dict>\n")
+	}
+	b.WriteString("\t<key>RunAtLoad</key>\n\t<true/>\n\t<key>KeepAlive</key>\n\t<true/>\n")
+	fmt.Fprintf(&b, "\t<key>StandardOutPath</key>\n\t<string>%s</string>\n", esc(logPath))
+	fmt.Fprintf(&b, "\t<key>StandardErrorPath</key>\n\t<string>%s</string>\n", esc(logPath))
+	b.WriteString("</dict>\n</plist>\n")
+	return b.String()
+}
+
+// serviceCommand runs a service manager command, or only prints it under
+// --dry-run
+func serviceCommand(name string, args ...string) error {
+	if globalOptions.dryRun {
+		fmt.Println(tr("Would run:"), name, strings.Join(args, " "))
+		return nil
+	}
+	out, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s %s: %v: %s", name, strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func runSchedulerInstall(args []string) error {
+	fs := newFlagSet("scheduler install", "scheduler install [--interval 30s] [--force]")
+	interval := fs.Duration("interval", 30*time.Second, "how often the scheduler checks for due posts")
+	force := fs.Bool("force", false, "replace a scheduler service that is installed already")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *interval < time.Second {
+		return withExitCode(exitUsage, errors.New(tr("--interval must be at least 1s")))
+	}
+	service, err := currentSchedulerService()
+	if err != nil {
+		return err
+	}
+	if service.installed() && !*force {
+		return withExitCode(exitRefused, fmt.Errorf(tr("the scheduler is installed already at %s; use --force to replace it"), service.path))
+	}
+
+	command, err := schedulerCommand(*interval)
+	if err != nil {
+		return err
+	}
+	workDir, err := schedulerWorkDir()
+	if err != nil {
+		return err
+	}
+	env := schedulerEnvironment()
+	dir, err := getDataDir()
+	if err != nil {
+		return err
+	}
+	envFile := filepath.Join(dir, schedulerEnvFile)
+	var content string
+	if service.manager == "systemd" {
+		if content, err = systemdUnit(command, workDir, envFile, env); err != nil {
+			return err
+		}
+	} else {
+		content = launchdPlist(command, workDir, env, filepath.Join(dir, schedulerLogFile))
+	}
+
+	if globalOptions.dryRun {
+		fmt.Printf(tr("Would write %s:\n\n%s\n"), service.path, content)
+	} else {
+		if err := os.MkdirAll(filepath.Dir(service.path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(service.path, []byte(content), 0644); err != nil {
+			return fmt.Errorf(tr("failed to write %s: %w"), service.path, err)
+		}
+	}
+	if service.manager == "systemd" {
+		if err := serviceCommand("systemctl", "--user", "daemon-reload"); err != nil {
+			return err
+		}
+		if err := serviceCommand("systemctl", "--user", "enable", "--now", systemdUnitName); err != nil {
+			return err
+		}
+		if *force {
+			if err := serviceCommand("systemctl", "--user", "restart", systemdUnitName); err != nil {
+				return err
+			}
+		}
+	} else {
+		// bootstrap fails for an agent that is loaded, so a replaced one
+		// is taken out first
+		if *force {
+			serviceCommand("launchctl", "bootout", launchdTarget())
+		}
+		if err := serviceCommand("launchctl", "bootstrap", launchdDomain(), service.path); err != nil {
+			return err
+		}
+	}
+	if globalOptions.dryRun {
+		return nil
+	}
+
+	if machineReadable() {
+		return printResult(map[string]string{"manager": service.manager, "path": service.path})
+	}
+	fmt.Printf(tr("Installed the scheduler as a %s service in %s, checking every %s.\n"), service.manager, service.path, *interval)
+	var secrets []string
+	if envCredentialsSet() {
+		for _, field := range clixconfig.CredentialFields(&Credentials{}) {
+			secrets = append(secrets, "$"+clixconfig.CredentialEnvVar(field.Key))
+		}
+	} else if data, err := os.ReadFile(getConfigFilePath()); err == nil && clixconfig.IsEncrypted(data) {
+		secrets = append(secrets, "$"+passphraseEnvVar)
+	}
+	if len(secrets) > 0 {
+		// Secrets are not written into the unit or plist, which others on
+		// the machine may be able to read
+		if service.manager == "systemd" {
+			fmt.Fprintf(os.Stderr, tr("Warning: the service needs %s, which it cannot ask for; put them in %s as NAME=value lines, readable by you alone\n"), strings.Join(secrets, ", "), envFile)
+		} else {
+			fmt.Fprintf(os.Stderr, tr("Warning: the service needs %s, which it cannot ask for; give them to it with 'launchctl setenv'\n"), strings.Join(secrets, ", "))
+		}
+	}
+	if service.manager == "systemd" {
+		fmt.Println(tr("To keep it running while you are logged out, run 'loginctl enable-linger'."))
+	}
+	fmt.Println(tr("See it with 'clix scheduler status' and 'clix scheduler logs'."))
+	return nil
+}
+
+func runSchedulerUninstall(args []string) error {
+	fs := newFlagSet("scheduler uninstall", "scheduler uninstall")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	service, err := currentSchedulerService()
+	if err != nil {
+		return err
+	}
+	if !service.installed() {
+		return withExitCode(exitNotFound, errors.New(tr("the scheduler is not installed")))
+	}
+	if service.manager == "systemd" {
+		if err := serviceCommand("systemctl", "--user", "disable", "--now", systemdUnitName); err != nil {
+			return err
+		}
+	} else if service.running() || globalOptions.dryRun {
+		if err := serviceCommand("launchctl", "bootout", launchdTarget()); err != nil {
+			return err
+		}
+	}
+	if globalOptions.dryRun {
+		fmt.Println(tr("Would remove"), service.path)
+		return nil
+	}
+	if err := os.Remove(service.path); err != nil {
+		return err
+	}
+	if service.manager == "systemd" {
+		if err := serviceCommand("systemctl", "--user", "daemon-reload"); err != nil {
+			return err
+		}
+	}
+	if machineReadable() {
+		return printResult(map[string]string{"removed": service.path})
+	}
+	fmt.Println(tr("Uninstalled the scheduler; scheduled posts are kept for when it runs again."))
+	return nil
+}
+
+// schedulerStatus is the output of `clix scheduler status`
+type schedulerStatus struct {
+	Manager   string     `json:"manager"`
+	Path      string     `json:"path"`
+	Installed bool       `json:"installed"`
+	Running   bool       `json:"running"`
+	Pending   int        `json:"pending"`
+	Next      *time.Time `json:"next,omitempty"`
+}
+
+func runSchedulerStatus(args []string) error {
+	fs := newFlagSet("scheduler status", "scheduler status")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	service, err := currentSchedulerService()
+	if err != nil {
+		return err
+	}
+	posts, err := loadSchedule()
+	if err != nil {
+		return err
+	}
+	status := schedulerStatus{Manager: service.manager, Path: service.path, Installed: service.installed()}
+	if status.Installed {
+		status.Running = service.running()
+	}
+	for _, post := range posts {
+		if post.Status != schedulePending {
+			continue
+		}
+		status.Pending++
+		if status.Next == nil || post.At.Before(*status.Next) {
+			at := post.At
+			status.Next = &at
+		}
+	}
+
+	if machineReadable() {
+		return printResult(status)
+	}
+	switch {
+	case !status.Installed:
+		fmt.Println(tr("The scheduler is not installed; set it up with 'clix scheduler install'."))
+	case status.Running:
+		fmt.Printf(tr("The scheduler is running (%s, %s).\n"), service.manager, service.path)
+	default:
+		fmt.Printf(tr("The scheduler is installed but not running (%s, %s); see 'clix scheduler logs'.\n"), service.manager, service.path)
+	}
+	switch {
+	case status.Pending == 0:
+		fmt.Println(tr("Nothing scheduled."))
+	case status.Pending == 1:
+		fmt.Printf(tr("1 post scheduled, due %s.\n"), formatTime(*status.Next))
+	default:
+		fmt.Printf(tr("%d posts scheduled, the next due %s.\n"), status.Pending, formatTime(*status.Next))
+	}
+	if status.Installed && !status.Running {
+		return withExitCode(exitPartial, errors.New(tr("the scheduler is not running")))
+	}
+	return nil
+}
+
+func runSchedulerLogs(args []string) error {
+	fs := newFlagSet("scheduler logs", "scheduler logs [-n 50] [-f]")
+	lines := fs.Int("n", 50, "how many of the last lines to show")
+	follow := fs.Bool("f", false, "keep showing new lines as they are written")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	service, err := currentSchedulerService()
+	if err != nil {
+		return err
+	}
+	var cmd *exec.Cmd
+	if service.manager == "systemd" {
+		cmdArgs := []string{"--user", "-u", systemdUnitName, "-n", strconv.Itoa(*lines), "--no-pager"}
+		if *follow {
+			cmdArgs = append(cmdArgs, "-f")
+		}
+		cmd = exec.CommandContext(rootCtx, "journalctl", cmdArgs...)
+	} else {
+		dir, err := getDataDir()
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, schedulerLogFile)
+		if _, err := os.Stat(path); err != nil {
+			return withExitCode(exitNotFound, fmt.Errorf(tr("the scheduler has not written %s yet"), path))
+		}
+		cmdArgs := []string{"-n", strconv.Itoa(*lines)}
+		if *follow {
+			cmdArgs = append(cmdArgs, "-f")
+		}
+		cmd = exec.CommandContext(rootCtx, "tail", append(cmdArgs, path)...)
+	}
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil && rootCtx.Err() == nil {
+		return fmt.Errorf(tr("failed to show the scheduler's logs: %w"), err)
+	}
+	return nil
+}
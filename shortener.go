@@ -0,0 +1,127 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/voltycodes/clix/compose"
+	"github.com/voltycodes/clix/store"
+)
+
+// shortenTimeout bounds each request to the shortener
+const shortenTimeout = 10 * time.Second
+
+// ShortenerConfig is the "shortener" section of the config: a Shlink
+// server the links in tweets are shortened with before posting, so their
+// clicks can be counted there
+type ShortenerConfig struct {
+	URL    string   `json:"url"` // e.g. https://s.example.com
+	APIKey string   `json:"api_key"`
+	Domain string   `json:"domain,omitempty"` // for a server with several domains
+	Tags   []string `json:"tags,omitempty"`
+	// Skip lists hosts whose links are left alone. Links to X always are,
+	// since a shortened one loses its card.
+	Skip []string `json:"skip,omitempty"`
+}
+
+// shortLink is a link that was shortened, as recorded in the history
+type shortLink = store.Link
+
+// shortenLinks replaces the links in text with short ones
+func (c *ShortenerConfig) shortenLinks(ctx context.Context, text string) (string, []shortLink, error) {
+	var b strings.Builder
+	var links []shortLink
+	for _, span := range compose.SplitURLs(text) {
+		long := span.Text
+		if span.URL && !strings.Contains(long, "://") {
+			long = "https://" + long
+		}
+		if !span.URL || c.skip(long) {
+			b.WriteString(span.Text)
+			continue
+		}
+		link, err := c.shorten(ctx, long)
+		if err != nil {
+			return "", nil, err
+		}
+		links = append(links, link)
+		b.WriteString(link.Short)
+	}
+	return b.String(), links, nil
+}
+
+func (c *ShortenerConfig) skip(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return true
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	skip := append([]string{"x.com", "twitter.com", c.Domain}, c.Skip...)
+	if server, err := url.Parse(c.URL); err == nil {
+		skip = append(skip, server.Hostname())
+	}
+	return slices.ContainsFunc(skip, func(s string) bool {
+		s = strings.TrimPrefix(strings.ToLower(s), "www.")
+		return s != "" && (host == s || strings.HasSuffix(host, "."+s))
+	})
+}
+
+// shorten creates a short URL on the Shlink server, or returns the one it
+// already has for the link
+func (c *ShortenerConfig) shorten(ctx context.Context, long string) (shortLink, error) {
+	if c.URL == "" || c.APIKey == "" {
+		return shortLink{}, errors.New(tr("the shortener config needs a url and an api_key"))
+	}
+	ctx, cancel := context.WithTimeout(ctx, shortenTimeout)
+	defer cancel()
+
+	params := map[string]any{"longUrl": long, "findIfExists": true}
+	if c.Domain != "" {
+		params["domain"] = c.Domain
+	}
+	if len(c.Tags) > 0 {
+		params["tags"] = c.Tags
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		return shortLink{}, err
+	}
+	endpoint := strings.TrimSuffix(c.URL, "/") + "/rest/v3/short-urls"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return shortLink{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Api-Key", c.APIKey)
+	res, err := newDirectHTTPClient().Do(req)
+	if err != nil {
+		return shortLink{}, fmt.Errorf(tr("failed to shorten %s: %w"), long, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		var problem struct {
+			Detail string `json:"detail"`
+		}
+		data, _ := io.ReadAll(io.LimitReader(res.Body, 1<<16))
+		json.Unmarshal(data, &problem)
+		return shortLink{}, fmt.Errorf(tr("failed to shorten %s: %s %s"), long, res.Status, problem.Detail)
+	}
+
+	var created struct {
+		ShortURL  string `json:"shortUrl"`
+		ShortCode string `json:"shortCode"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil || created.ShortURL == "" {
+		return shortLink{}, fmt.Errorf(tr("unexpected response from the shortener for %s"), long)
+	}
+	return shortLink{Short: created.ShortURL, Long: long, Code: created.ShortCode}, nil
+}
@@ -0,0 +1,122 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/resources"
+	searchtypes "github.com/michimani/gotwi/tweet/searchtweet/types"
+	"github.com/michimani/gotwi/tweet/tweetlookup"
+	"github.com/michimani/gotwi/tweet/tweetlookup/types"
+)
+
+// Fields clix show asks for on top of those of every tweet view
+var (
+	showTweetFields = append(slices.Clone(tweetViewFields), fields.TweetFieldAttachments)
+	showExpand      = append(slices.Clone(tweetViewExpand), fields.ExpansionAttachmentsMediaKeys)
+	showMediaFields = fields.MediaFieldList{fields.MediaFieldType, fields.MediaFieldUrl, fields.MediaFieldPreviewImageUrl, fields.MediaFieldAltText, fields.MediaFieldVariants}
+)
+
+// searchWindow is how far back recent search, and so --thread, reaches
+const searchWindow = 7 * 24 * time.Hour
+
+// showTweet fetches a tweet with its author and media, along with the ID
+// of the conversation it belongs to
+func (a *app) showTweet(ctx context.Context, id string) (*tweetView, string, error) {
+	if err := a.ensureToken(ctx); err != nil {
+		return nil, "", err
+	}
+	res, err := tweetlookup.Get(ctx, a.client, &types.GetInput{
+		ID:          id,
+		Expansions:  showExpand,
+		TweetFields: showTweetFields,
+		UserFields:  tweetViewUserFld,
+		MediaFields: showMediaFields,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf(tr("failed to fetch tweet %s: %w"), id, err)
+	}
+	tweets := []resources.Tweet{res.Data}
+	views := withMedia(newTweetViews(tweets, res.Includes.Users), tweets, res.Includes.Media)
+	return &views[0], gotwi.StringValue(res.Data.ConversationID), nil
+}
+
+// conversation returns root, the tweet that started a conversation,
+// followed by up to count replies in it, oldest first
+func (a *app) conversation(ctx context.Context, root *tweetView, count int) ([]tweetView, error) {
+	if time.Since(root.CreatedAt) > searchWindow {
+		fmt.Fprintln(os.Stderr, tr("Warning: the thread is older than 7 days and search only reaches that far back, so replies may be missing"))
+	}
+	replies, err := a.searchRecent(ctx, &searchtypes.ListRecentInput{
+		Query:       "conversation_id:" + root.ID,
+		Expansions:  showExpand,
+		TweetFields: showTweetFields,
+		UserFields:  tweetViewUserFld,
+		MediaFields: showMediaFields,
+	}, count)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(replies, func(x, y tweetView) int { return x.CreatedAt.Compare(y.CreatedAt) })
+	return append([]tweetView{*root}, replies...), nil
+}
+
+func runShow(args []string) error {
+	fs := newFlagSet("show", "show [--thread [--count n]] <id|url>")
+	thread := fs.Bool("thread", false, "show the whole conversation the tweet is part of, in order")
+	count := fs.Int("count", 100, "with --thread, the most replies to show")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+	if *count < 1 {
+		return errors.New(tr("--count must be at least 1"))
+	}
+	id, err := parseTweetID(args[0])
+	if err != nil {
+		return err
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	ctx := rootCtx
+	tweet, conversationID, err := a.showTweet(ctx, id)
+	if err != nil {
+		return err
+	}
+	views := []tweetView{*tweet}
+	if *thread && conversationID != "" {
+		root := tweet
+		if conversationID != tweet.ID {
+			if root, _, err = a.showTweet(ctx, conversationID); err != nil {
+				return err
+			}
+		}
+		if views, err = a.conversation(ctx, root, *count); err != nil {
+			return err
+		}
+	}
+
+	if machineReadable() {
+		if !*thread {
+			return printResult(views[0])
+		}
+		return printResult(views)
+	}
+	printTweets(os.Stdout, views)
+	return nil
+}
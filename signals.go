@@ -0,0 +1,108 @@
+package clix
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"golang.org/x/term"
+)
+
+// rootCtx is cancelled by the first SIGINT or SIGTERM. Commands make their
+// API calls and uploads with it, so an interrupt stops those where they
+// are and the command returns normally, running its deferred cleanup:
+// queued tweets are not flushed, metrics are sent and the history file is
+// left whole.
+var rootCtx, cancelRoot = context.WithCancel(context.Background())
+
+// interrupted reports whether a signal cancelled rootCtx
+func interrupted() bool {
+	return rootCtx.Err() != nil
+}
+
+// waitingForInput counts the reads blocked on the terminal. Nothing is
+// left to clean up while the user is being asked for something, so a
+// signal then exits straight away rather than waiting for the read.
+var waitingForInput atomic.Int32
+
+// savedTerminal is the state to put the terminal back to when a signal
+// makes clix exit while it is in raw or no-echo mode
+var savedTerminal struct {
+	sync.Mutex
+	fd    int
+	state *term.State
+}
+
+// makeRaw puts the terminal in raw mode, returning the function that
+// restores it
+func makeRaw(fd int) (func(), error) {
+	restore, err := saveTerminal(fd)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := term.MakeRaw(fd); err != nil {
+		restore()
+		return nil, err
+	}
+	return restore, nil
+}
+
+// saveTerminal records the terminal's state for restoreTerminal, for use
+// around anything that changes it, returning the function that restores
+// it and forgets it again
+func saveTerminal(fd int) (func(), error) {
+	state, err := term.GetState(fd)
+	if err != nil {
+		return nil, err
+	}
+	savedTerminal.Lock()
+	savedTerminal.fd, savedTerminal.state = fd, state
+	savedTerminal.Unlock()
+	return func() {
+		savedTerminal.Lock()
+		defer savedTerminal.Unlock()
+		term.Restore(fd, state)
+		savedTerminal.state = nil
+	}, nil
+}
+
+// restoreTerminal undoes raw mode and bracketed paste, if they are on
+func restoreTerminal() {
+	savedTerminal.Lock()
+	defer savedTerminal.Unlock()
+	if savedTerminal.state != nil {
+		term.Restore(savedTerminal.fd, savedTerminal.state)
+		fmt.Print("\x1b[?2004l\r\n")
+	}
+}
+
+// handleSignals cancels rootCtx on SIGINT or SIGTERM. A second signal, or
+// one that arrives while waiting for input, exits at once.
+func handleSignals() {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		for sig := range signals {
+			if !interrupted() {
+				// Shells report a process ended by a signal as 128 plus its number
+				exitInterrupted.Store(128 + int32(sig.(syscall.Signal)))
+			}
+			if interrupted() || waitingForInput.Load() > 0 {
+				restoreTerminal()
+				if waitingForInput.Load() > 0 {
+					fmt.Fprintln(os.Stderr)
+				}
+				os.Exit(int(exitInterrupted.Load()))
+			}
+			cancelRoot()
+		}
+	}()
+}
+
+// exitInterrupted is the exit status after a signal: 130 for SIGINT and
+// 143 for SIGTERM
+var exitInterrupted atomic.Int32
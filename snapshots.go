@@ -0,0 +1,217 @@
+package clix
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const statsSnapshotsFile = "stats_snapshots.json"
+
+// statsSnapshotRetention is how long snapshots are kept. Each one holds
+// every tracked tweet, so a daily cron job adds up.
+const statsSnapshotRetention = 365 * 24 * time.Hour
+
+var statsReportFormats = []string{"csv", "json"}
+
+// statsReportSpark is how many of the latest snapshots the growth column
+// of the report draws
+const statsReportSpark = 12
+
+// statsSnapshot is the metrics of an account's recent tweets at one time,
+// as `clix stats track` records them
+type statsSnapshot struct {
+	At      time.Time    `json:"at"`
+	Account string       `json:"account"`
+	Tweets  []tweetStats `json:"tweets"`
+}
+
+// statsPoint is one row of `clix stats report`: a tweet's metrics as one
+// snapshot found them
+type statsPoint struct {
+	At      time.Time `json:"at"`
+	Account string    `json:"account"`
+	tweetStats
+}
+
+func loadStatsSnapshots() ([]statsSnapshot, error) {
+	var snapshots []statsSnapshot
+	if err := loadState(statsSnapshotsFile, &snapshots); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+func runStatsTrack(args []string) error {
+	fs := newFlagSet("stats track", "stats track [--since 30d] [--count 100]  (records the metrics of your recent tweets; run it from cron)")
+	since := fs.String("since", "30d", "track your tweets posted after this date, time or duration ago")
+	count := fs.Int("count", 100, "the most tweets to track")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	now := time.Now()
+	start, err := parseHistoryTime(*since, now)
+	if err != nil {
+		return err
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+	stats, err := a.ownTweetStats(rootCtx, start, *count)
+	if err != nil {
+		return err
+	}
+	if stats == nil {
+		stats = []tweetStats{}
+	}
+	snapshot := statsSnapshot{At: now.UTC(), Account: a.config.Active, Tweets: stats}
+
+	unlock, err := lockState(statsSnapshotsFile)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	snapshots, err := loadStatsSnapshots()
+	if err != nil {
+		return err
+	}
+	snapshots = slices.DeleteFunc(snapshots, func(s statsSnapshot) bool {
+		return now.Sub(s.At) > statsSnapshotRetention
+	})
+	snapshots = append(snapshots, snapshot)
+	if err := saveState(statsSnapshotsFile, snapshots); err != nil {
+		return err
+	}
+
+	if machineReadable() {
+		return printResult(snapshot)
+	}
+	fmt.Printf(tr("Recorded the metrics of %d tweets.\n"), len(stats))
+	return nil
+}
+
+func runStatsReport(args []string) error {
+	fs := newFlagSet("stats report", "stats report [<id|url>...] [--since 30d] [--format csv|json] [--output file]")
+	since := fs.String("since", "30d", "report snapshots taken after this date, time or duration ago")
+	output := fs.String("output", "", "file to write with --format, - for stdout (the default)")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	// --format is the report's, and not a template for what clix prints
+	format := globalOptions.format
+	globalOptions.format, outputTemplate = "", nil
+	if format != "" && !slices.Contains(statsReportFormats, format) {
+		return withExitCode(exitUsage, fmt.Errorf(tr("--format for stats report must be one of %s"), strings.Join(statsReportFormats, ", ")))
+	}
+	if format == "" && globalOptions.json {
+		format = "json"
+	}
+	start, err := parseHistoryTime(*since, time.Now())
+	if err != nil {
+		return err
+	}
+	var ids []string
+	for _, arg := range args {
+		id, err := parseTweetID(arg)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+
+	snapshots, err := loadStatsSnapshots()
+	if err != nil {
+		return err
+	}
+	account := explicitAccount()
+	points := []statsPoint{}
+	for _, snapshot := range snapshots {
+		if snapshot.At.Before(start) || account != "" && snapshot.Account != account {
+			continue
+		}
+		for _, tweet := range snapshot.Tweets {
+			if len(ids) == 0 || slices.Contains(ids, tweet.ID) {
+				points = append(points, statsPoint{snapshot.At, snapshot.Account, tweet})
+			}
+		}
+	}
+	slices.SortStableFunc(points, func(x, y statsPoint) int { return x.At.Compare(y.At) })
+
+	if format == "" {
+		if len(points) == 0 {
+			fmt.Println(tr("No snapshots in that time; record them with 'clix stats track'."))
+			return nil
+		}
+		printStatsReport(points)
+		return nil
+	}
+	var w io.Writer = os.Stdout
+	if *output != "" && *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(points)
+	}
+	return writeStatsCSV(w, points)
+}
+
+func writeStatsCSV(w io.Writer, points []statsPoint) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"snapshot_at", "account", "id", "created_at", "impressions", "likes", "retweets", "replies", "quotes", "bookmarks", "text"})
+	for _, p := range points {
+		cw.Write([]string{
+			p.At.UTC().Format(time.RFC3339), p.Account, p.ID, p.CreatedAt.UTC().Format(time.RFC3339),
+			strconv.Itoa(p.Impressions), strconv.Itoa(p.Likes), strconv.Itoa(p.Retweets),
+			strconv.Itoa(p.Replies), strconv.Itoa(p.Quotes), strconv.Itoa(p.Bookmarks), p.Text,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// printStatsReport shows each tweet's latest metrics, what they gained
+// over the snapshots and how its impressions grew
+func printStatsReport(points []statsPoint) {
+	var order []string
+	series := map[string][]statsPoint{}
+	for _, p := range points {
+		if _, ok := series[p.ID]; !ok {
+			order = append(order, p.ID)
+		}
+		series[p.ID] = append(series[p.ID], p)
+	}
+	fmt.Printf("%-20s %-9s %18s %14s %10s  %-12s %s\n", "ID", "SNAPSHOTS", "IMPRESSIONS", "LIKES", "RTS", "GROWTH", "TEXT")
+	for _, id := range order {
+		s := series[id]
+		first, last := s[0], s[len(s)-1]
+		gain := func(metric func(tweetStats) int) string {
+			return fmt.Sprintf("%d (+%d)", metric(last.tweetStats), metric(last.tweetStats)-metric(first.tweetStats))
+		}
+		var impressions []int
+		for _, p := range s[max(len(s)-statsReportSpark, 0):] {
+			impressions = append(impressions, p.Impressions)
+		}
+		fmt.Printf("%-20s %-9d %18s %14s %10s  %-12s %s\n", id, len(s),
+			gain(func(t tweetStats) int { return t.Impressions }),
+			gain(func(t tweetStats) int { return t.Likes }),
+			gain(func(t tweetStats) int { return t.Retweets }),
+			sparkline(impressions), truncateRunes(last.Text, 40))
+	}
+}
@@ -0,0 +1,77 @@
+package clix
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/voltycodes/clix/store"
+)
+
+// storageEnvVar picks the storage backend, one of store.Backends
+const storageEnvVar = "CLIX_STORAGE"
+
+var opened struct {
+	sync.Mutex
+	dir   string
+	store store.Store
+}
+
+// openStore returns the store for the data directory, opening it on
+// first use
+func openStore() (store.Store, error) {
+	dir, err := getDataDir()
+	if err != nil {
+		return nil, err
+	}
+	opened.Lock()
+	defer opened.Unlock()
+	if opened.store != nil && opened.dir == dir {
+		return opened.store, nil
+	}
+	name := cmp.Or(os.Getenv(storageEnvVar), store.DefaultBackend)
+	if _, ok := store.Backends[name]; !ok {
+		names := slices.Sorted(maps.Keys(store.Backends))
+		return nil, fmt.Errorf(tr("unknown $%s %q, expected %s"), storageEnvVar, name, strings.Join(names, " or "))
+	}
+	s, err := store.Open(dir, name)
+	if err != nil {
+		return nil, err
+	}
+	opened.dir, opened.store = dir, s
+	return s, nil
+}
+
+// loadState decodes the named state document into v. A missing document
+// leaves v untouched.
+func loadState(name string, v any) error {
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+	return s.Load(name, v)
+}
+
+// saveState stores v as the named state document
+func saveState(name string, v any) error {
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+	return s.Save(name, v)
+}
+
+// lockState takes an exclusive lock on the named state file so the
+// scheduler daemon and CLI invocations do not overwrite each other's
+// changes. The returned function releases the lock.
+func lockState(name string) (func(), error) {
+	dir, err := getDataDir()
+	if err != nil {
+		return nil, err
+	}
+	return store.Lock(dir, name)
+}
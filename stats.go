@@ -0,0 +1,272 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	tweetsLookupEndpoint = "https://api.twitter.com/2/tweets"
+	userTweetsEndpoint   = "https://api.twitter.com/2/users/%s/tweets"
+	// statsFields asks for public_metrics, which unlike gotwi's type of it
+	// includes impressions
+	statsFields = "public_metrics,created_at"
+)
+
+// tweetStats is a tweet's public metrics as clix stats shows them
+type tweetStats struct {
+	ID          string    `json:"id"`
+	Text        string    `json:"text"`
+	CreatedAt   time.Time `json:"created_at"`
+	Impressions int       `json:"impressions"`
+	Likes       int       `json:"likes"`
+	Retweets    int       `json:"retweets"`
+	Replies     int       `json:"replies"`
+	Quotes      int       `json:"quotes"`
+	Bookmarks   int       `json:"bookmarks"`
+}
+
+// statsSummary is the --json output of clix stats
+type statsSummary struct {
+	Tweets []tweetStats `json:"tweets"`
+	Totals tweetStats   `json:"totals"`
+}
+
+type tweetStatsResponse struct {
+	Data []struct {
+		ID            string    `json:"id"`
+		Text          string    `json:"text"`
+		CreatedAt     time.Time `json:"created_at"`
+		PublicMetrics struct {
+			Impressions int `json:"impression_count"`
+			Likes       int `json:"like_count"`
+			Retweets    int `json:"retweet_count"`
+			Replies     int `json:"reply_count"`
+			Quotes      int `json:"quote_count"`
+			Bookmarks   int `json:"bookmark_count"`
+		} `json:"public_metrics"`
+	} `json:"data"`
+	Errors []struct {
+		Value  string `json:"value"`
+		Detail string `json:"detail"`
+	} `json:"errors"`
+	Meta struct {
+		NextToken string `json:"next_token"`
+	} `json:"meta"`
+}
+
+func (r *tweetStatsResponse) stats() []tweetStats {
+	stats := make([]tweetStats, 0, len(r.Data))
+	for _, tweet := range r.Data {
+		m := tweet.PublicMetrics
+		stats = append(stats, tweetStats{
+			ID: tweet.ID, Text: tweet.Text, CreatedAt: tweet.CreatedAt,
+			Impressions: m.Impressions, Likes: m.Likes, Retweets: m.Retweets,
+			Replies: m.Replies, Quotes: m.Quotes, Bookmarks: m.Bookmarks,
+		})
+	}
+	return stats
+}
+
+// tweetStatsByID looks up the metrics of up to 100 tweets
+func (a *app) tweetStatsByID(ctx context.Context, ids []string) ([]tweetStats, error) {
+	query := url.Values{"ids": {strings.Join(ids, ",")}, "tweet.fields": {statsFields}}
+	req, err := a.newSignedRequest(ctx, http.MethodGet, tweetsLookupEndpoint, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	var res tweetStatsResponse
+	if err := a.doJSON(req, &res); err != nil {
+		return nil, fmt.Errorf(tr("failed to fetch tweet metrics: %w"), err)
+	}
+	if len(res.Data) == 0 && len(res.Errors) > 0 {
+		return nil, fmt.Errorf(tr("failed to fetch tweet metrics: %s"), res.Errors[0].Detail)
+	}
+	for _, e := range res.Errors {
+		fmt.Fprintf(os.Stderr, tr("Warning: no metrics for %s: %s\n"), e.Value, e.Detail)
+	}
+	return res.stats(), nil
+}
+
+// ownTweetStats returns the metrics of up to count of your latest tweets,
+// leaving out retweets, posted after since when it is set
+func (a *app) ownTweetStats(ctx context.Context, since time.Time, count int) ([]tweetStats, error) {
+	userID, err := a.me(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var stats []tweetStats
+	token := ""
+	for len(stats) < count {
+		query := url.Values{
+			// The endpoint wants at least 5
+			"max_results":  {strconv.Itoa(min(max(count-len(stats), 5), 100))},
+			"exclude":      {"retweets"},
+			"tweet.fields": {statsFields},
+		}
+		if !since.IsZero() {
+			query.Set("start_time", since.UTC().Format(time.RFC3339))
+		}
+		if token != "" {
+			query.Set("pagination_token", token)
+		}
+		req, err := a.newSignedRequest(ctx, http.MethodGet, fmt.Sprintf(userTweetsEndpoint, url.PathEscape(userID)), query, nil)
+		if err != nil {
+			return nil, err
+		}
+		var res tweetStatsResponse
+		if err := a.doJSON(req, &res); err != nil {
+			return nil, fmt.Errorf(tr("failed to fetch your tweets: %w"), err)
+		}
+		stats = append(stats, res.stats()...)
+		if token = res.Meta.NextToken; token == "" {
+			break
+		}
+	}
+	if len(stats) > count {
+		stats = stats[:count]
+	}
+	return stats, nil
+}
+
+func summarizeStats(stats []tweetStats) statsSummary {
+	summary := statsSummary{Tweets: stats}
+	for _, s := range stats {
+		summary.Totals.Impressions += s.Impressions
+		summary.Totals.Likes += s.Likes
+		summary.Totals.Retweets += s.Retweets
+		summary.Totals.Replies += s.Replies
+		summary.Totals.Quotes += s.Quotes
+		summary.Totals.Bookmarks += s.Bookmarks
+	}
+	return summary
+}
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline draws values as a row of blocks scaled to the largest one
+func sparkline(values []int) string {
+	top := slices.Max(values)
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if top > 0 {
+			i = v * (len(sparkBlocks) - 1) / top
+		}
+		b.WriteRune(sparkBlocks[i])
+	}
+	return b.String()
+}
+
+func printStats(summary statsSummary) {
+	fmt.Printf("%-20s %-16s %11s %7s %5s %7s  %s\n", "ID", "POSTED", "IMPRESSIONS", "LIKES", "RTS", "REPLIES", "TEXT")
+	for _, s := range summary.Tweets {
+		fmt.Printf("%-20s %-16s %11d %7d %5d %7d  %s\n", s.ID, formatTime(s.CreatedAt),
+			s.Impressions, s.Likes, s.Retweets, s.Replies, truncateRunes(s.Text, 40))
+	}
+	if len(summary.Tweets) < 2 {
+		return
+	}
+	t := summary.Totals
+	fmt.Printf("%-37s %11d %7d %5d %7d\n", trf("Total (%d tweets)", len(summary.Tweets)), t.Impressions, t.Likes, t.Retweets, t.Replies)
+
+	// Oldest first, so the sparklines read left to right
+	tweets := slices.Clone(summary.Tweets)
+	slices.SortFunc(tweets, func(x, y tweetStats) int { return x.CreatedAt.Compare(y.CreatedAt) })
+	series := func(metric func(tweetStats) int) []int {
+		values := make([]int, len(tweets))
+		for i, s := range tweets {
+			values[i] = metric(s)
+		}
+		return values
+	}
+	fmt.Println()
+	fmt.Printf(tr("impressions %s\n"), sparkline(series(func(s tweetStats) int { return s.Impressions })))
+	fmt.Printf(tr("likes       %s\n"), sparkline(series(func(s tweetStats) int { return s.Likes })))
+	fmt.Printf(tr("retweets    %s\n"), sparkline(series(func(s tweetStats) int { return s.Retweets })))
+}
+
+func runStats(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "track":
+			return runStatsTrack(args[1:])
+		case "report":
+			return runStatsReport(args[1:])
+		}
+	}
+	fs := newFlagSet("stats", "stats <id|url>... | --last | --since 7d [--count n] | track | report")
+	last := fs.Bool("last", false, "show your latest tweet")
+	since := fs.String("since", "", "show your tweets posted after this date, time or duration ago, e.g. 7d")
+	count := fs.Int("count", 100, "with --since, the most tweets to show")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	modes := 0
+	for _, set := range []bool{len(args) > 0, *last, *since != ""} {
+		if set {
+			modes++
+		}
+	}
+	if modes != 1 {
+		fs.Usage()
+		return errUsage
+	}
+	if len(args) > 100 {
+		return errors.New(tr("at most 100 tweets at a time"))
+	}
+	ids := make([]string, 0, len(args))
+	for _, arg := range args {
+		id, err := parseTweetID(arg)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	var start time.Time
+	if *since != "" {
+		if start, err = parseHistoryTime(*since, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	ctx := rootCtx
+	var stats []tweetStats
+	switch {
+	case len(ids) > 0:
+		stats, err = a.tweetStatsByID(ctx, ids)
+	case *last:
+		stats, err = a.ownTweetStats(ctx, time.Time{}, 1)
+	default:
+		stats, err = a.ownTweetStats(ctx, start, *count)
+	}
+	if err != nil {
+		return err
+	}
+
+	summary := summarizeStats(stats)
+	if machineReadable() {
+		return printResult(summary)
+	}
+	if len(stats) == 0 {
+		fmt.Println(tr("No tweets."))
+		return nil
+	}
+	printStats(summary)
+	return nil
+}
@@ -0,0 +1,39 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// staleLockAge is how old a lock file must be before it is assumed to be
+// left over from a crashed process
+const staleLockAge = time.Minute
+
+// Lock takes an exclusive lock on the named document in dir so the
+// scheduler daemon and CLI invocations do not overwrite each other's
+// changes, whichever store is in use. The returned function releases the
+// lock.
+func Lock(dir, name string) (func(), error) {
+	path := filepath.Join(dir, name+".lock")
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to lock %s: %w", name, err)
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for lock on %s (remove %s if no other clix is running)", name, path)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
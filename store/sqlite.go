@@ -0,0 +1,253 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteFileName is the database of the sqlite store
+const SQLiteFileName = "clix.db"
+
+// sqliteMigrations bring the schema up to date, one version each. The
+// database's user_version records how many have been applied.
+var sqliteMigrations = []func(tx *sql.Tx, dir string) error{
+	// 1: the tables, filled from any flat files already there so switching
+	// loses nothing. The files are left in place for going back.
+	func(tx *sql.Tx, dir string) error {
+		_, err := tx.Exec(`
+			CREATE TABLE documents (
+				name       TEXT PRIMARY KEY,
+				data       TEXT NOT NULL,
+				updated_at TEXT NOT NULL
+			);
+			CREATE TABLE history (
+				seq        INTEGER PRIMARY KEY AUTOINCREMENT,
+				id         TEXT NOT NULL,
+				account    TEXT NOT NULL DEFAULT '',
+				text       TEXT NOT NULL,
+				posted_at  TEXT NOT NULL,
+				deleted_at TEXT,
+				links      TEXT
+			);
+			CREATE INDEX history_id ON history (id);
+			CREATE INDEX history_posted_at ON history (account, posted_at);
+		`)
+		if err != nil {
+			return err
+		}
+		return importFlatFiles(tx, dir)
+	},
+}
+
+// sqliteStore keeps the state in one SQLite database, which takes care of
+// concurrent access from the daemon and the CLI
+type sqliteStore struct {
+	db *sql.DB
+}
+
+// OpenSQLite opens the sqlite store in dir, creating the database and
+// copying in the files store's documents and history on first use
+func OpenSQLite(dir string) (Store, error) {
+	path := filepath.Join(dir, SQLiteFileName)
+	// WAL lets readers go on while another process writes; the busy
+	// timeout makes writers wait for each other instead of failing, and
+	// immediate transactions stop two first runs migrating at once
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)&_txlock=immediate")
+	if err != nil {
+		return nil, err
+	}
+	if err := migrateSQLite(db, dir); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &sqliteStore{db}, nil
+}
+
+func migrateSQLite(db *sql.DB, dir string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	var version int
+	if err := tx.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return err
+	}
+	if version > len(sqliteMigrations) {
+		return fmt.Errorf("%s is from a newer clix (schema version %d)", SQLiteFileName, version)
+	}
+	if version == len(sqliteMigrations) {
+		return nil
+	}
+	for i := version; i < len(sqliteMigrations); i++ {
+		if err := sqliteMigrations[i](tx, dir); err != nil {
+			return fmt.Errorf("failed to migrate %s to version %d: %w", SQLiteFileName, i+1, err)
+		}
+	}
+	// PRAGMA takes no parameters
+	if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", len(sqliteMigrations))); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// importFlatFiles copies the JSON state files and the history file of the
+// files store into the database
+func importFlatFiles(tx *sql.Tx, dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if !json.Valid(data) {
+			return fmt.Errorf("%s is not valid JSON", filepath.Base(path))
+		}
+		if _, err := tx.Exec("INSERT INTO documents (name, data, updated_at) VALUES (?, ?, ?)",
+			filepath.Base(path), string(data), time.Now().UTC().Format(time.RFC3339Nano)); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.Open(filepath.Join(dir, HistoryFileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	entries, err := readHistory(file)
+	if err != nil {
+		return err
+	}
+	return insertHistory(tx, entries)
+}
+
+func (s *sqliteStore) Load(name string, v any) error {
+	var data string
+	err := s.db.QueryRow("SELECT data FROM documents WHERE name = ?", name).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if err := json.Unmarshal([]byte(data), v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return nil
+}
+
+func (s *sqliteStore) Save(name string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`INSERT INTO documents (name, data, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT (name) DO UPDATE SET data = excluded.data, updated_at = excluded.updated_at`,
+		name, string(data), time.Now().UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+func (s *sqliteStore) AppendHistory(entry HistoryEntry) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := insertHistory(tx, []HistoryEntry{entry}); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return tx.Commit()
+}
+
+func insertHistory(tx *sql.Tx, entries []HistoryEntry) error {
+	stmt, err := tx.Prepare(`INSERT INTO history (id, account, text, posted_at, deleted_at, links) VALUES (?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, entry := range entries {
+		var deletedAt, links any
+		if entry.DeletedAt != nil {
+			deletedAt = entry.DeletedAt.UTC().Format(time.RFC3339Nano)
+		}
+		if len(entry.Links) > 0 {
+			data, err := json.Marshal(entry.Links)
+			if err != nil {
+				return err
+			}
+			links = string(data)
+		}
+		if _, err := stmt.Exec(entry.ID, entry.Account, entry.Text, entry.PostedAt.UTC().Format(time.RFC3339Nano), deletedAt, links); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *sqliteStore) LoadHistory() ([]HistoryEntry, error) {
+	rows, err := s.db.Query("SELECT id, account, text, posted_at, deleted_at, links FROM history ORDER BY seq")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	defer rows.Close()
+	var entries []HistoryEntry
+	for rows.Next() {
+		var entry HistoryEntry
+		var postedAt string
+		var deletedAt, links sql.NullString
+		if err := rows.Scan(&entry.ID, &entry.Account, &entry.Text, &postedAt, &deletedAt, &links); err != nil {
+			return nil, fmt.Errorf("failed to read history: %w", err)
+		}
+		if entry.PostedAt, err = time.Parse(time.RFC3339Nano, postedAt); err != nil {
+			return nil, fmt.Errorf("failed to read history: %w", err)
+		}
+		if deletedAt.Valid {
+			t, err := time.Parse(time.RFC3339Nano, deletedAt.String)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read history: %w", err)
+			}
+			entry.DeletedAt = &t
+		}
+		if links.Valid {
+			if err := json.Unmarshal([]byte(links.String), &entry.Links); err != nil {
+				return nil, fmt.Errorf("failed to read history: %w", err)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+func (s *sqliteStore) SaveHistory(entries []HistoryEntry) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec("DELETE FROM history"); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	if err := insertHistory(tx, entries); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,145 @@
+package store
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// openTestSQLite opens the sqlite store in dir, closing it when the test
+// ends
+func openTestSQLite(t *testing.T, dir string) (Store, error) {
+	t.Helper()
+	s, err := OpenSQLite(dir)
+	if err == nil {
+		t.Cleanup(func() { s.(*sqliteStore).db.Close() })
+	}
+	return s, err
+}
+
+func schemaVersion(t *testing.T, dir string) int {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(dir, SQLiteFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		t.Fatal(err)
+	}
+	return version
+}
+
+func writeFile(t *testing.T, dir, name, data string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSQLiteMigration(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       map[string]string
+		wantErr     string
+		wantDrafts  string
+		wantHistory int
+	}{
+		{"empty directory", nil, "", "", 0},
+		{"flat files copied in", map[string]string{
+			"drafts.json":   `{"d": "hello"}`,
+			HistoryFileName: `{"id": "1", "text": "one", "posted_at": "2024-01-01T00:00:00Z"}` + "\n\n" + `{"id": "2", "text": "two", "posted_at": "2024-01-02T00:00:00Z"}` + "\n",
+		}, "", "hello", 2},
+		{"broken document", map[string]string{"drafts.json": `{"d": `}, "not valid JSON", "", 0},
+		{"broken history", map[string]string{HistoryFileName: "{\n"}, "failed to parse history file", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, data := range tt.files {
+				writeFile(t, dir, name, data)
+			}
+			s, err := openTestSQLite(t, dir)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("OpenSQLite() = %v, want an error with %q", err, tt.wantErr)
+				}
+				// A failed migration leaves the database as it was, to try
+				// again once the file is fixed
+				if v := schemaVersion(t, dir); v != 0 {
+					t.Errorf("schema version %d after a failed migration, want 0", v)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v := schemaVersion(t, dir); v != len(sqliteMigrations) {
+				t.Errorf("schema version %d, want %d", v, len(sqliteMigrations))
+			}
+			var drafts map[string]string
+			if err := s.Load("drafts.json", &drafts); err != nil {
+				t.Fatal(err)
+			}
+			if drafts["d"] != tt.wantDrafts {
+				t.Errorf("drafts = %v, want d: %q", drafts, tt.wantDrafts)
+			}
+			history, err := s.LoadHistory()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(history) != tt.wantHistory {
+				t.Errorf("%d history entries, want %d", len(history), tt.wantHistory)
+			}
+		})
+	}
+}
+
+func TestSQLiteMigratesOnce(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "drafts.json", `{"d": "first"}`)
+	s, err := openTestSQLite(t, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AppendHistory(HistoryEntry{ID: "1", Text: "one", PostedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	s.(*sqliteStore).db.Close()
+
+	// The flat files are left in place, but not copied in again
+	writeFile(t, dir, "drafts.json", `{"d": "changed since"}`)
+	s, err = openTestSQLite(t, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var drafts map[string]string
+	if err := s.Load("drafts.json", &drafts); err != nil {
+		t.Fatal(err)
+	}
+	if drafts["d"] != "first" {
+		t.Errorf("drafts = %v after reopening, want those copied in the first time", drafts)
+	}
+	if history, err := s.LoadHistory(); err != nil || len(history) != 1 {
+		t.Errorf("LoadHistory() = %d entries, %v, want 1", len(history), err)
+	}
+}
+
+func TestSQLiteFromANewerClix(t *testing.T) {
+	dir := t.TempDir()
+	db, err := sql.Open("sqlite", filepath.Join(dir, SQLiteFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("PRAGMA user_version = 99"); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+	if _, err := openTestSQLite(t, dir); err == nil || !strings.Contains(err.Error(), "newer clix") {
+		t.Fatalf("OpenSQLite() = %v, want it to refuse a newer schema", err)
+	}
+}
@@ -0,0 +1,69 @@
+// Package store keeps clix's local state in a directory: named JSON
+// documents such as the drafts, queue and schedule, and the history of
+// posted tweets. Documents are kept in a SQLite database by default, or
+// in flat files.
+package store
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Store keeps the documents and history of one directory. The scheduler
+// daemon and CLI invocations coordinate their changes with Lock whichever
+// store is in use.
+type Store interface {
+	// Load decodes the named document into v, leaving v untouched when
+	// there is none
+	Load(name string, v any) error
+	Save(name string, v any) error
+
+	AppendHistory(entry HistoryEntry) error
+	// LoadHistory returns every recorded tweet, oldest first
+	LoadHistory() ([]HistoryEntry, error)
+	SaveHistory(entries []HistoryEntry) error
+}
+
+// HistoryEntry is one tweet posted through clix
+type HistoryEntry struct {
+	ID        string     `json:"id"`
+	Account   string     `json:"account,omitempty"`
+	Text      string     `json:"text"`
+	PostedAt  time.Time  `json:"posted_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Links are the links that were shortened before posting
+	Links []Link `json:"links,omitempty"`
+}
+
+// Link is a link that was shortened, as recorded in the history
+type Link struct {
+	Short string `json:"short"`
+	Long  string `json:"long"`
+	Code  string `json:"code,omitempty"`
+}
+
+// Backends open a store in a directory, by name
+var Backends = map[string]func(dir string) (Store, error){
+	"sqlite": OpenSQLite,
+	"files":  func(dir string) (Store, error) { return OpenFiles(dir), nil },
+}
+
+// DefaultBackend is SQLite, which copies in the files of the files store
+// on first use
+const DefaultBackend = "sqlite"
+
+// Open opens the store of the named backend in dir
+func Open(dir, backend string) (Store, error) {
+	open, ok := Backends[backend]
+	if !ok {
+		return nil, fmt.Errorf("unknown storage %q, expected %s", backend, strings.Join(slices.Sorted(maps.Keys(Backends)), " or "))
+	}
+	s, err := open(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s storage: %w", backend, err)
+	}
+	return s, nil
+}
@@ -0,0 +1,364 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/tweet/filteredstream"
+	"github.com/michimani/gotwi/tweet/filteredstream/types"
+)
+
+// streamStallTimeout is how long the stream may go quiet before it is
+// treated as dead; X sends a keep-alive newline every 20 seconds
+const streamStallTimeout = 30 * time.Second
+
+// streamRuleTag marks the rules clix stream --rule adds for the duration
+// of one run
+const streamRuleTag = "clix-stream"
+
+// streamRule is a filtered stream rule as the rules commands show it
+type streamRule struct {
+	ID    string `json:"id"`
+	Value string `json:"value"`
+	Tag   string `json:"tag,omitempty"`
+}
+
+// appClient returns a client authenticated as the app rather than the
+// user, which the filtered stream requires
+func (a *app) appClient() (*gotwi.Client, error) {
+	if a.creds.ConsumerKey == "" || a.creds.ConsumerSecret == "" {
+		return nil, errors.New(tr("the filtered stream needs the app's consumer key and secret (see 'clix config set')"))
+	}
+	client := newStreamHTTPClient()
+	token, err := gotwi.GenerateBearerToken(&gotwi.Client{Client: client}, a.creds.ConsumerKey, a.creds.ConsumerSecret)
+	if err != nil {
+		return nil, fmt.Errorf(tr("failed to get an app token: %w"), err)
+	}
+	return gotwi.NewClientWithAccessToken(&gotwi.NewClientWithAccessTokenInput{HTTPClient: client, AccessToken: token})
+}
+
+func listStreamRules(ctx context.Context, c *gotwi.Client) ([]streamRule, error) {
+	res, err := filteredstream.ListRules(ctx, c, &types.ListRulesInput{})
+	if err != nil {
+		return nil, fmt.Errorf(tr("failed to list stream rules: %w"), err)
+	}
+	rules := make([]streamRule, 0, len(res.Data))
+	for _, rule := range res.Data {
+		rules = append(rules, streamRule{
+			ID:    gotwi.StringValue(rule.ID),
+			Value: gotwi.StringValue(rule.Value),
+			Tag:   gotwi.StringValue(rule.Tag),
+		})
+	}
+	return rules, nil
+}
+
+func addStreamRules(ctx context.Context, c *gotwi.Client, values []string, tag string) ([]streamRule, error) {
+	input := &types.CreateRulesInput{}
+	for _, value := range values {
+		rule := types.AddingRule{Value: gotwi.String(value)}
+		if tag != "" {
+			rule.Tag = gotwi.String(tag)
+		}
+		input.Add = append(input.Add, rule)
+	}
+	res, err := filteredstream.CreateRules(ctx, c, input)
+	if err != nil {
+		return nil, fmt.Errorf(tr("failed to add stream rules: %w"), err)
+	}
+	if res.HasPartialError() {
+		return nil, fmt.Errorf(tr("invalid stream rule: %s"), partialErrors(res.Errors))
+	}
+	rules := make([]streamRule, 0, len(res.Data))
+	for _, rule := range res.Data {
+		rules = append(rules, streamRule{ID: gotwi.StringValue(rule.ID), Value: gotwi.StringValue(rule.Value), Tag: tag})
+	}
+	return rules, nil
+}
+
+func deleteStreamRules(ctx context.Context, c *gotwi.Client, ids []string) error {
+	res, err := filteredstream.DeleteRules(ctx, c, &types.DeleteRulesInput{Delete: &types.DeletingRules{IDs: ids}})
+	if err != nil {
+		return fmt.Errorf(tr("failed to delete stream rules: %w"), err)
+	}
+	if res.HasPartialError() {
+		return fmt.Errorf(tr("failed to delete stream rules: %s"), partialErrors(res.Errors))
+	}
+	return nil
+}
+
+func partialErrors(errs []resources.PartialError) string {
+	var messages []string
+	for _, e := range errs {
+		message := gotwi.StringValue(e.Title)
+		if detail := gotwi.StringValue(e.Detail); detail != "" {
+			message += ": " + detail
+		}
+		if value := gotwi.StringValue(e.Value); value != "" {
+			message += fmt.Sprintf(" (%s)", value)
+		}
+		messages = append(messages, message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// readStream prints tweets from one connection to the stream until it
+// ends, the context is cancelled or limit tweets have been printed. When
+// ruleIDs is not empty only tweets matching one of them are printed.
+func readStream(ctx context.Context, c *gotwi.Client, ruleIDs []string, printed *int, limit int) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s, err := filteredstream.SearchStream(ctx, c, &types.SearchStreamInput{
+		Expansions:  tweetViewExpand,
+		TweetFields: tweetViewFields,
+		UserFields:  tweetViewUserFld,
+	})
+	if err != nil {
+		return err
+	}
+	defer s.Stop()
+	verbosef("connected to the filtered stream")
+
+	// Closing the connection makes Receive return when it stalls
+	stall := time.AfterFunc(streamStallTimeout, cancel)
+	defer stall.Stop()
+	for s.Receive() {
+		stall.Reset(streamStallTimeout)
+		res, err := s.Read()
+		if err != nil || res == nil || res.Data.ID == nil {
+			continue // keep-alive or a line that is not a tweet
+		}
+		if len(ruleIDs) > 0 && !slices.ContainsFunc(res.MatchingRules, func(rule types.SearchStreamMatchedRule) bool {
+			return slices.Contains(ruleIDs, gotwi.StringValue(rule.ID))
+		}) {
+			continue
+		}
+
+		for _, view := range newTweetViews([]resources.Tweet{res.Data}, res.Includes.Users) {
+			if machineReadable() {
+				printResult(view)
+			} else {
+				printTweet(os.Stdout, view)
+			}
+		}
+		if *printed++; limit > 0 && *printed >= limit {
+			return nil
+		}
+	}
+	return errors.New(tr("stream disconnected"))
+}
+
+// streamClient sets up the app and its app-only client for the stream
+// commands
+func streamClient() (*app, *gotwi.Client, error) {
+	a, err := setup(false)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := a.appClient()
+	if err != nil {
+		a.close()
+		return nil, nil, err
+	}
+	return a, c, nil
+}
+
+func runStream(args []string) error {
+	if len(args) > 0 && args[0] == "rules" {
+		return runStreamRules(args[1:])
+	}
+	fs := newFlagSet("stream", `stream [--rule "<query>"]... [--count n]  (or: stream rules list|add|delete)`)
+	var rules stringList
+	fs.Var(&rules, "rule", "a filter rule, for this run only (repeatable); without it the saved rules are used")
+	count := fs.Int("count", 0, "stop after this many tweets")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	a, c, err := streamClient()
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	ctx := rootCtx
+
+	var ruleIDs []string
+	if len(rules) > 0 {
+		added, err := addStreamRules(ctx, c, rules, streamRuleTag)
+		if err != nil {
+			return err
+		}
+		for _, rule := range added {
+			ruleIDs = append(ruleIDs, rule.ID)
+		}
+		// The rules are removed even after Ctrl-C, so a fresh context is used
+		defer func() {
+			if err := deleteStreamRules(context.Background(), c, ruleIDs); err != nil {
+				fmt.Fprintln(os.Stderr, tr("Warning:"), err)
+			}
+		}()
+	} else {
+		saved, err := listStreamRules(ctx, c)
+		if err != nil {
+			return err
+		}
+		if len(saved) == 0 {
+			return errors.New(tr("no stream rules; pass --rule or add one with 'clix stream rules add'"))
+		}
+	}
+
+	if !machineReadable() {
+		fmt.Fprintln(os.Stderr, tr("Streaming; press Ctrl-C to stop."))
+	}
+	printed := 0
+	for attempt := 0; ; attempt++ {
+		started := time.Now()
+		err := readStream(ctx, c, ruleIDs, &printed, *count)
+		if ctx.Err() != nil || err == nil {
+			return nil
+		}
+		// A connection that lasted a while resets the backoff
+		if time.Since(started) > time.Minute {
+			attempt = 0
+		}
+		delay := backoff(attempt)
+		fmt.Fprintf(os.Stderr, tr("Stream error: %v; reconnecting in %s\n"), err, delay.Round(time.Second))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+	}
+}
+
+func runStreamRules(args []string) error {
+	if len(args) > 0 && isHelpArg(args[0]) {
+		fmt.Fprintln(os.Stderr, tr(`Usage: clix stream rules [list|add [--tag t] "<query>"...|delete <id>...|delete --all]`))
+		return nil
+	}
+	action := "list"
+	if len(args) > 0 && !isFlagArg(args[0]) {
+		action, args = args[0], args[1:]
+	}
+
+	switch action {
+	case "list":
+		return runStreamRulesList(args)
+	case "add":
+		return runStreamRulesAdd(args)
+	case "delete":
+		return runStreamRulesDelete(args)
+	default:
+		return withExitCode(exitUsage, fmt.Errorf(tr("unknown stream rules action %q"), action))
+	}
+}
+
+func runStreamRulesList(args []string) error {
+	fs := newFlagSet("stream rules list", "stream rules list")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	a, c, err := streamClient()
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	saved, err := listStreamRules(rootCtx, c)
+	if err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(saved)
+	}
+	if len(saved) == 0 {
+		fmt.Println(tr("No stream rules."))
+		return nil
+	}
+	for _, rule := range saved {
+		line := fmt.Sprintf("%-20s %s", rule.ID, rule.Value)
+		if rule.Tag != "" {
+			line += "  [" + rule.Tag + "]"
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func runStreamRulesAdd(args []string) error {
+	fs := newFlagSet("stream rules add", `stream rules add [--tag t] "<query>"...`)
+	tag := fs.String("tag", "", "a label for the rules, shown by rules list")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+	a, c, err := streamClient()
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	added, err := addStreamRules(rootCtx, c, args, *tag)
+	if err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(added)
+	}
+	for _, rule := range added {
+		fmt.Printf(tr("Added rule %s: %s\n"), rule.ID, rule.Value)
+	}
+	return nil
+}
+
+func runStreamRulesDelete(args []string) error {
+	fs := newFlagSet("stream rules delete", "stream rules delete <id>... | --all")
+	all := fs.Bool("all", false, "delete every rule")
+	ids, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if *all == (len(ids) > 0) {
+		fs.Usage()
+		return errUsage
+	}
+	a, c, err := streamClient()
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	ctx := rootCtx
+	if *all {
+		saved, err := listStreamRules(ctx, c)
+		if err != nil {
+			return err
+		}
+		for _, rule := range saved {
+			ids = append(ids, rule.ID)
+		}
+		if len(ids) == 0 {
+			fmt.Println(tr("No stream rules."))
+			return nil
+		}
+	}
+	if err := deleteStreamRules(ctx, c, ids); err != nil {
+		return err
+	}
+	if !machineReadable() {
+		fmt.Printf(tr("Deleted %d rule(s).\n"), len(ids))
+	}
+	return nil
+}
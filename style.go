@@ -0,0 +1,201 @@
+package clix
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/voltycodes/clix/compose"
+)
+
+// minShoutWords is how many words in capitals in a row no_all_caps takes
+// for shouting, so acronyms like "the API and SDK" pass
+const minShoutWords = 3
+
+// StyleRules are the house style posts are held to. Unlike lint and the
+// accessibility checks they refuse to post, unless --force is given.
+type StyleRules struct {
+	// BannedWords are words and phrases a post may not have, in any case
+	BannedWords []string `json:"banned_words,omitempty"`
+	// RequiredHashtags are the hashtags, by template name, that posts from
+	// the template must carry, e.g. {"sponsored": ["#ad"]}
+	RequiredHashtags map[string][]string `json:"required_hashtags,omitempty"`
+	// MaxHashtags is the most hashtags a tweet may have, 0 for any number
+	MaxHashtags int `json:"max_hashtags,omitempty"`
+	// NoAllCaps refuses tweets with three or more words in capitals in a row
+	NoAllCaps *bool `json:"no_all_caps,omitempty"`
+}
+
+// StyleConfig is the "style" section of the config, with, by account name,
+// the rules an account adds
+type StyleConfig struct {
+	StyleRules
+	Accounts map[string]StyleRules `json:"accounts,omitempty"`
+}
+
+// over returns s with defaults' banned words and required hashtags added,
+// and the settings it leaves unset taken from defaults
+func (s StyleRules) over(defaults StyleRules) StyleRules {
+	s.BannedWords = slices.Concat(defaults.BannedWords, s.BannedWords)
+	required := maps.Clone(defaults.RequiredHashtags)
+	for name, tags := range s.RequiredHashtags {
+		if required == nil {
+			required = map[string][]string{}
+		}
+		required[name] = slices.Concat(required[name], tags)
+	}
+	s.RequiredHashtags = required
+	s.MaxHashtags = cmp.Or(s.MaxHashtags, defaults.MaxHashtags)
+	s.NoAllCaps = cmp.Or(s.NoAllCaps, defaults.NoAllCaps)
+	return s
+}
+
+// styleRules returns the active account's style rules added to those for
+// every account
+func (c *Config) styleRules() StyleRules {
+	var s StyleRules
+	if c.Style != nil {
+		s = c.Style.Accounts[c.Active].over(c.Style.StyleRules)
+	}
+	return s
+}
+
+// checkStyle prints how the parts about to be posted break the style rules
+// and refuses to go on if they do, unless force is set. template is the
+// --template the text came from, if any; offset numbers the parts as
+// checkLint does.
+func checkStyle(config *Config, parts []string, template string, offset int, force bool) error {
+	if force {
+		return nil
+	}
+	s := config.styleRules()
+	var violations []lintWarning
+	for i, part := range parts {
+		for _, message := range s.check(part) {
+			violations = append(violations, lintWarning{i, message})
+		}
+	}
+	if missing := s.missingHashtags(template, parts); len(missing) > 0 {
+		violations = append(violations, lintWarning{0, trf("posts from the %q template need %s", template, strings.Join(missing, " "))})
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	for _, v := range violations {
+		if len(parts) > 1 || offset > 0 {
+			fmt.Fprintf(os.Stderr, tr("Style: part %d: %s\n"), offset+v.part+1, v.message)
+		} else {
+			fmt.Fprintf(os.Stderr, tr("Style: %s\n"), v.message)
+		}
+	}
+	return withExitCode(exitRefused, errors.New(tr("refusing to post against the style rules; fix the text or pass --force")))
+}
+
+// check returns how a tweet's text breaks the rules
+func (s StyleRules) check(text string) []string {
+	var violations []string
+	for _, word := range s.BannedWords {
+		if word = strings.TrimSpace(word); word != "" && bannedWordPattern(word).MatchString(text) {
+			violations = append(violations, trf("%q is a banned word", word))
+		}
+	}
+	if s.MaxHashtags > 0 {
+		if n := len(hashtags(text)); n > s.MaxHashtags {
+			violations = append(violations, trf("%d hashtags, more than the %d allowed", n, s.MaxHashtags))
+		}
+	}
+	if s.NoAllCaps != nil && *s.NoAllCaps {
+		if shout := shoutedWords(text); shout != "" {
+			violations = append(violations, trf("%q is in ALL CAPS", shout))
+		}
+	}
+	return violations
+}
+
+// missingHashtags returns the hashtags required for template that none of
+// parts has
+func (s StyleRules) missingHashtags(template string, parts []string) []string {
+	if template == "" {
+		return nil
+	}
+	var have []string
+	for _, part := range parts {
+		have = append(have, hashtags(part)...)
+	}
+	var missing []string
+	for _, tag := range s.RequiredHashtags[template] {
+		tag = "#" + strings.TrimPrefix(strings.TrimSpace(tag), "#")
+		if !slices.ContainsFunc(have, func(h string) bool { return strings.EqualFold(h, tag) }) {
+			missing = append(missing, tag)
+		}
+	}
+	return missing
+}
+
+// bannedWordPattern matches word on its own, so "ass" does not catch
+// "class"
+func bannedWordPattern(word string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)(?:^|[^\p{L}\p{N}_])` + regexp.QuoteMeta(word) + `(?:$|[^\p{L}\p{N}_])`)
+}
+
+// hashtags returns text's hashtags, leaving out cashtags
+func hashtags(text string) []string {
+	var tags []string
+	for _, tag := range hashtagPattern.FindAllString(text, -1) {
+		if strings.HasPrefix(tag, "#") {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// shoutedWords returns the first run of minShoutWords or more words in
+// capitals. Links, mentions, hashtags and words without two letters, like
+// "I" or "2024", neither make nor break a run.
+func shoutedWords(text string) string {
+	var plain strings.Builder
+	for _, span := range compose.SplitURLs(text) {
+		if !span.URL {
+			plain.WriteString(span.Text)
+		}
+		plain.WriteString(" ")
+	}
+	var run []string
+	for _, word := range strings.Fields(plain.String()) {
+		if strings.ContainsAny(word[:1], "@#$") {
+			continue
+		}
+		letters, upper := 0, 0
+		for _, r := range word {
+			if unicode.IsLetter(r) {
+				letters++
+			}
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+		if letters < 2 {
+			continue
+		}
+		if upper == letters {
+			run = append(run, word)
+		}
+		// A word in small letters or punctuation after one ends the run
+		if upper < letters || strings.ContainsAny(word[len(word)-1:], ",.;:!?") {
+			if len(run) >= minShoutWords {
+				break
+			}
+			run = nil
+		}
+	}
+	if len(run) < minShoutWords {
+		return ""
+	}
+	return strings.TrimRight(strings.Join(run, " "), ",.;:!?")
+}
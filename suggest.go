@@ -0,0 +1,212 @@
+package clix
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/michimani/gotwi/tweet/searchtweet/types"
+	"github.com/michimani/gotwi/tweet/tweetcount"
+	counttypes "github.com/michimani/gotwi/tweet/tweetcount/types"
+)
+
+// suggestSample is how many recent tweets about the draft are searched
+// for tags
+const suggestSample = 100
+
+// maxSuggestKeywords bounds the words of the draft the search looks for
+const maxSuggestKeywords = 5
+
+// suggestStopWords are common words that say nothing about what a draft
+// is about
+var suggestStopWords = map[string]bool{
+	"about": true, "after": true, "again": true, "also": true, "been": true, "before": true,
+	"being": true, "could": true, "does": true, "doing": true, "from": true, "have": true,
+	"here": true, "into": true, "just": true, "like": true, "made": true, "make": true,
+	"more": true, "most": true, "much": true, "only": true, "other": true, "over": true,
+	"really": true, "should": true, "some": true, "than": true, "that": true, "their": true,
+	"them": true, "then": true, "there": true, "these": true, "they": true, "this": true,
+	"today": true, "very": true, "want": true, "were": true, "what": true, "when": true,
+	"where": true, "which": true, "while": true, "will": true, "with": true, "would": true,
+	"your": true,
+}
+
+// tagSuggestion is a hashtag or cashtag used in recent tweets like the
+// draft
+type tagSuggestion struct {
+	Tag string `json:"tag"`
+	// Tweets is how many of the tweets searched used it, and Engagement
+	// their likes and retweets
+	Tweets     int `json:"tweets"`
+	Engagement int `json:"engagement"`
+	// Volume is the number of tweets with it in the last 7 days, the
+	// reach it is estimated by; nil when the API would not count them
+	Volume *int `json:"volume,omitempty"`
+}
+
+// draftKeywords picks the words of text to search for: the longest ones
+// that are not links, mentions, tags or common words
+func draftKeywords(text string) []string {
+	var words []string
+	for _, word := range strings.Fields(text) {
+		if strings.Contains(word, "://") || strings.ContainsAny(word[:1], "@#$") {
+			continue
+		}
+		word = strings.ToLower(strings.TrimFunc(word, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) }))
+		if utf8.RuneCountInString(word) < 4 || suggestStopWords[word] || slices.Contains(words, word) {
+			continue
+		}
+		words = append(words, word)
+	}
+	slices.SortStableFunc(words, func(a, b string) int { return utf8.RuneCountInString(b) - utf8.RuneCountInString(a) })
+	return words[:min(len(words), maxSuggestKeywords)]
+}
+
+// isTag reports whether a match of hashtagPattern is a hashtag or a
+// cashtag, which starts with a letter unlike $5
+func isTag(tag string) bool {
+	if tag[0] == '#' {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(tag[1:])
+	return unicode.IsLetter(r)
+}
+
+// suggestTags searches recent tweets with the draft's keywords and returns
+// up to count tags they use that the draft does not, most used first
+func (a *app) suggestTags(ctx context.Context, text, lang string, count int) ([]tagSuggestion, error) {
+	keywords := draftKeywords(text)
+	if len(keywords) == 0 {
+		return nil, withExitCode(exitValidation, errors.New(tr("the draft has no words to search for")))
+	}
+	query := buildQuery("("+strings.Join(keywords, " OR ")+") -is:retweet", lang, "")
+	views, err := a.searchRecent(ctx, &types.ListRecentInput{Query: query}, suggestSample)
+	if err != nil {
+		return nil, err
+	}
+
+	have := map[string]bool{}
+	for _, tag := range hashtagPattern.FindAllString(text, -1) {
+		have[strings.ToLower(tag)] = true
+	}
+	byTag := map[string]*tagSuggestion{}
+	for _, view := range views {
+		seen := map[string]bool{}
+		for _, tag := range hashtagPattern.FindAllString(view.Text, -1) {
+			key := strings.ToLower(tag)
+			if !isTag(tag) || have[key] || seen[key] {
+				continue
+			}
+			seen[key] = true
+			s := byTag[key]
+			if s == nil {
+				s = &tagSuggestion{Tag: tag}
+				byTag[key] = s
+			}
+			s.Tweets++
+			s.Engagement += view.Likes + view.Retweets
+		}
+	}
+	suggestions := make([]tagSuggestion, 0, len(byTag))
+	for _, s := range byTag {
+		suggestions = append(suggestions, *s)
+	}
+	slices.SortFunc(suggestions, func(a, b tagSuggestion) int {
+		return cmp.Or(b.Tweets-a.Tweets, b.Engagement-a.Engagement, strings.Compare(a.Tag, b.Tag))
+	})
+	suggestions = suggestions[:min(len(suggestions), count)]
+
+	for i := range suggestions {
+		volume, err := a.tweetVolume(ctx, suggestions[i].Tag, lang)
+		if err != nil {
+			// Counting needs a higher access level than searching
+			fmt.Fprintln(os.Stderr, tr("Warning: could not count tweets for the reach:"), err)
+			break
+		}
+		suggestions[i].Volume = &volume
+	}
+	return suggestions, nil
+}
+
+// tweetVolume returns the number of tweets with tag in the last 7 days
+func (a *app) tweetVolume(ctx context.Context, tag, lang string) (int, error) {
+	if err := a.ensureToken(ctx); err != nil {
+		return 0, err
+	}
+	res, err := tweetcount.ListRecent(ctx, a.client, &counttypes.ListRecentInput{
+		Query:       buildQuery(tag, lang, ""),
+		Granularity: counttypes.TweetCountsGranularityDay,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if res.Meta.TotalTweetCount != nil {
+		return *res.Meta.TotalTweetCount, nil
+	}
+	total := 0
+	for _, c := range res.Data {
+		if c.TweetCount != nil {
+			total += *c.TweetCount
+		}
+	}
+	return total, nil
+}
+
+// printSuggestions lists the suggestions with their reach
+func printSuggestions(suggestions []tagSuggestion) {
+	if len(suggestions) == 0 {
+		fmt.Println(tr("No tags found in recent tweets like this one."))
+		return
+	}
+	fmt.Printf("%-24s %12s %10s %11s\n", "TAG", "7-DAY VOLUME", "USED IN", "ENGAGEMENT")
+	for _, s := range suggestions {
+		volume := "-"
+		if s.Volume != nil {
+			volume = fmt.Sprint(*s.Volume)
+		}
+		fmt.Printf("%-24s %12s %10d %11d\n", s.Tag, volume, s.Tweets, s.Engagement)
+	}
+}
+
+func runSuggest(args []string) error {
+	fs := newFlagSet("suggest", `suggest [flags] "<draft text>"  (reads stdin when no text is given)`)
+	count := fs.Int("count", 5, "maximum number of tags to suggest")
+	lang := fs.String("lang", "", "only look at tweets in this language (e.g. en)")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	text, err := readText(args)
+	if err != nil {
+		return err
+	}
+	if text == "" {
+		fs.Usage()
+		return errUsage
+	}
+	if *count < 1 {
+		return withExitCode(exitUsage, errors.New(tr("--count must be at least 1")))
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	suggestions, err := a.suggestTags(rootCtx, text, *lang, *count)
+	if err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(suggestions)
+	}
+	printSuggestions(suggestions)
+	return nil
+}
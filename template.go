@@ -0,0 +1,288 @@
+package clix
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+)
+
+const templatesStateFile = "templates.json"
+
+// tweetTemplate is a tweet with {{.name}} placeholders, filled in with
+// --var when posted
+type tweetTemplate struct {
+	Name      string    `json:"name"`
+	Text      string    `json:"text"`
+	Vars      []string  `json:"vars"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func loadTemplates() (map[string]*tweetTemplate, error) {
+	templates := map[string]*tweetTemplate{}
+	if err := loadState(templatesStateFile, &templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// parseTweetTemplate parses text and returns it with the variables it uses
+func parseTweetTemplate(name, text string) (*template.Template, []string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, nil, fmt.Errorf(tr("invalid template: %w"), err)
+	}
+	var vars []string
+	if tmpl.Tree != nil {
+		collectTemplateVars(tmpl.Tree.Root, &vars)
+	}
+	sort.Strings(vars)
+	return tmpl, slices.Compact(vars), nil
+}
+
+// collectTemplateVars appends the top-level fields, like .version, that
+// are referenced under node
+func collectTemplateVars(node parse.Node, vars *[]string) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectTemplateVars(child, vars)
+		}
+	case *parse.ActionNode:
+		collectTemplateVars(n.Pipe, vars)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectTemplateVars(cmd, vars)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectTemplateVars(arg, vars)
+		}
+	case *parse.FieldNode:
+		*vars = append(*vars, n.Ident[0])
+	case *parse.IfNode:
+		collectTemplateVars(n.Pipe, vars)
+		collectTemplateVars(n.List, vars)
+		collectTemplateVars(n.ElseList, vars)
+	case *parse.RangeNode:
+		collectTemplateVars(n.Pipe, vars)
+		collectTemplateVars(n.List, vars)
+		collectTemplateVars(n.ElseList, vars)
+	case *parse.WithNode:
+		collectTemplateVars(n.Pipe, vars)
+		collectTemplateVars(n.List, vars)
+		collectTemplateVars(n.ElseList, vars)
+	}
+}
+
+// parseVars turns --var key=value flags into a map
+func parseVars(flags []string) (map[string]string, error) {
+	vars := make(map[string]string, len(flags))
+	for _, flag := range flags {
+		key, value, ok := strings.Cut(flag, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf(tr("invalid --var %q, expected key=value"), flag)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+// renderTemplate fills in the named template, failing before anything is
+// posted if a variable it uses was not given
+func renderTemplate(name string, vars map[string]string) (string, error) {
+	templates, err := loadTemplates()
+	if err != nil {
+		return "", err
+	}
+	saved, ok := templates[name]
+	if !ok {
+		return "", fmt.Errorf(tr("no template named %q (see 'clix template list')"), name)
+	}
+	tmpl, used, err := parseTweetTemplate(name, saved.Text)
+	if err != nil {
+		return "", err
+	}
+
+	var missing []string
+	for _, v := range used {
+		if _, ok := vars[v]; !ok {
+			missing = append(missing, v)
+		}
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf(tr("template %q needs --var for %s"), name, strings.Join(missing, ", "))
+	}
+	for key := range vars {
+		if !slices.Contains(used, key) {
+			fmt.Fprintf(os.Stderr, tr("Warning: template %q does not use --var %s\n"), name, key)
+		}
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, vars); err != nil {
+		return "", fmt.Errorf(tr("failed to fill in template: %w"), err)
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+func runTemplate(args []string) error {
+	if len(args) > 0 && isHelpArg(args[0]) {
+		fmt.Fprintln(os.Stderr, tr("Usage: clix template [list|save <name> <text>|show <name>|delete <name>...]"))
+		return nil
+	}
+	action := "list"
+	if len(args) > 0 && !isFlagArg(args[0]) {
+		action, args = args[0], args[1:]
+	}
+
+	switch action {
+	case "list":
+		return runTemplateList(args)
+	case "save":
+		return runTemplateSave(args)
+	case "show":
+		return runTemplateShow(args)
+	case "delete":
+		return runTemplateDelete(args)
+	default:
+		return withExitCode(exitUsage, fmt.Errorf(tr("unknown template action %q"), action))
+	}
+}
+
+func runTemplateSave(args []string) error {
+	fs := newFlagSet("template save", "template save <name> [text]  (reads stdin when no text is given)")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+	name := args[0]
+	text, err := readText(args[1:])
+	if err != nil {
+		return err
+	}
+	if text == "" {
+		return errors.New(tr("empty template"))
+	}
+	_, vars, err := parseTweetTemplate(name, text)
+	if err != nil {
+		return err
+	}
+
+	templates, err := loadTemplates()
+	if err != nil {
+		return err
+	}
+	saved := &tweetTemplate{Name: name, Text: text, Vars: vars, UpdatedAt: time.Now()}
+	templates[name] = saved
+	if err := saveState(templatesStateFile, templates); err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(saved)
+	}
+	if len(vars) > 0 {
+		fmt.Printf(tr("Template %q saved, with variables %s.\n"), name, strings.Join(vars, ", "))
+	} else {
+		fmt.Printf(tr("Template %q saved.\n"), name)
+	}
+	return nil
+}
+
+func runTemplateList(args []string) error {
+	fs := newFlagSet("template list", "template list")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	templates, err := loadTemplates()
+	if err != nil {
+		return err
+	}
+	list := make([]*tweetTemplate, 0, len(templates))
+	for _, t := range templates {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	if machineReadable() {
+		return printResult(list)
+	}
+	if len(list) == 0 {
+		fmt.Println(tr("No templates."))
+		return nil
+	}
+	for _, t := range list {
+		line, _, _ := strings.Cut(t.Text, "\n")
+		fmt.Printf("%-12s %s\n", t.Name, line)
+	}
+	return nil
+}
+
+func runTemplateShow(args []string) error {
+	fs := newFlagSet("template show", "template show <name>")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	templates, err := loadTemplates()
+	if err != nil {
+		return err
+	}
+	t, ok := templates[args[0]]
+	if !ok {
+		return fmt.Errorf(tr("no template named %q"), args[0])
+	}
+	if machineReadable() {
+		return printResult(t)
+	}
+	fmt.Println(t.Text)
+	if len(t.Vars) > 0 {
+		fmt.Println(tr("\nVariables:"), strings.Join(t.Vars, ", "))
+	}
+	return nil
+}
+
+func runTemplateDelete(args []string) error {
+	fs := newFlagSet("template delete", "template delete <name>...")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	templates, err := loadTemplates()
+	if err != nil {
+		return err
+	}
+	for _, name := range args {
+		if _, ok := templates[name]; !ok {
+			return fmt.Errorf(tr("no template named %q"), name)
+		}
+		delete(templates, name)
+	}
+	return saveState(templatesStateFile, templates)
+}
@@ -0,0 +1,117 @@
+package clix
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
+)
+
+// defaultTheme suits the dark background most terminals have
+const defaultTheme = "dark"
+
+// ThemeConfig is the "theme" section of the config: a built-in theme and
+// the colors that differ from it. A color is an ANSI number from 0 to 255
+// or a hex code like "#1d9bf0".
+type ThemeConfig struct {
+	// Name is dark or light, for the terminal's background
+	Name    string `json:"name,omitempty"`
+	Author  string `json:"author,omitempty"`
+	Metrics string `json:"metrics,omitempty"`
+	Time    string `json:"time,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+var builtinThemes = map[string]ThemeConfig{
+	"dark":  {Author: "75", Metrics: "245", Time: "245", Error: "203"},
+	"light": {Author: "25", Metrics: "242", Time: "242", Error: "124"},
+}
+
+// themeConfig is the section of the config file last read
+var themeConfig *ThemeConfig
+
+var hexColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// resolve fills in the colors c leaves out from its built-in theme,
+// reporting an unknown theme or a color that is not one
+func (c *ThemeConfig) resolve() (ThemeConfig, error) {
+	var t ThemeConfig
+	if c != nil {
+		t = *c
+	}
+	base, ok := builtinThemes[cmp.Or(t.Name, defaultTheme)]
+	if !ok {
+		return ThemeConfig{}, fmt.Errorf(tr("unknown theme %q, use one of %s"), t.Name, strings.Join(slices.Sorted(maps.Keys(builtinThemes)), ", "))
+	}
+	for _, color := range []struct {
+		name  string
+		value *string
+		def   string
+	}{
+		{"author", &t.Author, base.Author},
+		{"metrics", &t.Metrics, base.Metrics},
+		{"time", &t.Time, base.Time},
+		{"error", &t.Error, base.Error},
+	} {
+		if *color.value == "" {
+			*color.value = color.def
+			continue
+		}
+		if n, err := strconv.Atoi(*color.value); (err != nil || n < 0 || n > 255) && !hexColor.MatchString(*color.value) {
+			return ThemeConfig{}, fmt.Errorf(tr("%s: %q is not a color, expected 0 to 255 or #rrggbb"), color.name, *color.value)
+		}
+	}
+	return t, nil
+}
+
+// colorsEnabled reports whether w gets colors: it is a terminal, and
+// neither --no-color nor $NO_COLOR is set
+func colorsEnabled(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok || globalOptions.noColor || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// palette paints the parts of read commands' output in the theme's
+// colors. The zero palette leaves text as it is.
+type palette struct {
+	author, metrics, time, err *lipgloss.Style
+}
+
+// colorsFor returns the palette for output to w, which is plain when w
+// gets no colors. A broken theme falls back to the default one; clix
+// doctor reports it.
+func colorsFor(w io.Writer) palette {
+	if !colorsEnabled(w) {
+		return palette{}
+	}
+	t, err := themeConfig.resolve()
+	if err != nil {
+		t, _ = (*ThemeConfig)(nil).resolve()
+	}
+	r := lipgloss.NewRenderer(w)
+	style := func(color string) *lipgloss.Style {
+		s := r.NewStyle().Foreground(lipgloss.Color(color))
+		return &s
+	}
+	p := palette{author: style(t.Author), metrics: style(t.Metrics), time: style(t.Time), err: style(t.Error)}
+	*p.author = p.author.Bold(true)
+	return p
+}
+
+func paint(style *lipgloss.Style, text string) string {
+	if style == nil || text == "" {
+		return text
+	}
+	return style.Render(text)
+}
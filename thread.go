@@ -0,0 +1,411 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	clixclient "github.com/voltycodes/clix/client"
+	"github.com/voltycodes/clix/compose"
+)
+
+// defaultNumberFormat is how parts are numbered when the config does not
+// say otherwise
+const defaultNumberFormat = "{n}/{total}"
+
+// ThreadConfig is the "thread" section of the config
+type ThreadConfig struct {
+	// Numbering puts "1/n" before ("prefix") or after ("suffix") each part
+	Numbering string `json:"numbering,omitempty"`
+	// NumberFormat is the number with {n} and {total} filled in, e.g.
+	// "({n}/{total})"
+	NumberFormat string `json:"number_format,omitempty"`
+	// Signatures are footers added to every part, by account name
+	Signatures map[string]string `json:"signatures,omitempty"`
+	// Delay is the pause between posting one part and the next, e.g. "5s"
+	Delay string `json:"delay,omitempty"`
+}
+
+// threadDecoration is the numbering and signature added to each part
+type threadDecoration struct {
+	numbering string // prefix, suffix or none
+	format    string
+	signature string
+}
+
+// threadDecoration reads the decoration from the config, with numbering
+// overridden when it is not empty
+func (c *Config) threadDecoration(numbering string, signature bool) (threadDecoration, error) {
+	d := threadDecoration{numbering: "none", format: defaultNumberFormat}
+	if t := c.Thread; t != nil {
+		if t.Numbering != "" {
+			d.numbering = t.Numbering
+		}
+		if t.NumberFormat != "" {
+			d.format = t.NumberFormat
+		}
+		if signature {
+			d.signature = strings.TrimSpace(t.Signatures[c.Active])
+		}
+	}
+	if numbering != "" {
+		d.numbering = numbering
+	}
+	switch d.numbering {
+	case "prefix", "suffix", "none":
+	default:
+		return d, fmt.Errorf(tr("unknown thread numbering %q, expected prefix, suffix or none"), d.numbering)
+	}
+	if d.numbering != "none" && !strings.Contains(d.format, "{n}") {
+		return d, fmt.Errorf(tr("thread number_format %q has no {n}"), d.format)
+	}
+	return d, nil
+}
+
+// threadDelay returns the pause between the parts of a thread, 0 for none
+func (c *Config) threadDelay() (time.Duration, error) {
+	if c.Thread == nil || c.Thread.Delay == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.Thread.Delay)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf(tr("invalid thread delay %q, expected a duration like 5s"), c.Thread.Delay)
+	}
+	return d, nil
+}
+
+// apply returns the parts as they are posted. A thread of one part is not
+// numbered.
+func (d threadDecoration) apply(parts []string) []string {
+	decorated := make([]string, len(parts))
+	for i, part := range parts {
+		if d.numbering != "none" && len(parts) > 1 {
+			number := d.number(i+1, len(parts))
+			if d.numbering == "prefix" {
+				part = number + " " + part
+			} else {
+				part = part + " " + number
+			}
+		}
+		if d.signature != "" {
+			part += "\n\n" + d.signature
+		}
+		decorated[i] = part
+	}
+	return decorated
+}
+
+func (d threadDecoration) number(n, total int) string {
+	return strings.NewReplacer("{n}", strconv.Itoa(n), "{total}", strconv.Itoa(total)).Replace(d.format)
+}
+
+// added is as long as what apply adds to part n of total, the number
+// going after the part whether it is a prefix or a suffix
+func (d threadDecoration) added(n, total int) string {
+	var s string
+	if d.numbering != "none" && total > 1 {
+		s = " " + d.number(n, total)
+	}
+	if d.signature != "" {
+		s += "\n\n" + d.signature
+	}
+	return s
+}
+
+// split breaks text too long for a tweet into a thread whose parts fit
+// with their number and signature added
+func (d threadDecoration) split(text string) []string {
+	return compose.SplitNumbered(text, d.added)
+}
+
+// promptThread reads parts interactively until an empty part is entered
+func promptThread() ([]string, error) {
+	fmt.Println(tr("Enter each part of the thread; an empty line finishes."))
+	var parts []string
+	for {
+		part, err := promptLine(trf("part %d: ", len(parts)+1))
+		if err != nil || part == "" {
+			if err != nil {
+				fmt.Println()
+			}
+			return parts, nil
+		}
+		parts = append(parts, part)
+	}
+}
+
+// threadError reports a thread that stopped part way through
+type threadError struct {
+	posted []string // IDs of the parts that were posted, in order
+	failed int      // zero-based index of the part that failed
+	err    error
+}
+
+func (e *threadError) Error() string {
+	return trf("posted %d parts, part %d failed: %v", len(e.posted), e.failed+1, e.err)
+}
+
+func (e *threadError) Unwrap() error {
+	return e.err
+}
+
+// postThread posts parts as a reply chain. When replyTo is set the first
+// part replies to it. media, when not nil, holds the attachments of each
+// part. onPosted is called after each part is posted.
+func (a *app) postThread(ctx context.Context, parts []string, media [][]*mediaFile, replyTo string, settings TweetSettings, onPosted func(index int, id string)) ([]string, error) {
+	delay, err := a.config.threadDelay()
+	if err != nil {
+		return nil, err
+	}
+	thread := &clixclient.Thread{ReplyTo: replyTo, Settings: settings.apply, Delay: delay}
+	for i, part := range parts {
+		tweet := clixclient.Tweet{Text: part}
+		if i < len(media) && len(media[i]) > 0 {
+			files := media[i]
+			tweet.Upload = func(ctx context.Context) ([]string, error) {
+				return a.uploadMedia(ctx, files)
+			}
+		}
+		thread.Tweets = append(thread.Tweets, tweet)
+	}
+	ids, err := clixclient.Publish(ctx, thread, a.postTweet, onPosted)
+	var failed *clixclient.ThreadError
+	if errors.As(err, &failed) {
+		return ids, &threadError{posted: failed.Posted, failed: failed.Failed, err: failed.Err}
+	}
+	return ids, err
+}
+
+func runThread(args []string) error {
+	fs := newFlagSet("thread", "thread [flags]  (prompts for parts when no --tweet, --file or --from-markdown is given)")
+	var tweets stringList
+	fs.Var(&tweets, "tweet", "a part of the thread (repeat for each part)")
+	file := fs.String("file", "", "read parts from a file, separated by lines containing ---")
+	fromMarkdown := fs.String("from-markdown", "", "compile a markdown document into a thread, attaching its images")
+	keepEXIF := fs.Bool("keep-exif", false, "with --from-markdown, leave the EXIF metadata, such as the GPS location, in images")
+	replyTo := fs.String("reply-to", "", "post the first part as a reply to this tweet (ID or URL)")
+	resumeAt := fs.Int("resume-at", 1, "skip parts before this one, e.g. after a partial failure")
+	force := fs.Bool("force", false, "post even outside the configured posting window or against the style rules")
+	undo := fs.String("undo-delay", "", "hold the thread this long so it can be undone, e.g. 10s (default from config, 0 for none)")
+	numbering := fs.String("numbering", "", "number the parts as 1/n: prefix, suffix or none (default from config)")
+	noSignature := fs.Bool("no-signature", false, "leave out the account's signature from the config")
+	confirmThread := fs.Bool("confirm", false, "show the thread and ask before posting it (default from config)")
+	noConfirm := fs.Bool("no-confirm", false, "post without asking, even with confirm_before_post in the config")
+	lint := fs.Bool("lint", false, "check spelling, spacing, brackets, links and mentions before posting (default from config)")
+	noLint := fs.Bool("no-lint", false, "skip the lint checks, even with lint in the config")
+	noTransform := fs.Bool("no-transform", false, "post the parts as given, without the transforms from the config")
+	strictA11y := strictA11yFlag(fs)
+	openFlag := fs.Bool("open", false, "open the first tweet of the thread in the browser once it is posted")
+	settingsFlags := tweetSettingsFlags(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	confirmOverride, err := confirmFlag(*confirmThread, *noConfirm)
+	if err != nil {
+		return err
+	}
+	lintOverride, err := lintFlag(*lint, *noLint)
+	if err != nil {
+		return err
+	}
+
+	var replyID string
+	if *replyTo != "" {
+		if replyID, err = parseTweetID(*replyTo); err != nil {
+			return err
+		}
+	}
+
+	sources := 0
+	for _, given := range []bool{len(tweets) > 0, *file != "", *fromMarkdown != ""} {
+		if given {
+			sources++
+		}
+	}
+	var parts []string
+	var media [][]*mediaFile
+	switch {
+	case sources > 1:
+		return errors.New(tr("use only one of --tweet, --file and --from-markdown"))
+	case len(tweets) > 0:
+		parts = tweets
+	case *fromMarkdown != "":
+		data, err := os.ReadFile(*fromMarkdown)
+		if err != nil {
+			return fmt.Errorf(tr("failed to read markdown file: %w"), err)
+		}
+		for i, part := range compileMarkdownThread(string(data), filepath.Dir(*fromMarkdown)) {
+			var paths, alts []string
+			for _, image := range part.images {
+				paths = append(paths, image.path)
+				alts = append(alts, image.alt)
+			}
+			files, err := openMediaFiles(paths, alts, *keepEXIF)
+			if err != nil {
+				return withExitCode(exitValidation, fmt.Errorf(tr("part %d: %w"), i+1, err))
+			}
+			parts = append(parts, part.text)
+			media = append(media, files)
+		}
+	case *file != "":
+		data, err := os.ReadFile(*file)
+		if err != nil {
+			return fmt.Errorf(tr("failed to read thread file: %w"), err)
+		}
+		parts = compose.SplitThread(string(data))
+	case stdinIsTerminal():
+		var err error
+		if parts, err = promptThread(); err != nil {
+			return err
+		}
+	default:
+		text, err := readText(nil)
+		if err != nil {
+			return err
+		}
+		parts = compose.SplitThread(text)
+	}
+
+	if len(parts) == 0 {
+		return withExitCode(exitValidation, errors.New(tr("nothing to post")))
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	var transformed []string
+	if !*noTransform {
+		if parts, transformed, err = a.config.transformThread(parts); err != nil {
+			return err
+		}
+	}
+
+	// The whole thread is numbered before --resume-at skips any of it, and
+	// the lengths are checked with the numbers and signature added
+	decoration, err := a.config.threadDecoration(*numbering, !*noSignature)
+	if err != nil {
+		return err
+	}
+	// A single text too long for a tweet becomes a thread, leaving room in
+	// each part for its number and signature
+	if len(parts) == 1 && media == nil && checkLength(decoration.apply(parts)[0]) != nil {
+		parts = decoration.split(parts[0])
+	}
+	if *resumeAt < 1 || *resumeAt > len(parts) {
+		return fmt.Errorf(tr("--resume-at must be between 1 and %d"), len(parts))
+	}
+	decorated := decoration.apply(parts)
+	for i, part := range decorated {
+		if err := checkLength(part); err != nil {
+			if part != parts[i] {
+				return fmt.Errorf(tr("part %d: %w, including its numbering and signature"), i+1, err)
+			}
+			return fmt.Errorf(tr("part %d: %w"), i+1, err)
+		}
+	}
+	parts = decorated
+	offset := *resumeAt - 1
+	parts = parts[offset:]
+	if media != nil {
+		media = media[offset:]
+	}
+
+	settings, err := a.config.tweetSettings(settingsFlags())
+	if err != nil {
+		return err
+	}
+	checks := &postChecks{
+		config: a.config, a: a, parts: parts, media: media, offset: offset,
+		force: *force, lint: lintOverride, strictA11y: *strictA11y, ask: true, undo: *undo,
+	}
+	if a.config.confirmBeforePost(confirmOverride, false) {
+		checks.confirm = func() (bool, error) {
+			if !stdinIsTerminal() {
+				return false, errors.New(tr("--confirm needs a terminal to ask on"))
+			}
+			describeThread(os.Stdout, parts, media, replyID)
+			return confirm(trf("Post these %d tweets?", len(parts))), nil
+		}
+	}
+	if ok, err := checks.run(rootCtx); !ok || err != nil {
+		return err
+	}
+
+	if globalOptions.dryRun {
+		if machineReadable() {
+			results := make([]postResult, 0, len(parts))
+			for _, part := range parts {
+				results = append(results, postResult{Text: part, DryRun: true})
+			}
+			return printResult(results)
+		}
+		fmt.Println(tr("Dry run, nothing was posted."))
+		describeTransforms(os.Stdout, transformed)
+		describeSettings(os.Stdout, settings)
+		describeThread(os.Stdout, parts, media, replyID)
+		return nil
+	}
+	if settings, err = a.resolveGeo(rootCtx, settings); err != nil {
+		return err
+	}
+
+	var results []postResult
+	onPosted := func(i int, id string) {
+		results = append(results, postResult{ID: id, Text: parts[i]})
+		if !machineReadable() {
+			fmt.Println(id)
+		}
+	}
+
+	ctx := rootCtx
+	total := len(parts)
+	lastID := replyID
+	for {
+		_, err := a.postThread(ctx, parts, media, lastID, settings, onPosted)
+		if len(results) > 0 {
+			lastID = results[len(results)-1].ID
+		}
+
+		threadErr, ok := err.(*threadError)
+		if !ok {
+			break
+		}
+		fmt.Fprintf(os.Stderr, tr("Posted %d of %d parts; part %d failed: %v\n"),
+			len(results), total, offset+threadErr.failed+1, threadErr.err)
+
+		parts = parts[threadErr.failed:]
+		if media != nil {
+			media = media[threadErr.failed:]
+		}
+		offset += threadErr.failed
+		if stdinIsTerminal() && confirm(trf("Resume from part %d?", offset+1)) {
+			continue
+		}
+
+		resume := fmt.Sprintf("--resume-at %d", offset+1)
+		if lastID != "" {
+			resume += " --reply-to " + lastID
+		}
+		fmt.Fprintf(os.Stderr, tr("To resume later, run the same command with %s\n"), resume)
+		if machineReadable() {
+			printResult(results)
+		}
+		return threadErr
+	}
+
+	if *openFlag && len(results) > 0 {
+		openLink(tweetURL("", results[0].ID))
+	}
+	if machineReadable() {
+		return printResult(results)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package clix
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Named values of --time-format. Anything else is used as a Go layout,
+// e.g. "Jan 2 15:04"
+const (
+	timeFormatRelative = "relative"
+	timeFormatAbsolute = "absolute"
+	timeFormatISO      = "iso"
+)
+
+const (
+	absoluteTimeLayout = "2006-01-02 15:04"
+	// relativeTimeSpan is how far from now times are shown relative by
+	// default; older ones get the date instead
+	relativeTimeSpan = 7 * 24 * time.Hour
+)
+
+// formatTime renders t for the read commands following --time-format and
+// --utc: by default "3h ago" within a week and the local date and time
+// beyond. JSON output is not affected.
+func formatTime(t time.Time) string {
+	return formatTimeAt(t, time.Now())
+}
+
+func formatTimeAt(t, now time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	zoned := t.Local()
+	if globalOptions.utc {
+		zoned = t.UTC()
+	}
+	switch globalOptions.timeFormat {
+	case "", timeFormatRelative:
+		if d := now.Sub(t); d.Abs() < relativeTimeSpan {
+			return relativeTime(d)
+		}
+		fallthrough
+	case timeFormatAbsolute:
+		if globalOptions.utc {
+			return zoned.Format(absoluteTimeLayout + " UTC")
+		}
+		return zoned.Format(absoluteTimeLayout)
+	case timeFormatISO:
+		return zoned.Format(time.RFC3339)
+	default:
+		return zoned.Format(globalOptions.timeFormat)
+	}
+}
+
+// relativeTime renders how long ago, or with a negative d how long from
+// now, something is, in the largest whole unit
+func relativeTime(d time.Duration) string {
+	future := d < 0
+	d = d.Abs()
+	var n float64
+	var unit string
+	switch {
+	case d < time.Minute:
+		return tr("just now")
+	case d < time.Hour:
+		n, unit = d.Minutes(), "m"
+	case d < 24*time.Hour:
+		n, unit = d.Hours(), "h"
+	default:
+		n, unit = d.Hours()/24, "d"
+	}
+	amount := fmt.Sprintf("%d%s", int(math.Floor(n)), unit)
+	if future {
+		return trf("in %s", amount)
+	}
+	return trf("%s ago", amount)
+}
@@ -0,0 +1,90 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/tweet/timeline"
+	"github.com/michimani/gotwi/tweet/timeline/types"
+)
+
+// pageSize returns the max_results to request for count tweets; the
+// API accepts between 5 and 100
+func pageSize(count int) int {
+	return min(max(count, 5), 100)
+}
+
+// homeTimeline fetches one page of the home timeline and returns it with
+// the token for the next page
+func (a *app) homeTimeline(ctx context.Context, input *types.ListReverseChronologicalInput) ([]tweetView, string, error) {
+	if err := a.ensureToken(ctx); err != nil {
+		return nil, "", err
+	}
+	res, err := timeline.ListReverseChronological(ctx, a.client, input)
+	if err != nil {
+		return nil, "", fmt.Errorf(tr("failed to fetch timeline: %w"), err)
+	}
+	return newTweetViews(res.Data, res.Includes.Users), gotwi.StringValue(res.Meta.NextToken), nil
+}
+
+func runTimeline(args []string) error {
+	fs := newFlagSet("timeline", "timeline [--count n] [--since-id id]")
+	count := fs.Int("count", 20, "number of tweets to show per page")
+	sinceID := fs.String("since-id", "", "only show tweets newer than this ID")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *count < 1 {
+		return errors.New(tr("--count must be at least 1"))
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	ctx := rootCtx
+	userID, err := a.me(ctx)
+	if err != nil {
+		return err
+	}
+
+	input := &types.ListReverseChronologicalInput{
+		ID:          userID,
+		SinceID:     *sinceID,
+		MaxResults:  types.ListMaxResults(pageSize(*count)),
+		TweetFields: tweetViewFields,
+		Expansions:  tweetViewExpand,
+		UserFields:  tweetViewUserFld,
+	}
+
+	all := []tweetView{}
+	for {
+		views, next, err := a.homeTimeline(ctx, input)
+		if err != nil {
+			return err
+		}
+		if len(views) > *count {
+			views = views[:*count]
+		}
+		if machineReadable() {
+			all = append(all, views...)
+		} else {
+			printTweets(os.Stdout, views)
+		}
+
+		if next == "" || machineReadable() || !stdinIsTerminal() || !confirm(tr("Load more?")) {
+			break
+		}
+		input.PaginationToken = next
+	}
+
+	if machineReadable() {
+		return printResult(all)
+	}
+	return nil
+}
@@ -0,0 +1,433 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/michimani/gotwi/tweet/searchtweet/types"
+)
+
+const trackedFile = "tracked.json"
+
+// trackedReplyCount is the most new replies one check fetches per tweet
+const trackedReplyCount = 100
+
+// trackedTweet is a tweet `clix track` watches for replies
+type trackedTweet struct {
+	Account string    `json:"account"`
+	Text    string    `json:"text"`
+	URL     string    `json:"url"`
+	Tracked time.Time `json:"tracked"`
+	// SinceID is the newest reply seen, the tweet itself before any
+	SinceID   string    `json:"since_id"`
+	LastCheck time.Time `json:"last_check,omitempty"`
+	// Replies counts those seen since tracking started
+	Replies int `json:"replies"`
+}
+
+// trackedView is an entry of `clix track list`
+type trackedView struct {
+	ID string `json:"id"`
+	trackedTweet
+}
+
+// trackedReplies is what `clix track check --json` prints for a tweet
+type trackedReplies struct {
+	ID      string      `json:"id"`
+	Text    string      `json:"text"`
+	Replies []tweetView `json:"replies"`
+}
+
+func loadTracked() (map[string]*trackedTweet, error) {
+	tracked := map[string]*trackedTweet{}
+	if err := loadState(trackedFile, &tracked); err != nil {
+		return nil, err
+	}
+	return tracked, nil
+}
+
+// updateTracked applies fn to the tracked tweets under the state lock and
+// saves them
+func updateTracked(fn func(tracked map[string]*trackedTweet) error) error {
+	unlock, err := lockState(trackedFile)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	tracked, err := loadTracked()
+	if err != nil {
+		return err
+	}
+	if err := fn(tracked); err != nil {
+		return err
+	}
+	return saveState(trackedFile, tracked)
+}
+
+// newReplies returns the replies to a tracked tweet since the newest one
+// seen, oldest first, leaving out the account's own
+func (a *app) newReplies(ctx context.Context, id string, t *trackedTweet) ([]tweetView, error) {
+	input := &types.ListRecentInput{Query: "conversation_id:" + id, SinceID: t.SinceID}
+	// Search refuses a since_id older than it reaches back, so past that
+	// it starts where it can and the replies in between are missed
+	if since := tweetIDTime(t.SinceID); !since.IsZero() && time.Since(since) > searchWindow-time.Hour {
+		start := time.Now().Add(-searchWindow + time.Hour)
+		input.SinceID, input.StartTime = "", &start
+	}
+	views, err := a.searchRecent(ctx, input, trackedReplyCount)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := a.me(ctx)
+	if err != nil {
+		return nil, err
+	}
+	views = slices.DeleteFunc(views, func(v tweetView) bool { return v.AuthorID == userID })
+	slices.SortStableFunc(views, func(x, y tweetView) int { return compareTweetIDs(x.ID, y.ID) })
+	return views, nil
+}
+
+// replySummary is a line such as `3 new replies to "launch day" from @a,
+// @b and 1 more`
+func replySummary(t *trackedTweet, views []tweetView) string {
+	var authors []string
+	for _, v := range views {
+		if name := "@" + v.AuthorUsername; v.AuthorUsername != "" && !slices.Contains(authors, name) {
+			authors = append(authors, name)
+		}
+	}
+	from := strings.Join(authors, ", ")
+	if len(authors) > 3 {
+		from = trf("%s and %d more", strings.Join(authors[:3], ", "), len(authors)-3)
+	}
+	quoted := truncateRunes(t.Text, 40)
+	switch {
+	case len(views) == 1 && from == "":
+		return trf("1 new reply to %q", quoted)
+	case len(views) == 1:
+		return trf("1 new reply to %q from %s", quoted, from)
+	case from == "":
+		return trf("%d new replies to %q", len(views), quoted)
+	}
+	return trf("%d new replies to %q from %s", len(views), quoted, from)
+}
+
+func runTrack(args []string) error {
+	if len(args) == 0 || isHelpArg(args[0]) || isFlagArg(args[0]) {
+		fmt.Fprintln(os.Stderr, tr("Usage: clix track <id|url>...  |  track check [<id|url>...] [--notify] [--webhook url] [--watch]  |  track list"))
+		if len(args) > 0 && isHelpArg(args[0]) {
+			return nil
+		}
+		return errUsage
+	}
+	switch args[0] {
+	case "check":
+		return runTrackCheck(args[1:])
+	case "list":
+		return runTrackList(args[1:])
+	}
+
+	fs := newFlagSet("track", "track <id|url>...  (watch replies to your tweets; track check shows the new ones)")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+	var ids []string
+	for _, arg := range args {
+		id, err := parseTweetID(arg)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+	ctx := rootCtx
+	userID, err := a.me(ctx)
+	if err != nil {
+		return err
+	}
+	var added []trackedView
+	for _, id := range ids {
+		view, err := a.lookupTweet(ctx, id)
+		if err != nil {
+			return fmt.Errorf(tr("failed to fetch tweet %s: %w"), id, err)
+		}
+		if view.AuthorID != userID {
+			fmt.Fprintf(os.Stderr, tr("Warning: %s is not a tweet of yours; tracking it anyway\n"), id)
+		}
+		// The replies there already are the starting point
+		t := &trackedTweet{Account: a.config.Active, Text: view.Text, URL: view.URL, Tracked: time.Now().UTC(), SinceID: id}
+		replies, err := a.newReplies(ctx, id, t)
+		if err != nil {
+			return err
+		}
+		if len(replies) > 0 {
+			t.SinceID = replies[len(replies)-1].ID
+		}
+		t.LastCheck = t.Tracked
+		added = append(added, trackedView{id, *t})
+		if !machineReadable() {
+			fmt.Printf(tr("Tracking replies to %s (%d so far); 'clix track check' shows new ones.\n"), id, len(replies))
+		}
+	}
+	err = updateTracked(func(tracked map[string]*trackedTweet) error {
+		for _, view := range added {
+			tracked[view.ID] = &view.trackedTweet
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(added)
+	}
+	return nil
+}
+
+func runTrackList(args []string) error {
+	fs := newFlagSet("track list", "track list")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	tracked, err := loadTracked()
+	if err != nil {
+		return err
+	}
+	views := []trackedView{}
+	for _, id := range sortedKeys(tracked) {
+		views = append(views, trackedView{id, *tracked[id]})
+	}
+	if machineReadable() {
+		return printResult(views)
+	}
+	if len(views) == 0 {
+		fmt.Println(tr("No tracked tweets; track one with 'clix track <id|url>'."))
+		return nil
+	}
+	for _, view := range views {
+		fmt.Printf(tr("%-20s %-10s %4d replies  checked %-10s %s\n"), view.ID, view.Account, view.Replies,
+			formatTime(view.LastCheck), truncateRunes(view.Text, 40))
+	}
+	return nil
+}
+
+func runTrackCheck(args []string) error {
+	fs := newFlagSet("track check", "track check [<id|url>...] [--notify] [--webhook url] [--watch [--interval 5m]]  (new replies to the tracked tweets, all of them without an ID)")
+	notify := fs.Bool("notify", false, "show a desktop notification when there are new replies")
+	var webhooks stringList
+	fs.Var(&webhooks, "webhook", "also POST each new reply as a hook event to this URL (repeatable)")
+	watch := fs.Bool("watch", false, "keep checking until interrupted")
+	interval := fs.Duration("interval", 5*time.Minute, "with --watch, how often to check, at least 15s; a rate limit stretches it until the limit resets")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if *interval < minWatchInterval {
+		return fmt.Errorf(tr("--interval must be at least %s"), minWatchInterval)
+	}
+	var ids []string
+	for _, arg := range args {
+		id, err := parseTweetID(arg)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	tracked, err := loadTracked()
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		if ids = sortedKeys(tracked); len(ids) == 0 {
+			return withExitCode(exitNotFound, errors.New(tr("no tracked tweets; track one with 'clix track <id|url>'")))
+		}
+	}
+	for _, id := range ids {
+		if tracked[id] == nil {
+			return withExitCode(exitNotFound, fmt.Errorf(tr("tweet %s is not tracked"), id))
+		}
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+	ctx := rootCtx
+	var hooks []HookConfig
+	for _, u := range webhooks {
+		hooks = append(hooks, HookConfig{URL: u})
+	}
+	if *watch && !machineReadable() {
+		what := trf("%d tweets", len(ids))
+		if len(ids) == 1 {
+			what = ids[0]
+		}
+		fmt.Fprintf(os.Stderr, tr("Watching replies to %s, checking every %s.\n"), what, *interval)
+	}
+	for {
+		wait := *interval
+		results, err := a.checkTracked(ctx, ids, *notify, hooks)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil && !*watch:
+			return err
+		case err != nil:
+			if limited, ok := rateLimitWait(err); ok {
+				wait = max(wait, limited)
+				fmt.Fprintf(os.Stderr, tr("Rate limited; next check at %s.\n"), time.Now().Add(wait).Format("15:04:05"))
+			} else {
+				fmt.Fprintln(os.Stderr, tr("Error:"), err)
+			}
+		}
+		printTrackedReplies(results, *watch)
+		if !*watch {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// checkTracked fetches the new replies to each of ids, noting them as
+// seen and announcing them
+func (a *app) checkTracked(ctx context.Context, ids []string, notify bool, hooks []HookConfig) ([]trackedReplies, error) {
+	tracked, err := loadTracked()
+	if err != nil {
+		return nil, err
+	}
+	results := []trackedReplies{}
+	for _, id := range ids {
+		t := tracked[id]
+		if t == nil {
+			// Untracked while watching
+			continue
+		}
+		views, err := a.newReplies(ctx, id, t)
+		if err != nil {
+			return results, fmt.Errorf(tr("replies to %s: %w"), id, err)
+		}
+		checked := time.Now().UTC()
+		err = updateTracked(func(tracked map[string]*trackedTweet) error {
+			if s := tracked[id]; s != nil {
+				if len(views) > 0 {
+					s.SinceID = views[len(views)-1].ID
+				}
+				s.LastCheck = checked
+				s.Replies += len(views)
+			}
+			return nil
+		})
+		if err != nil {
+			return results, err
+		}
+		results = append(results, trackedReplies{id, t.Text, views})
+		if len(views) == 0 {
+			continue
+		}
+
+		summary := replySummary(t, views)
+		if notify {
+			if err := desktopNotify(summary, "@"+views[len(views)-1].AuthorUsername+": "+views[len(views)-1].Text); err != nil {
+				fmt.Fprintln(os.Stderr, tr("Warning: could not show a notification:"), err)
+			}
+		}
+		for _, view := range views {
+			a.fireHooks(hookEvent{
+				Event:     hookReply,
+				Account:   t.Account,
+				Tweet:     &hookTweet{ID: view.ID, Text: view.Text, URL: view.URL, Author: view.AuthorUsername},
+				InReplyTo: id,
+				Time:      checked,
+				Text:      fmt.Sprintf("@%s replied to %q: %s", view.AuthorUsername, truncateRunes(t.Text, 40), view.URL),
+			}, hooks)
+		}
+	}
+	return results, nil
+}
+
+// printTrackedReplies shows a summary and the replies for each tweet that
+// has new ones; quiet leaves out saying there are none, for --watch
+func printTrackedReplies(results []trackedReplies, quiet bool) {
+	if machineReadable() {
+		if !quiet || slices.ContainsFunc(results, func(r trackedReplies) bool { return len(r.Replies) > 0 }) {
+			printResult(results)
+		}
+		return
+	}
+	found := false
+	for _, result := range results {
+		if len(result.Replies) == 0 {
+			continue
+		}
+		found = true
+		fmt.Printf("== %s\n", replySummary(&trackedTweet{Text: result.Text}, result.Replies))
+		printTweets(os.Stdout, result.Replies)
+	}
+	if !found && !quiet {
+		fmt.Println(tr("No new replies."))
+	}
+}
+
+func runUntrack(args []string) error {
+	fs := newFlagSet("untrack", "untrack <id|url>... | --all")
+	all := fs.Bool("all", false, "stop tracking every tweet")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 == !*all {
+		fs.Usage()
+		return errUsage
+	}
+	var ids []string
+	for _, arg := range args {
+		id, err := parseTweetID(arg)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	err = updateTracked(func(tracked map[string]*trackedTweet) error {
+		if *all {
+			ids = sortedKeys(tracked)
+		}
+		for _, id := range ids {
+			if tracked[id] == nil {
+				return withExitCode(exitNotFound, fmt.Errorf(tr("tweet %s is not tracked"), id))
+			}
+			delete(tracked, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	switch {
+	case machineReadable():
+	case len(ids) == 1:
+		fmt.Printf(tr("Stopped tracking %s.\n"), ids[0])
+	default:
+		fmt.Printf(tr("Stopped tracking %d tweets.\n"), len(ids))
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package clix
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/voltycodes/clix/compose"
+)
+
+// Transform types
+const (
+	transformWhitespace = "normalize_whitespace"
+	transformEmoji      = "emoji"
+	transformQuotes     = "smart_quotes"
+	transformHashtags   = "append_hashtags"
+)
+
+var transformTypes = []string{transformWhitespace, transformEmoji, transformQuotes, transformHashtags}
+
+// hashtagOnly is a single hashtag, as append_hashtags takes them
+var hashtagOnly = regexp.MustCompile(`^#[\p{L}\p{N}_]+$`)
+
+// TransformConfig is an entry of the "transforms" config section. The
+// transforms rewrite the text of every post, in the order listed, before
+// it is checked and posted.
+type TransformConfig struct {
+	Type string `json:"type"` // normalize_whitespace, emoji, smart_quotes or append_hashtags
+	// Accounts limits the transform to these accounts; it applies to all
+	// of them when empty
+	Accounts []string `json:"accounts,omitempty"`
+	// Hashtags are what append_hashtags adds, unless the text has them
+	Hashtags []string `json:"hashtags,omitempty"`
+	// Emoji adds shortcodes to, or overrides, the built-in ones of emoji
+	Emoji map[string]string `json:"emoji,omitempty"`
+}
+
+// check reports a transform the config gets wrong
+func (t TransformConfig) check() error {
+	if !slices.Contains(transformTypes, t.Type) {
+		return fmt.Errorf(tr("transforms: unknown type %q, use one of %s"), t.Type, strings.Join(transformTypes, ", "))
+	}
+	if t.Type == transformHashtags {
+		if len(t.Hashtags) == 0 {
+			return errors.New(tr("transforms: append_hashtags needs hashtags"))
+		}
+		for _, tag := range t.Hashtags {
+			if !hashtagOnly.MatchString(tag) {
+				return fmt.Errorf(tr("transforms: %q is not a hashtag"), tag)
+			}
+		}
+	}
+	return nil
+}
+
+// apply runs the transform on text. first is false for the later parts of
+// a thread, which get no hashtags appended.
+func (t TransformConfig) apply(text string, first bool) string {
+	switch t.Type {
+	case transformWhitespace:
+		return normalizeWhitespace(text)
+	case transformEmoji:
+		return expandShortcodes(text, t.Emoji)
+	case transformQuotes:
+		return smartQuotes(text)
+	case transformHashtags:
+		if !first {
+			return text
+		}
+		return appendHashtags(text, t.Hashtags)
+	}
+	return text
+}
+
+// transformText runs the transforms of the active account on text, and
+// returns it with the types of those that changed it
+func (c *Config) transformText(text string, first bool) (string, []string, error) {
+	var applied []string
+	for _, t := range c.Transforms {
+		if len(t.Accounts) > 0 && !slices.Contains(t.Accounts, c.Active) {
+			continue
+		}
+		if err := t.check(); err != nil {
+			return "", nil, err
+		}
+		if out := t.apply(text, first); out != text {
+			text = out
+			applied = append(applied, t.Type)
+		}
+	}
+	return text, applied, nil
+}
+
+// transform rewrites the request's text with the configured transforms,
+// before prepare checks its length
+func (r *postRequest) transform(config *Config) error {
+	if r.text == "" {
+		return nil
+	}
+	text, applied, err := config.transformText(r.text, true)
+	if err != nil {
+		return err
+	}
+	r.text, r.transformed = text, applied
+	return nil
+}
+
+// transformThread rewrites each part of a thread, returning the types of
+// the transforms that changed any of them
+func (c *Config) transformThread(parts []string) ([]string, []string, error) {
+	out := make([]string, len(parts))
+	var applied []string
+	for i, part := range parts {
+		text, types, err := c.transformText(part, i == 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		out[i] = text
+		for _, t := range types {
+			if !slices.Contains(applied, t) {
+				applied = append(applied, t)
+			}
+		}
+	}
+	return out, applied, nil
+}
+
+var (
+	blankRun   = regexp.MustCompile(`[ \t\p{Zs}]+`)
+	blankLines = regexp.MustCompile(`\n{3,}`)
+)
+
+// normalizeWhitespace turns runs of spaces and tabs into one space, drops
+// spaces at the ends of lines and keeps at most one empty line in a row
+func normalizeWhitespace(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(blankRun.ReplaceAllString(line, " "))
+	}
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+// smartQuotes turns straight quotes outside links into curly ones: opening
+// at the start of a word, closing elsewhere, and apostrophes within words
+func smartQuotes(text string) string {
+	var b strings.Builder
+	for _, span := range compose.SplitURLs(text) {
+		if span.URL {
+			b.WriteString(span.Text)
+			continue
+		}
+		prev := rune(' ')
+		if b.Len() > 0 {
+			prev, _ = utf8.DecodeLastRuneInString(b.String())
+		}
+		for _, r := range span.Text {
+			opening := unicode.IsSpace(prev) || strings.ContainsRune("([{“‘—–", prev)
+			switch {
+			case r == '"' && opening:
+				b.WriteRune('“')
+			case r == '"':
+				b.WriteRune('”')
+			case r == '\'' && opening:
+				b.WriteRune('‘')
+			case r == '\'':
+				b.WriteRune('’')
+			default:
+				b.WriteRune(r)
+			}
+			prev = r
+		}
+	}
+	return b.String()
+}
+
+// appendHashtags adds the hashtags the text does not have yet, on a line
+// of their own after a multi-line text
+func appendHashtags(text string, hashtags []string) string {
+	have := map[string]bool{}
+	for _, tag := range hashtagPattern.FindAllString(text, -1) {
+		have[strings.ToLower(strings.TrimSpace(tag))] = true
+	}
+	var missing []string
+	for _, tag := range hashtags {
+		if !have[strings.ToLower(tag)] {
+			missing = append(missing, tag)
+		}
+	}
+	if len(missing) == 0 {
+		return text
+	}
+	sep := " "
+	if strings.Contains(text, "\n") {
+		sep = "\n\n"
+	}
+	return text + sep + strings.Join(missing, " ")
+}
@@ -0,0 +1,153 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const trendsEndpoint = "https://api.twitter.com/2/trends/by/woeid/%d"
+
+// worldwideWOEID is the place trends are listed for by default
+const worldwideWOEID = 1
+
+// trendPlaces are the where-on-earth IDs of the places --place knows, by
+// lowercase name; --woeid takes any other
+var trendPlaces = map[string]int{
+	"worldwide": 1, "world": 1,
+
+	"argentina": 23424747, "australia": 23424748, "brazil": 23424768, "canada": 23424775,
+	"france": 23424819, "germany": 23424829, "india": 23424848, "indonesia": 23424846,
+	"ireland": 23424803, "italy": 23424853, "japan": 23424856, "korea": 23424868,
+	"mexico": 23424900, "netherlands": 23424909, "nigeria": 23424908, "philippines": 23424934,
+	"saudi arabia": 23424938, "singapore": 23424948, "south africa": 23424942, "spain": 23424950,
+	"turkey": 23424969, "united kingdom": 23424975, "uk": 23424975, "united states": 23424977,
+	"us": 23424977, "usa": 23424977,
+
+	"amsterdam": 727232, "barcelona": 753692, "berlin": 638242, "boston": 2367105,
+	"buenos aires": 468739, "chicago": 2379574, "delhi": 20070458, "dublin": 560743,
+	"istanbul": 2344116, "johannesburg": 1582504, "lagos": 1398823, "london": 44418,
+	"los angeles": 2442047, "madrid": 766273, "manchester": 28218, "melbourne": 1103816,
+	"mexico city": 116545, "milan": 718345, "mumbai": 2295411, "new york": 2459115,
+	"osaka": 15015370, "paris": 615702, "rome": 721943, "san francisco": 2487956,
+	"são paulo": 455827, "sao paulo": 455827, "seattle": 2490383, "sydney": 1105779,
+	"tokyo": 1118370, "toronto": 4118, "washington": 2514815,
+}
+
+// trend is a topic trending in a place
+type trend struct {
+	Name string `json:"name"`
+	// Tweets is the volume X reports, 0 when it gives none
+	Tweets int    `json:"tweets,omitempty"`
+	URL    string `json:"url"`
+}
+
+// trendPlace returns the WOEID of a --place name
+func trendPlace(name string) (int, error) {
+	if woeid, ok := trendPlaces[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return woeid, nil
+	}
+	return 0, withExitCode(exitValidation, fmt.Errorf(tr("unknown place %q; use --woeid, or one of %s"), name, strings.Join(slices.Sorted(maps.Keys(trendPlaces)), ", ")))
+}
+
+// trendSearchURL is the search on x.com for a trend
+func trendSearchURL(name string) string {
+	return "https://x.com/search?q=" + url.QueryEscape(name)
+}
+
+// trends returns up to count topics trending at woeid, most popular first
+func (a *app) trends(ctx context.Context, woeid, count int) ([]trend, error) {
+	query := url.Values{"max_trends": {strconv.Itoa(count)}, "trend.fields": {"trend_name,tweet_count"}}
+	req, err := a.newSignedRequest(ctx, http.MethodGet, fmt.Sprintf(trendsEndpoint, woeid), query, nil)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Data []struct {
+			TrendName  string `json:"trend_name"`
+			TweetCount int    `json:"tweet_count"`
+		} `json:"data"`
+	}
+	if err := a.doJSON(req, &res); err != nil {
+		return nil, fmt.Errorf(tr("failed to fetch trends: %w"), err)
+	}
+	trends := make([]trend, 0, len(res.Data))
+	for _, t := range res.Data {
+		trends = append(trends, trend{Name: t.TrendName, Tweets: t.TweetCount, URL: trendSearchURL(t.TrendName)})
+	}
+	return trends, nil
+}
+
+func runTrends(args []string) error {
+	fs := newFlagSet("trends", `trends [--place "London" | --woeid n] [--count n] [--open n]`)
+	place := fs.String("place", "", "list the trends of this city or country")
+	woeid := fs.Int("woeid", 0, "list the trends of this where-on-earth ID")
+	count := fs.Int("count", 20, "number of trends to show, up to 50")
+	open := fs.Int("open", 0, "open the search for the nth trend of the list in the browser")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *place != "" && *woeid != 0 {
+		return withExitCode(exitUsage, errors.New(tr("--place and --woeid cannot be combined")))
+	}
+	if *count < 1 || *count > 50 {
+		return withExitCode(exitUsage, errors.New(tr("--count must be between 1 and 50")))
+	}
+	if *open < 0 {
+		return withExitCode(exitUsage, errors.New(tr("--open must be a position in the list, starting at 1")))
+	}
+	where := worldwideWOEID
+	switch {
+	case *woeid < 0:
+		return withExitCode(exitUsage, errors.New(tr("--woeid must be positive")))
+	case *woeid > 0:
+		where = *woeid
+	case *place != "":
+		var err error
+		if where, err = trendPlace(*place); err != nil {
+			return err
+		}
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	trends, err := a.trends(rootCtx, where, min(max(*count, *open), 50))
+	if err != nil {
+		return err
+	}
+
+	if *open > 0 {
+		if *open > len(trends) {
+			return withExitCode(exitNotFound, fmt.Errorf(tr("there are only %d trends"), len(trends)))
+		}
+		openLink(trends[*open-1].URL)
+		return nil
+	}
+
+	trends = trends[:min(len(trends), *count)]
+	if machineReadable() {
+		return printResult(trends)
+	}
+	if len(trends) == 0 {
+		fmt.Println(tr("No trends."))
+		return nil
+	}
+	for i, t := range trends {
+		if t.Tweets == 0 {
+			fmt.Printf("%3d. %s\n", i+1, t.Name)
+			continue
+		}
+		fmt.Printf(tr("%3d. %-40s %d tweets\n"), i+1, t.Name, t.Tweets)
+	}
+	return nil
+}
@@ -0,0 +1,458 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	timelinetypes "github.com/michimani/gotwi/tweet/timeline/types"
+	"github.com/voltycodes/clix/compose"
+)
+
+// Panes of the tweet list
+const (
+	paneTimeline = iota
+	paneMentions
+)
+
+var paneNames = [...]string{"Timeline", "Mentions"}
+
+// tuiPageSize is how many tweets a pane loads at a time
+const tuiPageSize = 50
+
+var (
+	tuiTabStyle      = lipgloss.NewStyle().Padding(0, 1)
+	tuiActiveStyle   = tuiTabStyle.Bold(true).Reverse(true)
+	tuiAuthorStyle   = lipgloss.NewStyle().Bold(true)
+	tuiMutedStyle    = lipgloss.NewStyle().Faint(true)
+	tuiSelectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("12"))
+	tuiErrorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	tuiComposeStyle  = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+	tuiFocusedStyle  = tuiComposeStyle.BorderForeground(lipgloss.Color("12"))
+)
+
+const tuiHelp = "1/2 pane · j/k move · c compose · r reply · l like · t retweet · R refresh · q quit"
+const tuiComposeHelp = "ctrl+s post · tab complete @mention · esc back to the list"
+
+type tweetsLoadedMsg struct {
+	pane   int
+	tweets []tweetView
+	err    error
+}
+
+type actionDoneMsg struct {
+	status string
+	err    error
+	posted bool // a tweet was posted, so the compose box can be cleared
+}
+
+type tuiModel struct {
+	a   *app
+	ctx context.Context
+	// api serialises API calls, which run as commands in the background
+	api *sync.Mutex
+
+	pane     int
+	tweets   [len(paneNames)][]tweetView
+	loaded   [len(paneNames)]bool
+	selected [len(paneNames)]int
+
+	composing bool
+	compose   textarea.Model
+	replyTo   *tweetView
+	// complete returns the handles for a partial @mention
+	complete func(prefix string) []string
+
+	status        string
+	statusIsError bool
+	width, height int
+}
+
+func newTuiModel(a *app, complete func(string) []string) tuiModel {
+	compose := textarea.New()
+	compose.Placeholder = tr("What's happening?")
+	compose.ShowLineNumbers = false
+	compose.CharLimit = 0
+	compose.SetHeight(4)
+	return tuiModel{a: a, ctx: rootCtx, api: &sync.Mutex{}, compose: compose, complete: complete}
+}
+
+func (m tuiModel) Init() tea.Cmd {
+	return m.load(paneTimeline)
+}
+
+// load fetches the tweets of a pane in the background
+func (m tuiModel) load(pane int) tea.Cmd {
+	return func() tea.Msg {
+		m.api.Lock()
+		defer m.api.Unlock()
+
+		userID, err := m.a.me(m.ctx)
+		if err != nil {
+			return tweetsLoadedMsg{pane: pane, err: err}
+		}
+		var tweets []tweetView
+		switch pane {
+		case paneTimeline:
+			tweets, _, err = m.a.homeTimeline(m.ctx, &timelinetypes.ListReverseChronologicalInput{
+				ID:          userID,
+				MaxResults:  timelinetypes.ListMaxResults(tuiPageSize),
+				TweetFields: tweetViewFields,
+				Expansions:  tweetViewExpand,
+				UserFields:  tweetViewUserFld,
+			})
+		case paneMentions:
+			tweets, _, err = m.a.listMentions(m.ctx, &timelinetypes.ListMentionsInput{
+				ID:          userID,
+				MaxResults:  timelinetypes.ListMaxResults(tuiPageSize),
+				TweetFields: tweetViewFields,
+				Expansions:  tweetViewExpand,
+				UserFields:  tweetViewUserFld,
+			})
+		}
+		return tweetsLoadedMsg{pane: pane, tweets: tweets, err: err}
+	}
+}
+
+// act runs an API call in the background and reports the outcome
+func (m tuiModel) act(status string, fn func() error) tea.Cmd {
+	return func() tea.Msg {
+		m.api.Lock()
+		defer m.api.Unlock()
+		return actionDoneMsg{status: status, err: fn()}
+	}
+}
+
+func (m tuiModel) post() tea.Cmd {
+	req := &postRequest{text: strings.TrimSpace(m.compose.Value())}
+	if m.replyTo != nil {
+		req.replyTo = m.replyTo.ID
+	}
+	return func() tea.Msg {
+		if err := req.transform(m.a.config); err != nil {
+			return actionDoneMsg{err: err}
+		}
+		prepared, err := req.prepare()
+		if err != nil {
+			return actionDoneMsg{err: err}
+		}
+		if err := checkPostingWindow(m.a.config.PostingWindow, time.Now()); err != nil {
+			return actionDoneMsg{err: err}
+		}
+		if globalOptions.dryRun {
+			// publish would print over the screen
+			return actionDoneMsg{status: trf("Dry run: %d tweet(s) not posted", len(prepared.parts))}
+		}
+
+		m.api.Lock()
+		defer m.api.Unlock()
+		results, err := m.a.publish(m.ctx, prepared, func(postResult) {})
+		if err != nil {
+			return actionDoneMsg{err: err}
+		}
+		return actionDoneMsg{status: trf("Posted %s", results[0].ID), posted: true}
+	}
+}
+
+func (m *tuiModel) setStatus(status string, err error) {
+	m.status, m.statusIsError = status, err != nil
+	if err != nil {
+		m.status = err.Error()
+	}
+}
+
+// current returns the selected tweet of the active pane, if any
+func (m *tuiModel) current() *tweetView {
+	tweets := m.tweets[m.pane]
+	if len(tweets) == 0 {
+		return nil
+	}
+	return &tweets[m.selected[m.pane]]
+}
+
+func (m *tuiModel) resize() {
+	m.compose.SetWidth(max(m.width-4, 10))
+}
+
+func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.resize()
+		return m, nil
+
+	case tweetsLoadedMsg:
+		if msg.err != nil {
+			m.setStatus("", msg.err)
+			return m, nil
+		}
+		m.tweets[msg.pane], m.loaded[msg.pane] = msg.tweets, true
+		m.selected[msg.pane] = min(m.selected[msg.pane], max(len(msg.tweets)-1, 0))
+		m.setStatus(trf("Loaded %d tweets", len(msg.tweets)), nil)
+		return m, nil
+
+	case actionDoneMsg:
+		m.setStatus(msg.status, msg.err)
+		if msg.posted {
+			m.compose.Reset()
+			m.replyTo = nil
+			m.composing = false
+			m.compose.Blur()
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if m.composing {
+			return m.updateCompose(msg)
+		}
+		return m.updateList(msg)
+	}
+	return m, nil
+}
+
+func (m tuiModel) updateCompose(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.composing = false
+		m.compose.Blur()
+		if m.compose.Value() == "" {
+			m.replyTo = nil
+		}
+		return m, nil
+	case "ctrl+s":
+		m.setStatus(tr("Posting..."), nil)
+		return m, m.post()
+	case "tab":
+		m.completeMention()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.compose, cmd = m.compose.Update(msg)
+	return m, cmd
+}
+
+// mention returns the partial @mention before the cursor, without the @,
+// and the handles it could be
+func (m tuiModel) mention() (string, []string) {
+	lines := strings.Split(m.compose.Value(), "\n")
+	if m.complete == nil || m.compose.Line() >= len(lines) {
+		return "", nil
+	}
+	line := []rune(lines[m.compose.Line()])
+	info := m.compose.LineInfo()
+	before := line[:min(info.StartColumn+info.ColumnOffset, len(line))]
+	at := mentionPrefix(before)
+	if at < 0 {
+		return "", nil
+	}
+	typed := string(before[at+1:])
+	return typed, m.complete(typed)
+}
+
+// completeMention replaces the partial @mention before the cursor with the
+// handle it matches, or with as much as all the matches share
+func (m *tuiModel) completeMention() {
+	typed, matches := m.mention()
+	var completion string
+	switch {
+	case len(matches) == 1:
+		completion = matches[0] + " "
+	case len(matches) > 1:
+		completion = commonPrefix(typed, matches)
+	}
+	if len(completion) <= len(typed) && len(matches) != 1 {
+		return
+	}
+	for range []rune(typed) {
+		m.compose, _ = m.compose.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	m.compose.InsertString(completion)
+}
+
+func (m tuiModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "1", "2":
+		m.pane = int(msg.String()[0] - '1')
+		if !m.loaded[m.pane] {
+			m.setStatus(tr("Loading..."), nil)
+			return m, m.load(m.pane)
+		}
+	case "tab":
+		m.pane = (m.pane + 1) % len(paneNames)
+		if !m.loaded[m.pane] {
+			m.setStatus(tr("Loading..."), nil)
+			return m, m.load(m.pane)
+		}
+	case "j", "down":
+		if m.selected[m.pane] < len(m.tweets[m.pane])-1 {
+			m.selected[m.pane]++
+		}
+	case "k", "up":
+		if m.selected[m.pane] > 0 {
+			m.selected[m.pane]--
+		}
+	case "g", "home":
+		m.selected[m.pane] = 0
+	case "G", "end":
+		m.selected[m.pane] = max(len(m.tweets[m.pane])-1, 0)
+	case "R":
+		m.setStatus(tr("Loading..."), nil)
+		return m, m.load(m.pane)
+	case "c", "n":
+		m.composing = true
+		return m, m.compose.Focus()
+	case "r":
+		if tweet := m.current(); tweet != nil {
+			m.replyTo = tweet
+			m.composing = true
+			return m, m.compose.Focus()
+		}
+	case "l":
+		if tweet := m.current(); tweet != nil {
+			id := tweet.ID
+			return m, m.act(trf("Liked %s", id), func() error { return m.a.like(m.ctx, id) })
+		}
+	case "t":
+		if tweet := m.current(); tweet != nil {
+			id := tweet.ID
+			return m, m.act(trf("Retweeted %s", id), func() error { return m.a.retweet(m.ctx, id) })
+		}
+	}
+	return m, nil
+}
+
+func (m tuiModel) View() string {
+	if m.width == 0 {
+		return tr("Loading...")
+	}
+
+	var tabs []string
+	for i, name := range paneNames {
+		label := fmt.Sprintf("%d %s", i+1, tr(name))
+		if i == m.pane {
+			tabs = append(tabs, tuiActiveStyle.Render(label))
+		} else {
+			tabs = append(tabs, tuiTabStyle.Render(label))
+		}
+	}
+	header := lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+
+	composeBox := m.viewCompose()
+	status := m.status
+	if m.statusIsError {
+		status = tuiErrorStyle.Render(status)
+	}
+	help := tr(tuiHelp)
+	if m.composing {
+		help = tr(tuiComposeHelp)
+		// While typing a mention the help shows what it could become
+		if _, matches := m.mention(); len(matches) > 0 {
+			help = "tab @" + strings.Join(matches[:min(len(matches), 10)], " @")
+		}
+	}
+	footer := truncate(status, m.width) + "\n" + tuiMutedStyle.Render(truncate(help, m.width))
+
+	listHeight := m.height - lipgloss.Height(header) - lipgloss.Height(composeBox) - lipgloss.Height(footer)
+	return lipgloss.JoinVertical(lipgloss.Left, header, m.viewList(listHeight), composeBox, footer)
+}
+
+// viewList renders as many tweets as fit in height lines, scrolled so the
+// selected one is visible. Every tweet takes three lines.
+func (m tuiModel) viewList(height int) string {
+	const linesPerTweet = 3
+	tweets := m.tweets[m.pane]
+	lines := make([]string, 0, height)
+	if len(tweets) == 0 && m.loaded[m.pane] {
+		lines = append(lines, tuiMutedStyle.Render(tr("Nothing here yet.")))
+	}
+
+	visible := max(height/linesPerTweet, 1)
+	selected := m.selected[m.pane]
+	first := max(selected-visible+1, 0)
+	for i := first; i < len(tweets) && i < first+visible; i++ {
+		tweet := tweets[i]
+		marker := "  "
+		if i == selected {
+			marker = tuiSelectedStyle.Render("▌ ")
+		}
+		author := tuiAuthorStyle.Render(strings.TrimSpace(tweet.AuthorName + " @" + tweet.AuthorUsername))
+		meta := tuiMutedStyle.Render(fmt.Sprintf(" · %s · ↩ %d ⟲ %d ♥ %d",
+			formatTime(tweet.CreatedAt), tweet.Replies, tweet.Retweets, tweet.Likes))
+		text := strings.Join(strings.Fields(tweet.Text), " ")
+		lines = append(lines, marker+author+meta, marker+truncate(text, m.width-2), "")
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines[:max(height, 0)], "\n")
+}
+
+func (m tuiModel) viewCompose() string {
+	text := strings.TrimSpace(m.compose.Value())
+	n := compose.Length(text)
+	counter := fmt.Sprintf("%d/%d", n, compose.MaxLength)
+	if n > compose.MaxLength {
+		counter = tuiErrorStyle.Render(counter)
+	} else {
+		counter = tuiMutedStyle.Render(counter)
+	}
+	title := tr("Compose")
+	if m.replyTo != nil {
+		title = trf("Replying to @%s", m.replyTo.AuthorUsername)
+	}
+	gap := max(m.width-4-lipgloss.Width(title)-lipgloss.Width(counter), 1)
+
+	style := tuiComposeStyle
+	if m.composing {
+		style = tuiFocusedStyle
+	}
+	return style.Width(max(m.width-2, 10)).Render(title + strings.Repeat(" ", gap) + counter + "\n" + m.compose.View())
+}
+
+// truncate shortens s to at most width cells
+func truncate(s string, width int) string {
+	if width <= 0 || lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+func runTui(args []string) error {
+	fs := newFlagSet("tui", "tui  (full-screen interface; `clix repl` is the lightweight alternative)")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if !stdinIsTerminal() {
+		return errors.New(tr("clix tui needs a terminal"))
+	}
+
+	a, err := setup(true)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	// Cancelling the context on a signal makes the program restore the
+	// terminal and return
+	complete := handleCompleter(a.config.Active)
+	_, err = tea.NewProgram(newTuiModel(a, complete), tea.WithAltScreen(), tea.WithContext(rootCtx)).Run()
+	if errors.Is(err, tea.ErrProgramKilled) && interrupted() {
+		return nil
+	}
+	return err
+}
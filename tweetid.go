@@ -0,0 +1,80 @@
+package clix
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// snowflakeEpoch is the Unix time in milliseconds tweet IDs count from
+const snowflakeEpoch = 1288834974657
+
+var tweetHosts = map[string]bool{
+	"x.com":              true,
+	"www.x.com":          true,
+	"mobile.x.com":       true,
+	"twitter.com":        true,
+	"www.twitter.com":    true,
+	"mobile.twitter.com": true,
+}
+
+func isTweetID(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// tweetURL links to a tweet on x.com; without the author's username the
+// /i/web form still resolves
+func tweetURL(username, id string) string {
+	if username == "" {
+		return "https://x.com/i/web/status/" + id
+	}
+	return "https://x.com/" + username + "/status/" + id
+}
+
+// tweetIDTime returns when a tweet was posted, from the timestamp in its
+// ID; zero for an ID from before IDs had one
+func tweetIDTime(id string) time.Time {
+	n, err := strconv.ParseUint(id, 10, 64)
+	if err != nil || n>>22 == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(int64(n>>22) + snowflakeEpoch)
+}
+
+// parseTweetID accepts a raw tweet ID or an x.com/twitter.com status URL
+// and returns the ID
+func parseTweetID(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if isTweetID(s) {
+		return s, nil
+	}
+
+	raw := s
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || !tweetHosts[strings.ToLower(u.Host)] {
+		return "", withExitCode(exitValidation, fmt.Errorf(tr("%q is not a tweet ID or URL"), s))
+	}
+
+	// Paths look like /user/status/<id>, /i/web/status/<id> or
+	// /user/statuses/<id>, optionally followed by /photo/1 and the like
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i, segment := range segments {
+		if (segment == "status" || segment == "statuses") && i+1 < len(segments) && isTweetID(segments[i+1]) {
+			return segments[i+1], nil
+		}
+	}
+	return "", withExitCode(exitValidation, fmt.Errorf(tr("%q is not a tweet URL"), s))
+}
@@ -0,0 +1,139 @@
+package clix
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/tweet/managetweet/types"
+)
+
+// replyRestrictions maps the values of --reply-restriction to the API's
+// reply_settings; everyone is the API's default and is not sent
+var replyRestrictions = map[string]string{
+	"everyone":  "",
+	"mentioned": "mentionedUsers",
+	"following": "following",
+}
+
+// TweetSettings are the options of a new tweet besides its content
+type TweetSettings struct {
+	// ReplyRestriction is who can reply: everyone, mentioned or following
+	ReplyRestriction string `json:"reply_restriction,omitempty"`
+	// SuperFollowersOnly shows the tweet to super followers alone
+	SuperFollowersOnly *bool `json:"super_followers_only,omitempty"`
+	// PlaceID tags the tweet with a place, as clix places search finds
+	// them; none tags it with nothing, overriding the config
+	PlaceID string `json:"place_id,omitempty"`
+	// Geo is "lat,lon", tagged as the place X knows nearest to it
+	Geo string `json:"geo,omitempty"`
+}
+
+// TweetConfig is the "tweet" section of the config: the settings new
+// tweets get unless a flag says otherwise, and, by account name, the ones
+// that differ for an account
+type TweetConfig struct {
+	TweetSettings
+	Accounts map[string]TweetSettings `json:"accounts,omitempty"`
+}
+
+// check reports a reply restriction clix does not know
+func (s TweetSettings) check() error {
+	if _, ok := replyRestrictions[s.ReplyRestriction]; !ok && s.ReplyRestriction != "" {
+		names := make([]string, 0, len(replyRestrictions))
+		for name := range replyRestrictions {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		return fmt.Errorf(tr("unknown reply restriction %q, use one of %s"), s.ReplyRestriction, strings.Join(names, ", "))
+	}
+	if s.PlaceID != "" && s.Geo != "" {
+		return errors.New(tr("a place ID and coordinates cannot be combined"))
+	}
+	if s.Geo != "" {
+		if _, _, err := parseCoordinates(s.Geo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// over returns s with the settings it leaves unset taken from defaults
+func (s TweetSettings) over(defaults TweetSettings) TweetSettings {
+	if s.ReplyRestriction == "" {
+		s.ReplyRestriction = defaults.ReplyRestriction
+	}
+	if s.SuperFollowersOnly == nil {
+		s.SuperFollowersOnly = defaults.SuperFollowersOnly
+	}
+	// A place and coordinates are the same setting
+	if s.PlaceID == "" && s.Geo == "" {
+		s.PlaceID, s.Geo = defaults.PlaceID, defaults.Geo
+	}
+	return s
+}
+
+// tweetSettings fills in what flags left unset from the active account's
+// settings, then from those for every account
+func (c *Config) tweetSettings(flags TweetSettings) (TweetSettings, error) {
+	if t := c.Tweet; t != nil {
+		flags = flags.over(t.Accounts[c.Active]).over(t.TweetSettings)
+	}
+	if err := flags.check(); err != nil {
+		return TweetSettings{}, fmt.Errorf(tr("tweet: %w"), err)
+	}
+	return flags, nil
+}
+
+// apply sets the settings on a tweet about to be created
+func (s TweetSettings) apply(input *types.CreateInput) {
+	if setting := replyRestrictions[s.ReplyRestriction]; setting != "" {
+		input.ReplySettings = gotwi.String(setting)
+	}
+	if s.SuperFollowersOnly != nil && *s.SuperFollowersOnly {
+		input.ForSuperFollowersOnly = gotwi.Bool(true)
+	}
+	if s.PlaceID != "" && s.PlaceID != noPlace {
+		input.Geo = &types.CreateInputGeo{PlaceID: gotwi.String(s.PlaceID)}
+	}
+}
+
+// describe lists the settings that differ from the API's defaults, for
+// --dry-run
+func (s TweetSettings) describe() []string {
+	var out []string
+	if s.ReplyRestriction != "" && s.ReplyRestriction != "everyone" {
+		out = append(out, trf("replies: %s", s.ReplyRestriction))
+	}
+	if s.SuperFollowersOnly != nil && *s.SuperFollowersOnly {
+		out = append(out, tr("super followers only"))
+	}
+	switch {
+	case s.Geo != "":
+		out = append(out, trf("place: nearest to %s", s.Geo))
+	case s.PlaceID != "" && s.PlaceID != noPlace:
+		out = append(out, trf("place: %s", s.PlaceID))
+	}
+	return out
+}
+
+// tweetSettingsFlags adds the flags that override the "tweet" config
+// section to fs, returning what they were set to once fs is parsed
+func tweetSettingsFlags(fs *flag.FlagSet) func() TweetSettings {
+	restriction := fs.String("reply-restriction", "", "who can reply: everyone, mentioned or following (default from config)")
+	superFollowers := fs.Bool("super-followers-only", false, "show the tweet to your super followers alone; =false overrides the config")
+	placeID := fs.String("place-id", "", "tag the tweet with this place, from clix places search; none for no place (default from config)")
+	geo := fs.String("geo", "", `tag the tweet with the place nearest to "lat,lon"`)
+	return func() TweetSettings {
+		s := TweetSettings{ReplyRestriction: *restriction, PlaceID: *placeID, Geo: *geo}
+		fs.Visit(func(f *flag.Flag) {
+			if f.Name == "super-followers-only" {
+				s.SuperFollowersOnly = superFollowers
+			}
+		})
+		return s
+	}
+}
@@ -0,0 +1,64 @@
+package clix
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"time"
+)
+
+// undoDelay returns how long to hold a tweet before posting it: the
+// --undo-delay flag when given, otherwise the config's undo_delay
+func undoDelay(config *Config, flag string) (time.Duration, error) {
+	value := cmp.Or(flag, config.UndoDelay)
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf(tr("invalid undo delay %q, expected a duration like 10s"), value)
+	}
+	return d, nil
+}
+
+// holdForUndo counts down delay before a tweet is posted and reports
+// whether to post it: u, Esc or Ctrl-C cancels it and enter posts it right
+// away. Without a terminal to press them on there is nothing to wait for.
+func holdForUndo(delay time.Duration) (bool, error) {
+	if delay <= 0 || globalOptions.dryRun || !stdinIsTerminal() {
+		return true, nil
+	}
+	fd := int(os.Stdin.Fd())
+	restore, err := makeRaw(fd)
+	if err != nil {
+		return false, err
+	}
+	defer restore()
+
+	deadline := time.Now().Add(delay)
+	buf := make([]byte, 16)
+	for {
+		left := time.Until(deadline)
+		if left <= 0 {
+			fmt.Fprint(os.Stderr, "\r\x1b[K")
+			return true, nil
+		}
+		fmt.Fprintf(os.Stderr, tr("\r\x1b[KPosting in %s; press u to undo or enter to post now"), (left + time.Second - 1).Truncate(time.Second))
+		// Wake up on the second to update the countdown
+		n, err := input.readTimeout(buf, left%time.Second+time.Millisecond)
+		if err != nil {
+			fmt.Fprint(os.Stderr, "\r\x1b[K")
+			return false, err
+		}
+		for _, b := range buf[:n] {
+			switch b {
+			case 'u', 'U', 27, 3:
+				fmt.Fprint(os.Stderr, "\r\x1b[K")
+				return false, nil
+			case '\r', '\n':
+				fmt.Fprint(os.Stderr, "\r\x1b[K")
+				return true, nil
+			}
+		}
+	}
+}
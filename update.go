@@ -0,0 +1,429 @@
+package clix
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	clixclient "github.com/voltycodes/clix/client"
+	"golang.org/x/term"
+)
+
+const (
+	latestReleaseEndpoint = "https://api.github.com/repos/voltycodes/clix/releases/latest"
+	checksumsAsset        = "checksums.txt"
+	checksumsSigAsset     = "checksums.txt.sig"
+
+	updateStateFile = "update.json"
+	// updateCheckInterval is how often the new version notice looks for a
+	// release, and how often it is shown
+	updateCheckInterval = 24 * time.Hour
+	updateCheckTimeout  = 2 * time.Second
+	maxReleaseBytes     = 100 << 20
+)
+
+// version is set at build time with
+// -ldflags "-X github.com/voltycodes/clix.version=v1.2.3"
+var version string
+
+// releaseKey is the base64 ed25519 public key that signs checksums.txt,
+// set at build time like version. Without it update refuses to install a
+// release: checksums.txt comes from the same release as the download, so
+// the checksum alone says nothing about who published it.
+var releaseKey string
+
+// currentVersion returns the version of this binary, or "dev" for a local
+// build
+func currentVersion() string {
+	if version != "" {
+		return version
+	}
+	// go install pkg@v1.2.3 records the version; a build from a checkout
+	// only has a pseudo-version
+	if info, ok := debug.ReadBuildInfo(); ok {
+		v := info.Main.Version
+		if v != "" && v != "(devel)" && !strings.HasPrefix(v, "v0.0.0-") && !strings.HasSuffix(v, "+dirty") {
+			return v
+		}
+	}
+	return "dev"
+}
+
+type release struct {
+	TagName string         `json:"tag_name"`
+	URL     string         `json:"html_url"`
+	Assets  []releaseAsset `json:"assets"`
+}
+
+type releaseAsset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+	Size int64  `json:"size"`
+}
+
+func latestRelease(ctx context.Context) (*release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, latestReleaseEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	var r release
+	if err := clixclient.DoJSON(newDirectHTTPClient(), req, &r); err != nil {
+		return nil, fmt.Errorf(tr("failed to check for a new release: %w"), err)
+	}
+	return &r, nil
+}
+
+func (r *release) asset(name string) *releaseAsset {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// platformAsset finds the archive or binary built for this OS and
+// architecture, which has _<os>_<arch> in its name
+func (r *release) platformAsset() *releaseAsset {
+	platform := "_" + runtime.GOOS + "_" + runtime.GOARCH
+	for i, a := range r.Assets {
+		if strings.Contains(a.Name, platform) && !strings.HasSuffix(a.Name, ".sig") && !strings.HasSuffix(a.Name, ".sha256") {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// compareVersions compares two versions like v1.2.3, returning -1, 0 or
+// +1. A pre-release such as v1.3.0-rc1 comes before its release.
+func compareVersions(a, b string) int {
+	a, preA, _ := strings.Cut(strings.TrimPrefix(a, "v"), "-")
+	b, preB, _ := strings.Cut(strings.TrimPrefix(b, "v"), "-")
+	partsA, partsB := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(partsA), len(partsB)); i++ {
+		var x, y int
+		if i < len(partsA) {
+			x, _ = strconv.Atoi(partsA[i])
+		}
+		if i < len(partsB) {
+			y, _ = strconv.Atoi(partsB[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case preA == preB:
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	}
+	return strings.Compare(preA, preB)
+}
+
+func download(ctx context.Context, a *releaseAsset) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := newDirectHTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(tr("failed to download %s: %w"), a.Name, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(tr("failed to download %s: %s"), a.Name, res.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxReleaseBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf(tr("failed to download %s: %w"), a.Name, err)
+	}
+	if len(data) > maxReleaseBytes {
+		return nil, fmt.Errorf(tr("%s is over %d MB"), a.Name, maxReleaseBytes>>20)
+	}
+	return data, nil
+}
+
+// verifyDownload checks the signature of checksums.txt with the release
+// key, then data against its line in it
+func verifyDownload(ctx context.Context, r *release, asset *releaseAsset, data []byte) error {
+	sumsAsset := r.asset(checksumsAsset)
+	if sumsAsset == nil {
+		return fmt.Errorf(tr("release %s has no %s to verify the download with"), r.TagName, checksumsAsset)
+	}
+	sums, err := download(ctx, sumsAsset)
+	if err != nil {
+		return err
+	}
+
+	key, err := base64.StdEncoding.DecodeString(releaseKey)
+	if releaseKey == "" || err != nil || len(key) != ed25519.PublicKeySize {
+		return errors.New(tr("this build has an invalid release key"))
+	}
+	sigAsset := r.asset(checksumsSigAsset)
+	if sigAsset == nil {
+		return fmt.Errorf(tr("release %s is not signed"), r.TagName)
+	}
+	sig, err := download(ctx, sigAsset)
+	if err != nil {
+		return err
+	}
+	// The signature may be raw or base64
+	if decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig))); err == nil {
+		sig = decoded
+	}
+	if !ed25519.Verify(ed25519.PublicKey(key), sums, sig) {
+		return fmt.Errorf(tr("the signature of %s does not match; not updating"), checksumsAsset)
+	}
+
+	sum := sha256.Sum256(data)
+	scanner := bufio.NewScanner(bytes.NewReader(sums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == asset.Name {
+			if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+				return fmt.Errorf(tr("the checksum of %s does not match; not updating"), asset.Name)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf(tr("%s has no checksum for %s"), checksumsAsset, asset.Name)
+}
+
+// extractBinary takes the clix binary out of a .tar.gz or .zip archive,
+// or returns data as it is when the asset is the binary itself
+func extractBinary(name string, data []byte) ([]byte, error) {
+	binary := "clix"
+	if runtime.GOOS == "windows" {
+		binary = "clix.exe"
+	}
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf(tr("failed to read %s: %w"), name, err)
+		}
+		tarReader := tar.NewReader(gz)
+		for {
+			header, err := tarReader.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf(tr("failed to read %s: %w"), name, err)
+			}
+			if header.Typeflag == tar.TypeReg && path.Base(header.Name) == binary {
+				return io.ReadAll(io.LimitReader(tarReader, maxReleaseBytes))
+			}
+		}
+	case strings.HasSuffix(name, ".zip"):
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, fmt.Errorf(tr("failed to read %s: %w"), name, err)
+		}
+		for _, file := range zr.File {
+			if path.Base(file.Name) == binary {
+				f, err := file.Open()
+				if err != nil {
+					return nil, err
+				}
+				defer f.Close()
+				return io.ReadAll(io.LimitReader(f, maxReleaseBytes))
+			}
+		}
+	default:
+		return data, nil
+	}
+	return nil, fmt.Errorf(tr("%s has no %s in it"), name, binary)
+}
+
+// replaceExecutable swaps the running binary for data. The new file is
+// written next to it and renamed over it, so a failure leaves the old one.
+func replaceExecutable(data []byte) (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".clix-update-*")
+	if err != nil {
+		if os.IsPermission(err) {
+			return "", fmt.Errorf(tr("cannot write to %s; run the update with the permissions it was installed with"), filepath.Dir(exe))
+		}
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		return "", err
+	}
+	// Windows cannot replace a running executable, but it can rename it
+	if runtime.GOOS == "windows" {
+		old := exe + ".old"
+		os.Remove(old)
+		if err := os.Rename(exe, old); err != nil {
+			return "", err
+		}
+	}
+	if err := os.Rename(tmp.Name(), exe); err != nil {
+		return "", err
+	}
+	return exe, nil
+}
+
+// updateState is when the notice last looked for, and last told of, a
+// new release
+type updateState struct {
+	CheckedAt  time.Time `json:"checked_at"`
+	Latest     string    `json:"latest,omitempty"`
+	NotifiedAt time.Time `json:"notified_at"`
+}
+
+// notifyUpdate tells the user, at most once a day, that a newer release is
+// out. It only runs for release builds in a terminal, and "update_check":
+// false in the config turns it off.
+func notifyUpdate(ctx context.Context, config *Config) {
+	current := currentVersion()
+	if current == "dev" || (config.UpdateCheck != nil && !*config.UpdateCheck) ||
+		machineReadable() || !term.IsTerminal(int(os.Stderr.Fd())) || interrupted() {
+		return
+	}
+	var state updateState
+	if err := loadState(updateStateFile, &state); err != nil {
+		return
+	}
+	now := time.Now()
+	if now.Sub(state.CheckedAt) >= updateCheckInterval {
+		ctx, cancel := context.WithTimeout(ctx, updateCheckTimeout)
+		defer cancel()
+		// A failed check waits for the next interval like any other
+		state.CheckedAt = now
+		if r, err := latestRelease(ctx); err == nil {
+			state.Latest = r.TagName
+		}
+	}
+	notify := state.Latest != "" && compareVersions(state.Latest, current) > 0 && now.Sub(state.NotifiedAt) >= updateCheckInterval
+	if notify {
+		state.NotifiedAt = now
+		fmt.Fprintf(os.Stderr, tr("A new version of clix is available: %s (you have %s). Run 'clix update' to install it.\n"), state.Latest, current)
+	}
+	saveState(updateStateFile, state)
+}
+
+func runUpdate(args []string) error {
+	fs := newFlagSet("update", "update [--check] [--force]  (replaces this binary with the latest release)")
+	check := fs.Bool("check", false, "only report whether a newer release is out")
+	force := fs.Bool("force", false, "install the latest release even over a development build or a newer version")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	ctx := rootCtx
+	current := currentVersion()
+	r, err := latestRelease(ctx)
+	if err != nil {
+		return err
+	}
+	newer := current != "dev" && compareVersions(r.TagName, current) > 0
+	if machineReadable() && *check {
+		return printResult(struct {
+			Current string `json:"current"`
+			Latest  string `json:"latest"`
+			Newer   bool   `json:"newer"`
+			URL     string `json:"url"`
+		}{current, r.TagName, newer, r.URL})
+	}
+	switch {
+	case *check && newer:
+		fmt.Printf(tr("clix %s is out (you have %s): %s\n"), r.TagName, current, r.URL)
+		return nil
+	case *check:
+		fmt.Printf(tr("clix %s is the latest release (you have %s).\n"), r.TagName, current)
+		return nil
+	case current == "dev" && !*force:
+		return fmt.Errorf(tr("this is a development build; pass --force to replace it with %s"), r.TagName)
+	case !newer && current != "dev" && !*force:
+		fmt.Printf(tr("clix %s is up to date.\n"), current)
+		return nil
+	}
+
+	asset := r.platformAsset()
+	if asset == nil {
+		return fmt.Errorf(tr("release %s has no build for %s/%s"), r.TagName, runtime.GOOS, runtime.GOARCH)
+	}
+	if releaseKey == "" {
+		return withExitCode(exitRefused, fmt.Errorf(tr("this build has no release key to check the signature of %s with, so it cannot update itself; get the release from %s"), r.TagName, r.URL))
+	}
+	if globalOptions.dryRun {
+		fmt.Printf(tr("Dry run, would install %s from %s.\n"), r.TagName, asset.Name)
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, tr("Downloading %s (%d KB)...\n"), asset.Name, max(asset.Size>>10, 1))
+	data, err := download(ctx, asset)
+	if err != nil {
+		return err
+	}
+	if err := verifyDownload(ctx, r, asset, data); err != nil {
+		return err
+	}
+	binary, err := extractBinary(asset.Name, data)
+	if err != nil {
+		return err
+	}
+	exe, err := replaceExecutable(binary)
+	if err != nil {
+		return fmt.Errorf(tr("failed to install the update: %w"), err)
+	}
+	fmt.Printf(tr("Updated %s from %s to %s.\n"), exe, current, r.TagName)
+	return nil
+}
+
+func runVersion(args []string) error {
+	fs := newFlagSet("version", "version")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(struct {
+			Version string `json:"version"`
+			Go      string `json:"go"`
+			OS      string `json:"os"`
+			Arch    string `json:"arch"`
+		}{currentVersion(), runtime.Version(), runtime.GOOS, runtime.GOARCH})
+	}
+	fmt.Printf("clix %s (%s, %s/%s)\n", currentVersion(), runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	return nil
+}
@@ -0,0 +1,197 @@
+package clix
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	clixclient "github.com/voltycodes/clix/client"
+	"golang.org/x/term"
+)
+
+// uploadsStateFile keeps the chunked uploads that were interrupted, so
+// trying the same file again continues where the last attempt stopped
+const uploadsStateFile = "uploads.json"
+
+// defaultUploadExpiry is how long the API keeps an unfinished upload when
+// INIT does not say
+const defaultUploadExpiry = 24 * time.Hour
+
+// progressBarWidth is the number of cells in the upload progress bar
+const progressBarWidth = 30
+
+// pendingUpload is an upload the API has taken the first Segments chunks
+// of
+type pendingUpload struct {
+	MediaID   string    `json:"media_id"`
+	Segments  int       `json:"segments"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// uploadKey names the upload of a file by account and the SHA-256 of its
+// content, so the same file resumes wherever it was moved or copied to,
+// and a file changed since, or another one at the same path, does not
+func (a *app) uploadKey(file *mediaFile) (string, error) {
+	f, err := os.Open(file.path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	sum := sha256.New()
+	if _, err := io.Copy(sum, f); err != nil {
+		return "", err
+	}
+	return a.config.Active + "|" + hex.EncodeToString(sum.Sum(nil)), nil
+}
+
+// pendingUploadFor returns the unfinished upload of key, unless it is
+// about to expire
+func pendingUploadFor(key string) (pendingUpload, bool) {
+	pending := map[string]pendingUpload{}
+	if err := loadState(uploadsStateFile, &pending); err != nil {
+		return pendingUpload{}, false
+	}
+	p, ok := pending[key]
+	if !ok || time.Until(p.ExpiresAt) < 10*time.Minute {
+		return pendingUpload{}, false
+	}
+	return p, true
+}
+
+// savePendingUpload records how far the upload of key got; nil forgets it,
+// along with any that have expired
+func savePendingUpload(key string, p *pendingUpload) error {
+	unlock, err := lockState(uploadsStateFile)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	pending := map[string]pendingUpload{}
+	if err := loadState(uploadsStateFile, &pending); err != nil {
+		return err
+	}
+	for k, other := range pending {
+		if time.Now().After(other.ExpiresAt) {
+			delete(pending, k)
+		}
+	}
+	if p == nil {
+		delete(pending, key)
+	} else {
+		pending[key] = *p
+	}
+	return saveState(uploadsStateFile, pending)
+}
+
+// appendChunk sends one APPEND, retrying it when the network fails or the
+// API answers with a 5xx. The client only retries reads on those, but
+// sending a segment again replaces it, so for a chunk a retry is safe.
+func (a *app) appendChunk(ctx context.Context, mediaID string, segment int, data []byte, progress *uploadProgress) error {
+	for attempt := 0; ; attempt++ {
+		err := a.mediaCommand(ctx, url.Values{
+			"command":       {"APPEND"},
+			"media_id":      {mediaID},
+			"segment_index": {strconv.Itoa(segment)},
+		}, data, nil)
+		if err == nil || ctx.Err() != nil || attempt >= maxRetries || !chunkRetryable(err) {
+			return err
+		}
+		delay := backoff(attempt)
+		progress.note(trf("chunk %d failed, retrying in %s", segment+1, delay.Round(100*time.Millisecond)))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// chunkRetryable reports whether an APPEND that failed with err may go
+// through if sent again: the network failed, or the API did
+func chunkRetryable(err error) bool {
+	var statusErr *clixclient.StatusError
+	return isNetworkError(err) || errors.As(err, &statusErr) && statusErr.StatusCode >= 500
+}
+
+// uploadProgress draws a progress bar for a large upload on stderr, when
+// it is a terminal, and a line per quarter otherwise
+type uploadProgress struct {
+	name      string
+	total     int64
+	sent      int64
+	resumed   int64 // sent by an earlier attempt
+	start     time.Time
+	terminal  bool
+	lastShown int64
+}
+
+// newUploadProgress returns nil, which draws nothing, for files small
+// enough to go up in a moment
+func newUploadProgress(file *mediaFile, sent int64) *uploadProgress {
+	if file.size <= progressThreshold || machineReadable() {
+		return nil
+	}
+	p := &uploadProgress{
+		name: filepath.Base(file.path), total: file.size, sent: sent, resumed: sent, start: time.Now(),
+		terminal: term.IsTerminal(int(os.Stderr.Fd())), lastShown: -1,
+	}
+	p.draw()
+	return p
+}
+
+func (p *uploadProgress) add(n int) {
+	if p == nil {
+		return
+	}
+	p.sent += int64(n)
+	p.draw()
+}
+
+func (p *uploadProgress) draw() {
+	percent := p.sent * 100 / p.total
+	if !p.terminal {
+		if p.lastShown < 0 || percent/25 != p.lastShown/25 {
+			fmt.Fprintf(os.Stderr, tr("Uploading %s: %d%%\n"), p.name, percent)
+		}
+		p.lastShown = percent
+		return
+	}
+	filled := int(p.sent * progressBarWidth / p.total)
+	rate := ""
+	if elapsed := time.Since(p.start).Seconds(); elapsed > 1 {
+		rate = fmt.Sprintf(" %.1f MB/s", float64(p.sent-p.resumed)/elapsed/(1<<20))
+	}
+	fmt.Fprintf(os.Stderr, tr("\r\033[KUploading %s [%s%s] %3d%% %.1f/%.1f MB%s"),
+		p.name, strings.Repeat("█", filled), strings.Repeat("░", progressBarWidth-filled),
+		percent, float64(p.sent)/(1<<20), float64(p.total)/(1<<20), rate)
+}
+
+// note prints a message under the bar and draws the bar again
+func (p *uploadProgress) note(msg string) {
+	if p == nil {
+		fmt.Fprintln(os.Stderr, tr("Upload:"), msg)
+		return
+	}
+	if p.terminal {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
+	fmt.Fprintf(os.Stderr, tr("Uploading %s: %s\n"), p.name, msg)
+	if p.terminal {
+		p.draw()
+	}
+}
+
+func (p *uploadProgress) done() {
+	if p != nil && p.terminal {
+		fmt.Fprintln(os.Stderr)
+	}
+}
@@ -0,0 +1,159 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/tweet/timeline"
+	timelinetypes "github.com/michimani/gotwi/tweet/timeline/types"
+	"github.com/michimani/gotwi/user/userlookup"
+	"github.com/michimani/gotwi/user/userlookup/types"
+)
+
+// userProfile is what `clix user` shows about an account
+type userProfile struct {
+	userView
+	Location  string      `json:"location,omitempty"`
+	Website   string      `json:"website,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	Pinned    *tweetView  `json:"pinned,omitempty"`
+	Recent    []tweetView `json:"recent"`
+}
+
+// profile looks up a user with their pinned tweet and up to count recent
+// tweets
+func (a *app) profile(ctx context.Context, username string, count int) (*userProfile, error) {
+	username = trimHandle(username)
+	if err := a.ensureToken(ctx); err != nil {
+		return nil, err
+	}
+	res, err := userlookup.GetByUsername(ctx, a.client, &types.GetByUsernameInput{
+		Username:    username,
+		Expansions:  fields.ExpansionList{fields.ExpansionPinnedTweetID},
+		TweetFields: tweetViewFields,
+		UserFields: append(fields.UserFieldList{
+			fields.UserFieldLocation, fields.UserFieldUrl, fields.UserFieldCreatedAt, fields.UserFieldEntities,
+		}, userViewFields...),
+	})
+	if err != nil {
+		return nil, fmt.Errorf(tr("failed to look up @%s: %w"), username, err)
+	}
+	if res.Data.ID == nil {
+		return nil, withExitCode(exitNotFound, fmt.Errorf(tr("@%s does not exist or is suspended"), username))
+	}
+
+	user := res.Data
+	p := &userProfile{
+		userView:  newUserView(user),
+		Location:  gotwi.StringValue(user.Location),
+		Website:   profileWebsite(user),
+		CreatedAt: gotwi.TimeValue(user.CreatedAt),
+		Recent:    []tweetView{},
+	}
+	self := []resources.User{user}
+	if pinned := newTweetViews(res.Includes.Tweets, self); len(pinned) > 0 {
+		p.Pinned = &pinned[0]
+	}
+
+	if count > 0 {
+		tweets, err := timeline.ListTweets(ctx, a.client, &timelinetypes.ListTweetsInput{
+			ID:          p.ID,
+			MaxResults:  timelinetypes.ListMaxResults(pageSize(count)),
+			TweetFields: tweetViewFields,
+		})
+		if err != nil {
+			return nil, fmt.Errorf(tr("failed to fetch tweets of @%s: %w"), username, err)
+		}
+		p.Recent = newTweetViews(tweets.Data, self)
+		if len(p.Recent) > count {
+			p.Recent = p.Recent[:count]
+		}
+	}
+	return p, nil
+}
+
+// profileWebsite returns the expanded form of the t.co link in a profile
+func profileWebsite(user resources.User) string {
+	website := gotwi.StringValue(user.URL)
+	if user.Entities != nil && user.Entities.URL != nil {
+		for _, u := range user.Entities.URL.URLs {
+			if gotwi.StringValue(u.URL) == website && u.ExpandedURL != nil {
+				return *u.ExpandedURL
+			}
+		}
+	}
+	return website
+}
+
+func runUser(args []string) error {
+	fs := newFlagSet("user", "user [--count n] @user")
+	count := fs.Int("count", 5, "number of recent tweets to show, 0 for none")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+	if *count < 0 {
+		return errors.New(tr("--count cannot be negative"))
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	p, err := a.profile(rootCtx, args[0], *count)
+	if err != nil {
+		return err
+	}
+	if machineReadable() {
+		return printResult(p)
+	}
+
+	name := p.Name + " @" + p.Username
+	if p.Verified {
+		name += " ✓"
+	}
+	if p.Protected {
+		name += " (protected)"
+	}
+	fmt.Println(name)
+	if p.Description != "" {
+		fmt.Println(p.Description)
+	}
+	var details []string
+	if p.Location != "" {
+		details = append(details, p.Location)
+	}
+	if p.Website != "" {
+		details = append(details, p.Website)
+	}
+	if !p.CreatedAt.IsZero() {
+		details = append(details, "joined "+p.CreatedAt.Local().Format("January 2006"))
+	}
+	if len(details) > 0 {
+		fmt.Println(strings.Join(details, " · "))
+	}
+	fmt.Printf(tr("%d following · %d followers · %d tweets\n"), p.Following, p.Followers, p.Tweets)
+
+	if p.Pinned != nil {
+		fmt.Println(tr("\nPinned:"))
+		printTweet(os.Stdout, *p.Pinned)
+	}
+	if len(p.Recent) > 0 {
+		fmt.Println(tr("\nRecent:"))
+		printTweets(os.Stdout, p.Recent)
+	}
+	return nil
+}
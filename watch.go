@@ -0,0 +1,237 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/tweet/timeline/types"
+)
+
+// watchStateFile maps account names to the newest mention clix watch has
+// notified about. It is kept apart from mentionsStateFile so watching
+// does not mark mentions as seen for `clix mentions --new`.
+const watchStateFile = "watch.json"
+
+// minWatchInterval keeps polling well inside the mentions rate limit
+const minWatchInterval = 15 * time.Second
+
+// desktopNotify shows a notification with the tool the platform has:
+// notify-send, osascript or a PowerShell toast
+func desktopNotify(title, body string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("osascript", "-e", fmt.Sprintf("display notification %s with title %s", appleScriptString(body), appleScriptString(title)))
+	case "windows":
+		script := `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $template.GetElementsByTagName("text")
+$text.Item(0).AppendChild($template.CreateTextNode($env:CLIX_TITLE)) > $null
+$text.Item(1).AppendChild($template.CreateTextNode($env:CLIX_BODY)) > $null
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier("clix").Show([Windows.UI.Notifications.ToastNotification]::new($template))`
+		cmd = exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", script)
+		// Passed through the environment to stay clear of quoting
+		cmd.Env = append(os.Environ(), "CLIX_TITLE="+title, "CLIX_BODY="+body)
+	default:
+		if _, err := exec.LookPath("notify-send"); err != nil {
+			return errors.New(tr("notify-send is not installed"))
+		}
+		cmd = exec.Command("notify-send", "--app-name=clix", title, body)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf(tr("%s failed: %v %s"), cmd.Args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// mentionWatcher polls for mentions newer than the last one it saw
+type mentionWatcher struct {
+	a       *app
+	userID  string
+	sinceID string
+	notify  bool
+	hooks   []HookConfig
+}
+
+// poll fetches the mentions since the last poll, oldest first. The first
+// poll of an account only notes where things stand, so starting to watch
+// does not replay every old mention.
+func (w *mentionWatcher) poll(ctx context.Context) ([]tweetView, error) {
+	input := &types.ListMentionsInput{
+		ID:          w.userID,
+		MaxResults:  100,
+		SinceID:     w.sinceID,
+		TweetFields: tweetViewFields,
+		Expansions:  tweetViewExpand,
+		UserFields:  tweetViewUserFld,
+	}
+	if w.sinceID == "" {
+		input.MaxResults = 5
+	}
+	views, newest, err := w.a.listMentions(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	first := w.sinceID == ""
+	if newest != "" {
+		w.sinceID = newest
+		if err := w.save(); err != nil {
+			fmt.Fprintln(os.Stderr, tr("Warning: could not save watch state:"), err)
+		}
+	}
+	if first {
+		return nil, nil
+	}
+	for i, j := 0, len(views)-1; i < j; i, j = i+1, j-1 {
+		views[i], views[j] = views[j], views[i]
+	}
+	return views, nil
+}
+
+func (w *mentionWatcher) save() error {
+	unlock, err := lockState(watchStateFile)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	seen := map[string]string{}
+	if err := loadState(watchStateFile, &seen); err != nil {
+		return err
+	}
+	seen[w.a.config.Active] = w.sinceID
+	return saveState(watchStateFile, seen)
+}
+
+// announce prints a new mention and sends it to the desktop and hooks
+func (w *mentionWatcher) announce(view tweetView) {
+	if machineReadable() {
+		printResult(view)
+	} else {
+		printTweet(os.Stdout, view)
+	}
+	author := "@" + view.AuthorUsername
+	if view.AuthorUsername == "" {
+		author = view.AuthorID
+	}
+	if w.notify {
+		if err := desktopNotify(author+" mentioned you", view.Text); err != nil {
+			fmt.Fprintln(os.Stderr, tr("Warning: could not show a notification:"), err)
+			// Once is enough to know
+			w.notify = false
+		}
+	}
+	w.a.fireHooks(hookEvent{
+		Event:   hookMention,
+		Account: w.a.config.Active,
+		Tweet:   &hookTweet{ID: view.ID, Text: view.Text, URL: view.URL, Author: view.AuthorUsername},
+		Time:    time.Now(),
+		Text:    fmt.Sprintf("%s mentioned you: %s", author, view.URL),
+	}, w.hooks)
+}
+
+// rateLimitWait returns how long to hold off after err, when it is the
+// API saying the rate limit is used up
+func rateLimitWait(err error) (time.Duration, bool) {
+	var gotwiErr *gotwi.GotwiError
+	if !errors.As(err, &gotwiErr) || !gotwiErr.OnAPI || gotwiErr.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if gotwiErr.RateLimitInfo != nil && gotwiErr.RateLimitInfo.ResetAt != nil {
+		return max(time.Until(*gotwiErr.RateLimitInfo.ResetAt)+time.Second, minWatchInterval), true
+	}
+	return 15 * time.Minute, true
+}
+
+func runWatch(args []string) error {
+	if len(args) > 0 && isHelpArg(args[0]) {
+		fmt.Fprintln(os.Stderr, tr("Usage: clix watch mentions [--interval 1m] [--no-notify] [--webhook url] [--once]"))
+		return nil
+	}
+	if len(args) == 0 || isFlagArg(args[0]) {
+		fmt.Fprintln(os.Stderr, tr("Usage: clix watch mentions [--interval 1m] [--no-notify] [--webhook url] [--once]"))
+		return errUsage
+	}
+	if args[0] != "mentions" {
+		return withExitCode(exitUsage, fmt.Errorf(tr("unknown watch action %q"), args[0]))
+	}
+
+	fs := newFlagSet("watch mentions", "watch mentions [--interval 1m] [--no-notify] [--webhook url] [--once]  (mentions and replies as they come in)")
+	interval := fs.Duration("interval", time.Minute, "how often to check, at least 15s; a rate limit stretches it until the limit resets")
+	noNotify := fs.Bool("no-notify", false, "print new mentions without desktop notifications")
+	var webhooks stringList
+	fs.Var(&webhooks, "webhook", "also POST each mention as a hook event to this URL (repeatable)")
+	once := fs.Bool("once", false, "check once and exit, for cron")
+	if _, err := parseFlags(fs, args[1:]); err != nil {
+		return err
+	}
+	if *interval < minWatchInterval {
+		return fmt.Errorf(tr("--interval must be at least %s"), minWatchInterval)
+	}
+
+	a, err := setup(false)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+	ctx := rootCtx
+
+	userID, err := a.me(ctx)
+	if err != nil {
+		return err
+	}
+	seen := map[string]string{}
+	if err := loadState(watchStateFile, &seen); err != nil {
+		return err
+	}
+	w := &mentionWatcher{a: a, userID: userID, sinceID: seen[a.config.Active], notify: !*noNotify}
+	for _, u := range webhooks {
+		w.hooks = append(w.hooks, HookConfig{URL: u})
+	}
+
+	if !*once && !machineReadable() {
+		fmt.Fprintf(os.Stderr, tr("Watching mentions, checking every %s.\n"), *interval)
+	}
+	for {
+		wait := *interval
+		views, err := w.poll(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil:
+			if *once {
+				return err
+			}
+			if limited, ok := rateLimitWait(err); ok {
+				wait = max(wait, limited)
+				fmt.Fprintf(os.Stderr, tr("Rate limited; next check at %s.\n"), time.Now().Add(wait).Format("15:04:05"))
+			} else {
+				fmt.Fprintln(os.Stderr, tr("Error:"), err)
+			}
+		}
+		for _, view := range views {
+			w.announce(view)
+		}
+		if *once {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package clix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PostingWindow restricts posting to a daily time range on selected weekdays
+type PostingWindow struct {
+	Start    string   `json:"start"`              // "HH:MM", start of the window
+	End      string   `json:"end"`                // "HH:MM", end of the window (may wrap past midnight)
+	Timezone string   `json:"timezone,omitempty"` // IANA zone name, defaults to the local zone
+	Weekdays []string `json:"weekdays,omitempty"` // "mon".."sun", empty means every day
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf(tr("invalid time %q, expected HH:MM"), s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// onDay returns the time of day clock, such as parseClock returns, on the
+// date of day. It is the wall clock time, so an hour lost or repeated when
+// the clocks change before it does not move it.
+func onDay(day time.Time, clock time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), int(clock/time.Hour), int(clock%time.Hour/time.Minute), 0, 0, day.Location())
+}
+
+// bounds resolves the window into the times of day it opens and closes, a
+// location and the set of weekdays on which a window opens. An end before
+// the start is on the next day.
+func (w *PostingWindow) bounds() (start, end time.Duration, loc *time.Location, days map[time.Weekday]bool, err error) {
+	if start, err = parseClock(w.Start); err != nil {
+		return 0, 0, nil, nil, fmt.Errorf(tr("posting_window start: %w"), err)
+	}
+	if end, err = parseClock(w.End); err != nil {
+		return 0, 0, nil, nil, fmt.Errorf(tr("posting_window end: %w"), err)
+	}
+	if start == end {
+		return 0, 0, nil, nil, errors.New(tr("posting_window start and end must differ"))
+	}
+
+	loc = time.Local
+	if w.Timezone != "" {
+		if loc, err = time.LoadLocation(w.Timezone); err != nil {
+			return 0, 0, nil, nil, fmt.Errorf(tr("posting_window timezone: %w"), err)
+		}
+	}
+
+	days = make(map[time.Weekday]bool)
+	for _, name := range w.Weekdays {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if len(key) > 3 {
+			key = key[:3] // accept "monday" as well as "mon"
+		}
+		day, ok := weekdayNames[key]
+		if !ok {
+			return 0, 0, nil, nil, fmt.Errorf(tr("posting_window: unknown weekday %q"), name)
+		}
+		days[day] = true
+	}
+	if len(days) == 0 {
+		for _, day := range weekdayNames {
+			days[day] = true
+		}
+	}
+	return start, end, loc, days, nil
+}
+
+// NextAllowed returns t if posting is allowed at t, otherwise the time the
+// next window opens. A nil window always allows posting.
+func (w *PostingWindow) NextAllowed(t time.Time) (time.Time, error) {
+	if w == nil {
+		return t, nil
+	}
+	start, end, loc, days, err := w.bounds()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	local := t.In(loc)
+	// Start a day early so a window that wraps past midnight is still seen
+	for offset := -1; offset <= 7; offset++ {
+		day := time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, loc)
+		if !days[day.Weekday()] {
+			continue
+		}
+		opens := onDay(day, start)
+		closes := onDay(day, end)
+		if end < start {
+			closes = onDay(day.AddDate(0, 0, 1), end)
+		}
+		if !local.Before(opens) && local.Before(closes) {
+			return t, nil
+		}
+		if opens.After(local) {
+			return opens, nil
+		}
+	}
+	return time.Time{}, errors.New(tr("posting_window never opens"))
+}
+
+// String describes the window for messages
+func (w *PostingWindow) String() string {
+	desc := fmt.Sprintf("%s-%s", w.Start, w.End)
+	if w.Timezone != "" {
+		desc += " " + w.Timezone
+	}
+	if len(w.Weekdays) > 0 {
+		desc += " (" + strings.Join(w.Weekdays, ", ") + ")"
+	}
+	return desc
+}
+
+// checkPostingWindow returns an error explaining when posting is next
+// allowed if t falls outside the configured window
+func checkPostingWindow(w *PostingWindow, t time.Time) error {
+	next, err := w.NextAllowed(t)
+	if err != nil {
+		return err
+	}
+	if !next.Equal(t) {
+		return withExitCode(exitRefused, fmt.Errorf(tr("posting is restricted to %s; next allowed time is %s"),
+			w, next.Format("Mon Jan 2 15:04 MST")))
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package clix
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextAllowed(t *testing.T) {
+	// 2024-01-01 is a Monday
+	at := func(day, hour, min int) time.Time { return time.Date(2024, 1, day, hour, min, 0, 0, time.UTC) }
+	office := &PostingWindow{Start: "09:00", End: "17:00", Timezone: "UTC"}
+	night := &PostingWindow{Start: "22:00", End: "02:00", Timezone: "UTC"}
+	weekdays := &PostingWindow{Start: "09:00", End: "17:00", Timezone: "UTC", Weekdays: []string{"mon", "Tuesday", "fri"}}
+	tests := []struct {
+		name   string
+		window *PostingWindow
+		t      time.Time
+		want   time.Time
+	}{
+		{"no window", nil, at(1, 3, 0), at(1, 3, 0)},
+		{"inside", office, at(1, 12, 0), at(1, 12, 0)},
+		{"at the start", office, at(1, 9, 0), at(1, 9, 0)},
+		{"at the end", office, at(1, 17, 0), at(2, 9, 0)},
+		{"before", office, at(1, 8, 59), at(1, 9, 0)},
+		{"after", office, at(1, 18, 0), at(2, 9, 0)},
+		{"wrapping, before midnight", night, at(1, 23, 0), at(1, 23, 0)},
+		{"wrapping, after midnight", night, at(2, 1, 0), at(2, 1, 0)},
+		{"wrapping, closed", night, at(2, 3, 0), at(2, 22, 0)},
+		{"weekday", weekdays, at(2, 10, 0), at(2, 10, 0)},
+		{"day off", weekdays, at(3, 10, 0), at(5, 9, 0)},
+		{"after the last day of the week", weekdays, at(5, 18, 0), at(8, 9, 0)},
+		{"other zone", &PostingWindow{Start: "09:00", End: "17:00", Timezone: "Asia/Tokyo"}, at(1, 1, 0), at(1, 1, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.window.NextAllowed(tt.t)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("NextAllowed(%s) = %s, want %s", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextAllowedAcrossClockChanges(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	at := func(month, day, hour, min int) time.Time {
+		return time.Date(2024, time.Month(month), day, hour, min, 0, 0, ny)
+	}
+	office := &PostingWindow{Start: "09:00", End: "17:00", Timezone: "America/New_York"}
+	night := &PostingWindow{Start: "22:00", End: "06:00", Timezone: "America/New_York"}
+	tests := []struct {
+		name   string
+		window *PostingWindow
+		t      time.Time
+		want   time.Time
+	}{
+		// The clocks go forward at 2:00 on March 10 and back on November 3
+		{"spring forward, before", office, at(3, 10, 8, 0), at(3, 10, 9, 0)},
+		{"spring forward, opening", office, at(3, 10, 9, 30), at(3, 10, 9, 30)},
+		{"spring forward, closing", office, at(3, 10, 16, 30), at(3, 10, 16, 30)},
+		{"spring forward, closed", office, at(3, 10, 17, 0), at(3, 11, 9, 0)},
+		{"fall back, before", office, at(11, 3, 8, 30), at(11, 3, 9, 0)},
+		{"fall back, closed", office, at(11, 3, 17, 0), at(11, 4, 9, 0)},
+		{"night, spring forward", night, at(3, 10, 5, 30), at(3, 10, 5, 30)},
+		{"night, spring forward, closed", night, at(3, 10, 6, 0), at(3, 10, 22, 0)},
+		{"night, fall back, closed", night, at(11, 3, 6, 0), at(11, 3, 22, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.window.NextAllowed(tt.t)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("NextAllowed(%s) = %s, want %s", tt.t, got.In(ny), tt.want)
+			}
+		})
+	}
+}
+
+func TestPostingWindowErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		window *PostingWindow
+	}{
+		{"bad start", &PostingWindow{Start: "9am", End: "17:00"}},
+		{"bad end", &PostingWindow{Start: "09:00", End: "25:00"}},
+		{"empty", &PostingWindow{Start: "09:00", End: "09:00"}},
+		{"bad zone", &PostingWindow{Start: "09:00", End: "17:00", Timezone: "Mars/Olympus"}},
+		{"bad weekday", &PostingWindow{Start: "09:00", End: "17:00", Weekdays: []string{"funday"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.window.NextAllowed(time.Now()); err == nil {
+				t.Error("NextAllowed() = nil error, want one")
+			}
+		})
+	}
+}
+
+func TestCheckPostingWindow(t *testing.T) {
+	window := &PostingWindow{Start: "09:00", End: "17:00", Timezone: "UTC"}
+	if err := checkPostingWindow(window, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)); err != nil {
+		t.Errorf("inside the window: %v", err)
+	}
+	err := checkPostingWindow(window, time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC))
+	if err == nil {
+		t.Fatal("outside the window: nil error, want one")
+	}
+	if code := exitCode(err); code != exitRefused {
+		t.Errorf("exit code %d, want %d", code, exitRefused)
+	}
+}